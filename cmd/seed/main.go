@@ -3,7 +3,9 @@ package main
 import (
 	"database/sql"
 	"log"
+	"math"
 	"path/filepath"
+	"time"
 
 	"github.com/y-suzuki/standard-truck-rate/internal/database"
 	"github.com/y-suzuki/standard-truck-rate/internal/model"
@@ -20,12 +22,42 @@ func main() {
 	}
 	defer db.Close()
 
+	// 運賃版投入
+	if err := seedTariffVersions(db); err != nil {
+		log.Fatalf("運賃版投入エラー: %v", err)
+	}
+	log.Println("運賃版投入完了")
+
 	// JTA時間制運賃投入
 	if err := seedJtaTimeFares(db); err != nil {
 		log.Fatalf("JTA時間制運賃投入エラー: %v", err)
 	}
 	log.Println("JTA時間制運賃投入完了")
 
+	// 特殊車両割増投入
+	if err := seedBodyTypeSurcharges(db); err != nil {
+		log.Fatalf("特殊車両割増投入エラー: %v", err)
+	}
+	log.Println("特殊車両割増投入完了")
+
+	// 割増項目投入
+	if err := seedSurchargeItems(db); err != nil {
+		log.Fatalf("割増項目投入エラー: %v", err)
+	}
+	log.Println("割増項目投入完了")
+
+	// 燃料サーチャージ投入
+	if err := seedFuelSurcharges(db); err != nil {
+		log.Fatalf("燃料サーチャージ投入エラー: %v", err)
+	}
+	log.Println("燃料サーチャージ投入完了")
+
+	// 会社休日投入
+	if err := seedCompanyHolidays(db); err != nil {
+		log.Fatalf("会社休日投入エラー: %v", err)
+	}
+	log.Println("会社休日投入完了")
+
 	// 赤帽運賃投入
 	if err := seedAkabouFares(db); err != nil {
 		log.Fatalf("赤帽運賃投入エラー: %v", err)
@@ -35,6 +67,36 @@ func main() {
 	log.Println("全マスタデータ投入完了")
 }
 
+// seedTariffVersions 運賃版を投入する
+// 運賃データは版共通（tariff_version_id=0）として投入し、告示改定時に版固有のデータを追加する
+func seedTariffVersions(db *sql.DB) error {
+	repo := repository.NewTariffVersionRepository(db)
+
+	jtaDesc := "令和6年3月22日 国土交通省告示第209号"
+	akabouDesc := "税込（消費税10%）料金表。改定日は未確認のため消費税率改定日を適用開始日とする"
+	versions := []*model.TariffVersion{
+		{
+			TariffType:    model.TariffTypeJTA,
+			Name:          "令和6年3月告示",
+			EffectiveFrom: time.Date(2024, 3, 22, 0, 0, 0, 0, time.Local),
+			Description:   &jtaDesc,
+		},
+		{
+			TariffType:    model.TariffTypeAkabou,
+			Name:          "赤帽運賃（税込10%）",
+			EffectiveFrom: time.Date(2019, 10, 1, 0, 0, 0, 0, time.Local),
+			Description:   &akabouDesc,
+		},
+	}
+	for _, v := range versions {
+		if _, err := repo.Create(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // seedJtaTimeFares JTA時間制運賃を投入する
 func seedJtaTimeFares(db *sql.DB) error {
 	repo := repository.NewJtaTimeFareRepository(db)
@@ -168,6 +230,130 @@ func seedJtaTimeFares(db *sql.DB) error {
 	return nil
 }
 
+// 燃料サーチャージの算出条件
+const (
+	fuelSurchargeStepYen = 5 // 価格差の刻み（円/L）
+)
+
+// fuelEconomyKmPerL 車格別の燃費（km/L）
+var fuelEconomyKmPerL = map[int]float64{
+	1: 8.0, // 小型車(2t)
+	2: 6.0, // 中型車(4t)
+	3: 4.0, // 大型車(10t)
+	4: 3.5, // トレーラー(20t)
+}
+
+// fuelSurchargeBandsKm 燃料サーチャージの距離帯（上限km、最後の帯は上限なし）
+var fuelSurchargeBandsKm = []int{50, 100, 150, 200, 300, 400, 500, 600, 700, 800, 900, 1000, 1100}
+
+// seedFuelSurcharges 燃料サーチャージ表を投入する
+// 1刻みあたりの加算額 = 距離帯の上限km ÷ 燃費 × 刻み（円）を切り上げた額
+// 告示の燃料サーチャージ表に合わせる場合はマスタを直接更新する
+func seedFuelSurcharges(db *sql.DB) error {
+	repo := repository.NewFuelSurchargeRepository(db)
+
+	for vehicleCode := 1; vehicleCode <= 4; vehicleCode++ {
+		minKm := 0
+		for i, upperKm := range fuelSurchargeBandsKm {
+			var maxKm *int
+			if i < len(fuelSurchargeBandsKm)-1 {
+				maxKm = intPtr(upperKm)
+			}
+			surcharge := &model.FuelSurcharge{
+				VehicleCode: vehicleCode,
+				MinKm:       minKm,
+				MaxKm:       maxKm,
+				StepYen:     fuelSurchargeStepYen,
+				AmountYen:   int(math.Ceil(float64(upperKm) / fuelEconomyKmPerL[vehicleCode] * fuelSurchargeStepYen)),
+			}
+			if _, err := repo.CreateSurcharge(surcharge); err != nil {
+				return err
+			}
+			minKm = upperKm + 1
+		}
+	}
+
+	return nil
+}
+
+// seedBodyTypeSurcharges 特殊車両割増（車体種別ごとの割増率）を投入する
+// 告示の特殊車両割増に基づき版共通（tariff_version_id=0）として投入する
+func seedBodyTypeSurcharges(db *sql.DB) error {
+	repo := repository.NewBodyTypeSurchargeRepository(db)
+
+	surcharges := []*model.BodyTypeSurcharge{
+		{BodyType: model.BodyTypeStandard, Name: "標準（平ボディ・バン・ウイング）", SurchargePercent: 0},
+		{BodyType: "refrigerated", Name: "冷蔵車・冷凍車", SurchargePercent: 20},
+		{BodyType: "marine_container", Name: "海上コンテナ輸送車", SurchargePercent: 40},
+		{BodyType: "cement_bulk", Name: "セメントバラ輸送車", SurchargePercent: 20},
+		{BodyType: "hazardous", Name: "危険物輸送車（タンク車以外）", SurchargePercent: 20},
+		{BodyType: "petroleum_tank", Name: "石油製品輸送車（タンクローリー）", SurchargePercent: 30},
+		{BodyType: "chemical_tank", Name: "化成品輸送車", SurchargePercent: 40},
+		{BodyType: "high_pressure_gas", Name: "高圧ガス輸送車", SurchargePercent: 40},
+	}
+	for i, s := range surcharges {
+		s.SortOrder = i + 1
+		if err := repo.Upsert(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultSurchargeItems 既定の割増項目（速達割増は告示の「2割以内」の上限で登録）
+var defaultSurchargeItems = []*model.SurchargeItem{
+	{Code: "express", Name: "速達割増", CalcType: model.SurchargeCalcTypeRate, RatePercent: 20},
+	{Code: "hand_loading", Name: "手積み・手降ろし", CalcType: model.SurchargeCalcTypeFixed, AmountYen: 3000, VehicleCodes: "1,2,3,4"},
+	{Code: "special_work", Name: "付帯作業（棚入れ・ラベル貼りなど）", CalcType: model.SurchargeCalcTypeFixed, AmountYen: 2000},
+}
+
+// seedSurchargeItems 割増項目を投入する
+// 既に登録済みの場合は利用者の設定を上書きしないようスキップする
+func seedSurchargeItems(db *sql.DB) error {
+	repo := repository.NewSurchargeItemRepository(db)
+
+	existing, err := repo.GetAll()
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	for i, item := range defaultSurchargeItems {
+		item.SortOrder = i + 1
+		if err := repo.Upsert(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultCompanyHolidays 既定の会社休日（年末年始 12/29〜1/3）
+var defaultCompanyHolidays = []string{"12-29", "12-30", "12-31", "01-01", "01-02", "01-03"}
+
+// seedCompanyHolidays 会社休日を投入する
+// 既に登録済みの場合は利用者の設定を上書きしないようスキップする
+func seedCompanyHolidays(db *sql.DB) error {
+	repo := repository.NewCompanyHolidayRepository(db)
+
+	existing, err := repo.GetAll()
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	for _, monthDay := range defaultCompanyHolidays {
+		md := monthDay
+		if _, err := repo.Create(&model.CompanyHoliday{Name: "年末年始", MonthDay: &md}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // seedAkabouFares 赤帽運賃を投入する
 func seedAkabouFares(db *sql.DB) error {
 	repo := repository.NewAkabouFareRepository(db)
@@ -200,10 +386,11 @@ func seedAkabouFares(db *sql.DB) error {
 	// 時間制運賃（税込）
 	// 基本（2時間・20km迄）: 6,050円、超過30分ごと: +1,375円
 	timeFare := &model.AkabouTimeFare{
-		BaseHours:    2,
-		BaseKm:       20,
-		BaseFare:     6050,
-		OvertimeRate: 1375, // 30分ごと
+		BaseHours:           2,
+		BaseKm:              20,
+		BaseFare:            6050,
+		OvertimeRate:        1375,
+		OvertimeUnitMinutes: 30,
 	}
 	if _, err := repo.CreateTimeFare(timeFare); err != nil {
 		return err
@@ -5,8 +5,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/y-suzuki/standard-truck-rate/internal/database"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
 	"github.com/y-suzuki/standard-truck-rate/internal/repository"
 )
 
@@ -28,6 +30,37 @@ func setupTestDB(t *testing.T) (*sql.DB, func()) {
 	return db, cleanup
 }
 
+func TestSeedTariffVersions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// データ投入実行
+	if err := seedTariffVersions(db); err != nil {
+		t.Fatalf("運賃版投入失敗: %v", err)
+	}
+
+	repo := repository.NewTariffVersionRepository(db)
+
+	// 令和6年3月告示の施行日以降はJTA現行版が適用されること
+	v, err := repo.GetEffective(model.TariffTypeJTA, time.Date(2024, 3, 22, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("JTA運賃版取得失敗: %v", err)
+	}
+	if v.Name != "令和6年3月告示" || !v.IsCurrent() {
+		t.Errorf("JTA運賃版: got %+v", v)
+	}
+
+	// 施行日前は該当版なし
+	if _, err := repo.GetEffective(model.TariffTypeJTA, time.Date(2024, 3, 21, 0, 0, 0, 0, time.Local)); err != sql.ErrNoRows {
+		t.Errorf("施行日前のJTA運賃版: got err %v, want sql.ErrNoRows", err)
+	}
+
+	// 赤帽の版も投入されていること
+	if _, err := repo.GetEffective(model.TariffTypeAkabou, time.Now()); err != nil {
+		t.Errorf("赤帽運賃版取得失敗: %v", err)
+	}
+}
+
 func TestSeedJtaTimeBaseFares(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -217,3 +250,137 @@ func TestSeedAkabouFares(t *testing.T) {
 		t.Errorf("付帯料金件数: got %d, want 2", len(additionalFees))
 	}
 }
+
+func TestSeedFuelSurcharges(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// データ投入実行
+	if err := seedFuelSurcharges(db); err != nil {
+		t.Fatalf("燃料サーチャージ投入失敗: %v", err)
+	}
+
+	repo := repository.NewFuelSurchargeRepository(db)
+
+	// 件数確認（4車格 × 13距離帯 = 52件）
+	surcharges, err := repo.GetAllSurcharges()
+	if err != nil {
+		t.Fatalf("燃料サーチャージ取得失敗: %v", err)
+	}
+	if len(surcharges) != 52 {
+		t.Errorf("燃料サーチャージ件数: got %d, want 52", len(surcharges))
+	}
+
+	// 大型車・100km迄: 100km ÷ 4.0km/L × 5円 = 125円
+	s, err := repo.GetSurcharge(nil, 3, 100)
+	if err != nil {
+		t.Fatalf("大型車・100kmの燃料サーチャージ取得失敗: %v", err)
+	}
+	if s.AmountYen != 125 || s.StepYen != 5 {
+		t.Errorf("大型車・100km: got %+v, want 125円/5円刻み", s)
+	}
+
+	// 上限を超える距離は最後の距離帯（上限なし）
+	s, err = repo.GetSurcharge(nil, 1, 3000)
+	if err != nil {
+		t.Fatalf("小型車・3000kmの燃料サーチャージ取得失敗: %v", err)
+	}
+	if s.MaxKm != nil || s.MinKm != 1001 {
+		t.Errorf("小型車・3000km: got %+v, want 1001km〜上限なし", s)
+	}
+}
+
+func TestSeedCompanyHolidays(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// 2回実行しても重複しない
+	for i := 0; i < 2; i++ {
+		if err := seedCompanyHolidays(db); err != nil {
+			t.Fatalf("会社休日投入失敗: %v", err)
+		}
+	}
+
+	repo := repository.NewCompanyHolidayRepository(db)
+	holidays, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("会社休日取得失敗: %v", err)
+	}
+	if len(holidays) != 6 {
+		t.Errorf("会社休日件数: got %d, want 6", len(holidays))
+	}
+	for _, h := range holidays {
+		if h.Name != "年末年始" || h.MonthDay == nil {
+			t.Errorf("会社休日: got %+v, want 年末年始（毎年）", h)
+		}
+	}
+}
+
+func TestSeedBodyTypeSurcharges(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// 2回実行しても重複しないこと
+	for i := 0; i < 2; i++ {
+		if err := seedBodyTypeSurcharges(db); err != nil {
+			t.Fatalf("特殊車両割増投入失敗: %v", err)
+		}
+	}
+
+	repo := repository.NewBodyTypeSurchargeRepository(db)
+	all, err := repo.GetBodyTypeSurcharges(nil)
+	if err != nil {
+		t.Fatalf("特殊車両割増取得失敗: %v", err)
+	}
+	if len(all) != 8 {
+		t.Fatalf("特殊車両割増件数: got %d, want 8", len(all))
+	}
+	if all[0].BodyType != model.BodyTypeStandard || all[0].SurchargePercent != 0 {
+		t.Errorf("先頭は標準（割増なし）: got %+v", all[0])
+	}
+
+	s, err := repo.GetBodyTypeSurcharge(nil, "refrigerated")
+	if err != nil {
+		t.Fatalf("冷蔵車・冷凍車取得失敗: %v", err)
+	}
+	if s.SurchargePercent != 20 {
+		t.Errorf("冷蔵車・冷凍車の割増率: got %d, want 20", s.SurchargePercent)
+	}
+}
+
+func TestSeedSurchargeItems(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := seedSurchargeItems(db); err != nil {
+		t.Fatalf("割増項目投入失敗: %v", err)
+	}
+
+	repo := repository.NewSurchargeItemRepository(db)
+	items, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("割増項目取得失敗: %v", err)
+	}
+	if len(items) != len(defaultSurchargeItems) {
+		t.Fatalf("割増項目件数: got %d, want %d", len(items), len(defaultSurchargeItems))
+	}
+	if items[0].Code != "express" || items[0].RatePercent != 20 {
+		t.Errorf("先頭は速達割増: got %+v", items[0])
+	}
+
+	// 登録済みの場合は利用者の設定を上書きしない
+	items[0].RatePercent = 10
+	if err := repo.Upsert(items[0]); err != nil {
+		t.Fatalf("割増項目更新失敗: %v", err)
+	}
+	if err := seedSurchargeItems(db); err != nil {
+		t.Fatalf("割増項目再投入失敗: %v", err)
+	}
+	express, err := repo.GetSurchargeItem("express")
+	if err != nil {
+		t.Fatalf("速達割増取得失敗: %v", err)
+	}
+	if express.RatePercent != 10 {
+		t.Errorf("再投入で利用者の設定が上書きされた: got %d%%", express.RatePercent)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -123,29 +125,60 @@ func main() {
 	// Static files
 	e.Static("/static", "web/static")
 
-	// サービス作成
-	fareCalculator := createFareCalculatorService(mainDB)
+	// サービス作成（トラ協運賃の計算サービスは見積と運賃表で共有）
+	jta := createJtaFareServices(mainDB)
+	fareCalculator := createFareCalculatorService(mainDB, jta)
+	fareMatrixService := service.NewFareMatrixService(jta.distanceFare, jta.timeFare)
+	fareMatrixService.SetTariffVersionResolver(repository.NewTariffVersionRepository(mainDB))
+	fareCrossoverService := service.NewFareCrossoverService(jta.distanceFare, jta.timeFare, service.NewAkabouFareService(repository.NewAkabouFareRepository(mainDB)))
+	fareCrossoverService.SetTariffVersionResolver(repository.NewTariffVersionRepository(mainDB))
+	fareBudgetService := service.NewFareBudgetService(fareCalculator)
 
-	// ルートクライアント・Geocodingクライアント作成（モック or Google API）
-	var routeClient service.RouteClient
+	// 休日カレンダー（出発日時からの休日判定・休日一覧APIで共有）
+	holidayCalendar := createHolidayCalendarService(mainDB)
+	fareCalculator.SetHolidayChecker(holidayCalendar)
+
+	// API使用量サービス（ルートクライアントの切り替え・ルートハンドラで使用するため先に作成）
+	apiUsageRepo := repository.NewApiUsageRepository(mainDB)
+	apiUsageService := service.NewApiUsageService(apiUsageRepo)
+
+	// ルートクライアント・Geocodingクライアント作成（モック or Google API、セルフホストのルーティングエンジン）
 	var geocodingClient service.GeocodingClient
 	googleAPIKey := os.Getenv("GOOGLE_MAPS_API_KEY")
 	if googleAPIKey != "" {
-		routeClient = service.NewGoogleRoutesClient(googleAPIKey)
-		geocodingClient = service.NewGoogleGeocodingClient(googleAPIKey)
-		log.Println("Google Maps APIを使用します")
+		// Geocoding APIの結果はキャッシュし、使用量は Routes API とは別にカウントする
+		geocodingClient = service.NewCachedGeocodingClient(
+			service.NewGoogleGeocodingClient(googleAPIKey),
+			repository.NewGeocodeCacheRepository(cacheDB),
+			apiUsageService,
+		)
 	} else {
-		log.Println("GOOGLE_MAPS_API_KEYが未設定のため、モッククライアントを使用します")
-		routeClient = service.NewMockRoutesClient()
 		geocodingClient = service.NewMockGeocodingClient()
 	}
-
-	// API使用量サービス（ルートハンドラで使用するため先に作成）
-	apiUsageRepo := repository.NewApiUsageRepository(mainDB)
-	apiUsageService := service.NewApiUsageService(apiUsageRepo)
+	routeClient := createRouteClient(googleAPIKey, apiUsageService)
 
 	// キャッシュ付きルートサービス（CalculateHandler と RouteHandler で共有）
 	routeCacheRepo := repository.NewRouteCacheRepository(cacheDB)
+	// 住所の表記ゆれで分かれていた既存のキャッシュを統一したキーにまとめる（データ移行として1回のみ）
+	if _, err := database.RunCacheMigration(cacheDB, database.CacheMigrationNormalizeRouteAddresses, func() error {
+		merged, err := routeCacheRepo.MergeNormalizedKeys(service.NormalizeAddress)
+		if err == nil && merged > 0 {
+			log.Printf("ルートキャッシュの住所表記を統一しました: %d件", merged)
+		}
+		return err
+	}); err != nil {
+		log.Printf("ルートキャッシュの住所統一エラー: %v", err)
+	}
+	// 寸法・重量・危険物のキーのキャッシュは取得したエンジンが分からない（Google・OSRMの乗用車のルートを含む）ため削除する
+	if _, err := database.RunCacheMigration(cacheDB, database.CacheMigrationPurgeVehicleRouteKeys, func() error {
+		deleted, err := routeCacheRepo.DeleteByOptionsKey(func(key string) bool { return !service.IsAvoidanceOnlyKey(key) })
+		if err == nil && deleted > 0 {
+			log.Printf("ルートキャッシュの車両条件付きの行を削除しました: %d件", deleted)
+		}
+		return err
+	}); err != nil {
+		log.Printf("ルートキャッシュの条件キー移行エラー: %v", err)
+	}
 	cachedRouteService := service.NewCachedRouteService(routeClient, routeCacheRepo, 0) // TTL=0: 無期限
 
 	// ハンドラ
@@ -153,7 +186,20 @@ func main() {
 	indexHandler := handler.NewIndexHandler()
 	calculateHandler := handler.NewCalculateHandler(fareCalculator, cachedRouteService, apiUsageService, geocodingClient, mainDB, cacheDB)
 	routeHandler := handler.NewRouteHandler(cacheDB, routeClient, apiUsageService)
+
+	// 郵便番号データ（取込済みの場合のみ）で出発地の運輸局・赤帽地区をAPIを呼ばずに判定する
+	if gazetteer := createGazetteer(mainDB); gazetteer != nil {
+		calculateHandler.SetGazetteer(gazetteer)
+		routeHandler.SetGazetteer(gazetteer)
+	}
 	apiUsageHandler := handler.NewApiUsageHandler(apiUsageService)
+	calendarHandler := handler.NewCalendarHandler(holidayCalendar)
+	surchargeItemHandler := handler.NewSurchargeItemHandler(repository.NewSurchargeItemRepository(mainDB))
+	bodyTypeHandler := handler.NewBodyTypeHandler(repository.NewBodyTypeSurchargeRepository(mainDB), repository.NewTariffVersionRepository(mainDB))
+	customerHandler := handler.NewCustomerHandler(repository.NewCustomerRepository(mainDB))
+	fareMatrixHandler := handler.NewFareMatrixHandler(fareMatrixService)
+	fareCrossoverHandler := handler.NewFareCrossoverHandler(fareCrossoverService)
+	fareBudgetHandler := handler.NewFareBudgetHandler(fareBudgetService)
 
 	// Routes
 	e.GET("/", indexHandler.Index)
@@ -165,6 +211,14 @@ func main() {
 	// 運賃計算API
 	e.POST("/api/fare/calculate", calculateHandler.Calculate)
 	e.POST("/api/fare/calculate/json", calculateHandler.CalculateJSON)
+	e.GET("/api/fare/body-types", bodyTypeHandler.GetBodyTypes)
+	e.GET("/api/fare/surcharge-items", surchargeItemHandler.GetSurchargeItems)
+	e.GET("/api/fare/matrix", fareMatrixHandler.GetFareMatrix)
+	e.GET("/api/fare/crossover", fareCrossoverHandler.GetFareCrossover)
+	e.GET("/api/fare/budget", fareBudgetHandler.GetFareBudget)
+
+	// 荷主マスタ（契約運賃）
+	e.GET("/api/customers", customerHandler.GetCustomers)
 
 	// ルート情報API
 	e.GET("/api/route", routeHandler.GetRoute)
@@ -176,6 +230,9 @@ func main() {
 	// API使用量
 	e.GET("/api/usage", apiUsageHandler.GetUsage)
 
+	// 休日カレンダー
+	e.GET("/api/calendar/holidays", calendarHandler.GetHolidays)
+
 	// Start server
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -188,38 +245,253 @@ func main() {
 	}
 }
 
-// createFareCalculatorService 運賃計算サービスを作成
-func createFareCalculatorService(mainDB *sql.DB) *service.FareCalculatorService {
+// jtaFareServices トラ協運賃の計算サービス
+type jtaFareServices struct {
+	distanceFare *service.DistanceFareService
+	timeFare     *service.TimeFareService
+	charge       *service.JtaChargeService // 付帯料金（Supabase未設定の場合はnil）
+}
+
+// createJtaFareServices トラ協運賃の計算サービスを作成
+// 距離制運賃・付帯料金の取得元は JTA_FARE_SOURCE で選ぶ（Supabaseとローカルミラー）
+func createJtaFareServices(mainDB *sql.DB) *jtaFareServices {
 	// Supabase設定
 	supabaseURL := os.Getenv("SUPABASE_URL")
 	supabaseKey := os.Getenv("SUPABASE_ANON_KEY")
 
-	var distanceFareService *service.DistanceFareService
+	// 取得元（デフォルトはSupabase優先、取得できない場合はローカルミラー）
+	mode := service.JtaFareSourceSupabaseFirst
+	if v := os.Getenv("JTA_FARE_SOURCE"); v != "" {
+		parsed, err := service.ParseJtaFareSourceMode(v)
+		if err != nil {
+			log.Fatalf("JTA_FARE_SOURCEが不正です: %v", err)
+		}
+		mode = parsed
+	}
+	mirror := repository.NewJtaMirrorRepository(mainDB)
+	mirrorFareGetter := service.NewJtaMirrorFareGetter(mirror)
 
+	jta := &jtaFareServices{}
 	if supabaseURL != "" && supabaseKey != "" {
-		// Supabaseクライアントを使用
+		// Supabaseクライアントを使用（設定に応じてローカルミラーと併用）
 		supabaseClient := service.NewJtaSupabaseClient(supabaseURL, supabaseKey)
 		adapter := service.NewJtaSupabaseClientAdapter(supabaseClient)
-		distanceFareService = service.NewDistanceFareService(adapter)
+		jta.distanceFare = service.NewDistanceFareService(mode.FareGetter(adapter, mirrorFareGetter))
+		jta.charge = service.NewJtaChargeService(mode.ChargeDataGetter(supabaseClient, mirror))
+		if mode.UsesLocalMirror() {
+			// sync_jta_fares でミラーを再同期した場合は再起動なしで付帯料金を取り直す
+			jta.charge.SetSyncStatusGetter(mirror)
+		}
+		log.Printf("トラ協データの取得元: %s", mode)
+	} else if status, err := mirror.GetSyncStatus(model.JtaTableFareRates); err == nil && mode.UsesLocalMirror() {
+		// Supabase未設定でもローカルミラーが同期済みなら使用
+		jta.distanceFare = service.NewDistanceFareService(mirrorFareGetter)
+		if _, err := mirror.GetSyncStatus(model.JtaTableChargeData); err == nil {
+			jta.charge = service.NewJtaChargeService(mirror)
+			jta.charge.SetSyncStatusGetter(mirror)
+		}
+		log.Printf("SUPABASE_URL/SUPABASE_ANON_KEYが未設定のため、ローカルミラー（%s同期）を使用します", status.SyncedAt.Format("2006-01-02 15:04"))
 	} else {
-		log.Println("SUPABASE_URL/SUPABASE_ANON_KEYが未設定のため、距離制運賃はモックを使用します")
-		distanceFareService = service.NewDistanceFareService(&mockFareGetter{})
+		log.Println("SUPABASE_URL/SUPABASE_ANON_KEYが未設定のため、距離制運賃はモックを使用し、付帯料金は計算しません")
+		jta.distanceFare = service.NewDistanceFareService(&mockFareGetter{})
 	}
 
 	// 時間制運賃（DBから取得）
 	timeFareRepo := repository.NewJtaTimeFareRepository(mainDB)
-	timeFareService := service.NewTimeFareService(timeFareRepo)
+	jta.timeFare = service.NewTimeFareService(timeFareRepo)
+	return jta
+}
+
+// createFareCalculatorService 運賃計算サービスを作成
+func createFareCalculatorService(mainDB *sql.DB, jta *jtaFareServices) *service.FareCalculatorService {
+	// 赤帽運賃（DBから取得）
+	akabouFareRepo := repository.NewAkabouFareRepository(mainDB)
+	akabouFareService := service.NewAkabouFareService(akabouFareRepo)
+
+	fareCalculator := service.NewFareCalculatorService(jta.distanceFare, jta.timeFare, akabouFareService)
+
+	// 運賃版（見積日から適用版を判定）
+	fareCalculator.SetTariffVersionResolver(repository.NewTariffVersionRepository(mainDB))
+
+	// 特殊車両割増（車体種別ごとの割増率）
+	fareCalculator.SetBodyTypeSurchargeGetter(repository.NewBodyTypeSurchargeRepository(mainDB))
+
+	// 割増項目（速達割増・付帯作業など）
+	fareCalculator.SetSurchargeItemGetter(repository.NewSurchargeItemRepository(mainDB))
+
+	// 荷主別の契約条件（値引き・割増・最低運賃）
+	fareCalculator.SetCustomerPricingGetter(repository.NewCustomerRepository(mainDB))
 
-	// 赤帽運賃
-	akabouFareService := service.NewAkabouFareService()
+	// 燃料サーチャージ（DBの燃料価格・サーチャージ表から計算）
+	fareCalculator.SetFuelSurchargeService(service.NewFuelSurchargeService(repository.NewFuelSurchargeRepository(mainDB)))
+	fareCalculator.SetComplianceService(service.NewComplianceService())
 
-	return service.NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
+	// トラ協付帯料金（待機時間料・積込取卸料）
+	if jta.charge != nil {
+		fareCalculator.SetJtaChargeService(jta.charge)
+	}
+
+	// 消費税（税率・端数処理）
+	fareCalculator.SetTaxCalculator(createTaxCalculator())
+
+	// キロ単価契約（設定時のみランキングに追加）
+	if strategy := createPerKmRateStrategy(); strategy != nil {
+		if err := fareCalculator.RegisterStrategy(strategy); err != nil {
+			log.Fatalf("運賃計算方式の登録エラー: %v", err)
+		}
+	}
+
+	return fareCalculator
+}
+
+// createPerKmRateStrategy 環境変数からキロ単価契約の運賃計算方式を作成（未設定の場合はnil）
+// PER_KM_RATE_YEN: キロ単価（円/km、税抜）、PER_KM_MINIMUM_FARE_YEN: 最低運賃（円、税抜、デフォルト0）
+func createPerKmRateStrategy() *service.PerKmRateStrategy {
+	v := os.Getenv("PER_KM_RATE_YEN")
+	if v == "" {
+		return nil
+	}
+	rate, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("PER_KM_RATE_YENが不正です: %s", v)
+	}
+
+	minimum := 0
+	if v := os.Getenv("PER_KM_MINIMUM_FARE_YEN"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("PER_KM_MINIMUM_FARE_YENが不正です: %s", v)
+		}
+		minimum = parsed
+	}
+
+	// トラック（2t以上）の車格のみ
+	strategy, err := service.NewPerKmRateStrategy("キロ単価契約", rate, minimum, 1, 2, 3, 4)
+	if err != nil {
+		log.Fatalf("キロ単価契約の設定エラー: %v", err)
+	}
+	log.Printf("キロ単価契約: %d円/km（最低運賃%d円）", rate, minimum)
+	return strategy
+}
+
+// createGazetteer 郵便番号データによる住所の判定を作成（未取込・読み込みエラーの場合は nil）
+func createGazetteer(mainDB *sql.DB) *service.Gazetteer {
+	gazetteer, err := service.NewGazetteer(repository.NewPostalCodeRepository(mainDB))
+	if err != nil {
+		log.Printf("郵便番号データの読み込みエラー: %v", err)
+		return nil
+	}
+	if gazetteer.Size() == 0 {
+		log.Println("郵便番号データが未取込のため、運輸局・赤帽地区はGeocodingで判定します（go run ./cmd/tools/import_postal_codes で取込）")
+		return nil
+	}
+	log.Printf("郵便番号データ: %d市区町村", gazetteer.Size())
+	return gazetteer
+}
+
+// createRouteClient ルートクライアントを作成
+// ROUTE_ENGINE（osrm / valhalla）と ROUTE_ENGINE_URL が設定されていればセルフホストのルーティングエンジンを使用する。
+// Google Maps APIキーも設定されている場合はGoogleを優先し、月間の使用量が上限に達したらセルフホストのエンジンに切り替える
+func createRouteClient(googleAPIKey string, apiUsageService *service.ApiUsageService) service.RouteClient {
+	var selfHosted service.RouteClient
+	if engine := os.Getenv("ROUTE_ENGINE"); engine != "" {
+		// 住所はNominatim（GEOCODER_URL）で緯度・経度に変換する
+		geocoderURL := os.Getenv("GEOCODER_URL")
+		if geocoderURL == "" {
+			log.Fatalf("ROUTE_ENGINEを使用する場合はGEOCODER_URL（Nominatim）を設定してください")
+		}
+		client, err := service.NewSelfHostedRoutesClient(engine, os.Getenv("ROUTE_ENGINE_URL"), service.NewNominatimClient(geocoderURL))
+		if err != nil {
+			log.Fatalf("ROUTE_ENGINEの設定が不正です: %v", err)
+		}
+		selfHosted = client
+	}
+
+	switch {
+	case googleAPIKey != "" && selfHosted != nil:
+		log.Printf("Google Maps APIを使用します（使用量の上限到達後は%sを使用）", os.Getenv("ROUTE_ENGINE"))
+		apiUsageService.SetFallbackEngine(os.Getenv("ROUTE_ENGINE"))
+		return service.NewFallbackRouteClient(service.NewGoogleRoutesClient(googleAPIKey), selfHosted, apiUsageService)
+	case googleAPIKey != "":
+		log.Println("Google Maps APIを使用します")
+		return service.NewGoogleRoutesClient(googleAPIKey)
+	case selfHosted != nil:
+		log.Printf("セルフホストのルーティングエンジン（%s）を使用します", os.Getenv("ROUTE_ENGINE"))
+		apiUsageService.SetFallbackEngine(os.Getenv("ROUTE_ENGINE"))
+		return selfHosted
+	default:
+		log.Println("GOOGLE_MAPS_API_KEYが未設定のため、モッククライアントを使用します")
+		return service.NewMockRoutesClient()
+	}
+}
+
+// createHolidayCalendarService 休日カレンダーサービスを作成
+// HOLIDAY_DATA_PATH が設定されていれば祝日CSVを読み込み、未設定の場合は同梱データを使用する
+func createHolidayCalendarService(mainDB *sql.DB) *service.HolidayCalendarService {
+	var calendar *service.HolidayCalendarService
+	if path := os.Getenv("HOLIDAY_DATA_PATH"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("祝日データ読み込みエラー: %v", err)
+		}
+		defer f.Close()
+		holidays, err := service.LoadNationalHolidays(f)
+		if err != nil {
+			log.Fatalf("祝日データ読み込みエラー: %v", err)
+		}
+		calendar = service.NewHolidayCalendarService(holidays)
+	} else {
+		bundled, err := service.NewBundledHolidayCalendarService()
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		calendar = bundled
+	}
+
+	first, last := calendar.YearRange()
+	log.Printf("祝日データ: %d年〜%d年", first, last)
+	if year := time.Now().Year(); !calendar.Covers(year + 1) {
+		log.Printf("祝日データが%d年を含んでいません。go run ./cmd/tools/update_holidays で更新してください", year+1)
+	}
+
+	// 会社休日（年末年始など）
+	calendar.SetCompanyHolidayGetter(repository.NewCompanyHolidayRepository(mainDB))
+	return calendar
+}
+
+// createTaxCalculator 環境変数から消費税設定を作成
+// TAX_RATE_PERCENT: 税率（%、デフォルト10）、TAX_ROUNDING: 端数処理（floor / round、デフォルトfloor）
+func createTaxCalculator() *service.TaxCalculator {
+	rate := service.DefaultTaxRatePercent
+	if v := os.Getenv("TAX_RATE_PERCENT"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("TAX_RATE_PERCENTが不正です: %s", v)
+		}
+		rate = parsed
+	}
+
+	rounding := service.DefaultTaxRounding
+	if v := os.Getenv("TAX_ROUNDING"); v != "" {
+		parsed, err := service.ParseTaxRounding(v)
+		if err != nil {
+			log.Fatalf("TAX_ROUNDINGが不正です: %v", err)
+		}
+		rounding = parsed
+	}
+
+	tax, err := service.NewTaxCalculator(rate, rounding)
+	if err != nil {
+		log.Fatalf("消費税設定エラー: %v", err)
+	}
+	log.Printf("消費税: %s", tax.Label())
+	return tax
 }
 
 // mockFareGetter 距離制運賃のモック
 type mockFareGetter struct{}
 
-func (m *mockFareGetter) GetDistanceFareYen(regionCode, vehicleCode, distanceKm int) (int, error) {
+func (m *mockFareGetter) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
 	// モック: 基本的な運賃計算
 	baseFare := 10000 + distanceKm*100
 	return baseFare, nil
@@ -228,7 +500,7 @@ func (m *mockFareGetter) GetDistanceFareYen(regionCode, vehicleCode, distanceKm
 // mockTimeFareGetter 時間制運賃のモック
 type mockTimeFareGetter struct{}
 
-func (m *mockTimeFareGetter) GetBaseFare(regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
+func (m *mockTimeFareGetter) GetBaseFare(version *model.TariffVersion, regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
 	return &model.JtaTimeBaseFare{
 		RegionCode:  regionCode,
 		VehicleCode: vehicleCode,
@@ -238,7 +510,7 @@ func (m *mockTimeFareGetter) GetBaseFare(regionCode, vehicleCode, hours int) (*m
 	}, nil
 }
 
-func (m *mockTimeFareGetter) GetSurcharge(regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
+func (m *mockTimeFareGetter) GetSurcharge(version *model.TariffVersion, regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
 	fareYen := 0
 	switch surchargeType {
 	case "distance":
@@ -0,0 +1,128 @@
+// 距離制・時間制の運賃表（CSV・XLSX）を作成するツール
+// 見積と同じ計算サービスで運輸局・車格・距離・割増条件ごとの運賃を計算する
+// 距離制運賃はSupabase（SUPABASE_URL / SUPABASE_ANON_KEY）から取得し、未設定の場合は同期済みのローカルミラーを使う
+// 使用方法:
+//
+//	go run ./cmd/tools/fare_matrix -regions 3 -vehicles 1,2,3,4 -from 10 -to 500 -step 10 -o fare_matrix.xlsx
+//	go run ./cmd/tools/fare_matrix -regions 3,6 -variants normal,night,holiday,night_holiday -format csv -o fare_matrix.csv
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/database"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/repository"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+func main() {
+	// コマンドライン引数
+	dbPath := flag.String("db", "data/str.db", "メインDBのパス（時間制運賃・運賃版）")
+	regions := flag.String("regions", "3", "運輸局コード（カンマ区切り）")
+	vehicles := flag.String("vehicles", "1,2,3,4", "車格コード（カンマ区切り）")
+	from := flag.Int("from", 10, "開始距離（km）")
+	to := flag.Int("to", 500, "終了距離（km）")
+	step := flag.Int("step", 10, "距離の刻み幅（km）")
+	variants := flag.String("variants", "normal", "割増条件（normal / night / holiday / night_holiday をカンマ区切り）")
+	quoteDate := flag.String("quote-date", "", "見積日（YYYY-MM-DD、空は当日）")
+	speed := flag.Int("speed", service.DefaultFareMatrixSpeedKmh, "時間制運賃の平均速度（km/h）")
+	loading := flag.Int("loading", service.DefaultFareMatrixLoadingMinutes, "時間制運賃の荷役時間（分）")
+	simpleBaseKm := flag.Bool("simple-base-km", false, "シンプル版基礎走行キロを使用する")
+	format := flag.String("format", "", "出力形式（csv / xlsx、空は出力ファイルの拡張子から判定）")
+	output := flag.String("o", "", "出力ファイル（空は標準出力）")
+	flag.Parse()
+
+	req := &service.FareMatrixRequest{
+		SpeedKmh:        *speed,
+		LoadingMinutes:  *loading,
+		UseSimpleBaseKm: *simpleBaseKm,
+	}
+	var err error
+	if req.RegionCodes, err = service.ParseFareMatrixCodes(*regions); err != nil {
+		log.Fatalf("運輸局コードが不正です: %v", err)
+	}
+	if req.VehicleCodes, err = service.ParseFareMatrixCodes(*vehicles); err != nil {
+		log.Fatalf("車格コードが不正です: %v", err)
+	}
+	if req.DistancesKm, err = service.FareMatrixDistances(*from, *to, *step); err != nil {
+		log.Fatalf("%v", err)
+	}
+	for _, s := range strings.Split(*variants, ",") {
+		variant, err := service.ParseFareMatrixVariant(s)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		req.Variants = append(req.Variants, variant)
+	}
+	if *quoteDate != "" {
+		d, err := time.ParseInLocation(model.TariffDateFormat, *quoteDate, time.Local)
+		if err != nil {
+			log.Fatalf("見積日の形式が不正です（YYYY-MM-DD）: %s", *quoteDate)
+		}
+		req.QuoteDate = d
+	}
+
+	if *format == "" {
+		*format = service.FareMatrixFormatCSV
+		if strings.EqualFold(filepath.Ext(*output), ".xlsx") {
+			*format = service.FareMatrixFormatXLSX
+		}
+	}
+
+	// 時間制運賃・運賃版・ローカルミラー（メインDB）
+	absPath, err := filepath.Abs(*dbPath)
+	if err != nil {
+		log.Fatalf("パス解決エラー: %v", err)
+	}
+	db, err := database.InitMainDB(absPath)
+	if err != nil {
+		log.Fatalf("DB初期化エラー: %v", err)
+	}
+	defer db.Close()
+
+	// 距離制運賃（Supabase、未設定の場合はローカルミラー）
+	var fareGetter service.FareGetter
+	if supabaseURL, supabaseKey := os.Getenv("SUPABASE_URL"), os.Getenv("SUPABASE_ANON_KEY"); supabaseURL != "" && supabaseKey != "" {
+		fareGetter = service.NewJtaSupabaseClientAdapter(service.NewJtaSupabaseClient(supabaseURL, supabaseKey))
+	} else {
+		mirror := repository.NewJtaMirrorRepository(db)
+		if _, err := mirror.GetSyncStatus(model.JtaTableFareRates); err != nil {
+			log.Fatal("SUPABASE_URL/SUPABASE_ANON_KEYを設定するか、go run ./cmd/tools/sync_jta_fares でローカルミラーを同期してください")
+		}
+		fareGetter = service.NewJtaMirrorFareGetter(mirror)
+		log.Println("距離制運賃はローカルミラーを使用します")
+	}
+
+	matrixService := service.NewFareMatrixService(
+		service.NewDistanceFareService(fareGetter),
+		service.NewTimeFareService(repository.NewJtaTimeFareRepository(db)),
+	)
+	matrixService.SetTariffVersionResolver(repository.NewTariffVersionRepository(db))
+
+	matrix, err := matrixService.Build(req)
+	if err != nil {
+		log.Fatalf("運賃表の作成エラー: %v", err)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("出力ファイル作成エラー: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := matrix.Write(out, *format); err != nil {
+		log.Fatalf("運賃表の出力エラー: %v", err)
+	}
+	if *output != "" {
+		log.Printf("運賃表を出力しました: %s（%d表）", *output, len(matrix.Tables))
+	}
+}
@@ -0,0 +1,147 @@
+// 日本郵便の郵便番号データ（KEN_ALL、全国一括）をメインDB（str.db）へ取り込むツール
+// 取り込んだデータで郵便番号・住所の一部から都道府県・市区町村を判定し、運輸局・赤帽地区をAPIなしで求める
+// 使用方法:
+//
+//	go run ./cmd/tools/import_postal_codes
+//	go run ./cmd/tools/import_postal_codes -src ken_all.zip
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/database"
+	"github.com/y-suzuki/standard-truck-rate/internal/repository"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// defaultSourceURL 日本郵便「郵便番号データ（読み仮名データの促音・拗音を小書きで表記するもの）全国一括」
+const defaultSourceURL = "https://www.post.japanpost.jp/zipcode/dl/kogaki/zip/ken_all.zip"
+
+func main() {
+	// コマンドライン引数
+	dbPath := flag.String("db", "data/str.db", "メインDBのパス")
+	url := flag.String("url", defaultSourceURL, "郵便番号データ（ZIP）の取得元URL")
+	src := flag.String("src", "", "郵便番号データのローカルファイル（ZIPまたはCSV、指定時はURLから取得しない）")
+	dryRun := flag.Bool("dry-run", false, "実際にDBに書き込まない（確認用）")
+	flag.Parse()
+
+	log.Println("=== 郵便番号データ取込ツール ===")
+
+	var body []byte
+	var err error
+	if *src != "" {
+		log.Printf("読み込み: %s", *src)
+		body, err = os.ReadFile(*src)
+	} else {
+		log.Printf("取得: %s", *url)
+		body, err = fetch(*url)
+	}
+	if err != nil {
+		log.Fatalf("郵便番号データ取得エラー: %v", err)
+	}
+
+	// ZIPの場合は中のCSVを取り出す
+	if bytes.HasPrefix(body, []byte("PK")) {
+		body, err = extractCSV(body)
+		if err != nil {
+			log.Fatalf("ZIP展開エラー: %v", err)
+		}
+	}
+
+	// 日本郵便のCSVはShift_JISのため、UTF-8でなければ変換する
+	if !utf8.Valid(body) {
+		body, err = japanese.ShiftJIS.NewDecoder().Bytes(body)
+		if err != nil {
+			log.Fatalf("文字コード変換エラー: %v", err)
+		}
+	}
+
+	codes, err := service.LoadKenAll(bytes.NewReader(body))
+	if err != nil {
+		log.Fatalf("郵便番号データ解析エラー: %v", err)
+	}
+	log.Printf("読み込み件数: %d件", len(codes))
+
+	if *dryRun {
+		log.Println("--- dry-runモード：DBへの書き込みをスキップ ---")
+		for _, c := range codes[:min(10, len(codes))] {
+			fmt.Printf("  %s %s%s%s\n", c.PostalCode, c.Prefecture, c.City, c.Town)
+		}
+		return
+	}
+
+	absPath, err := filepath.Abs(*dbPath)
+	if err != nil {
+		log.Fatalf("パス解決エラー: %v", err)
+	}
+	log.Printf("DB: %s", absPath)
+	db, err := database.InitMainDB(absPath)
+	if err != nil {
+		log.Fatalf("DB初期化エラー: %v", err)
+	}
+	defer db.Close()
+
+	// 全件入れ替え
+	repo := repository.NewPostalCodeRepository(db)
+	if err := repo.ReplaceAll(codes); err != nil {
+		log.Fatalf("登録エラー: %v", err)
+	}
+
+	count, err := repo.Count()
+	if err != nil {
+		log.Fatalf("件数取得エラー: %v", err)
+	}
+	municipalities, err := repo.GetMunicipalities()
+	if err != nil {
+		log.Fatalf("市区町村取得エラー: %v", err)
+	}
+
+	log.Printf("登録完了: %d件（%d市区町村）", count, len(municipalities))
+	log.Println("=== 完了 ===")
+}
+
+// fetch URLから郵便番号データを取得
+func fetch(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// extractCSV ZIPから最初のCSVファイルを取り出す
+func extractCSV(data []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if !strings.EqualFold(filepath.Ext(f.Name), ".csv") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("ZIPにCSVファイルがありません")
+}
@@ -0,0 +1,158 @@
+// 荷主と契約条件（値引き・割増・最低運賃）を登録するツール
+// 使用方法:
+//
+//	go run ./cmd/tools/set_customer_rule -code C001 -name 山田商事 -rule 2025年度契約 -percent -10 -from 2025-04-01
+//	go run ./cmd/tools/set_customer_rule -code C001 -rule 大型車最低運賃 -minimum 30000 -vehicles 3,4
+//	go run ./cmd/tools/set_customer_rule -list
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/database"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/repository"
+)
+
+func main() {
+	// コマンドライン引数
+	dbPath := flag.String("db", "data/str.db", "メインDBのパス")
+	code := flag.String("code", "", "荷主コード")
+	name := flag.String("name", "", "荷主名（新規登録・変更時）")
+	ruleName := flag.String("rule", "", "契約条件名（指定時は契約条件を追加する）")
+	percent := flag.Int("percent", 0, "増減率（%、-10で1割引）")
+	yen := flag.Int("yen", 0, "増減額（円、-2000で2000円引）。-percentとは同時に指定できない")
+	minimum := flag.Int("minimum", 0, "最低運賃（円、税抜）")
+	vehicles := flag.String("vehicles", "", "対象車格コード（カンマ区切り、空は全車格）")
+	regions := flag.String("regions", "", "対象運輸局コード（カンマ区切り、空は全地域）")
+	from := flag.String("from", "", "適用開始日（YYYY-MM-DD）")
+	to := flag.String("to", "", "適用終了日（YYYY-MM-DD）")
+	list := flag.Bool("list", false, "登録済みの荷主と契約条件を一覧表示する")
+	flag.Parse()
+
+	absPath, err := filepath.Abs(*dbPath)
+	if err != nil {
+		log.Fatalf("パス解決エラー: %v", err)
+	}
+	db, err := database.InitMainDB(absPath)
+	if err != nil {
+		log.Fatalf("DB初期化エラー: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewCustomerRepository(db)
+
+	if *list {
+		printCustomers(repo)
+		return
+	}
+
+	if *code == "" {
+		log.Fatal("荷主コードを -code で指定してください")
+	}
+
+	// 荷主（名前指定時は登録・更新）
+	customer, err := repo.GetCustomerByCode(*code)
+	if errors.Is(err, sql.ErrNoRows) {
+		if *name == "" {
+			log.Fatalf("荷主が登録されていません。-name で荷主名を指定してください: %s", *code)
+		}
+		customer = &model.Customer{Code: *code}
+	} else if err != nil {
+		log.Fatalf("荷主取得エラー: %v", err)
+	}
+	if *name != "" {
+		customer.Name = *name
+		id, err := repo.Upsert(customer)
+		if err != nil {
+			log.Fatalf("荷主登録エラー: %v", err)
+		}
+		customer.ID = id
+		log.Printf("荷主を登録しました: %s %s（ID %d）", customer.Code, customer.Name, customer.ID)
+	}
+
+	if *ruleName == "" {
+		return
+	}
+
+	// 契約条件
+	rule := &model.CustomerPricingRule{
+		CustomerID:        customer.ID,
+		Name:              *ruleName,
+		AdjustmentType:    model.CustomerAdjustmentRate,
+		AdjustmentPercent: *percent,
+		MinimumFareYen:    *minimum,
+		VehicleCodes:      *vehicles,
+		RegionCodes:       *regions,
+	}
+	if *yen != 0 {
+		if *percent != 0 {
+			log.Fatal("-percent と -yen は同時に指定できません")
+		}
+		rule.AdjustmentType = model.CustomerAdjustmentFixed
+		rule.AdjustmentPercent = 0
+		rule.AdjustmentYen = *yen
+	}
+	if *minimum < 0 {
+		log.Fatalf("最低運賃が不正です: %d", *minimum)
+	}
+	rule.ValidFrom = parseDateFlag("適用開始日", *from)
+	rule.ValidTo = parseDateFlag("適用終了日", *to)
+
+	if _, err := repo.CreatePricingRule(rule); err != nil {
+		log.Fatalf("契約条件登録エラー: %v", err)
+	}
+	log.Printf("契約条件を登録しました: %s %s（%s）", customer.Name, rule.Name, rule.AdjustmentLabel())
+}
+
+// parseDateFlag 日付の引数を検証する（空の場合はnil）
+func parseDateFlag(label, value string) *string {
+	if value == "" {
+		return nil
+	}
+	if _, err := time.Parse(model.TariffDateFormat, value); err != nil {
+		log.Fatalf("%sの形式が不正です（YYYY-MM-DD）: %s", label, value)
+	}
+	return &value
+}
+
+// printCustomers 登録済みの荷主と契約条件を表示する
+func printCustomers(repo *repository.CustomerRepository) {
+	customers, err := repo.GetAll()
+	if err != nil {
+		log.Fatalf("荷主取得エラー: %v", err)
+	}
+	for _, c := range customers {
+		fmt.Printf("%s  %s（ID %d）\n", c.Code, c.Name, c.ID)
+		rules, err := repo.GetPricingRules(c.ID)
+		if err != nil {
+			log.Fatalf("契約条件取得エラー: %v", err)
+		}
+		for _, r := range rules {
+			fmt.Printf("  - %s: %s 車格[%s] 運輸局[%s] 期間 %s〜%s\n",
+				r.Name, r.AdjustmentLabel(), orAll(r.VehicleCodes), orAll(r.RegionCodes), deref(r.ValidFrom), deref(r.ValidTo))
+		}
+	}
+}
+
+// orAll 空のコード一覧を「全て」と表示する
+func orAll(codes string) string {
+	if codes == "" {
+		return "全て"
+	}
+	return codes
+}
+
+// deref 日付を表示用文字列にする（nilは空）
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,73 @@
+// 月別の軽油価格（燃料サーチャージ用）を登録するツール
+// 使用方法: go run ./cmd/tools/set_fuel_price -month 2026-10 -price 154.3
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/database"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/repository"
+)
+
+// defaultReferencePriceYen 標準的な運賃の基準価格（軽油 円/L）
+const defaultReferencePriceYen = 120.0
+
+func main() {
+	// コマンドライン引数
+	dbPath := flag.String("db", "data/str.db", "メインDBのパス")
+	month := flag.String("month", time.Now().Format(model.FuelYearMonthFormat), "対象月（YYYY-MM）")
+	price := flag.Float64("price", 0, "軽油価格（円/L）")
+	reference := flag.Float64("reference", defaultReferencePriceYen, "基準価格（円/L）")
+	source := flag.String("source", "", "出典（任意）")
+	list := flag.Bool("list", false, "登録済みの燃料価格を一覧表示する")
+	flag.Parse()
+
+	absPath, err := filepath.Abs(*dbPath)
+	if err != nil {
+		log.Fatalf("パス解決エラー: %v", err)
+	}
+	db, err := database.InitMainDB(absPath)
+	if err != nil {
+		log.Fatalf("DB初期化エラー: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewFuelSurchargeRepository(db)
+
+	if *list {
+		prices, err := repo.GetAllFuelPrices()
+		if err != nil {
+			log.Fatalf("燃料価格取得エラー: %v", err)
+		}
+		for _, p := range prices {
+			fmt.Printf("%s  軽油 %.1f円/L（基準 %.1f円/L）\n", p.YearMonth, p.PriceYen, p.ReferencePriceYen)
+		}
+		return
+	}
+
+	if _, err := time.Parse(model.FuelYearMonthFormat, *month); err != nil {
+		log.Fatalf("対象月の形式が不正です（YYYY-MM）: %s", *month)
+	}
+	if *price <= 0 {
+		log.Fatal("軽油価格を -price で指定してください")
+	}
+
+	fuelPrice := &model.FuelPrice{
+		YearMonth:         *month,
+		PriceYen:          *price,
+		ReferencePriceYen: *reference,
+	}
+	if *source != "" {
+		fuelPrice.Source = source
+	}
+	if err := repo.UpsertFuelPrice(fuelPrice); err != nil {
+		log.Fatalf("登録エラー: %v", err)
+	}
+
+	log.Printf("登録完了: %s 軽油 %.1f円/L（基準 %.1f円/L）", *month, *price, *reference)
+}
@@ -0,0 +1,85 @@
+// トラ協データ（Supabase fare_rates・charge_data）をローカルミラー（str.db）へ同期するツール
+// Supabaseが利用できない場合もローカルミラーで距離制運賃・付帯料金を計算できるようにする
+// 使用方法:
+//
+//	SUPABASE_URL=... SUPABASE_ANON_KEY=... go run ./cmd/tools/sync_jta_fares
+//	go run ./cmd/tools/sync_jta_fares -status
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/database"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/repository"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+func main() {
+	// コマンドライン引数
+	dbPath := flag.String("db", "data/str.db", "メインDBのパス")
+	status := flag.Bool("status", false, "同期せずにローカルミラーの同期状態を表示する")
+	flag.Parse()
+
+	absPath, err := filepath.Abs(*dbPath)
+	if err != nil {
+		log.Fatalf("パス解決エラー: %v", err)
+	}
+	db, err := database.InitMainDB(absPath)
+	if err != nil {
+		log.Fatalf("DB初期化エラー: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewJtaMirrorRepository(db)
+
+	if *status {
+		printSyncStatus(repo)
+		return
+	}
+
+	supabaseURL := os.Getenv("SUPABASE_URL")
+	supabaseKey := os.Getenv("SUPABASE_ANON_KEY")
+	if supabaseURL == "" || supabaseKey == "" {
+		log.Fatal("SUPABASE_URL/SUPABASE_ANON_KEYを設定してください")
+	}
+
+	log.Println("Supabaseからトラ協データを取得しています...")
+	syncService := service.NewJtaMirrorSyncService(service.NewJtaSupabaseClient(supabaseURL, supabaseKey), repo)
+	result, err := syncService.Sync()
+	if err != nil {
+		log.Fatalf("同期エラー: %v", err)
+	}
+
+	fmt.Println(result.FareRates.Summary())
+	fmt.Println(result.ChargeData.Summary())
+	fmt.Printf("同期日時: %s\n", result.FareRates.Status.SyncedAt.Format("2006-01-02 15:04:05"))
+}
+
+// printSyncStatus ローカルミラーの同期状態を表示
+func printSyncStatus(repo *repository.JtaMirrorRepository) {
+	for _, table := range []string{model.JtaTableFareRates, model.JtaTableChargeData} {
+		status, err := repo.GetSyncStatus(table)
+		if errors.Is(err, sql.ErrNoRows) {
+			fmt.Printf("%s: 未同期\n", table)
+			continue
+		}
+		if err != nil {
+			log.Fatalf("同期状態の取得エラー: %v", err)
+		}
+
+		stale := ""
+		if time.Since(status.SyncedAt) > service.JtaMirrorStaleAfter {
+			stale = "（要再同期）"
+		}
+		fmt.Printf("%s: %d件 チェックサム %s 同期日時 %s%s\n",
+			table, status.RowCount, status.Checksum, status.SyncedAt.Format("2006-01-02 15:04:05"), stale)
+	}
+}
@@ -0,0 +1,94 @@
+// 国民の祝日データ（内閣府「国民の祝日」CSV）を取得して同梱データを更新するツール
+// 使用方法: go run ./cmd/tools/update_holidays
+// 更新後は再ビルドで同梱データに反映される（HOLIDAY_DATA_PATH で実行時に差し替えることも可能）
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// defaultSourceURL 内閣府「国民の祝日」CSV（Shift_JIS）
+const defaultSourceURL = "https://www8.cao.go.jp/chosei/shukujitsu/syukujitsu.csv"
+
+func main() {
+	// コマンドライン引数
+	url := flag.String("url", defaultSourceURL, "祝日CSVの取得元URL")
+	src := flag.String("src", "", "祝日CSVのローカルファイル（指定時はURLから取得しない）")
+	out := flag.String("out", "internal/service/data/national_holidays.csv", "出力先")
+	dryRun := flag.Bool("dry-run", false, "ファイルに書き込まない（確認用）")
+	flag.Parse()
+
+	log.Println("=== 祝日データ更新ツール ===")
+
+	var body []byte
+	var err error
+	if *src != "" {
+		log.Printf("読み込み: %s", *src)
+		body, err = os.ReadFile(*src)
+	} else {
+		log.Printf("取得: %s", *url)
+		body, err = fetch(*url)
+	}
+	if err != nil {
+		log.Fatalf("祝日CSV取得エラー: %v", err)
+	}
+
+	// 内閣府CSVはShift_JISのため、UTF-8でなければ変換する
+	if !utf8.Valid(body) {
+		body, err = japanese.ShiftJIS.NewDecoder().Bytes(body)
+		if err != nil {
+			log.Fatalf("文字コード変換エラー: %v", err)
+		}
+	}
+
+	holidays, err := service.LoadNationalHolidays(bytes.NewReader(body))
+	if err != nil {
+		log.Fatalf("祝日CSV解析エラー: %v", err)
+	}
+	log.Printf("国民の祝日: %d件（%s 〜 %s）", len(holidays),
+		holidays[0].Date.Format(service.HolidayDateFormat), holidays[len(holidays)-1].Date.Format(service.HolidayDateFormat))
+
+	if *dryRun {
+		log.Println("--- dry-runモード：ファイルへの書き込みをスキップ ---")
+		for _, h := range holidays[max(0, len(holidays)-20):] {
+			fmt.Printf("  %s %s\n", h.Date.Format(service.HolidayDateFormat), h.Name)
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := service.WriteNationalHolidays(&buf, holidays); err != nil {
+		log.Fatalf("CSV書き出しエラー: %v", err)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0644); err != nil {
+		log.Fatalf("ファイル書き込みエラー: %v", err)
+	}
+
+	log.Printf("更新完了: %s", *out)
+}
+
+// fetch URLからデータを取得する
+func fetch(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTPステータス %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -4,10 +4,17 @@ import (
 	"database/sql"
 	"os"
 	"path/filepath"
+	"slices"
 
 	_ "modernc.org/sqlite"
 )
 
+// キャッシュDBのデータ移行の版（cache_migrations に版ごとに記録し、各移行は1回だけ実行する）
+const (
+	CacheMigrationNormalizeRouteAddresses = 1 // ルートキャッシュの住所表記の統一
+	CacheMigrationPurgeVehicleRouteKeys   = 2 // 条件を使わないエンジンのルートを寸法・重量のキーで保存したキャッシュの削除
+)
+
 // InitMainDB メインDB（str.db）を初期化し、全テーブルを作成する
 func InitMainDB(dbPath string) (*sql.DB, error) {
 	if err := ensureDir(dbPath); err != nil {
@@ -51,32 +58,71 @@ func ensureDir(dbPath string) error {
 	return os.MkdirAll(dir, 0755)
 }
 
+// tariffVersionedTables 運賃版（tariff_version_id）を持つマスタテーブルと移行対象カラム
+var tariffVersionedTables = []struct {
+	name    string
+	columns string
+}{
+	{"jta_time_base_fares", "id, region_code, vehicle_code, hours, base_km, fare_yen"},
+	{"jta_time_surcharges", "id, region_code, vehicle_code, surcharge_type, fare_yen"},
+	{"akabou_distance_fares", "id, min_km, max_km, base_fare, per_km_rate"},
+	{"akabou_time_fares", "id, base_hours, base_km, base_fare, overtime_rate"},
+	{"akabou_surcharges", "id, surcharge_type, rate_percent, description"},
+	{"akabou_area_surcharges", "id, area_name, surcharge_amount"},
+	{"akabou_additional_fees", "id, fee_type, free_minutes, unit_minutes, fee_amount"},
+}
+
 func createMainTables(db *sql.DB) error {
+	// 運賃版導入前のテーブルはUNIQUE制約が異なるため退避して作り直す
+	legacyTables, err := renameLegacyTariffTables(db)
+	if err != nil {
+		return err
+	}
+	// API種別（api_type）導入前のAPI使用量は主キーが異なるため退避して作り直す
+	legacyApiUsage, err := renameLegacyTable(db, "api_usage", "api_type")
+	if err != nil {
+		return err
+	}
+
 	schemas := []string{
+		// 運賃版（告示改定ごとの適用期間）
+		`CREATE TABLE IF NOT EXISTS tariff_versions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			tariff_type TEXT NOT NULL,
+			name TEXT NOT NULL,
+			effective_from TEXT NOT NULL,
+			effective_to TEXT,
+			description TEXT,
+			UNIQUE(tariff_type, effective_from)
+		)`,
+
 		// トラ協時間制・基礎額
 		`CREATE TABLE IF NOT EXISTS jta_time_base_fares (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
 			region_code INTEGER NOT NULL,
 			vehicle_code INTEGER NOT NULL,
 			hours INTEGER NOT NULL,
 			base_km INTEGER NOT NULL,
 			fare_yen INTEGER NOT NULL,
-			UNIQUE(region_code, vehicle_code, hours)
+			UNIQUE(tariff_version_id, region_code, vehicle_code, hours)
 		)`,
 
 		// トラ協時間制・加算額
 		`CREATE TABLE IF NOT EXISTS jta_time_surcharges (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
 			region_code INTEGER NOT NULL,
 			vehicle_code INTEGER NOT NULL,
 			surcharge_type TEXT NOT NULL,
 			fare_yen INTEGER NOT NULL,
-			UNIQUE(region_code, vehicle_code, surcharge_type)
+			UNIQUE(tariff_version_id, region_code, vehicle_code, surcharge_type)
 		)`,
 
 		// 赤帽距離制運賃
 		`CREATE TABLE IF NOT EXISTS akabou_distance_fares (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
 			min_km INTEGER NOT NULL,
 			max_km INTEGER,
 			base_fare INTEGER,
@@ -86,42 +132,163 @@ func createMainTables(db *sql.DB) error {
 		// 赤帽時間制運賃
 		`CREATE TABLE IF NOT EXISTS akabou_time_fares (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
 			base_hours INTEGER NOT NULL,
 			base_km INTEGER NOT NULL,
 			base_fare INTEGER NOT NULL,
-			overtime_rate INTEGER NOT NULL
+			overtime_rate INTEGER NOT NULL,
+			overtime_unit_minutes INTEGER NOT NULL DEFAULT 30
 		)`,
 
 		// 赤帽割増料金
 		`CREATE TABLE IF NOT EXISTS akabou_surcharges (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			surcharge_type TEXT NOT NULL UNIQUE,
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
+			surcharge_type TEXT NOT NULL,
 			rate_percent INTEGER NOT NULL,
-			description TEXT
+			description TEXT,
+			UNIQUE(tariff_version_id, surcharge_type)
 		)`,
 
 		// 赤帽地区割増
 		`CREATE TABLE IF NOT EXISTS akabou_area_surcharges (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			area_name TEXT NOT NULL UNIQUE,
-			surcharge_amount INTEGER NOT NULL
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
+			area_name TEXT NOT NULL,
+			surcharge_amount INTEGER NOT NULL,
+			UNIQUE(tariff_version_id, area_name)
 		)`,
 
 		// 赤帽付帯料金
 		`CREATE TABLE IF NOT EXISTS akabou_additional_fees (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			fee_type TEXT NOT NULL UNIQUE,
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
+			fee_type TEXT NOT NULL,
 			free_minutes INTEGER NOT NULL,
 			unit_minutes INTEGER NOT NULL,
-			fee_amount INTEGER NOT NULL
+			fee_amount INTEGER NOT NULL,
+			UNIQUE(tariff_version_id, fee_type)
 		)`,
 
-		// API使用量
-		`CREATE TABLE IF NOT EXISTS api_usage (
+		// 燃料価格（月別の軽油価格）
+		`CREATE TABLE IF NOT EXISTS fuel_prices (
 			year_month TEXT PRIMARY KEY,
+			price_yen REAL NOT NULL,
+			reference_price_yen REAL NOT NULL,
+			source TEXT
+		)`,
+
+		// 燃料サーチャージ（距離帯・車格別）
+		`CREATE TABLE IF NOT EXISTS fuel_surcharges (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
+			vehicle_code INTEGER NOT NULL,
+			min_km INTEGER NOT NULL,
+			max_km INTEGER,
+			step_yen INTEGER NOT NULL DEFAULT 5,
+			amount_yen INTEGER NOT NULL,
+			UNIQUE(tariff_version_id, vehicle_code, min_km)
+		)`,
+
+		// 特殊車両割増（車体種別ごとの割増率、トラ協運賃用）
+		`CREATE TABLE IF NOT EXISTS jta_body_type_surcharges (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			tariff_version_id INTEGER NOT NULL DEFAULT 0,
+			body_type TEXT NOT NULL,
+			name TEXT NOT NULL,
+			surcharge_percent INTEGER NOT NULL,
+			sort_order INTEGER NOT NULL DEFAULT 0,
+			UNIQUE(tariff_version_id, body_type)
+		)`,
+
+		// 割増項目（速達割増・手積み手降ろしなど任意で選択する割増。割合 rate または定額 fixed）
+		`CREATE TABLE IF NOT EXISTS surcharge_items (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			code TEXT NOT NULL UNIQUE,
+			name TEXT NOT NULL,
+			calc_type TEXT NOT NULL CHECK (calc_type IN ('rate', 'fixed')),
+			rate_percent INTEGER NOT NULL DEFAULT 0,
+			amount_yen INTEGER NOT NULL DEFAULT 0,
+			vehicle_codes TEXT NOT NULL DEFAULT '',
+			sort_order INTEGER NOT NULL DEFAULT 0
+		)`,
+
+		// 荷主マスタ
+		`CREATE TABLE IF NOT EXISTS customers (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			code TEXT NOT NULL UNIQUE,
+			name TEXT NOT NULL,
+			note TEXT
+		)`,
+
+		// 荷主ごとの契約条件（値引き・割増・最低運賃。車格・運輸局・適用期間で対象を絞り込む）
+		`CREATE TABLE IF NOT EXISTS customer_pricing_rules (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			customer_id INTEGER NOT NULL,
+			name TEXT NOT NULL DEFAULT '',
+			adjustment_type TEXT NOT NULL CHECK (adjustment_type IN ('rate', 'fixed')),
+			adjustment_percent INTEGER NOT NULL DEFAULT 0,
+			adjustment_yen INTEGER NOT NULL DEFAULT 0,
+			minimum_fare_yen INTEGER NOT NULL DEFAULT 0,
+			vehicle_codes TEXT NOT NULL DEFAULT '',
+			region_codes TEXT NOT NULL DEFAULT '',
+			valid_from TEXT,
+			valid_to TEXT
+		)`,
+
+		// 会社休日（年末年始など。特定日 date または毎年の月日 month_day のどちらかを指定）
+		`CREATE TABLE IF NOT EXISTS company_holidays (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			date TEXT,
+			month_day TEXT,
+			CHECK ((date IS NULL) <> (month_day IS NULL))
+		)`,
+
+		// トラ協距離制運賃のローカルミラー（Supabase fare_rates を同期）
+		`CREATE TABLE IF NOT EXISTS jta_fare_rates (
+			region_code INTEGER NOT NULL,
+			vehicle_code INTEGER NOT NULL,
+			upto_km INTEGER NOT NULL,
+			fare_yen INTEGER NOT NULL,
+			PRIMARY KEY (region_code, vehicle_code, upto_km)
+		)`,
+
+		// トラ協付帯料金のローカルミラー（Supabase charge_data を同期）
+		`CREATE TABLE IF NOT EXISTS jta_charge_data (
+			id_code INTEGER PRIMARY KEY,
+			vehicle_code INTEGER NOT NULL,
+			time_code INTEGER NOT NULL,
+			charge_yen INTEGER NOT NULL,
+			per_1m_yen INTEGER
+		)`,
+
+		// ローカルミラーの同期状態（同期元テーブルごとの件数・チェックサム・同期日時）
+		`CREATE TABLE IF NOT EXISTS jta_sync_status (
+			table_name TEXT PRIMARY KEY,
+			row_count INTEGER NOT NULL,
+			checksum TEXT NOT NULL,
+			synced_at DATETIME NOT NULL
+		)`,
+
+		// 郵便番号データ（日本郵便 KEN_ALL 形式、郵便番号・住所から都道府県・市区町村をAPIなしで判定する）
+		`CREATE TABLE IF NOT EXISTS postal_codes (
+			postal_code TEXT NOT NULL,
+			jis_code TEXT NOT NULL,
+			prefecture TEXT NOT NULL,
+			city TEXT NOT NULL,
+			town TEXT NOT NULL DEFAULT '',
+			PRIMARY KEY (postal_code, jis_code, town)
+		)`,
+
+		// API使用量（API種別ごとの月別カウント。routes: Routes API、geocoding: Geocoding API）
+		`CREATE TABLE IF NOT EXISTS api_usage (
+			year_month TEXT NOT NULL,
+			api_type TEXT NOT NULL DEFAULT 'routes',
 			request_count INTEGER NOT NULL DEFAULT 0,
 			limit_count INTEGER NOT NULL DEFAULT 9000,
-			last_updated DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+			last_updated DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (year_month, api_type)
 		)`,
 
 		// 高速道路ICマスタ
@@ -138,6 +305,12 @@ func createMainTables(db *sql.DB) error {
 		// ICマスタ検索用インデックス
 		`CREATE INDEX IF NOT EXISTS idx_highway_ic_name ON highway_ic_master(name)`,
 		`CREATE INDEX IF NOT EXISTS idx_highway_ic_yomi ON highway_ic_master(yomi)`,
+
+		// 郵便番号データの市区町村検索用インデックス
+		`CREATE INDEX IF NOT EXISTS idx_postal_codes_city ON postal_codes(prefecture, city)`,
+
+		// 契約条件の荷主別検索用インデックス
+		`CREATE INDEX IF NOT EXISTS idx_customer_pricing_rules_customer ON customer_pricing_rules(customer_id)`,
 	}
 
 	for _, schema := range schemas {
@@ -146,19 +319,148 @@ func createMainTables(db *sql.DB) error {
 		}
 	}
 
+	if err := restoreLegacyTariffTables(db, legacyTables); err != nil {
+		return err
+	}
+	if legacyApiUsage {
+		// 旧データはすべて Routes API の使用量として移す
+		if err := restoreLegacyTable(db, "api_usage", "year_month, request_count, limit_count, last_updated"); err != nil {
+			return err
+		}
+	}
+
+	return addMissingColumns(db, mainAddedColumns)
+}
+
+// addedColumn 既存テーブルに後から追加したカラム
+type addedColumn struct {
+	table      string
+	column     string
+	definition string
+}
+
+// mainAddedColumns メインDBで後から追加したカラム（既存DBに ALTER TABLE で追加する）
+var mainAddedColumns = []addedColumn{
+	{"akabou_time_fares", "overtime_unit_minutes", "INTEGER NOT NULL DEFAULT 30"},
+}
+
+// addMissingColumns 既存テーブルに不足しているカラムを追加する
+func addMissingColumns(db *sql.DB, columns []addedColumn) error {
+	for _, c := range columns {
+		exists, err := hasColumn(db, c.table, c.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.Exec(`ALTER TABLE ` + c.table + ` ADD COLUMN ` + c.column + ` ` + c.definition); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// renameLegacyTariffTables tariff_version_id 列のない旧テーブルを *_legacy にリネームする
+func renameLegacyTariffTables(db *sql.DB) ([]string, error) {
+	var renamed []string
+	for _, t := range tariffVersionedTables {
+		exists, err := hasTable(db, t.name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		hasVersion, err := hasColumn(db, t.name, "tariff_version_id")
+		if err != nil {
+			return nil, err
+		}
+		if hasVersion {
+			continue
+		}
+		if _, err := db.Exec(`ALTER TABLE ` + t.name + ` RENAME TO ` + t.name + `_legacy`); err != nil {
+			return nil, err
+		}
+		renamed = append(renamed, t.name)
+	}
+	return renamed, nil
+}
+
+// restoreLegacyTariffTables 退避した旧テーブルのデータを版共通（tariff_version_id=0）として新テーブルへ移す
+func restoreLegacyTariffTables(db *sql.DB, names []string) error {
+	for _, t := range tariffVersionedTables {
+		if !slices.Contains(names, t.name) {
+			continue
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO ` + t.name + ` (` + t.columns + `) SELECT ` + t.columns + ` FROM ` + t.name + `_legacy`); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := tx.Exec(`DROP TABLE ` + t.name + `_legacy`); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasTable テーブルが存在するか確認する
+func hasTable(db *sql.DB, table string) (bool, error) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// hasColumn テーブルにカラムが存在するか確認する
+func hasColumn(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(`PRAGMA table_info(` + table + `)`)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dfltValue interface{}
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
 func createCacheTables(db *sql.DB) error {
+	// ルート検索の条件（options_key）導入前のルートキャッシュは主キーが異なるため退避して作り直す
+	legacyRouteCache, err := renameLegacyTable(db, "route_cache", "options_key")
+	if err != nil {
+		return err
+	}
+
 	schemas := []string{
-		// ルートキャッシュ
+		// ルートキャッシュ（ルート検索の条件ごと、空文字は条件の指定なし）
 		`CREATE TABLE IF NOT EXISTS route_cache (
 			origin TEXT NOT NULL,
 			dest TEXT NOT NULL,
+			options_key TEXT NOT NULL DEFAULT '',
 			distance_km REAL NOT NULL,
 			duration_min INTEGER NOT NULL,
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			PRIMARY KEY (origin, dest)
+			PRIMARY KEY (origin, dest, options_key)
 		)`,
 
 		// 高速料金キャッシュ
@@ -174,6 +476,20 @@ func createCacheTables(db *sql.DB) error {
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			PRIMARY KEY (origin_ic, dest_ic, car_type)
 		)`,
+
+		// ジオコーディングキャッシュ（表記を統一した住所ごとの都道府県・市区町村）
+		`CREATE TABLE IF NOT EXISTS geocode_cache (
+			address TEXT PRIMARY KEY,
+			prefecture TEXT NOT NULL,
+			city TEXT NOT NULL DEFAULT '',
+			formatted_address TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// 実行済みのデータ移行（版ごとに1行、失敗した移行は記録しない）
+		`CREATE TABLE IF NOT EXISTS cache_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
 	}
 
 	for _, schema := range schemas {
@@ -182,5 +498,62 @@ func createCacheTables(db *sql.DB) error {
 		}
 	}
 
+	if legacyRouteCache {
+		// 旧データは条件の指定なし（options_key が空文字）として移す
+		return restoreLegacyTable(db, "route_cache", "origin, dest, distance_km, duration_min, created_at")
+	}
 	return nil
 }
+
+// renameLegacyTable column 列のない旧テーブルを *_legacy にリネームする（テーブルがない・移行済みの場合は false）
+func renameLegacyTable(db *sql.DB, table, column string) (bool, error) {
+	exists, err := hasTable(db, table)
+	if err != nil || !exists {
+		return false, err
+	}
+	migrated, err := hasColumn(db, table, column)
+	if err != nil || migrated {
+		return false, err
+	}
+	if _, err := db.Exec(`ALTER TABLE ` + table + ` RENAME TO ` + table + `_legacy`); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// restoreLegacyTable 退避した旧テーブルの columns を新テーブルへ移す（新しいカラムはデフォルト値になる）
+func restoreLegacyTable(db *sql.DB, table, columns string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO ` + table + ` (` + columns + `) SELECT ` + columns + ` FROM ` + table + `_legacy`); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(`DROP TABLE ` + table + `_legacy`); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// RunCacheMigration 版 version のデータ移行が未実行の場合のみ migrate を実行し、版を記録する（実行した場合は true）
+// スキーマの作成だけでは行えない移行（住所の正規化など、アプリケーション側の処理が必要なもの）に使う
+// 版は移行ごとに記録するため、先の版が失敗しても後の版の実行で飛ばされることはなく、次回の起動時に再実行する
+func RunCacheMigration(db *sql.DB, version int, migrate func() error) (bool, error) {
+	var applied int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM cache_migrations WHERE version = ?`, version).Scan(&applied); err != nil {
+		return false, err
+	}
+	if applied > 0 {
+		return false, nil
+	}
+	if err := migrate(); err != nil {
+		return false, err
+	}
+	if _, err := db.Exec(`INSERT INTO cache_migrations (version) VALUES (?)`, version); err != nil {
+		return false, err
+	}
+	return true, nil
+}
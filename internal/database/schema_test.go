@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -22,6 +23,7 @@ func TestInitMainDB(t *testing.T) {
 
 	// 期待するテーブル一覧
 	expectedTables := []string{
+		"tariff_versions",
 		"jta_time_base_fares",
 		"jta_time_surcharges",
 		"akabou_distance_fares",
@@ -29,6 +31,17 @@ func TestInitMainDB(t *testing.T) {
 		"akabou_surcharges",
 		"akabou_area_surcharges",
 		"akabou_additional_fees",
+		"fuel_prices",
+		"fuel_surcharges",
+		"jta_body_type_surcharges",
+		"surcharge_items",
+		"customers",
+		"customer_pricing_rules",
+		"company_holidays",
+		"jta_fare_rates",
+		"jta_charge_data",
+		"jta_sync_status",
+		"postal_codes",
 		"api_usage",
 		"highway_ic_master",
 	}
@@ -55,6 +68,8 @@ func TestInitCacheDB(t *testing.T) {
 	expectedTables := []string{
 		"route_cache",
 		"highway_toll_cache",
+		"geocode_cache",
+		"cache_migrations",
 	}
 
 	for _, table := range expectedTables {
@@ -64,6 +79,29 @@ func TestInitCacheDB(t *testing.T) {
 	}
 }
 
+// TestTariffVersionsSchema tariff_versionsテーブルのカラム確認
+func TestTariffVersionsSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	expectedColumns := map[string]string{
+		"id":             "INTEGER",
+		"tariff_type":    "TEXT",
+		"name":           "TEXT",
+		"effective_from": "TEXT",
+		"effective_to":   "TEXT",
+		"description":    "TEXT",
+	}
+
+	checkTableColumns(t, db, "tariff_versions", expectedColumns)
+}
+
 // TestJtaTimeBaseFaresSchema jta_time_base_faresテーブルのカラム確認
 func TestJtaTimeBaseFaresSchema(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -76,12 +114,13 @@ func TestJtaTimeBaseFaresSchema(t *testing.T) {
 	defer db.Close()
 
 	expectedColumns := map[string]string{
-		"id":           "INTEGER",
-		"region_code":  "INTEGER",
-		"vehicle_code": "INTEGER",
-		"hours":        "INTEGER",
-		"base_km":      "INTEGER",
-		"fare_yen":     "INTEGER",
+		"id":                "INTEGER",
+		"tariff_version_id": "INTEGER",
+		"region_code":       "INTEGER",
+		"vehicle_code":      "INTEGER",
+		"hours":             "INTEGER",
+		"base_km":           "INTEGER",
+		"fare_yen":          "INTEGER",
 	}
 
 	checkTableColumns(t, db, "jta_time_base_fares", expectedColumns)
@@ -99,11 +138,12 @@ func TestJtaTimeSurchargesSchema(t *testing.T) {
 	defer db.Close()
 
 	expectedColumns := map[string]string{
-		"id":             "INTEGER",
-		"region_code":    "INTEGER",
-		"vehicle_code":   "INTEGER",
-		"surcharge_type": "TEXT",
-		"fare_yen":       "INTEGER",
+		"id":                "INTEGER",
+		"tariff_version_id": "INTEGER",
+		"region_code":       "INTEGER",
+		"vehicle_code":      "INTEGER",
+		"surcharge_type":    "TEXT",
+		"fare_yen":          "INTEGER",
 	}
 
 	checkTableColumns(t, db, "jta_time_surcharges", expectedColumns)
@@ -121,11 +161,12 @@ func TestAkabouDistanceFaresSchema(t *testing.T) {
 	defer db.Close()
 
 	expectedColumns := map[string]string{
-		"id":          "INTEGER",
-		"min_km":      "INTEGER",
-		"max_km":      "INTEGER",
-		"base_fare":   "INTEGER",
-		"per_km_rate": "INTEGER",
+		"id":                "INTEGER",
+		"tariff_version_id": "INTEGER",
+		"min_km":            "INTEGER",
+		"max_km":            "INTEGER",
+		"base_fare":         "INTEGER",
+		"per_km_rate":       "INTEGER",
 	}
 
 	checkTableColumns(t, db, "akabou_distance_fares", expectedColumns)
@@ -143,11 +184,13 @@ func TestAkabouTimeFaresSchema(t *testing.T) {
 	defer db.Close()
 
 	expectedColumns := map[string]string{
-		"id":            "INTEGER",
-		"base_hours":    "INTEGER",
-		"base_km":       "INTEGER",
-		"base_fare":     "INTEGER",
-		"overtime_rate": "INTEGER",
+		"id":                    "INTEGER",
+		"tariff_version_id":     "INTEGER",
+		"base_hours":            "INTEGER",
+		"base_km":               "INTEGER",
+		"base_fare":             "INTEGER",
+		"overtime_rate":         "INTEGER",
+		"overtime_unit_minutes": "INTEGER",
 	}
 
 	checkTableColumns(t, db, "akabou_time_fares", expectedColumns)
@@ -165,10 +208,11 @@ func TestAkabouSurchargesSchema(t *testing.T) {
 	defer db.Close()
 
 	expectedColumns := map[string]string{
-		"id":             "INTEGER",
-		"surcharge_type": "TEXT",
-		"rate_percent":   "INTEGER",
-		"description":    "TEXT",
+		"id":                "INTEGER",
+		"tariff_version_id": "INTEGER",
+		"surcharge_type":    "TEXT",
+		"rate_percent":      "INTEGER",
+		"description":       "TEXT",
 	}
 
 	checkTableColumns(t, db, "akabou_surcharges", expectedColumns)
@@ -186,9 +230,10 @@ func TestAkabouAreaSurchargesSchema(t *testing.T) {
 	defer db.Close()
 
 	expectedColumns := map[string]string{
-		"id":               "INTEGER",
-		"area_name":        "TEXT",
-		"surcharge_amount": "INTEGER",
+		"id":                "INTEGER",
+		"tariff_version_id": "INTEGER",
+		"area_name":         "TEXT",
+		"surcharge_amount":  "INTEGER",
 	}
 
 	checkTableColumns(t, db, "akabou_area_surcharges", expectedColumns)
@@ -206,16 +251,220 @@ func TestAkabouAdditionalFeesSchema(t *testing.T) {
 	defer db.Close()
 
 	expectedColumns := map[string]string{
-		"id":           "INTEGER",
-		"fee_type":     "TEXT",
-		"free_minutes": "INTEGER",
-		"unit_minutes": "INTEGER",
-		"fee_amount":   "INTEGER",
+		"id":                "INTEGER",
+		"tariff_version_id": "INTEGER",
+		"fee_type":          "TEXT",
+		"free_minutes":      "INTEGER",
+		"unit_minutes":      "INTEGER",
+		"fee_amount":        "INTEGER",
 	}
 
 	checkTableColumns(t, db, "akabou_additional_fees", expectedColumns)
 }
 
+// TestFuelPricesSchema fuel_pricesテーブルのカラム確認
+func TestFuelPricesSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	expectedColumns := map[string]string{
+		"year_month":          "TEXT",
+		"price_yen":           "REAL",
+		"reference_price_yen": "REAL",
+		"source":              "TEXT",
+	}
+
+	checkTableColumns(t, db, "fuel_prices", expectedColumns)
+}
+
+// TestFuelSurchargesSchema fuel_surchargesテーブルのカラム確認
+func TestFuelSurchargesSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	expectedColumns := map[string]string{
+		"id":                "INTEGER",
+		"tariff_version_id": "INTEGER",
+		"vehicle_code":      "INTEGER",
+		"min_km":            "INTEGER",
+		"max_km":            "INTEGER",
+		"step_yen":          "INTEGER",
+		"amount_yen":        "INTEGER",
+	}
+
+	checkTableColumns(t, db, "fuel_surcharges", expectedColumns)
+}
+
+// TestJtaBodyTypeSurchargesSchema jta_body_type_surchargesテーブルのカラム確認
+func TestJtaBodyTypeSurchargesSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	expectedColumns := map[string]string{
+		"id":                "INTEGER",
+		"tariff_version_id": "INTEGER",
+		"body_type":         "TEXT",
+		"name":              "TEXT",
+		"surcharge_percent": "INTEGER",
+		"sort_order":        "INTEGER",
+	}
+
+	checkTableColumns(t, db, "jta_body_type_surcharges", expectedColumns)
+}
+
+// TestSurchargeItemsSchema surcharge_itemsテーブルのカラム確認
+func TestSurchargeItemsSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	expectedColumns := map[string]string{
+		"id":            "INTEGER",
+		"code":          "TEXT",
+		"name":          "TEXT",
+		"calc_type":     "TEXT",
+		"rate_percent":  "INTEGER",
+		"amount_yen":    "INTEGER",
+		"vehicle_codes": "TEXT",
+		"sort_order":    "INTEGER",
+	}
+
+	checkTableColumns(t, db, "surcharge_items", expectedColumns)
+}
+
+// TestCustomersSchema customers・customer_pricing_rulesテーブルのカラム確認
+func TestCustomersSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	checkTableColumns(t, db, "customers", map[string]string{
+		"id":   "INTEGER",
+		"code": "TEXT",
+		"name": "TEXT",
+		"note": "TEXT",
+	})
+
+	checkTableColumns(t, db, "customer_pricing_rules", map[string]string{
+		"id":                 "INTEGER",
+		"customer_id":        "INTEGER",
+		"name":               "TEXT",
+		"adjustment_type":    "TEXT",
+		"adjustment_percent": "INTEGER",
+		"adjustment_yen":     "INTEGER",
+		"minimum_fare_yen":   "INTEGER",
+		"vehicle_codes":      "TEXT",
+		"region_codes":       "TEXT",
+		"valid_from":         "TEXT",
+		"valid_to":           "TEXT",
+	})
+}
+
+// TestJtaMirrorSchema トラ協データのローカルミラーのカラム確認
+func TestJtaMirrorSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	checkTableColumns(t, db, "jta_fare_rates", map[string]string{
+		"region_code":  "INTEGER",
+		"vehicle_code": "INTEGER",
+		"upto_km":      "INTEGER",
+		"fare_yen":     "INTEGER",
+	})
+
+	checkTableColumns(t, db, "jta_charge_data", map[string]string{
+		"id_code":      "INTEGER",
+		"vehicle_code": "INTEGER",
+		"time_code":    "INTEGER",
+		"charge_yen":   "INTEGER",
+		"per_1m_yen":   "INTEGER",
+	})
+
+	checkTableColumns(t, db, "jta_sync_status", map[string]string{
+		"table_name": "TEXT",
+		"row_count":  "INTEGER",
+		"checksum":   "TEXT",
+		"synced_at":  "DATETIME",
+	})
+}
+
+// TestCompanyHolidaysSchema company_holidaysテーブルのカラム確認
+func TestCompanyHolidaysSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	expectedColumns := map[string]string{
+		"id":        "INTEGER",
+		"name":      "TEXT",
+		"date":      "TEXT",
+		"month_day": "TEXT",
+	}
+
+	checkTableColumns(t, db, "company_holidays", expectedColumns)
+}
+
+// TestPostalCodesSchema postal_codesテーブルのカラム確認
+func TestPostalCodesSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	expectedColumns := map[string]string{
+		"postal_code": "TEXT",
+		"jis_code":    "TEXT",
+		"prefecture":  "TEXT",
+		"city":        "TEXT",
+		"town":        "TEXT",
+	}
+
+	checkTableColumns(t, db, "postal_codes", expectedColumns)
+}
+
 // TestApiUsageSchema api_usageテーブルのカラム確認
 func TestApiUsageSchema(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -229,6 +478,7 @@ func TestApiUsageSchema(t *testing.T) {
 
 	expectedColumns := map[string]string{
 		"year_month":    "TEXT",
+		"api_type":      "TEXT",
 		"request_count": "INTEGER",
 		"limit_count":   "INTEGER",
 		"last_updated":  "DATETIME",
@@ -251,6 +501,7 @@ func TestRouteCacheSchema(t *testing.T) {
 	expectedColumns := map[string]string{
 		"origin":       "TEXT",
 		"dest":         "TEXT",
+		"options_key":  "TEXT",
 		"distance_km":  "REAL",
 		"duration_min": "INTEGER",
 		"created_at":   "DATETIME",
@@ -309,6 +560,28 @@ func TestHighwayTollCacheSchema(t *testing.T) {
 	checkTableColumns(t, db, "highway_toll_cache", expectedColumns)
 }
 
+// TestGeocodeCacheSchema geocode_cacheテーブルのカラム確認
+func TestGeocodeCacheSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "cache.db")
+
+	db, err := InitCacheDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitCacheDB failed: %v", err)
+	}
+	defer db.Close()
+
+	expectedColumns := map[string]string{
+		"address":           "TEXT",
+		"prefecture":        "TEXT",
+		"city":              "TEXT",
+		"formatted_address": "TEXT",
+		"created_at":        "DATETIME",
+	}
+
+	checkTableColumns(t, db, "geocode_cache", expectedColumns)
+}
+
 // TestInitMainDBIdempotent 複数回初期化しても問題ないことを確認
 func TestInitMainDBIdempotent(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -329,6 +602,212 @@ func TestInitMainDBIdempotent(t *testing.T) {
 	db2.Close()
 }
 
+// TestInitMainDBMigratesLegacyTariffTables 運賃版導入前のDBが版共通データとして移行されることを確認
+func TestInitMainDBMigratesLegacyTariffTables(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	// 旧スキーマのテーブルを作成
+	legacy, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	_, err = legacy.Exec(`CREATE TABLE jta_time_base_fares (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		region_code INTEGER NOT NULL,
+		vehicle_code INTEGER NOT NULL,
+		hours INTEGER NOT NULL,
+		base_km INTEGER NOT NULL,
+		fare_yen INTEGER NOT NULL,
+		UNIQUE(region_code, vehicle_code, hours)
+	)`)
+	if err != nil {
+		t.Fatalf("旧テーブル作成失敗: %v", err)
+	}
+	if _, err := legacy.Exec(`INSERT INTO jta_time_base_fares (region_code, vehicle_code, hours, base_km, fare_yen) VALUES (3, 2, 8, 130, 45000)`); err != nil {
+		t.Fatalf("旧データ投入失敗: %v", err)
+	}
+	legacy.Close()
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	var versionID int64
+	var fareYen int
+	err = db.QueryRow(`SELECT tariff_version_id, fare_yen FROM jta_time_base_fares WHERE region_code = 3 AND vehicle_code = 2 AND hours = 8`).Scan(&versionID, &fareYen)
+	if err != nil {
+		t.Fatalf("移行後のデータ取得失敗: %v", err)
+	}
+	if versionID != 0 || fareYen != 45000 {
+		t.Errorf("移行後 = (%d, %d), want (0, 45000)", versionID, fareYen)
+	}
+
+	// 新しいUNIQUE制約で別版のデータが登録できること
+	if _, err := db.Exec(`INSERT INTO jta_time_base_fares (tariff_version_id, region_code, vehicle_code, hours, base_km, fare_yen) VALUES (1, 3, 2, 8, 130, 47000)`); err != nil {
+		t.Errorf("別版のデータ登録失敗: %v", err)
+	}
+
+	if tableExists(t, db, "jta_time_base_fares_legacy") {
+		t.Error("退避テーブルが残っている")
+	}
+}
+
+// TestInitCacheDBMigratesLegacyRouteCache options_key 導入前のルートキャッシュを条件の指定なしとして移行する
+func TestInitCacheDBMigratesLegacyRouteCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "cache.db")
+
+	// 旧スキーマのテーブルを作成
+	legacy, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	_, err = legacy.Exec(`CREATE TABLE route_cache (
+		origin TEXT NOT NULL,
+		dest TEXT NOT NULL,
+		distance_km REAL NOT NULL,
+		duration_min INTEGER NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (origin, dest)
+	)`)
+	if err != nil {
+		t.Fatalf("旧テーブル作成失敗: %v", err)
+	}
+	if _, err := legacy.Exec(`INSERT INTO route_cache (origin, dest, distance_km, duration_min) VALUES ('東京', '大阪', 500, 360)`); err != nil {
+		t.Fatalf("旧データ投入失敗: %v", err)
+	}
+	legacy.Close()
+
+	db, err := InitCacheDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitCacheDB failed: %v", err)
+	}
+	defer db.Close()
+
+	var distanceKm float64
+	err = db.QueryRow(`SELECT distance_km FROM route_cache WHERE origin = '東京' AND dest = '大阪' AND options_key = ''`).Scan(&distanceKm)
+	if err != nil || distanceKm != 500 {
+		t.Fatalf("移行後のデータ = %v, %v", distanceKm, err)
+	}
+
+	// 同じ区間で別の条件のルートが登録できること
+	if _, err := db.Exec(`INSERT INTO route_cache (origin, dest, options_key, distance_km, duration_min) VALUES ('東京', '大阪', 'h3.8', 520, 420)`); err != nil {
+		t.Errorf("別の条件のデータ登録失敗: %v", err)
+	}
+
+	if tableExists(t, db, "route_cache_legacy") {
+		t.Error("退避テーブルが残っている")
+	}
+}
+
+// TestInitMainDBMigratesLegacyApiUsage api_type 導入前のAPI使用量を Routes API の使用量として移行する
+func TestInitMainDBMigratesLegacyApiUsage(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "str.db")
+
+	// 旧スキーマのテーブルを作成
+	legacy, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	_, err = legacy.Exec(`CREATE TABLE api_usage (
+		year_month TEXT PRIMARY KEY,
+		request_count INTEGER NOT NULL DEFAULT 0,
+		limit_count INTEGER NOT NULL DEFAULT 9000,
+		last_updated DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		t.Fatalf("旧テーブル作成失敗: %v", err)
+	}
+	if _, err := legacy.Exec(`INSERT INTO api_usage (year_month, request_count, limit_count) VALUES ('2026-01', 1234, 9000)`); err != nil {
+		t.Fatalf("旧データ投入失敗: %v", err)
+	}
+	legacy.Close()
+
+	db, err := InitMainDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer db.Close()
+
+	var requestCount int
+	err = db.QueryRow(`SELECT request_count FROM api_usage WHERE year_month = '2026-01' AND api_type = 'routes'`).Scan(&requestCount)
+	if err != nil || requestCount != 1234 {
+		t.Fatalf("移行後のデータ = %v, %v", requestCount, err)
+	}
+
+	// 同じ年月で別のAPI種別が登録できること
+	if _, err := db.Exec(`INSERT INTO api_usage (year_month, api_type, request_count) VALUES ('2026-01', 'geocoding', 10)`); err != nil {
+		t.Errorf("別のAPI種別のデータ登録失敗: %v", err)
+	}
+
+	if tableExists(t, db, "api_usage_legacy") {
+		t.Error("退避テーブルが残っている")
+	}
+}
+
+// TestRunCacheMigration データ移行を1回だけ実行し、失敗した場合は版を記録しないこと
+func TestRunCacheMigration(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "cache.db")
+	db, err := InitCacheDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitCacheDB failed: %v", err)
+	}
+
+	calls := 0
+	failing := func() error { calls++; return errors.New("移行エラー") }
+	if ran, err := RunCacheMigration(db, CacheMigrationNormalizeRouteAddresses, failing); err == nil || ran {
+		t.Fatalf("RunCacheMigration() 失敗時 = %v, %v", ran, err)
+	}
+
+	migrate := func() error { calls++; return nil }
+	if ran, err := RunCacheMigration(db, CacheMigrationNormalizeRouteAddresses, migrate); err != nil || !ran {
+		t.Fatalf("RunCacheMigration() 初回 = %v, %v", ran, err)
+	}
+	db.Close()
+
+	// 再起動後は実行しない
+	db, err = InitCacheDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitCacheDB failed: %v", err)
+	}
+	defer db.Close()
+	if ran, err := RunCacheMigration(db, CacheMigrationNormalizeRouteAddresses, migrate); err != nil || ran {
+		t.Errorf("RunCacheMigration() 2回目 = %v, %v", ran, err)
+	}
+	if calls != 2 {
+		t.Errorf("移行の実行回数 = %d, want 2", calls)
+	}
+}
+
+// TestRunCacheMigrationRetriesFailedVersion 先の版が失敗して後の版が成功しても、先の版は次回に再実行されること
+func TestRunCacheMigrationRetriesFailedVersion(t *testing.T) {
+	db, err := InitCacheDB(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("InitCacheDB failed: %v", err)
+	}
+	defer db.Close()
+
+	failing := func() error { return errors.New("移行エラー") }
+	if ran, err := RunCacheMigration(db, CacheMigrationNormalizeRouteAddresses, failing); err == nil || ran {
+		t.Fatalf("RunCacheMigration(1) 失敗時 = %v, %v", ran, err)
+	}
+	if ran, err := RunCacheMigration(db, CacheMigrationPurgeVehicleRouteKeys, func() error { return nil }); err != nil || !ran {
+		t.Fatalf("RunCacheMigration(2) = %v, %v", ran, err)
+	}
+
+	calls := 0
+	if ran, err := RunCacheMigration(db, CacheMigrationNormalizeRouteAddresses, func() error { calls++; return nil }); err != nil || !ran {
+		t.Fatalf("RunCacheMigration(1) 再実行 = %v, %v", ran, err)
+	}
+	if calls != 1 {
+		t.Errorf("版1の再実行回数 = %d, want 1", calls)
+	}
+}
+
 // TestDBFileCreated DBファイルが作成されることを確認
 func TestDBFileCreated(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -16,14 +16,14 @@ type mockApiUsageStore struct {
 	err   error
 }
 
-func (m *mockApiUsageStore) GetOrCreateCurrent() (*model.ApiUsage, error) {
+func (m *mockApiUsageStore) GetOrCreateCurrent(apiType string) (*model.ApiUsage, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.usage, nil
 }
 
-func (m *mockApiUsageStore) IncrementCount(yearMonth string) error {
+func (m *mockApiUsageStore) IncrementCount(yearMonth, apiType string) error {
 	return m.err
 }
 
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+// BodyTypeSurchargeLister 選択できる車体種別の一覧取得インターフェース（テスト用にモック可能）
+type BodyTypeSurchargeLister interface {
+	GetBodyTypeSurcharges(version *model.TariffVersion) ([]*model.BodyTypeSurcharge, error)
+}
+
+// BodyTypeHandler 車体種別（特殊車両割増）ハンドラ
+type BodyTypeHandler struct {
+	lister   BodyTypeSurchargeLister
+	resolver service.TariffVersionResolver // nilの場合は版共通のみ
+}
+
+// NewBodyTypeHandler 新しいBodyTypeHandlerを作成
+func NewBodyTypeHandler(lister BodyTypeSurchargeLister, resolver service.TariffVersionResolver) *BodyTypeHandler {
+	return &BodyTypeHandler{
+		lister:   lister,
+		resolver: resolver,
+	}
+}
+
+// BodyTypeInfo 車体種別情報
+type BodyTypeInfo struct {
+	BodyType         string `json:"body_type"`
+	Name             string `json:"name"`
+	SurchargePercent int    `json:"surcharge_percent"`
+	Label            string `json:"label"` // 表示用（例: 冷蔵車・冷凍車（2割増））
+}
+
+// GetBodyTypes 見積日に選択できる車体種別の一覧を取得
+// GET /api/fare/body-types?quote_date=2026-10-16（未指定は当日）
+func (h *BodyTypeHandler) GetBodyTypes(c echo.Context) error {
+	quoteDate := time.Now()
+	if v := c.QueryParam("quote_date"); v != "" {
+		d, err := time.ParseInLocation(model.TariffDateFormat, v, time.Local)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "見積日の形式が不正です（YYYY-MM-DD）: " + v,
+			})
+		}
+		quoteDate = d
+	}
+
+	var version *model.TariffVersion
+	if h.resolver != nil {
+		v, err := h.resolver.GetEffective(model.TariffTypeJTA, quoteDate)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "運賃版の取得に失敗しました",
+			})
+		}
+		version = v
+	}
+
+	surcharges, err := h.lister.GetBodyTypeSurcharges(version)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "車体種別の取得に失敗しました",
+		})
+	}
+
+	bodyTypes := make([]BodyTypeInfo, 0, len(surcharges))
+	for _, s := range surcharges {
+		label := s.Name
+		if s.SurchargePercent > 0 {
+			label = fmt.Sprintf("%s（%d%%増）", s.Name, s.SurchargePercent)
+		}
+		bodyTypes = append(bodyTypes, BodyTypeInfo{
+			BodyType:         s.BodyType,
+			Name:             s.Name,
+			SurchargePercent: s.SurchargePercent,
+			Label:            label,
+		})
+	}
+
+	return c.JSON(http.StatusOK, bodyTypes)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// mockBodyTypeSurchargeLister テスト用の車体種別一覧モック
+type mockBodyTypeSurchargeLister struct {
+	version *model.TariffVersion
+	err     error
+}
+
+func (m *mockBodyTypeSurchargeLister) GetBodyTypeSurcharges(version *model.TariffVersion) ([]*model.BodyTypeSurcharge, error) {
+	m.version = version
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*model.BodyTypeSurcharge{
+		{BodyType: model.BodyTypeStandard, Name: "標準", SurchargePercent: 0},
+		{BodyType: "refrigerated", Name: "冷蔵車・冷凍車", SurchargePercent: 20},
+	}, nil
+}
+
+// mockTariffVersionResolver テスト用の運賃版リゾルバー
+type mockTariffVersionResolver struct {
+	version *model.TariffVersion
+}
+
+func (m *mockTariffVersionResolver) GetEffective(tariffType string, date time.Time) (*model.TariffVersion, error) {
+	return m.version, nil
+}
+
+func TestBodyTypeHandler_GetBodyTypes(t *testing.T) {
+	e := echo.New()
+	version := &model.TariffVersion{ID: 1, TariffType: model.TariffTypeJTA, Name: "令和6年3月告示"}
+
+	t.Run("一覧を取得", func(t *testing.T) {
+		lister := &mockBodyTypeSurchargeLister{}
+		handler := NewBodyTypeHandler(lister, &mockTariffVersionResolver{version: version})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/fare/body-types?quote_date=2026-10-16", nil)
+		rec := httptest.NewRecorder()
+		if err := handler.GetBodyTypes(e.NewContext(req, rec)); err != nil {
+			t.Fatalf("GetBodyTypes() error = %v", err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", rec.Code)
+		}
+
+		var got []BodyTypeInfo
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("JSONパースエラー: %v", err)
+		}
+		if len(got) != 2 || got[0].Label != "標準" || got[1].Label != "冷蔵車・冷凍車（20%増）" {
+			t.Errorf("body types = %+v", got)
+		}
+		if lister.version != version {
+			t.Errorf("一覧取得に渡された運賃版 = %v, want %v", lister.version, version)
+		}
+	})
+
+	t.Run("見積日が不正", func(t *testing.T) {
+		handler := NewBodyTypeHandler(&mockBodyTypeSurchargeLister{}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/fare/body-types?quote_date=2026/10/16", nil)
+		rec := httptest.NewRecorder()
+		if err := handler.GetBodyTypes(e.NewContext(req, rec)); err != nil {
+			t.Fatalf("GetBodyTypes() error = %v", err)
+		}
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400", rec.Code)
+		}
+	})
+
+	t.Run("取得エラー", func(t *testing.T) {
+		handler := NewBodyTypeHandler(&mockBodyTypeSurchargeLister{err: errors.New("DB接続エラー")}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/fare/body-types", nil)
+		rec := httptest.NewRecorder()
+		if err := handler.GetBodyTypes(e.NewContext(req, rec)); err != nil {
+			t.Fatalf("GetBodyTypes() error = %v", err)
+		}
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want 500", rec.Code)
+		}
+	})
+}
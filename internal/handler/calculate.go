@@ -5,6 +5,8 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/y-suzuki/standard-truck-rate/internal/model"
@@ -18,18 +20,20 @@ type CalculateHandler struct {
 	cachedRouteService *service.CachedRouteService
 	apiUsageService    *service.ApiUsageService
 	geocodingClient    service.GeocodingClient
+	gazetteer          *service.Gazetteer // 郵便番号データによる住所の判定（未設定の場合はGeocodingのみ）
 	// 高速料金関連
 	icRepo     *repository.HighwayICRepository
 	tollRepo   *repository.HighwayTollRepository
 	drivePlaza *service.DrivePlazaClient
 }
 
-// NewCalculateHandler 新しいCalculateHandlerを作成
+// NewCalculateHandler 新しいCalculateHandlerを作成（fareCalculator は必須、未設定の場合は panic する）
 func NewCalculateHandler(fareCalculator *service.FareCalculatorService, cachedRouteService *service.CachedRouteService, apiUsageService *service.ApiUsageService, geocodingClient service.GeocodingClient, mainDB, cacheDB *sql.DB) *CalculateHandler {
-	// デフォルト値の設定
 	if fareCalculator == nil {
-		fareCalculator = createMockFareCalculator()
+		panic("NewCalculateHandler: fareCalculator が未設定です")
 	}
+
+	// デフォルト値の設定
 	if geocodingClient == nil {
 		geocodingClient = service.NewMockGeocodingClient()
 	}
@@ -51,54 +55,117 @@ func NewCalculateHandler(fareCalculator *service.FareCalculatorService, cachedRo
 	return h
 }
 
-// createMockFareCalculator テスト用のモックFareCalculatorを作成
-func createMockFareCalculator() *service.FareCalculatorService {
-	// モックリポジトリを使用
-	distanceFare := service.NewDistanceFareService(&mockFareGetter{})
-	timeFare := service.NewTimeFareService(&mockTimeFareGetter{})
-	akabouFare := service.NewAkabouFareService()
-	return service.NewFareCalculatorService(distanceFare, timeFare, akabouFare)
+// SetGazetteer 郵便番号データによる住所の判定を設定（出発地の運輸局・赤帽地区をAPIを呼ばずに判定する）
+func (h *CalculateHandler) SetGazetteer(gazetteer *service.Gazetteer) {
+	h.gazetteer = gazetteer
 }
 
+// defaultStopLoadingMinutes 経由地1か所あたりの荷役時間のデフォルト（分）
+const defaultStopLoadingMinutes = 30
+
 // CalculateRequest 運賃計算リクエスト
 type CalculateRequest struct {
 	// 新UI: 出発地/目的地入力
 	Origin string `form:"origin"` // 出発地（住所）
 	Dest   string `form:"dest"`   // 目的地（住所）
 
+	// 経由地（訪問順、複数指定可）
+	Waypoints          []string `form:"waypoints"`
+	StopLoadingMinutes int      `form:"stop_loading_minutes"` // 経由地1か所あたりの荷役時間（分）
+	Route              *service.MultiStopRoute
+
+	// 運行形態（片道 / 往復 / 片道＋空車回送）
+	TripMode               service.TripMode `form:"trip_mode"`
+	EmptyReturnRatePercent int              `form:"empty_return_rate"` // 空車回送の計上率（%）
+
 	// 旧UI互換: 直接指定（origin/destが指定されていない場合に使用）
 	RegionCode     int `form:"region_code"`
 	DistanceKm     int `form:"distance_km"`
 	DrivingMinutes int `form:"driving_minutes"`
 
 	// 共通パラメータ
-	VehicleCode     int     `form:"vehicle_code"`
-	LoadingMinutes  int     `form:"loading_minutes"`
-	DistanceKmRaw   float64 // 元距離（km、小数点付き）- 表示用
-	IsNight         bool   `form:"is_night"`
-	IsHoliday       bool   `form:"is_holiday"`
-	UseSimpleBaseKm bool   `form:"use_simple_base_km"`
-	Area            string `form:"area"`
-
-	// 赤帽付帯料金パラメータ
+	VehicleCode      int       `form:"vehicle_code"`
+	LoadingMinutes   int       `form:"loading_minutes"`
+	DistanceKmRaw    float64   // 元距離（km、小数点付き）- 表示用
+	IsNight          bool      `form:"is_night"`
+	IsHoliday        bool      `form:"is_holiday"`
+	UseSimpleBaseKm  bool      `form:"use_simple_base_km"`
+	UseFuelSurcharge bool      `form:"use_fuel_surcharge"` // 燃料サーチャージ加算（トラック用）
+	Area             string    `form:"area"`
+	BodyType         string    `form:"body_type"` // 車体種別（特殊車両割増、トラック用）
+	QuoteDate        time.Time // 見積日（適用運賃版の判定用、未指定は当日）
+	DepartureAt      time.Time // 出発日時（指定時は深夜・休日割増を自動判定）
+
+	// 割増項目（速達割増・手積み手降ろしなど、複数選択可）
+	SurchargeItems []string `form:"surcharge_items"`
+
+	// 荷主ID（指定時は契約運賃も表示、0は指定なし）
+	CustomerID int64 `form:"customer_id"`
+
+	// 付帯料金パラメータ（赤帽・トラ協共通）
 	WorkMinutes    int `form:"work_minutes"`    // 作業時間（分）
 	WaitingMinutes int `form:"waiting_minutes"` // 待機時間（分）
 
+	// 複数日運行の1泊あたりの宿泊費（円、時間制のみ）
+	OvernightCostYen int `form:"overnight_cost"`
+
+	// 改善基準告示の休憩時間・運行日数を時間制運賃に含めるか（トラック用）
+	IncludeComplianceTime bool `form:"include_compliance_time"`
+
+	// ルート検索の条件（車両の寸法・車両総重量は車格から設定）
+	AvoidTolls    bool                  `form:"avoid_tolls"`    // 有料道路を避ける
+	AvoidHighways bool                  `form:"avoid_highways"` // 高速道路を避ける
+	Hazmat        bool                  `form:"hazmat"`         // 危険物積載
+	RouteOptions  *service.RouteOptions // 距離・走行時間の取得に使用した条件（出発地/目的地入力時のみ）
+
 	// 高速道路パラメータ
 	UseHighway bool   `form:"use_highway"` // 高速道路使用
 	OriginIC   string `form:"origin_ic"`   // 乗IC
 	DestIC     string `form:"dest_ic"`     // 降IC
 }
 
+// fareCalculationRequest 運賃計算サービスへのリクエストを作成
+func (req *CalculateRequest) fareCalculationRequest() *service.FareCalculationRequest {
+	return &service.FareCalculationRequest{
+		RegionCode:             req.RegionCode,
+		VehicleCode:            req.VehicleCode,
+		DistanceKm:             req.DistanceKm,
+		DistanceKmRaw:          req.DistanceKmRaw,
+		DrivingMinutes:         req.DrivingMinutes,
+		LoadingMinutes:         req.LoadingMinutes,
+		IsNight:                req.IsNight,
+		IsHoliday:              req.IsHoliday,
+		QuoteDate:              req.QuoteDate,
+		DepartureAt:            req.DepartureAt,
+		UseSimpleBaseKm:        req.UseSimpleBaseKm,
+		UseFuelSurcharge:       req.UseFuelSurcharge,
+		Area:                   req.Area,
+		BodyType:               req.BodyType,
+		SurchargeItems:         req.SurchargeItems,
+		CustomerID:             req.CustomerID,
+		WorkMinutes:            req.WorkMinutes,
+		WaitingMinutes:         req.WaitingMinutes,
+		OvernightCostYen:       req.OvernightCostYen,
+		IncludeComplianceTime:  req.IncludeComplianceTime,
+		Route:                  req.Route,
+		TripMode:               req.TripMode,
+		EmptyReturnRatePercent: req.EmptyReturnRatePercent,
+	}
+}
+
 // CalculateResultWithHighway 運賃計算結果＋高速料金
 type CalculateResultWithHighway struct {
 	*service.FareComparisonResult
 	// 高速料金
-	UseHighway   bool              `json:"use_highway"`
-	HighwayToll  *HighwayTollInfo  `json:"highway_toll,omitempty"`
-	HighwayError string            `json:"highway_error,omitempty"`
+	UseHighway   bool             `json:"use_highway"`
+	HighwayToll  *HighwayTollInfo `json:"highway_toll,omitempty"`
+	HighwayError string           `json:"highway_error,omitempty"`
 	// 合計金額
 	TotalWithHighway *TotalWithHighway `json:"total_with_highway,omitempty"`
+	// 一般道と高速道路利用（走行時間を短縮した運賃＋高速代）の比較
+	HighwayComparison *service.HighwayComparison `json:"highway_comparison,omitempty"`
+	// 距離・走行時間の取得に使用したルート検索の条件（出発地/目的地入力時のみ）
+	RouteOptions *service.RouteOptions `json:"route_options,omitempty"`
 }
 
 // HighwayTollInfo 高速料金情報
@@ -116,12 +183,38 @@ type HighwayTollInfo struct {
 }
 
 // TotalWithHighway 運賃＋高速代の合計
+// 高速料金は税込のため、運賃も税込で合算する
 type TotalWithHighway struct {
-	MinFare      int `json:"min_fare"`       // 最安運賃
-	MaxFare      int `json:"max_fare"`       // 最高運賃
-	HighwayToll  int `json:"highway_toll"`   // 高速代（ETC料金）
-	MinTotal     int `json:"min_total"`      // 最安合計
-	MaxTotal     int `json:"max_total"`      // 最高合計
+	MinFare            int `json:"min_fare"`              // 最安運賃（税込）
+	MaxFare            int `json:"max_fare"`              // 最高運賃（税込）
+	HighwayToll        int `json:"highway_toll"`          // 高速代（ETC料金、税込）
+	HighwayTollExclTax int `json:"highway_toll_excl_tax"` // 高速代（税抜）
+	MinTotal           int `json:"min_total"`             // 最安合計（税込）
+	MaxTotal           int `json:"max_total"`             // 最高合計（税込）
+	MinTotalExclTax    int `json:"min_total_excl_tax"`    // 最安合計（税抜）
+	MaxTotalExclTax    int `json:"max_total_excl_tax"`    // 最高合計（税抜）
+}
+
+// newTotalWithHighway 運賃ランキングと高速代（ETC料金）から合計金額を計算
+func newTotalWithHighway(fareResult *service.FareComparisonResult, etcToll int) *TotalWithHighway {
+	tax := fareResult.TaxCalculator
+	if tax == nil {
+		tax = service.DefaultTaxCalculator()
+	}
+	toll := tax.FromInclusive(etcToll)
+	cheapest := fareResult.Rankings[0]
+	highest := fareResult.Rankings[len(fareResult.Rankings)-1]
+
+	return &TotalWithHighway{
+		MinFare:            cheapest.Fare,
+		MaxFare:            highest.Fare,
+		HighwayToll:        toll.Inclusive,
+		HighwayTollExclTax: toll.Exclusive,
+		MinTotal:           cheapest.Fare + toll.Inclusive,
+		MaxTotal:           highest.Fare + toll.Inclusive,
+		MinTotalExclTax:    cheapest.FareExclTax + toll.Exclusive,
+		MaxTotalExclTax:    highest.FareExclTax + toll.Exclusive,
+	}
 }
 
 // Calculate 運賃を計算してHTMLフラグメントを返す（HTMX用）
@@ -138,48 +231,15 @@ func (h *CalculateHandler) Calculate(c echo.Context) error {
 	}
 
 	// 運賃計算
-	fareResult, err := h.fareCalculator.CalculateAll(&service.FareCalculationRequest{
-		RegionCode:      req.RegionCode,
-		VehicleCode:     req.VehicleCode,
-		DistanceKm:      req.DistanceKm,
-		DistanceKmRaw:   req.DistanceKmRaw,
-		DrivingMinutes:  req.DrivingMinutes,
-		LoadingMinutes:  req.LoadingMinutes,
-		IsNight:         req.IsNight,
-		IsHoliday:       req.IsHoliday,
-		UseSimpleBaseKm: req.UseSimpleBaseKm,
-		Area:            req.Area,
-		WorkMinutes:     req.WorkMinutes,
-		WaitingMinutes:  req.WaitingMinutes,
-	})
+	fareResult, err := h.fareCalculator.CalculateAll(req.fareCalculationRequest())
 	if err != nil {
 		return c.Render(http.StatusOK, "error", map[string]string{"Error": "運賃計算エラー: " + err.Error()})
 	}
 
-	// 結果を構築
-	result := &CalculateResultWithHighway{
-		FareComparisonResult: fareResult,
-		UseHighway:           req.UseHighway,
-	}
-
-	// 高速料金を取得（高速道路使用時）
-	if req.UseHighway && req.OriginIC != "" && req.DestIC != "" {
-		// 車格から高速料金車種を自動マッピング
-		highwayCarType := vehicleCodeToHighwayCarType(req.VehicleCode)
-		tollInfo, tollErr := h.fetchHighwayToll(req.OriginIC, req.DestIC, highwayCarType)
-		if tollErr != nil {
-			result.HighwayError = tollErr.Error()
-		} else {
-			result.HighwayToll = tollInfo
-			// 合計金額を計算
-			result.TotalWithHighway = &TotalWithHighway{
-				MinFare:     fareResult.CheapestFare,
-				MaxFare:     fareResult.Rankings[len(fareResult.Rankings)-1].Fare,
-				HighwayToll: tollInfo.EtcToll, // ETC料金を使用
-				MinTotal:    fareResult.CheapestFare + tollInfo.EtcToll,
-				MaxTotal:    fareResult.Rankings[len(fareResult.Rankings)-1].Fare + tollInfo.EtcToll,
-			}
-		}
+	// 結果を構築（高速道路使用時は高速料金と一般道との比較を追加）
+	result, err := h.buildResult(req, fareResult)
+	if err != nil {
+		return c.Render(http.StatusOK, "error", map[string]string{"Error": "運賃計算エラー: " + err.Error()})
 	}
 
 	return c.Render(http.StatusOK, "result", result)
@@ -199,51 +259,54 @@ func (h *CalculateHandler) CalculateJSON(c echo.Context) error {
 	}
 
 	// 運賃計算
-	fareResult, err := h.fareCalculator.CalculateAll(&service.FareCalculationRequest{
-		RegionCode:      req.RegionCode,
-		VehicleCode:     req.VehicleCode,
-		DistanceKm:      req.DistanceKm,
-		DistanceKmRaw:   req.DistanceKmRaw,
-		DrivingMinutes:  req.DrivingMinutes,
-		LoadingMinutes:  req.LoadingMinutes,
-		IsNight:         req.IsNight,
-		IsHoliday:       req.IsHoliday,
-		UseSimpleBaseKm: req.UseSimpleBaseKm,
-		Area:            req.Area,
-		WorkMinutes:     req.WorkMinutes,
-		WaitingMinutes:  req.WaitingMinutes,
-	})
+	fareResult, err := h.fareCalculator.CalculateAll(req.fareCalculationRequest())
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "運賃計算エラー: " + err.Error()})
 	}
 
-	// 結果を構築
+	// 結果を構築（高速道路使用時は高速料金と一般道との比較を追加）
+	result, err := h.buildResult(req, fareResult)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "運賃計算エラー: " + err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// buildResult 運賃計算結果に高速料金を追加する（高速道路使用時）
+// 高速料金の取得に失敗した場合は HighwayError に設定し、運賃計算結果はそのまま返す
+func (h *CalculateHandler) buildResult(req *CalculateRequest, fareResult *service.FareComparisonResult) (*CalculateResultWithHighway, error) {
 	result := &CalculateResultWithHighway{
 		FareComparisonResult: fareResult,
 		UseHighway:           req.UseHighway,
+		RouteOptions:         req.RouteOptions,
+	}
+	if !req.UseHighway || req.OriginIC == "" || req.DestIC == "" {
+		return result, nil
 	}
 
-	// 高速料金を取得（高速道路使用時）
-	if req.UseHighway && req.OriginIC != "" && req.DestIC != "" {
-		// 車格から高速料金車種を自動マッピング
-		highwayCarType := vehicleCodeToHighwayCarType(req.VehicleCode)
-		tollInfo, tollErr := h.fetchHighwayToll(req.OriginIC, req.DestIC, highwayCarType)
-		if tollErr != nil {
-			result.HighwayError = tollErr.Error()
-		} else {
-			result.HighwayToll = tollInfo
-			// 合計金額を計算
-			result.TotalWithHighway = &TotalWithHighway{
-				MinFare:     fareResult.CheapestFare,
-				MaxFare:     fareResult.Rankings[len(fareResult.Rankings)-1].Fare,
-				HighwayToll: tollInfo.EtcToll,
-				MinTotal:    fareResult.CheapestFare + tollInfo.EtcToll,
-				MaxTotal:    fareResult.Rankings[len(fareResult.Rankings)-1].Fare + tollInfo.EtcToll,
-			}
-		}
+	// 車格から高速料金車種を自動マッピング
+	highwayCarType := vehicleCodeToHighwayCarType(req.VehicleCode)
+	tollInfo, tollErr := h.fetchHighwayToll(req.OriginIC, req.DestIC, highwayCarType)
+	if tollErr != nil {
+		result.HighwayError = tollErr.Error()
+		return result, nil
 	}
+	result.HighwayToll = tollInfo
+	// 合計金額を計算（ETC料金を使用）
+	result.TotalWithHighway = newTotalWithHighway(fareResult, tollInfo.EtcToll)
 
-	return c.JSON(http.StatusOK, result)
+	// 高速道路区間の所要時間で走行時間を短縮した運賃と比較
+	comparison, err := h.fareCalculator.CompareHighway(req.fareCalculationRequest(), fareResult, service.HighwaySection{
+		DistanceKm:  tollInfo.DistanceKm,
+		DurationMin: tollInfo.DurationMin,
+		TollYen:     tollInfo.EtcToll,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.HighwayComparison = comparison
+	return result, nil
 }
 
 // parseRequest フォームデータをパース
@@ -254,6 +317,22 @@ func (h *CalculateHandler) parseRequest(c echo.Context) (*CalculateRequest, erro
 	req.Origin = c.FormValue("origin")
 	req.Dest = c.FormValue("dest")
 
+	// 経由地（空欄は無視）
+	if params, err := c.FormParams(); err == nil {
+		for _, w := range params["waypoints"] {
+			if w = strings.TrimSpace(w); w != "" {
+				req.Waypoints = append(req.Waypoints, w)
+			}
+		}
+	}
+	if v := c.FormValue("stop_loading_minutes"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			req.StopLoadingMinutes = n
+		}
+	} else {
+		req.StopLoadingMinutes = defaultStopLoadingMinutes
+	}
+
 	// 各フィールドを手動でパース（デフォルト値対応）
 	if v := c.FormValue("region_code"); v != "" {
 		if n, err := strconv.Atoi(v); err == nil {
@@ -297,9 +376,61 @@ func (h *CalculateHandler) parseRequest(c echo.Context) (*CalculateRequest, erro
 	req.IsNight = c.FormValue("is_night") == "true"
 	req.IsHoliday = c.FormValue("is_holiday") == "true"
 	req.UseSimpleBaseKm = c.FormValue("use_simple_base_km") == "true"
+	req.UseFuelSurcharge = c.FormValue("use_fuel_surcharge") == "true"
+	req.IncludeComplianceTime = c.FormValue("include_compliance_time") == "true"
 	req.Area = c.FormValue("area")
+	req.BodyType = c.FormValue("body_type")
 
-	// 赤帽付帯料金パラメータ
+	// 割増項目（複数選択、空欄は無視）
+	if params, err := c.FormParams(); err == nil {
+		for _, code := range params["surcharge_items"] {
+			if code = strings.TrimSpace(code); code != "" {
+				req.SurchargeItems = append(req.SurchargeItems, code)
+			}
+		}
+	}
+
+	// 荷主（契約運賃）
+	if v := strings.TrimSpace(c.FormValue("customer_id")); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id < 0 {
+			return nil, &ValidationError{Message: "荷主IDが不正です: " + v}
+		}
+		req.CustomerID = id
+	}
+
+	// 運行形態
+	tripMode, err := service.ParseTripMode(c.FormValue("trip_mode"))
+	if err != nil {
+		return nil, &ValidationError{Message: err.Error()}
+	}
+	req.TripMode = tripMode
+	req.EmptyReturnRatePercent = service.DefaultEmptyReturnRatePercent
+	if v := c.FormValue("empty_return_rate"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &ValidationError{Message: "回送計上率は整数で入力してください: " + v}
+		}
+		req.EmptyReturnRatePercent = n
+	}
+
+	// 見積日（YYYY-MM-DD）
+	if v := c.FormValue("quote_date"); v != "" {
+		d, err := time.ParseInLocation(model.TariffDateFormat, v, time.Local)
+		if err != nil {
+			return nil, &ValidationError{Message: "見積日の形式が不正です（YYYY-MM-DD）: " + v}
+		}
+		req.QuoteDate = d
+	}
+	if v := c.FormValue("departure_at"); v != "" {
+		d, err := time.ParseInLocation(service.DepartureDateTimeFormat, v, time.Local)
+		if err != nil {
+			return nil, &ValidationError{Message: "出発日時の形式が不正です（YYYY-MM-DDTHH:MM）: " + v}
+		}
+		req.DepartureAt = d
+	}
+
+	// 付帯料金パラメータ（赤帽・トラ協共通）
 	if v := c.FormValue("work_minutes"); v != "" {
 		if n, err := strconv.Atoi(v); err == nil {
 			req.WorkMinutes = n
@@ -310,12 +441,30 @@ func (h *CalculateHandler) parseRequest(c echo.Context) (*CalculateRequest, erro
 			req.WaitingMinutes = n
 		}
 	}
+	if v := c.FormValue("overnight_cost"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			req.OvernightCostYen = n
+		}
+	}
 
 	// 高速道路パラメータ
 	req.UseHighway = c.FormValue("use_highway") == "true"
 	req.OriginIC = c.FormValue("origin_ic")
 	req.DestIC = c.FormValue("dest_ic")
 
+	// ルート検索の条件
+	req.AvoidTolls = c.FormValue("avoid_tolls") == "true"
+	req.AvoidHighways = c.FormValue("avoid_highways") == "true"
+	req.Hazmat = c.FormValue("hazmat") == "true"
+
+	// 経由地がある場合は経由地ごとの荷役時間を加算する（手入力時は区間なし）
+	if len(req.Waypoints) > 0 {
+		req.Route = &service.MultiStopRoute{
+			Waypoints:          req.Waypoints,
+			StopLoadingMinutes: req.StopLoadingMinutes,
+		}
+	}
+
 	// origin/dest が指定されている場合、ルート情報から距離・時間・運輸局を取得
 	// ただし、距離と走行時間が手入力されている場合はスキップ（API上限到達時の手入力モード対応）
 	if req.Origin != "" && req.Dest != "" {
@@ -327,7 +476,11 @@ func (h *CalculateHandler) parseRequest(c echo.Context) (*CalculateRequest, erro
 			// 手入力モード：API呼び出しをスキップ
 			// 赤帽地区の判定（出発地が空でない場合のみ）
 			if req.Area == "" && req.Origin != "" {
-				req.Area = service.ResolveAkabouArea(req.Origin)
+				if components, ok := h.gazetteer.Resolve(req.Origin); ok {
+					req.Area = service.ResolveAkabouAreaFromComponents(components)
+				} else {
+					req.Area = service.ResolveAkabouArea(req.Origin)
+				}
 			}
 		} else {
 			// 自動取得モード
@@ -342,10 +495,18 @@ func (h *CalculateHandler) parseRequest(c echo.Context) (*CalculateRequest, erro
 
 // resolveRouteInfo 出発地/目的地からルート情報を取得してリクエストに設定
 func (h *CalculateHandler) resolveRouteInfo(req *CalculateRequest) error {
-	// Geocoding APIで出発地から都道府県を取得
-	prefecture, err := h.geocodingClient.GetPrefecture(req.Origin)
-	if err != nil {
-		return &ValidationError{Message: "出発地の都道府県を特定できません: " + req.Origin + " (" + err.Error() + ")"}
+	// 郵便番号データで出発地の都道府県・市区町村を判定（APIを呼ばない）
+	components, resolved := h.gazetteer.Resolve(req.Origin)
+	prefecture := ""
+	if resolved {
+		prefecture = components.Prefecture
+	} else {
+		// 判定できない場合はGeocoding APIで出発地から都道府県を取得
+		p, err := h.geocodingClient.GetPrefecture(req.Origin)
+		if err != nil {
+			return &ValidationError{Message: "出発地の都道府県を特定できません: " + req.Origin + " (" + err.Error() + ")"}
+		}
+		prefecture = p
 	}
 
 	// 都道府県から運輸局コードを取得
@@ -355,12 +516,16 @@ func (h *CalculateHandler) resolveRouteInfo(req *CalculateRequest) error {
 	}
 	req.RegionCode = regionCode
 
-	// 赤帽地区を判定（Geocodingで取得した住所情報を使用）
+	// 赤帽地区を判定（郵便番号データまたはGeocodingで取得した都道府県・市区町村を使用）
 	if req.Area == "" {
-		// Geocodingで詳細住所を取得して判定
-		components, err := h.geocodingClient.GetAddressComponents(req.Origin)
-		if err == nil && components != nil {
-			req.Area = service.ResolveAkabouArea(components.Address)
+		if !resolved {
+			// Geocodingで詳細住所を取得して判定
+			if c, err := h.geocodingClient.GetAddressComponents(req.Origin); err == nil && c != nil {
+				components = c
+			}
+		}
+		if components != nil {
+			req.Area = service.ResolveAkabouAreaFromComponents(components)
 		} else {
 			req.Area = service.ResolveAkabouArea(req.Origin)
 		}
@@ -371,17 +536,34 @@ func (h *CalculateHandler) resolveRouteInfo(req *CalculateRequest) error {
 		return &ValidationError{Message: "ルートサービスが初期化されていません"}
 	}
 
-	result, err := h.cachedRouteService.GetRoute(req.Origin, req.Dest)
-	if err != nil {
-		return &ValidationError{Message: "ルート取得エラー: " + err.Error()}
-	}
+	// ルート検索の条件（車格の寸法・車両総重量、危険物積載、有料道路・高速道路の回避）
+	opts := service.RouteOptionsForVehicle(req.VehicleCode)
+	opts.AvoidTolls = req.AvoidTolls
+	opts.AvoidHighways = req.AvoidHighways
+	opts.Hazmat = req.Hazmat
+	req.RouteOptions = &opts
 
-	// キャッシュミス時（API呼び出し時）はAPI使用量をカウントアップ
-	if !result.FromCache && h.apiUsageService != nil {
-		if err := h.apiUsageService.IncrementAndCheck(); err != nil {
-			log.Printf("API使用量カウントエラー: %v", err)
+	// 経由地がある場合は区間ごとに取得し、合計を距離・走行時間とする
+	if req.Route != nil {
+		points := append(append([]string{req.Origin}, req.Waypoints...), req.Dest)
+		legsResult, err := h.cachedRouteService.GetRouteLegs(points, opts)
+		if err != nil {
+			return &ValidationError{Message: "ルート取得エラー: " + err.Error()}
 		}
+		h.countApiUsage(legsResult.CountsApiUsage())
+
+		req.Route.Legs = legsResult.RouteLegs()
+		req.DistanceKmRaw = req.Route.TotalDistanceKm()
+		req.DistanceKm = int(req.DistanceKmRaw)
+		req.DrivingMinutes = req.Route.TotalDurationMin()
+		return nil
+	}
+
+	result, err := h.cachedRouteService.GetRoute(req.Origin, req.Dest, opts)
+	if err != nil {
+		return &ValidationError{Message: "ルート取得エラー: " + err.Error()}
 	}
+	h.countApiUsage(result.CountsApiUsage())
 
 	req.DistanceKmRaw = result.Route.DistanceKm
 	req.DistanceKm = int(result.Route.DistanceKm)
@@ -390,6 +572,15 @@ func (h *CalculateHandler) resolveRouteInfo(req *CalculateRequest) error {
 	return nil
 }
 
+// countApiUsage Google Maps APIを呼び出した場合（キャッシュミスかつセルフホストのエンジン以外）はAPI使用量をカウントアップ
+func (h *CalculateHandler) countApiUsage(calledApi bool) {
+	if calledApi && h.apiUsageService != nil {
+		if err := h.apiUsageService.IncrementAndCheck(); err != nil {
+			log.Printf("API使用量カウントエラー: %v", err)
+		}
+	}
+}
+
 // validateRequest リクエストをバリデーション
 func (h *CalculateHandler) validateRequest(req *CalculateRequest) error {
 	if req.DistanceKm <= 0 {
@@ -416,45 +607,6 @@ func (e *ValidationError) Error() string {
 	return e.Message
 }
 
-// mockFareGetter テスト用の距離制運賃取得モック
-type mockFareGetter struct{}
-
-func (m *mockFareGetter) GetDistanceFareYen(regionCode, vehicleCode, distanceKm int) (int, error) {
-	// モック: 距離 * 100円
-	return distanceKm * 100, nil
-}
-
-// mockTimeFareGetter テスト用の時間制運賃取得モック
-type mockTimeFareGetter struct{}
-
-func (m *mockTimeFareGetter) GetBaseFare(regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
-	// モック: 基礎運賃
-	return &model.JtaTimeBaseFare{
-		RegionCode:  regionCode,
-		VehicleCode: vehicleCode,
-		Hours:       hours,
-		FareYen:     10000,
-		BaseKm:      30,
-	}, nil
-}
-
-func (m *mockTimeFareGetter) GetSurcharge(regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
-	// モック: 加算額
-	fareYen := 0
-	switch surchargeType {
-	case "distance":
-		fareYen = 50
-	case "time":
-		fareYen = 500
-	}
-	return &model.JtaTimeSurcharge{
-		RegionCode:    regionCode,
-		VehicleCode:   vehicleCode,
-		SurchargeType: surchargeType,
-		FareYen:       fareYen,
-	}, nil
-}
-
 // vehicleCodeToHighwayCarType 車格コードから高速料金車種を自動マッピング
 func vehicleCodeToHighwayCarType(vehicleCode int) int {
 	switch vehicleCode {
@@ -1,14 +1,22 @@
 package handler
 
 import (
+	"database/sql"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/database"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/repository"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
 )
 
 // mockRenderer テスト用のモックレンダラー
@@ -29,7 +37,7 @@ func TestCalculateHandler_Calculate(t *testing.T) {
 	renderer := &mockRenderer{}
 	e.Renderer = renderer
 
-	handler := NewCalculateHandler(nil, nil, nil, nil, nil, nil)
+	handler := NewCalculateHandler(createMockFareCalculator(), nil, nil, nil, nil, nil)
 
 	tests := []struct {
 		name           string
@@ -89,6 +97,253 @@ func TestCalculateHandler_Calculate(t *testing.T) {
 			wantStatusCode: http.StatusOK,
 			wantTemplate:   "result",
 		},
+		{
+			name: "見積日指定あり",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"quote_date":      {"2024-03-22"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "燃料サーチャージを加算",
+			formData: url.Values{
+				"region_code":        {"3"},
+				"vehicle_code":       {"3"},
+				"distance_km":        {"100"},
+				"driving_minutes":    {"120"},
+				"loading_minutes":    {"60"},
+				"use_fuel_surcharge": {"true"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "見積日の形式が不正な場合エラー",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"quote_date":      {"2024/03/22"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "error",
+		},
+		{
+			name: "出発日時から深夜・休日を判定",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"departure_at":    {"2026-10-18T21:00"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "出発日時の形式が不正な場合エラー",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"departure_at":    {"2026-10-18 21:00"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "error",
+		},
+		{
+			name: "往復",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"trip_mode":       {"round_trip"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "空車回送（回送計上率指定）",
+			formData: url.Values{
+				"region_code":       {"3"},
+				"vehicle_code":      {"3"},
+				"distance_km":       {"100"},
+				"driving_minutes":   {"120"},
+				"loading_minutes":   {"60"},
+				"trip_mode":         {"empty_return"},
+				"empty_return_rate": {"30"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "運行形態が不正な場合エラー",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"trip_mode":       {"return"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "error",
+		},
+		{
+			name: "回送計上率が範囲外の場合エラー",
+			formData: url.Values{
+				"region_code":       {"3"},
+				"vehicle_code":      {"3"},
+				"distance_km":       {"100"},
+				"driving_minutes":   {"120"},
+				"loading_minutes":   {"60"},
+				"trip_mode":         {"empty_return"},
+				"empty_return_rate": {"150"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "error",
+		},
+		{
+			name: "車体種別指定（特殊車両割増）",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"body_type":       {"refrigerated"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "未登録の車体種別の場合エラー",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"body_type":       {"unknown"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "error",
+		},
+		{
+			name: "割増項目を複数選択",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"surcharge_items": {"express", "hand_loading"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "軽貨物で速達割増",
+			formData: url.Values{
+				"vehicle_code":    {"0"},
+				"distance_km":     {"30"},
+				"driving_minutes": {"60"},
+				"loading_minutes": {"30"},
+				"surcharge_items": {"express"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "軽貨物に適用できない割増項目の場合エラー",
+			formData: url.Values{
+				"vehicle_code":    {"0"},
+				"distance_km":     {"30"},
+				"driving_minutes": {"60"},
+				"loading_minutes": {"30"},
+				"surcharge_items": {"hand_loading"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "error",
+		},
+		{
+			name: "荷主指定（契約運賃）",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"customer_id":     {"1"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "複数日運行・宿泊費あり",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"700"},
+				"driving_minutes": {"840"},
+				"loading_minutes": {"120"},
+				"overnight_cost":  {"8000"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "改善基準告示の休憩を時間制に含める",
+			formData: url.Values{
+				"region_code":             {"3"},
+				"vehicle_code":            {"3"},
+				"distance_km":             {"400"},
+				"driving_minutes":         {"600"},
+				"loading_minutes":         {"60"},
+				"include_compliance_time": {"true"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "result",
+		},
+		{
+			name: "未登録の荷主の場合エラー",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"customer_id":     {"99"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "error",
+		},
+		{
+			name: "荷主IDが不正な場合エラー",
+			formData: url.Values{
+				"region_code":     {"3"},
+				"vehicle_code":    {"3"},
+				"distance_km":     {"100"},
+				"driving_minutes": {"120"},
+				"loading_minutes": {"60"},
+				"customer_id":     {"abc"},
+			},
+			wantStatusCode: http.StatusOK,
+			wantTemplate:   "error",
+		},
 		{
 			name: "距離が未入力の場合エラー",
 			formData: url.Values{
@@ -142,7 +397,7 @@ func TestCalculateHandler_Calculate(t *testing.T) {
 func TestCalculateHandler_CalculateJSON(t *testing.T) {
 	e := echo.New()
 
-	handler := NewCalculateHandler(nil, nil, nil, nil, nil, nil)
+	handler := NewCalculateHandler(createMockFareCalculator(), nil, nil, nil, nil, nil)
 
 	tests := []struct {
 		name           string
@@ -183,13 +438,80 @@ func TestCalculateHandler_CalculateJSON(t *testing.T) {
 	}
 }
 
+// TestCalculateHandler_CalculateJSON_Customer 荷主指定時に標準運賃と契約運賃の両方を返すこと
+func TestCalculateHandler_CalculateJSON_Customer(t *testing.T) {
+	e := echo.New()
+	handler := NewCalculateHandler(createMockFareCalculator(), nil, nil, nil, nil, nil)
+
+	formData := url.Values{
+		"region_code":     {"3"},
+		"vehicle_code":    {"3"},
+		"distance_km":     {"100"},
+		"driving_minutes": {"120"},
+		"loading_minutes": {"60"},
+		"customer_id":     {"1"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/fare/calculate/json", strings.NewReader(formData.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	if err := handler.CalculateJSON(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("CalculateJSON() error = %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("CalculateJSON() status = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	var got struct {
+		Rankings []service.FareRanking
+		Contract *service.ContractFareResult
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("JSONパースエラー: %v", err)
+	}
+	if got.Contract == nil || len(got.Contract.Fares) != len(got.Rankings) {
+		t.Fatalf("Contract = %+v", got.Contract)
+	}
+	for _, f := range got.Contract.Fares {
+		if f.Contract.Exclusive != f.Standard.Exclusive*90/100 {
+			t.Errorf("%s: 契約運賃（税抜） = %d, want %d", f.Type, f.Contract.Exclusive, f.Standard.Exclusive*90/100)
+		}
+	}
+}
+
+// TestNewTotalWithHighway 運賃（税込）と高速代（税込）を合算すること
+func TestNewTotalWithHighway(t *testing.T) {
+	fareResult := &service.FareComparisonResult{
+		Rankings: []service.FareRanking{
+			{Rank: 1, Type: "距離制", Fare: 38500, FareExclTax: 35000},
+			{Rank: 2, Type: "時間制", Fare: 44000, FareExclTax: 40000},
+		},
+		TaxCalculator: service.DefaultTaxCalculator(),
+	}
+
+	total := newTotalWithHighway(fareResult, 5500)
+
+	want := TotalWithHighway{
+		MinFare:            38500,
+		MaxFare:            44000,
+		HighwayToll:        5500,
+		HighwayTollExclTax: 5000,
+		MinTotal:           44000,
+		MaxTotal:           49500,
+		MinTotalExclTax:    40000,
+		MaxTotalExclTax:    45000,
+	}
+	if *total != want {
+		t.Errorf("newTotalWithHighway() = %+v, want %+v", *total, want)
+	}
+}
+
 // TestCalculateHandler_CalculateWithRoute 出発地/目的地入力ベースの運賃計算テスト
 func TestCalculateHandler_CalculateWithRoute(t *testing.T) {
 	e := echo.New()
 	renderer := &mockRenderer{}
 	e.Renderer = renderer
 
-	handler := NewCalculateHandler(nil, nil, nil, nil, nil, nil)
+	handler := NewCalculateHandler(createMockFareCalculator(), nil, nil, nil, nil, nil)
 
 	tests := []struct {
 		name           string
@@ -277,3 +599,336 @@ func TestCalculateHandler_CalculateWithRoute(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateHandler_CalculateWithWaypoints(t *testing.T) {
+	e := echo.New()
+	renderer := &mockRenderer{}
+	e.Renderer = renderer
+
+	routesClient := service.NewMockRoutesClient()
+	routesClient.SetMockRoute("東京都千代田区", "神奈川県横浜市", 30.0, 50)
+	routesClient.SetMockRoute("神奈川県横浜市", "静岡県静岡市", 150.0, 120)
+	routesClient.SetMockRoute("静岡県静岡市", "愛知県名古屋市", 180.0, 150)
+	routeService := service.NewCachedRouteService(routesClient, &mockCacheStore{}, 0)
+	handler := NewCalculateHandler(createMockFareCalculator(), routeService, nil, nil, nil, nil)
+
+	formData := url.Values{
+		"origin":               {"東京都千代田区"},
+		"waypoints":            {"神奈川県横浜市", " ", "静岡県静岡市"},
+		"dest":                 {"愛知県名古屋市"},
+		"vehicle_code":         {"3"},
+		"loading_minutes":      {"60"},
+		"stop_loading_minutes": {"20"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/fare/calculate", strings.NewReader(formData.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+
+	if err := handler.Calculate(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if renderer.lastTemplate != "result" {
+		t.Fatalf("template = %v, want result（%v）", renderer.lastTemplate, renderer.lastData)
+	}
+
+	result := renderer.lastData.(*CalculateResultWithHighway)
+	if result.Route == nil || len(result.Route.Legs) != 3 {
+		t.Fatalf("Route = %+v, want 3 legs", result.Route)
+	}
+	if result.DistanceKmRaw != 360.0 || result.DrivingMinutes != 320 {
+		t.Errorf("DistanceKmRaw = %.1f, DrivingMinutes = %d, want 360.0/320", result.DistanceKmRaw, result.DrivingMinutes)
+	}
+	if result.LoadingMinutes != 100 {
+		t.Errorf("LoadingMinutes = %d, want 100（60 + 20 × 2）", result.LoadingMinutes)
+	}
+}
+
+// recordingRouteClient ルート検索の条件を記録するテスト用のルート取得クライアント
+type recordingRouteClient struct {
+	*service.MockRoutesClient
+	opts []service.RouteOptions
+}
+
+func (c *recordingRouteClient) GetRoute(origin, dest string, opts service.RouteOptions) (*model.RouteCache, error) {
+	c.opts = append(c.opts, opts)
+	return c.MockRoutesClient.GetRoute(origin, dest, opts)
+}
+
+// TestCalculateHandler_Calculate_RouteOptions 有料道路・高速道路の回避と危険物積載をルート検索の条件に渡すこと
+func TestCalculateHandler_Calculate_RouteOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		form     url.Values
+		wantOpts service.RouteOptions
+	}{
+		{
+			name:     "指定なし",
+			form:     url.Values{},
+			wantOpts: service.RouteOptionsForVehicle(3),
+		},
+		{
+			name: "有料道路・高速道路の回避と危険物積載",
+			form: url.Values{"avoid_tolls": {"true"}, "avoid_highways": {"true"}, "hazmat": {"true"}},
+			wantOpts: func() service.RouteOptions {
+				opts := service.RouteOptionsForVehicle(3)
+				opts.AvoidTolls, opts.AvoidHighways, opts.Hazmat = true, true, true
+				return opts
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			renderer := &mockRenderer{}
+			e.Renderer = renderer
+
+			client := &recordingRouteClient{MockRoutesClient: service.NewMockRoutesClient()}
+			client.SetMockRoute("東京都千代田区", "大阪府大阪市", 500.0, 360)
+			routeService := service.NewCachedRouteService(client, &mockCacheStore{}, 0)
+			handler := NewCalculateHandler(createMockFareCalculator(), routeService, nil, nil, nil, nil)
+
+			formData := url.Values{
+				"origin":          {"東京都千代田区"},
+				"dest":            {"大阪府大阪市"},
+				"vehicle_code":    {"3"},
+				"loading_minutes": {"60"},
+			}
+			for k, v := range tt.form {
+				formData[k] = v
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/fare/calculate", strings.NewReader(formData.Encode()))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			rec := httptest.NewRecorder()
+
+			if err := handler.Calculate(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if renderer.lastTemplate != "result" {
+				t.Fatalf("template = %v, want result（%v）", renderer.lastTemplate, renderer.lastData)
+			}
+			if len(client.opts) != 1 || client.opts[0] != tt.wantOpts {
+				t.Errorf("RouteOptions = %+v, want %+v", client.opts, tt.wantOpts)
+			}
+		})
+	}
+}
+
+// TestCalculateHandler_Calculate_HighwayComparison 高速道路使用時に一般道との比較を返すこと
+func TestCalculateHandler_Calculate_HighwayComparison(t *testing.T) {
+	e := echo.New()
+	renderer := &mockRenderer{}
+	e.Renderer = renderer
+
+	mainDB, err := database.InitMainDB(filepath.Join(t.TempDir(), "str.db"))
+	if err != nil {
+		t.Fatalf("InitMainDB failed: %v", err)
+	}
+	defer mainDB.Close()
+	cacheDB, err := database.InitCacheDB(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("InitCacheDB failed: %v", err)
+	}
+	defer cacheDB.Close()
+
+	// キャッシュ済みの高速料金（大型車・200km・2時間）
+	toll := &model.HighwayToll{OriginIC: "東京", DestIC: "浜松", CarType: model.CarTypeLarge, NormalToll: 12000, EtcToll: 11000, Etc2Toll: 11000, DistanceKm: 200, DurationMin: 120}
+	if err := repository.NewHighwayTollRepository(cacheDB).Create(toll); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	handler := NewCalculateHandler(createMockFareCalculator(), nil, nil, nil, mainDB, cacheDB)
+	formData := url.Values{
+		"region_code":     {"3"},
+		"vehicle_code":    {"3"},
+		"distance_km":     {"300"},
+		"driving_minutes": {"480"},
+		"loading_minutes": {"60"},
+		"use_highway":     {"true"},
+		"origin_ic":       {"東京"},
+		"dest_ic":         {"浜松"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/fare/calculate", strings.NewReader(formData.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+
+	if err := handler.Calculate(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if renderer.lastTemplate != "result" {
+		t.Fatalf("template = %v, want result（%v）", renderer.lastTemplate, renderer.lastData)
+	}
+
+	result := renderer.lastData.(*CalculateResultWithHighway)
+	c := result.HighwayComparison
+	if c == nil {
+		t.Fatalf("HighwayComparison = nil（HighwayError: %s）", result.HighwayError)
+	}
+	// 一般道では200km÷(300km/8時間)=5時間20分 → 高速道路2時間で3時間20分短縮
+	if c.SavedMinutes != 200 {
+		t.Errorf("SavedMinutes = %d, want 200", c.SavedMinutes)
+	}
+	if c.Highway.DrivingMinutes != 280 {
+		t.Errorf("Highway.DrivingMinutes = %d, want 280", c.Highway.DrivingMinutes)
+	}
+	if len(c.Rankings) != 2*len(result.Rankings) {
+		t.Errorf("Rankings = %d件, want %d件", len(c.Rankings), 2*len(result.Rankings))
+	}
+}
+
+// TestNewCalculateHandlerRequiresFareCalculator 運賃計算サービスが未設定の場合は作成時に panic すること
+func TestNewCalculateHandlerRequiresFareCalculator(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewCalculateHandler(nil, ...) が panic しない")
+		}
+	}()
+	NewCalculateHandler(nil, nil, nil, nil, nil, nil)
+}
+
+// createMockFareCalculator テスト用のモックFareCalculatorを作成
+func createMockFareCalculator() *service.FareCalculatorService {
+	// モックリポジトリを使用
+	distanceFare := service.NewDistanceFareService(&mockFareGetter{})
+	timeFare := service.NewTimeFareService(&mockTimeFareGetter{})
+	akabouFare := service.NewAkabouFareService(&mockAkabouFareGetter{})
+	calculator := service.NewFareCalculatorService(distanceFare, timeFare, akabouFare)
+	calculator.SetFuelSurchargeService(service.NewFuelSurchargeService(&mockFuelSurchargeGetter{}))
+	calculator.SetComplianceService(service.NewComplianceService())
+	calculator.SetBodyTypeSurchargeGetter(&mockBodyTypeSurchargeGetter{})
+	calculator.SetSurchargeItemGetter(&mockSurchargeItemGetter{})
+	calculator.SetCustomerPricingGetter(&mockCustomerPricingGetter{})
+	return calculator
+}
+
+// mockFareGetter テスト用の距離制運賃取得モック
+type mockFareGetter struct{}
+
+func (m *mockFareGetter) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
+	// モック: 距離 * 100円
+	return distanceKm * 100, nil
+}
+
+// mockTimeFareGetter テスト用の時間制運賃取得モック
+type mockTimeFareGetter struct{}
+
+func (m *mockTimeFareGetter) GetBaseFare(version *model.TariffVersion, regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
+	// モック: 基礎運賃
+	return &model.JtaTimeBaseFare{
+		RegionCode:  regionCode,
+		VehicleCode: vehicleCode,
+		Hours:       hours,
+		FareYen:     10000,
+		BaseKm:      30,
+	}, nil
+}
+
+func (m *mockTimeFareGetter) GetSurcharge(version *model.TariffVersion, regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
+	// モック: 加算額
+	fareYen := 0
+	switch surchargeType {
+	case "distance":
+		fareYen = 50
+	case "time":
+		fareYen = 500
+	}
+	return &model.JtaTimeSurcharge{
+		RegionCode:    regionCode,
+		VehicleCode:   vehicleCode,
+		SurchargeType: surchargeType,
+		FareYen:       fareYen,
+	}, nil
+}
+
+// mockAkabouFareGetter テスト用の赤帽運賃マスタ取得モック（cmd/seed と同じ料金表）
+type mockAkabouFareGetter struct{}
+
+func (m *mockAkabouFareGetter) GetDistanceFares(version *model.TariffVersion) ([]*model.AkabouDistanceFare, error) {
+	intPtr := func(i int) *int { return &i }
+	return []*model.AkabouDistanceFare{
+		{MinKm: 0, MaxKm: intPtr(20), BaseFare: intPtr(5500)},
+		{MinKm: 21, MaxKm: intPtr(50), PerKmRate: intPtr(242)},
+		{MinKm: 51, MaxKm: intPtr(100), PerKmRate: intPtr(187)},
+		{MinKm: 101, MaxKm: intPtr(150), PerKmRate: intPtr(154)},
+		{MinKm: 151, PerKmRate: intPtr(132)},
+	}, nil
+}
+
+func (m *mockAkabouFareGetter) GetTimeFare(version *model.TariffVersion) (*model.AkabouTimeFare, error) {
+	return &model.AkabouTimeFare{BaseHours: 2, BaseKm: 20, BaseFare: 6050, OvertimeRate: 1375, OvertimeUnitMinutes: 30}, nil
+}
+
+func (m *mockAkabouFareGetter) GetSurchargeByType(version *model.TariffVersion, surchargeType string) (*model.AkabouSurcharge, error) {
+	ratePercent := 20
+	if surchargeType == service.AkabouSurchargeTypeNight {
+		ratePercent = 30
+	}
+	return &model.AkabouSurcharge{SurchargeType: surchargeType, RatePercent: ratePercent}, nil
+}
+
+func (m *mockAkabouFareGetter) GetAreaSurchargeByName(version *model.TariffVersion, areaName string) (*model.AkabouAreaSurcharge, error) {
+	if areaName == "東京23区" || areaName == "大阪市内" {
+		return &model.AkabouAreaSurcharge{AreaName: areaName, SurchargeAmount: 440}, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockAkabouFareGetter) GetAdditionalFeeByType(version *model.TariffVersion, feeType string) (*model.AkabouAdditionalFee, error) {
+	if feeType == service.AkabouFeeTypeWork {
+		return &model.AkabouAdditionalFee{FeeType: feeType, FreeMinutes: 30, UnitMinutes: 15, FeeAmount: 550}, nil
+	}
+	return &model.AkabouAdditionalFee{FeeType: feeType, FreeMinutes: 30, UnitMinutes: 30, FeeAmount: 1100}, nil
+}
+
+// mockFuelSurchargeGetter テスト用の燃料サーチャージ取得モック
+// 軽油価格は常に150円/L（基準120円/L）、加算額は距離帯によらず100円/5円刻み
+type mockFuelSurchargeGetter struct{}
+
+func (m *mockFuelSurchargeGetter) GetFuelPrice(date time.Time) (*model.FuelPrice, error) {
+	return &model.FuelPrice{YearMonth: date.Format(model.FuelYearMonthFormat), PriceYen: 150, ReferencePriceYen: 120}, nil
+}
+
+func (m *mockFuelSurchargeGetter) GetSurcharge(version *model.TariffVersion, vehicleCode, distanceKm int) (*model.FuelSurcharge, error) {
+	return &model.FuelSurcharge{VehicleCode: vehicleCode, StepYen: 5, AmountYen: 100}, nil
+}
+
+// mockBodyTypeSurchargeGetter テスト用の特殊車両割増取得モック
+// 冷蔵車・冷凍車（2割増）のみ登録済みとする
+type mockBodyTypeSurchargeGetter struct{}
+
+func (m *mockBodyTypeSurchargeGetter) GetBodyTypeSurcharge(version *model.TariffVersion, bodyType string) (*model.BodyTypeSurcharge, error) {
+	if bodyType == "refrigerated" {
+		return &model.BodyTypeSurcharge{BodyType: bodyType, Name: "冷蔵車・冷凍車", SurchargePercent: 20}, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+// mockSurchargeItemGetter テスト用の割増項目取得モック
+// 速達割増（2割増、全車格）と手積み・手降ろし（定額3000円、トラックのみ）を登録済みとする
+type mockSurchargeItemGetter struct{}
+
+func (m *mockSurchargeItemGetter) GetSurchargeItem(code string) (*model.SurchargeItem, error) {
+	switch code {
+	case "express":
+		return &model.SurchargeItem{Code: code, Name: "速達割増", CalcType: model.SurchargeCalcTypeRate, RatePercent: 20}, nil
+	case "hand_loading":
+		return &model.SurchargeItem{Code: code, Name: "手積み・手降ろし", CalcType: model.SurchargeCalcTypeFixed, AmountYen: 3000, VehicleCodes: "1,2,3,4"}, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+// mockCustomerPricingGetter テスト用の荷主・契約条件取得モック
+// 荷主ID 1（1割引）のみ登録済みとする
+type mockCustomerPricingGetter struct{}
+
+func (m *mockCustomerPricingGetter) GetCustomer(id int64) (*model.Customer, error) {
+	if id == 1 {
+		return &model.Customer{ID: 1, Code: "C001", Name: "テスト荷主"}, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockCustomerPricingGetter) GetPricingRules(customerID int64) ([]*model.CustomerPricingRule, error) {
+	return []*model.CustomerPricingRule{
+		{CustomerID: customerID, Name: "基本契約", AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: -10},
+	}, nil
+}
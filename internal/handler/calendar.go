@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+// CalendarHandler 休日カレンダーハンドラ
+type CalendarHandler struct {
+	calendar *service.HolidayCalendarService
+}
+
+// NewCalendarHandler 新しいCalendarHandlerを作成
+func NewCalendarHandler(calendar *service.HolidayCalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendar: calendar,
+	}
+}
+
+// HolidayInfo 休日情報
+type HolidayInfo struct {
+	Date      string              `json:"date"` // YYYY-MM-DD
+	Weekday   string              `json:"weekday"`
+	Name      string              `json:"name"`
+	Type      service.HolidayType `json:"type"`
+	TypeLabel string              `json:"type_label"`
+}
+
+// HolidaysResponse 休日一覧レスポンス
+type HolidaysResponse struct {
+	Year     int           `json:"year"`
+	Covered  bool          `json:"covered"` // 祝日データの収録範囲内か
+	Holidays []HolidayInfo `json:"holidays"`
+}
+
+// GetHolidays 指定年の休日一覧を取得（国民の祝日・振替休日・国民の休日・会社休日）
+// GET /api/calendar/holidays?year=2026（未指定は当年）
+func (h *CalendarHandler) GetHolidays(c echo.Context) error {
+	year := time.Now().Year()
+	if v := c.QueryParam("year"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "yearパラメータが不正です: " + v,
+			})
+		}
+		year = n
+	}
+
+	holidays, err := h.calendar.Holidays(year)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "休日の取得に失敗しました",
+		})
+	}
+
+	resp := HolidaysResponse{
+		Year:     year,
+		Covered:  h.calendar.Covers(year),
+		Holidays: make([]HolidayInfo, 0, len(holidays)),
+	}
+	for _, holiday := range holidays {
+		resp.Holidays = append(resp.Holidays, HolidayInfo{
+			Date:      holiday.Date.Format(service.HolidayDateFormat),
+			Weekday:   service.WeekdayLabel(holiday.Date.Weekday()),
+			Name:      holiday.Name,
+			Type:      holiday.Type,
+			TypeLabel: holiday.Type.Label(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
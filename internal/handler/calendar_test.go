@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+func TestCalendarHandler_GetHolidays(t *testing.T) {
+	calendar, err := service.NewBundledHolidayCalendarService()
+	if err != nil {
+		t.Fatalf("NewBundledHolidayCalendarService() error = %v", err)
+	}
+	handler := NewCalendarHandler(calendar)
+
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantCovered bool
+		wantCount   int
+	}{
+		{"2026年の休日", "?year=2026", http.StatusOK, true, 18},
+		{"収録範囲外の年", "?year=1990", http.StatusOK, false, 0},
+		{"不正な年", "?year=abc", http.StatusBadRequest, false, 0},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/calendar/holidays"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := handler.GetHolidays(c); err != nil {
+				t.Fatalf("GetHolidays() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("GetHolidays() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp HolidaysResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("レスポンスのJSON解析エラー: %v", err)
+			}
+			if resp.Covered != tt.wantCovered {
+				t.Errorf("Covered = %v, want %v", resp.Covered, tt.wantCovered)
+			}
+			if len(resp.Holidays) != tt.wantCount {
+				t.Errorf("len(Holidays) = %d, want %d", len(resp.Holidays), tt.wantCount)
+			}
+		})
+	}
+}
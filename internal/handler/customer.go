@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// CustomerLister 荷主の一覧取得インターフェース（テスト用にモック可能）
+type CustomerLister interface {
+	GetAll() ([]*model.Customer, error)
+}
+
+// CustomerHandler 荷主マスタハンドラ
+type CustomerHandler struct {
+	lister CustomerLister
+}
+
+// NewCustomerHandler 新しいCustomerHandlerを作成
+func NewCustomerHandler(lister CustomerLister) *CustomerHandler {
+	return &CustomerHandler{lister: lister}
+}
+
+// CustomerInfo 荷主情報
+type CustomerInfo struct {
+	ID    int64  `json:"id"`
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Label string `json:"label"` // 表示用（例: C001 山田商事）
+}
+
+// GetCustomers 契約運賃を計算できる荷主の一覧を取得
+// GET /api/customers
+func (h *CustomerHandler) GetCustomers(c echo.Context) error {
+	customers, err := h.lister.GetAll()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "荷主の取得に失敗しました",
+		})
+	}
+
+	infos := make([]CustomerInfo, 0, len(customers))
+	for _, customer := range customers {
+		infos = append(infos, CustomerInfo{
+			ID:    customer.ID,
+			Code:  customer.Code,
+			Name:  customer.Name,
+			Label: customer.Code + " " + customer.Name,
+		})
+	}
+
+	return c.JSON(http.StatusOK, infos)
+}
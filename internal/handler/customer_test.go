@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// mockCustomerLister テスト用の荷主一覧モック
+type mockCustomerLister struct {
+	err error
+}
+
+func (m *mockCustomerLister) GetAll() ([]*model.Customer, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*model.Customer{
+		{ID: 1, Code: "C001", Name: "山田商事"},
+		{ID: 2, Code: "C002", Name: "東信物産"},
+	}, nil
+}
+
+func TestCustomerHandler_GetCustomers(t *testing.T) {
+	e := echo.New()
+
+	t.Run("一覧", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/customers", nil)
+		rec := httptest.NewRecorder()
+		if err := NewCustomerHandler(&mockCustomerLister{}).GetCustomers(e.NewContext(req, rec)); err != nil {
+			t.Fatalf("GetCustomers() error = %v", err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+
+		var got []CustomerInfo
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("JSONパースエラー: %v", err)
+		}
+		if len(got) != 2 || got[0].ID != 1 || got[0].Label != "C001 山田商事" {
+			t.Errorf("customers = %+v", got)
+		}
+	})
+
+	t.Run("取得エラー", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/customers", nil)
+		rec := httptest.NewRecorder()
+		if err := NewCustomerHandler(&mockCustomerLister{err: errors.New("DB接続エラー")}).GetCustomers(e.NewContext(req, rec)); err != nil {
+			t.Fatalf("GetCustomers() error = %v", err)
+		}
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+		}
+	})
+}
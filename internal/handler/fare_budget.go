@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+// defaultFareBudgetLoadingMinutes 予算からの逆算の荷役時間のデフォルト（分、見積と同じ）
+const defaultFareBudgetLoadingMinutes = 60
+
+// FareBudgetHandler 予算からの逆算ハンドラ
+type FareBudgetHandler struct {
+	budget *service.FareBudgetService
+}
+
+// NewFareBudgetHandler 新しいFareBudgetHandlerを作成
+func NewFareBudgetHandler(budget *service.FareBudgetService) *FareBudgetHandler {
+	return &FareBudgetHandler{budget: budget}
+}
+
+// FareBudgetLimitInfo 予算内に収まる上限（運賃タイプごと）
+type FareBudgetLimitInfo struct {
+	Name           string `json:"name"`
+	Feasible       bool   `json:"feasible"`
+	DistanceKm     int    `json:"distance_km"`
+	RoundedKm      int    `json:"rounded_km,omitempty"`      // 距離制のみ
+	WorkingMinutes int    `json:"working_minutes,omitempty"` // 時間制のみ
+	DrivingMinutes int    `json:"driving_minutes"`
+	AppliedHours   int    `json:"applied_hours,omitempty"` // 時間制のみ
+	WorkingDays    int    `json:"working_days,omitempty"`  // 時間制のみ
+	Fare           int    `json:"fare"`
+	NextFare       int    `json:"next_fare"` // 上限を超えた場合の運賃（0は探索上限）
+	ReachedLimit   bool   `json:"reached_limit"`
+	Label          string `json:"label"`
+}
+
+// FareBudgetResponse 予算からの逆算結果レスポンス
+type FareBudgetResponse struct {
+	Budget         int                 `json:"budget"`
+	TaxIncluded    bool                `json:"tax_included"`
+	RegionCode     int                 `json:"region_code"`
+	VehicleCode    int                 `json:"vehicle_code"`
+	SpeedKmh       int                 `json:"speed_kmh"`
+	LoadingMinutes int                 `json:"loading_minutes"`
+	Summary        string              `json:"summary"`
+	Distance       FareBudgetLimitInfo `json:"distance"` // 距離制
+	Time           FareBudgetLimitInfo `json:"time"`     // 時間制
+}
+
+// GetFareBudget 予算内に収まる最大距離（距離制）・最大拘束時間（時間制）を逆算
+// GET /api/fare/budget?budget=80000&region=3&vehicle=2&tax_included=true
+func (h *FareBudgetHandler) GetFareBudget(c echo.Context) error {
+	req, err := parseFareBudgetRequest(c)
+	if err != nil {
+		return fareMatrixError(c, err.Error())
+	}
+
+	result, err := h.budget.Solve(req)
+	if err != nil {
+		return fareMatrixError(c, "予算からの逆算に失敗しました: "+err.Error())
+	}
+
+	return c.JSON(http.StatusOK, FareBudgetResponse{
+		Budget:         result.Budget,
+		TaxIncluded:    result.TaxIncluded,
+		RegionCode:     result.RegionCode,
+		VehicleCode:    result.VehicleCode,
+		SpeedKmh:       result.SpeedKmh,
+		LoadingMinutes: result.LoadingMinutes,
+		Summary:        result.Summary(),
+		Distance:       newFareBudgetLimitInfo(result.Distance),
+		Time:           newFareBudgetLimitInfo(result.Time),
+	})
+}
+
+// newFareBudgetLimitInfo 上限をレスポンス形式に変換
+func newFareBudgetLimitInfo(l *service.FareBudgetLimit) FareBudgetLimitInfo {
+	return FareBudgetLimitInfo{
+		Name:           l.Name,
+		Feasible:       l.Feasible,
+		DistanceKm:     l.DistanceKm,
+		RoundedKm:      l.RoundedKm,
+		WorkingMinutes: l.WorkingMinutes,
+		DrivingMinutes: l.DrivingMinutes,
+		AppliedHours:   l.AppliedHours,
+		WorkingDays:    l.WorkingDays,
+		Fare:           l.Fare,
+		NextFare:       l.NextFare,
+		ReachedLimit:   l.ReachedLimit,
+		Label:          l.Label(),
+	}
+}
+
+// parseFareBudgetRequest クエリパラメータから逆算条件を作成
+func parseFareBudgetRequest(c echo.Context) (*service.FareBudgetRequest, error) {
+	for _, name := range []string{"budget", "region", "vehicle"} {
+		if c.QueryParam(name) == "" {
+			return nil, fmt.Errorf("%sパラメータを指定してください", name)
+		}
+	}
+
+	req := &service.FareBudgetRequest{
+		TaxIncluded: c.QueryParam("tax_included") == "true",
+		Conditions: service.FareCalculationRequest{
+			IsNight:          c.QueryParam("night") == "true",
+			IsHoliday:        c.QueryParam("holiday") == "true",
+			UseSimpleBaseKm:  c.QueryParam("use_simple_base_km") == "true",
+			UseFuelSurcharge: c.QueryParam("use_fuel_surcharge") == "true",
+			BodyType:         c.QueryParam("body_type"),
+		},
+	}
+
+	var err error
+	cond := &req.Conditions
+	params := []struct {
+		name         string
+		defaultValue int
+		dst          *int
+	}{
+		{"budget", 0, &req.Budget},
+		{"region", 0, &cond.RegionCode},
+		{"vehicle", 0, &cond.VehicleCode},
+		{"speed", 0, &req.SpeedKmh},
+		{"loading_minutes", defaultFareBudgetLoadingMinutes, &cond.LoadingMinutes},
+		{"work_minutes", 0, &cond.WorkMinutes},
+		{"waiting_minutes", 0, &cond.WaitingMinutes},
+	}
+	for _, p := range params {
+		if *p.dst, err = fareMatrixIntParam(c, p.name, p.defaultValue); err != nil {
+			return nil, err
+		}
+	}
+
+	// 割増項目（複数指定・カンマ区切り）
+	if v := fareMatrixListParam(c, "surcharge_items"); v != "" {
+		for _, code := range strings.Split(v, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				cond.SurchargeItems = append(cond.SurchargeItems, code)
+			}
+		}
+	}
+
+	// 見積日（YYYY-MM-DD）
+	if v := c.QueryParam("quote_date"); v != "" {
+		d, err := time.ParseInLocation(model.TariffDateFormat, v, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("見積日の形式が不正です（YYYY-MM-DD）: %s", v)
+		}
+		cond.QuoteDate = d
+	}
+
+	return req, nil
+}
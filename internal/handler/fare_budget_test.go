@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+func TestFareBudgetHandler_GetFareBudget(t *testing.T) {
+	calculator := service.NewFareCalculatorService(
+		service.NewDistanceFareService(&mockFareGetter{}),
+		service.NewTimeFareService(&mockTimeFareGetter{}),
+		service.NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+	handler := NewFareBudgetHandler(service.NewFareBudgetService(calculator))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{"正常", "?budget=30000&region=3&vehicle=3&speed=40", http.StatusOK},
+		{"予算なし", "?region=3&vehicle=3", http.StatusBadRequest},
+		{"不正な予算", "?budget=abc&region=3&vehicle=3", http.StatusBadRequest},
+		{"軽貨物", "?budget=30000&region=3&vehicle=0", http.StatusBadRequest},
+		{"不正な見積日", "?budget=30000&region=3&vehicle=3&quote_date=2026/10/01", http.StatusBadRequest},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/fare/budget"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			if err := handler.GetFareBudget(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("GetFareBudget() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp FareBudgetResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("JSONパースエラー: %v", err)
+			}
+			// 距離制（運賃計算距離×100円）: 300km=30000円、301kmは320kmに丸めて32000円
+			if resp.Distance.DistanceKm != 300 || resp.Distance.RoundedKm != 300 || resp.Distance.NextFare != 32000 {
+				t.Errorf("Distance = %+v", resp.Distance)
+			}
+			if !resp.Time.Feasible || resp.Time.WorkingMinutes == 0 || resp.Time.Fare > 30000 {
+				t.Errorf("Time = %+v", resp.Time)
+			}
+			if resp.LoadingMinutes != 60 || resp.Summary == "" {
+				t.Errorf("LoadingMinutes = %d, Summary = %q", resp.LoadingMinutes, resp.Summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+// FareCrossoverHandler 運賃の損益分岐分析ハンドラ
+type FareCrossoverHandler struct {
+	crossover *service.FareCrossoverService
+}
+
+// NewFareCrossoverHandler 新しいFareCrossoverHandlerを作成
+func NewFareCrossoverHandler(crossover *service.FareCrossoverService) *FareCrossoverHandler {
+	return &FareCrossoverHandler{crossover: crossover}
+}
+
+// FareCrossoverSeriesInfo 運賃タイプごとの距離別運賃（グラフの系列）
+type FareCrossoverSeriesInfo struct {
+	Name  string `json:"name"`
+	Fares []int  `json:"fares"` // distances_km の順
+}
+
+// FareCrossoverPointInfo 安い運賃が入れ替わる距離
+type FareCrossoverPointInfo struct {
+	FromKm int    `json:"from_km"` // この距離までは before が安い
+	ToKm   int    `json:"to_km"`   // この距離からは after が安い
+	Before string `json:"before"`
+	After  string `json:"after"`
+	Label  string `json:"label"` // 表示用（例: 100〜110kmで時間制→距離制）
+}
+
+// FareCrossoverResponse 損益分岐の分析結果レスポンス
+type FareCrossoverResponse struct {
+	RegionCode     int                       `json:"region_code"`
+	VehicleCode    int                       `json:"vehicle_code"`
+	SpeedKmh       int                       `json:"speed_kmh"`
+	LoadingMinutes int                       `json:"loading_minutes"`
+	TariffVersion  string                    `json:"tariff_version,omitempty"`
+	TaxIncluded    bool                      `json:"tax_included"` // 赤帽は税込、トラ協は税抜
+	Note           string                    `json:"note"`         // 前提条件
+	Summary        string                    `json:"summary"`      // 要約（例: 10〜100kmは時間制、110〜500kmは距離制が安い）
+	DistancesKm    []int                     `json:"distances_km"`
+	DrivingMinutes []int                     `json:"driving_minutes"`
+	Series         []FareCrossoverSeriesInfo `json:"series"`
+	Cheapest       []string                  `json:"cheapest"` // 距離ごとの最安運賃タイプ
+	Crossovers     []FareCrossoverPointInfo  `json:"crossovers"`
+}
+
+// GetFareCrossover 距離制・時間制（軽貨物は赤帽）の運賃が入れ替わる距離を分析
+// GET /api/fare/crossover?region=3&vehicle=3&from=10&to=500&step=10&speed=40&loading_minutes=60
+func (h *FareCrossoverHandler) GetFareCrossover(c echo.Context) error {
+	req, err := parseFareCrossoverRequest(c)
+	if err != nil {
+		return fareMatrixError(c, err.Error())
+	}
+
+	analysis, err := h.crossover.Analyze(req)
+	if err != nil {
+		return fareMatrixError(c, "損益分岐の分析に失敗しました: "+err.Error())
+	}
+
+	resp := FareCrossoverResponse{
+		RegionCode:     analysis.RegionCode,
+		VehicleCode:    analysis.VehicleCode,
+		SpeedKmh:       analysis.SpeedKmh,
+		LoadingMinutes: analysis.LoadingMinutes,
+		TaxIncluded:    analysis.TaxIncluded,
+		Note:           analysis.Note(),
+		Summary:        analysis.Summary(),
+		DistancesKm:    analysis.DistancesKm,
+		DrivingMinutes: analysis.DrivingMinutes,
+		Cheapest:       analysis.Cheapest,
+		Crossovers:     make([]FareCrossoverPointInfo, 0, len(analysis.Crossovers)),
+	}
+	if analysis.TariffVersion != nil {
+		resp.TariffVersion = analysis.TariffVersion.Label()
+	}
+	for _, series := range analysis.Series {
+		resp.Series = append(resp.Series, FareCrossoverSeriesInfo{Name: series.Name, Fares: series.Fares})
+	}
+	for _, p := range analysis.Crossovers {
+		resp.Crossovers = append(resp.Crossovers, FareCrossoverPointInfo{
+			FromKm: p.FromKm,
+			ToKm:   p.ToKm,
+			Before: p.Before,
+			After:  p.After,
+			Label:  p.Label(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// parseFareCrossoverRequest クエリパラメータから損益分岐の分析条件を作成
+func parseFareCrossoverRequest(c echo.Context) (*service.FareCrossoverRequest, error) {
+	if c.QueryParam("vehicle") == "" {
+		return nil, fmt.Errorf("vehicleパラメータを指定してください")
+	}
+
+	req := &service.FareCrossoverRequest{
+		IsNight:         c.QueryParam("night") == "true",
+		IsHoliday:       c.QueryParam("holiday") == "true",
+		Area:            c.QueryParam("area"),
+		UseSimpleBaseKm: c.QueryParam("use_simple_base_km") == "true",
+	}
+
+	var err error
+	params := []struct {
+		name         string
+		defaultValue int
+		dst          *int
+	}{
+		{"vehicle", 0, &req.VehicleCode},
+		{"region", 0, &req.RegionCode},
+		{"from", defaultFareMatrixFromKm, &req.FromKm},
+		{"to", defaultFareMatrixToKm, &req.ToKm},
+		{"step", defaultFareMatrixStepKm, &req.StepKm},
+		{"speed", 0, &req.SpeedKmh},
+		{"loading_minutes", 0, &req.LoadingMinutes},
+	}
+	for _, p := range params {
+		if *p.dst, err = fareMatrixIntParam(c, p.name, p.defaultValue); err != nil {
+			return nil, err
+		}
+	}
+	if req.VehicleCode != service.VehicleCodeLight && req.RegionCode == 0 {
+		return nil, fmt.Errorf("regionパラメータを指定してください")
+	}
+
+	// 見積日（YYYY-MM-DD）
+	if v := c.QueryParam("quote_date"); v != "" {
+		d, err := time.ParseInLocation(model.TariffDateFormat, v, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("見積日の形式が不正です（YYYY-MM-DD）: %s", v)
+		}
+		req.QuoteDate = d
+	}
+
+	return req, nil
+}
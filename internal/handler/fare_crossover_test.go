@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+func TestFareCrossoverHandler_GetFareCrossover(t *testing.T) {
+	crossover := service.NewFareCrossoverService(
+		service.NewDistanceFareService(&mockFareGetter{}),
+		service.NewTimeFareService(&mockTimeFareGetter{}),
+		service.NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+	handler := NewFareCrossoverHandler(crossover)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantSeries string
+		wantPoints int
+	}{
+		{"トラック", "?region=3&vehicle=3&from=10&to=200&step=10&speed=40&loading_minutes=60", http.StatusOK, "距離制", 20},
+		{"軽貨物", "?vehicle=0&from=10&to=50&step=10&area=東京23区", http.StatusOK, "赤帽（距離制）", 5},
+		{"車格なし", "?region=3", http.StatusBadRequest, "", 0},
+		{"運輸局なし", "?vehicle=3", http.StatusBadRequest, "", 0},
+		{"不正な距離", "?region=3&vehicle=3&from=x", http.StatusBadRequest, "", 0},
+		{"無効な車格", "?region=3&vehicle=9", http.StatusBadRequest, "", 0},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/fare/crossover"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			if err := handler.GetFareCrossover(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("GetFareCrossover() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp FareCrossoverResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("JSONパースエラー: %v", err)
+			}
+			if len(resp.Series) != 2 || resp.Series[0].Name != tt.wantSeries {
+				t.Fatalf("Series = %+v", resp.Series)
+			}
+			if len(resp.DistancesKm) != tt.wantPoints || len(resp.Series[1].Fares) != tt.wantPoints || len(resp.Cheapest) != tt.wantPoints {
+				t.Errorf("系列の件数 = %d / %d / %d, want %d", len(resp.DistancesKm), len(resp.Series[1].Fares), len(resp.Cheapest), tt.wantPoints)
+			}
+			if resp.Summary == "" || !strings.Contains(resp.Note, "荷役") {
+				t.Errorf("Summary = %q, Note = %q", resp.Summary, resp.Note)
+			}
+			if resp.Crossovers == nil {
+				t.Error("crossovers が null")
+			}
+		})
+	}
+}
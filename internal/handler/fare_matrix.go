@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+// 運賃表の条件の既定値（関東・全車格・10〜500kmを10km刻み）
+const (
+	defaultFareMatrixRegions  = "3"
+	defaultFareMatrixVehicles = "1,2,3,4"
+	defaultFareMatrixFromKm   = 10
+	defaultFareMatrixToKm     = 500
+	defaultFareMatrixStepKm   = 10
+)
+
+// FareMatrixHandler 運賃表ハンドラ
+type FareMatrixHandler struct {
+	matrix *service.FareMatrixService
+}
+
+// NewFareMatrixHandler 新しいFareMatrixHandlerを作成
+func NewFareMatrixHandler(matrix *service.FareMatrixService) *FareMatrixHandler {
+	return &FareMatrixHandler{matrix: matrix}
+}
+
+// GetFareMatrix 距離制・時間制の運賃表をCSV・XLSXでダウンロード
+// GET /api/fare/matrix?format=xlsx&regions=3,6&vehicles=1,2,3,4&from=10&to=500&step=10&variants=normal,night
+func (h *FareMatrixHandler) GetFareMatrix(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = service.FareMatrixFormatCSV
+	}
+	if format != service.FareMatrixFormatCSV && format != service.FareMatrixFormatXLSX {
+		return fareMatrixError(c, "formatパラメータが不正です（csv / xlsx）: "+format)
+	}
+
+	req, err := parseFareMatrixRequest(c)
+	if err != nil {
+		return fareMatrixError(c, err.Error())
+	}
+
+	matrix, err := h.matrix.Build(req)
+	if err != nil {
+		return fareMatrixError(c, "運賃表の作成に失敗しました: "+err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err := matrix.Write(&buf, format); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "運賃表の出力に失敗しました",
+		})
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == service.FareMatrixFormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	filename := fmt.Sprintf("fare_matrix_%s.%s", matrix.QuoteDate.Format("20060102"), format)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.Blob(http.StatusOK, contentType, buf.Bytes())
+}
+
+// parseFareMatrixRequest クエリパラメータから運賃表の作成条件を作成
+func parseFareMatrixRequest(c echo.Context) (*service.FareMatrixRequest, error) {
+	req := &service.FareMatrixRequest{}
+
+	regions := fareMatrixListParam(c, "regions")
+	if regions == "" {
+		regions = defaultFareMatrixRegions
+	}
+	codes, err := service.ParseFareMatrixCodes(regions)
+	if err != nil {
+		return nil, fmt.Errorf("regionsパラメータが不正です: %w", err)
+	}
+	req.RegionCodes = codes
+
+	vehicles := fareMatrixListParam(c, "vehicles")
+	if vehicles == "" {
+		vehicles = defaultFareMatrixVehicles
+	}
+	codes, err = service.ParseFareMatrixCodes(vehicles)
+	if err != nil {
+		return nil, fmt.Errorf("vehiclesパラメータが不正です: %w", err)
+	}
+	req.VehicleCodes = codes
+
+	fromKm, err := fareMatrixIntParam(c, "from", defaultFareMatrixFromKm)
+	if err != nil {
+		return nil, err
+	}
+	toKm, err := fareMatrixIntParam(c, "to", defaultFareMatrixToKm)
+	if err != nil {
+		return nil, err
+	}
+	stepKm, err := fareMatrixIntParam(c, "step", defaultFareMatrixStepKm)
+	if err != nil {
+		return nil, err
+	}
+	req.DistancesKm, err = service.FareMatrixDistances(fromKm, toKm, stepKm)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := fareMatrixListParam(c, "variants"); v != "" {
+		for _, s := range strings.Split(v, ",") {
+			variant, err := service.ParseFareMatrixVariant(s)
+			if err != nil {
+				return nil, err
+			}
+			req.Variants = append(req.Variants, variant)
+		}
+	}
+
+	// 見積日（YYYY-MM-DD）
+	if v := c.QueryParam("quote_date"); v != "" {
+		d, err := time.ParseInLocation(model.TariffDateFormat, v, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("見積日の形式が不正です（YYYY-MM-DD）: %s", v)
+		}
+		req.QuoteDate = d
+	}
+
+	if req.SpeedKmh, err = fareMatrixIntParam(c, "speed", 0); err != nil {
+		return nil, err
+	}
+	if req.LoadingMinutes, err = fareMatrixIntParam(c, "loading_minutes", 0); err != nil {
+		return nil, err
+	}
+	req.UseSimpleBaseKm = c.QueryParam("use_simple_base_km") == "true"
+
+	return req, nil
+}
+
+// fareMatrixListParam カンマ区切りの一覧パラメータを取得（フォームのチェックボックスの複数指定も結合する）
+func fareMatrixListParam(c echo.Context, name string) string {
+	return strings.Join(c.QueryParams()[name], ",")
+}
+
+// fareMatrixIntParam 整数のクエリパラメータを取得（未指定は既定値）
+func fareMatrixIntParam(c echo.Context, name string, defaultValue int) (int, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%sパラメータが不正です: %s", name, v)
+	}
+	return n, nil
+}
+
+// fareMatrixError 条件エラーのレスポンス
+func fareMatrixError(c echo.Context, message string) error {
+	return c.JSON(http.StatusBadRequest, map[string]string{
+		"error": message,
+	})
+}
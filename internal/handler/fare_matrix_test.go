@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
+)
+
+func TestFareMatrixHandler_GetFareMatrix(t *testing.T) {
+	matrix := service.NewFareMatrixService(
+		service.NewDistanceFareService(&mockFareGetter{}),
+		service.NewTimeFareService(&mockTimeFareGetter{}),
+	)
+	handler := NewFareMatrixHandler(matrix)
+
+	tests := []struct {
+		name            string
+		query           string
+		wantStatus      int
+		wantContentType string
+		wantFilename    string
+		wantBody        string
+	}{
+		{
+			name:            "CSV",
+			query:           "?regions=3&vehicles=3&from=50&to=100&step=50&variants=normal&variants=night&quote_date=2026-04-01",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantFilename:    "fare_matrix_20260401.csv",
+			wantBody:        "関東・深夜割増",
+		},
+		{
+			name:            "XLSX",
+			query:           "?format=xlsx&vehicles=1,2&from=10&to=30&step=10",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			wantFilename:    ".xlsx",
+			wantBody:        "PK",
+		},
+		{"不正な形式", "?format=pdf", http.StatusBadRequest, "", "", "formatパラメータが不正です"},
+		{"不正な車格", "?vehicles=1,x", http.StatusBadRequest, "", "", "vehiclesパラメータが不正です"},
+		{"不正な割増条件", "?variants=midnight", http.StatusBadRequest, "", "", "無効な割増条件"},
+		{"距離の範囲", "?from=100&to=50", http.StatusBadRequest, "", "", "無効な距離の範囲"},
+		{"不正な見積日", "?quote_date=2026/04/01", http.StatusBadRequest, "", "", "見積日の形式が不正です"},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/fare/matrix"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			if err := handler.GetFareMatrix(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("GetFareMatrix() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("レスポンスに %q が含まれていない", tt.wantBody)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get(echo.HeaderContentType); got != tt.wantContentType {
+				t.Errorf("Content-Type = %s, want %s", got, tt.wantContentType)
+			}
+			if got := rec.Header().Get(echo.HeaderContentDisposition); !strings.Contains(got, tt.wantFilename) {
+				t.Errorf("Content-Disposition = %s, want %s を含む", got, tt.wantFilename)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"database/sql"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/y-suzuki/standard-truck-rate/internal/model"
@@ -14,6 +15,7 @@ import (
 type RouteHandler struct {
 	routeService    *service.CachedRouteService
 	apiUsageService *service.ApiUsageService
+	gazetteer       *service.Gazetteer // 郵便番号データによる住所の判定（未設定の場合は住所の文字列から判定）
 }
 
 // NewRouteHandler 新しいRouteHandlerを作成
@@ -41,6 +43,11 @@ func NewRouteHandler(cacheDB *sql.DB, routeClient service.RouteClient, apiUsageS
 	}
 }
 
+// SetGazetteer 郵便番号データによる住所の判定を設定（出発地の運輸局・赤帽地区の判定に使用）
+func (h *RouteHandler) SetGazetteer(gazetteer *service.Gazetteer) {
+	h.gazetteer = gazetteer
+}
+
 // RouteResponse ルート取得レスポンス
 type RouteResponse struct {
 	Success     bool    `json:"success"`
@@ -50,6 +57,7 @@ type RouteResponse struct {
 	DistanceKm  float64 `json:"distance_km"`
 	DurationMin int     `json:"duration_min"`
 	FromCache   bool    `json:"from_cache"`
+	Source      string  `json:"source,omitempty"` // 取得元（google / osrm / valhalla、キャッシュの場合は空）
 	// 運輸局・地区判定情報（出発地ベース）
 	Prefecture string `json:"prefecture"`   // 都道府県
 	RegionCode int    `json:"region_code"`  // 運輸局コード（1-10）
@@ -59,6 +67,7 @@ type RouteResponse struct {
 
 // GetRoute ルート情報を取得するAPI
 // GET /api/route?origin=東京&dest=大阪
+// vehicle_code を指定すると車格の寸法・車両総重量で検索する（avoid_tolls / avoid_highways / hazmat も指定可）
 func (h *RouteHandler) GetRoute(c echo.Context) error {
 	origin := c.QueryParam("origin")
 	dest := c.QueryParam("dest")
@@ -83,8 +92,24 @@ func (h *RouteHandler) GetRoute(c echo.Context) error {
 		})
 	}
 
+	// ルート検索の条件
+	var opts service.RouteOptions
+	if v := c.QueryParam("vehicle_code"); v != "" {
+		vehicleCode, err := strconv.Atoi(v)
+		if err != nil {
+			return c.JSON(http.StatusOK, &RouteResponse{
+				Success: false,
+				Error:   "車格コードが不正です: " + v,
+			})
+		}
+		opts = service.RouteOptionsForVehicle(vehicleCode)
+	}
+	opts.AvoidTolls = c.QueryParam("avoid_tolls") == "true"
+	opts.AvoidHighways = c.QueryParam("avoid_highways") == "true"
+	opts.Hazmat = c.QueryParam("hazmat") == "true"
+
 	// ルート情報を取得
-	result, err := h.routeService.GetRoute(origin, dest)
+	result, err := h.routeService.GetRoute(origin, dest, opts)
 	if err != nil {
 		return c.JSON(http.StatusOK, &RouteResponse{
 			Success: false,
@@ -92,13 +117,13 @@ func (h *RouteHandler) GetRoute(c echo.Context) error {
 		})
 	}
 
-	// キャッシュミス時（API呼び出し時）はAPI使用量をカウントアップ
-	if !result.FromCache && h.apiUsageService != nil {
+	// Google Maps APIを呼び出した場合はAPI使用量をカウントアップ（セルフホストのエンジンは対象外）
+	if result.CountsApiUsage() && h.apiUsageService != nil {
 		_ = h.apiUsageService.IncrementAndCheck()
 	}
 
 	// 出発地から都道府県・運輸局情報を取得
-	prefecture, regionCode, regionName, akabouArea := resolveRegionInfo(origin)
+	prefecture, regionCode, regionName, akabouArea := resolveRegionInfo(h.gazetteer, origin)
 
 	return c.JSON(http.StatusOK, &RouteResponse{
 		Success:     true,
@@ -107,6 +132,7 @@ func (h *RouteHandler) GetRoute(c echo.Context) error {
 		DistanceKm:  result.Route.DistanceKm,
 		DurationMin: result.Route.DurationMin,
 		FromCache:   result.FromCache,
+		Source:      result.Route.Source,
 		Prefecture:  prefecture,
 		RegionCode:  regionCode,
 		RegionName:  regionName,
@@ -114,14 +140,19 @@ func (h *RouteHandler) GetRoute(c echo.Context) error {
 	})
 }
 
-// resolveRegionInfo 住所から運輸局情報を取得
-func resolveRegionInfo(address string) (prefecture string, regionCode int, regionName string, akabouArea string) {
-	// 住所から都道府県を抽出
-	pref, ok := service.ExtractPrefectureFromAddress(address)
-	if !ok {
-		return "", 0, "", ""
+// resolveRegionInfo 住所から運輸局情報を取得（郵便番号データで判定できない場合は住所の文字列から判定）
+func resolveRegionInfo(gazetteer *service.Gazetteer, address string) (prefecture string, regionCode int, regionName string, akabouArea string) {
+	components, resolved := gazetteer.Resolve(address)
+	if resolved {
+		prefecture = components.Prefecture
+	} else {
+		// 住所から都道府県を抽出
+		pref, ok := service.ExtractPrefectureFromAddress(address)
+		if !ok {
+			return "", 0, "", ""
+		}
+		prefecture = pref
 	}
-	prefecture = pref
 
 	// 都道府県から運輸局コードを取得
 	code, err := service.ResolveRegionCode(prefecture)
@@ -138,7 +169,11 @@ func resolveRegionInfo(address string) (prefecture string, regionCode int, regio
 	regionName = name
 
 	// 赤帽地区を判定
-	akabouArea = service.ResolveAkabouArea(address)
+	if resolved {
+		akabouArea = service.ResolveAkabouAreaFromComponents(components)
+	} else {
+		akabouArea = service.ResolveAkabouArea(address)
+	}
 
 	return prefecture, regionCode, regionName, akabouArea
 }
@@ -146,7 +181,7 @@ func resolveRegionInfo(address string) (prefecture string, regionCode int, regio
 // mockCacheStore テスト用のモックキャッシュストア
 type mockCacheStore struct{}
 
-func (s *mockCacheStore) Get(origin, dest string) (*model.RouteCache, error) {
+func (s *mockCacheStore) Get(origin, dest, optionsKey string) (*model.RouteCache, error) {
 	return nil, nil
 }
 
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"github.com/y-suzuki/standard-truck-rate/internal/service"
 )
 
 func TestRouteHandler_GetRoute(t *testing.T) {
@@ -18,6 +21,7 @@ func TestRouteHandler_GetRoute(t *testing.T) {
 		name           string
 		origin         string
 		dest           string
+		query          string // 追加のクエリパラメータ（ルート検索の条件）
 		wantStatusCode int
 		wantSuccess    bool
 		wantError      string
@@ -29,6 +33,23 @@ func TestRouteHandler_GetRoute(t *testing.T) {
 			wantStatusCode: http.StatusOK,
 			wantSuccess:    true,
 		},
+		{
+			name:           "車格・回避を指定したルート取得",
+			origin:         "東京都千代田区丸の内",
+			dest:           "大阪府大阪市中央区",
+			query:          "&vehicle_code=4&hazmat=true&avoid_tolls=true",
+			wantStatusCode: http.StatusOK,
+			wantSuccess:    true,
+		},
+		{
+			name:           "車格コードが不正な場合エラー",
+			origin:         "東京都千代田区丸の内",
+			dest:           "大阪府大阪市中央区",
+			query:          "&vehicle_code=large",
+			wantStatusCode: http.StatusOK,
+			wantSuccess:    false,
+			wantError:      "車格コードが不正です: large",
+		},
 		{
 			name:           "出発地が空の場合エラー",
 			origin:         "",
@@ -57,7 +78,7 @@ func TestRouteHandler_GetRoute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodGet, "/api/route?origin="+tt.origin+"&dest="+tt.dest, nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/route?origin="+tt.origin+"&dest="+tt.dest+tt.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
@@ -190,3 +211,52 @@ func TestRouteHandler_GetRoute_WithRegionInfo(t *testing.T) {
 		})
 	}
 }
+
+// mockGazetteerStore テスト用の郵便番号データストア
+type mockGazetteerStore struct{}
+
+func (m *mockGazetteerStore) FindByPostalCode(postalCode string) ([]*model.PostalCode, error) {
+	if postalCode == "5300001" {
+		return []*model.PostalCode{{PostalCode: "5300001", JisCode: "27127", Prefecture: "大阪府", City: "大阪市北区", Town: "梅田"}}, nil
+	}
+	return nil, nil
+}
+
+func (m *mockGazetteerStore) GetMunicipalities() ([]*model.Municipality, error) {
+	return []*model.Municipality{
+		{JisCode: "13101", Prefecture: "東京都", City: "千代田区"},
+		{JisCode: "27127", Prefecture: "大阪府", City: "大阪市北区"},
+	}, nil
+}
+
+// TestRouteHandler_GetRoute_WithGazetteer 都道府県を省略した住所・郵便番号の運輸局・赤帽地区を郵便番号データで判定する
+func TestRouteHandler_GetRoute_WithGazetteer(t *testing.T) {
+	e := echo.New()
+
+	gazetteer, err := service.NewGazetteer(&mockGazetteerStore{})
+	if err != nil {
+		t.Fatalf("NewGazetteer() error = %v", err)
+	}
+	handler := NewRouteHandler(nil, nil, nil)
+	handler.SetGazetteer(gazetteer)
+
+	for _, origin := range []string{"大阪市北区梅田3-1-1", "〒530-0001"} {
+		t.Run(origin, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/route?origin="+url.QueryEscape(origin)+"&dest="+url.QueryEscape("東京都新宿区"), nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := handler.GetRoute(c); err != nil {
+				t.Fatalf("GetRoute() error = %v", err)
+			}
+
+			var resp RouteResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("GetRoute() JSON parse error = %v", err)
+			}
+			if resp.Prefecture != "大阪府" || resp.RegionCode != 6 || resp.AkabouArea != "大阪市内" {
+				t.Errorf("GetRoute() = %+v, want 大阪府 6 大阪市内", resp)
+			}
+		})
+	}
+}
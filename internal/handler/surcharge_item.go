@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// SurchargeItemLister 割増項目の一覧取得インターフェース（テスト用にモック可能）
+type SurchargeItemLister interface {
+	GetAll() ([]*model.SurchargeItem, error)
+}
+
+// SurchargeItemHandler 割増項目（速達割増・付帯作業など）ハンドラ
+type SurchargeItemHandler struct {
+	lister SurchargeItemLister
+}
+
+// NewSurchargeItemHandler 新しいSurchargeItemHandlerを作成
+func NewSurchargeItemHandler(lister SurchargeItemLister) *SurchargeItemHandler {
+	return &SurchargeItemHandler{lister: lister}
+}
+
+// SurchargeItemInfo 割増項目情報
+type SurchargeItemInfo struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	CalcType    string `json:"calc_type"`
+	RatePercent int    `json:"rate_percent"`
+	AmountYen   int    `json:"amount_yen"`
+	Label       string `json:"label"` // 表示用（例: 速達割増（20%増））
+}
+
+// GetSurchargeItems 選択できる割増項目の一覧を取得
+// GET /api/fare/surcharge-items?vehicle_code=3（指定時は車格に適用できる項目のみ）
+func (h *SurchargeItemHandler) GetSurchargeItems(c echo.Context) error {
+	vehicleCode := -1
+	if v := c.QueryParam("vehicle_code"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "車格コードが不正です: " + v,
+			})
+		}
+		vehicleCode = n
+	}
+
+	items, err := h.lister.GetAll()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "割増項目の取得に失敗しました",
+		})
+	}
+
+	infos := make([]SurchargeItemInfo, 0, len(items))
+	for _, item := range items {
+		if vehicleCode >= 0 && !item.AppliesTo(vehicleCode) {
+			continue
+		}
+		infos = append(infos, SurchargeItemInfo{
+			Code:        item.Code,
+			Name:        item.Name,
+			CalcType:    item.CalcType,
+			RatePercent: item.RatePercent,
+			AmountYen:   item.AmountYen,
+			Label:       fmt.Sprintf("%s（%s）", item.Name, item.RateLabel()),
+		})
+	}
+
+	return c.JSON(http.StatusOK, infos)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// mockSurchargeItemLister テスト用の割増項目一覧モック
+type mockSurchargeItemLister struct {
+	err error
+}
+
+func (m *mockSurchargeItemLister) GetAll() ([]*model.SurchargeItem, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*model.SurchargeItem{
+		{Code: "express", Name: "速達割増", CalcType: model.SurchargeCalcTypeRate, RatePercent: 20},
+		{Code: "hand_loading", Name: "手積み・手降ろし", CalcType: model.SurchargeCalcTypeFixed, AmountYen: 3000, VehicleCodes: "1,2,3,4"},
+	}, nil
+}
+
+func TestSurchargeItemHandler_GetSurchargeItems(t *testing.T) {
+	e := echo.New()
+
+	tests := []struct {
+		name       string
+		query      string
+		lister     *mockSurchargeItemLister
+		wantStatus int
+		wantLabels []string
+	}{
+		{
+			name:       "全項目",
+			lister:     &mockSurchargeItemLister{},
+			wantStatus: http.StatusOK,
+			wantLabels: []string{"速達割増（20%増）", "手積み・手降ろし（+3000円）"},
+		},
+		{
+			name:       "軽貨物に適用できる項目のみ",
+			query:      "?vehicle_code=0",
+			lister:     &mockSurchargeItemLister{},
+			wantStatus: http.StatusOK,
+			wantLabels: []string{"速達割増（20%増）"},
+		},
+		{
+			name:       "車格コードが不正",
+			query:      "?vehicle_code=abc",
+			lister:     &mockSurchargeItemLister{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "取得エラー",
+			lister:     &mockSurchargeItemLister{err: errors.New("DB接続エラー")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSurchargeItemHandler(tt.lister)
+			req := httptest.NewRequest(http.MethodGet, "/api/fare/surcharge-items"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			if err := handler.GetSurchargeItems(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("GetSurchargeItems() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var got []SurchargeItemInfo
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("JSONパースエラー: %v", err)
+			}
+			if len(got) != len(tt.wantLabels) {
+				t.Fatalf("items = %+v, want labels %v", got, tt.wantLabels)
+			}
+			for i, label := range tt.wantLabels {
+				if got[i].Label != label {
+					t.Errorf("items[%d].Label = %q, want %q", i, got[i].Label, label)
+				}
+			}
+		})
+	}
+}
@@ -2,9 +2,16 @@ package model
 
 import "time"
 
+// API種別（api_usage はAPI種別ごとに月別の使用量と上限を持つ）
+const (
+	ApiTypeRoutes    = "routes"    // Routes API（距離・時間の取得）
+	ApiTypeGeocoding = "geocoding" // Geocoding API（住所から都道府県・市区町村の取得）
+)
+
 // ApiUsage API使用量
 type ApiUsage struct {
 	YearMonth    string    `json:"year_month"`    // 年月 (YYYY-MM形式)
+	ApiType      string    `json:"api_type"`      // API種別（routes / geocoding、空の場合は routes）
 	RequestCount int       `json:"request_count"` // リクエスト数
 	LimitCount   int       `json:"limit_count"`   // 上限数（デフォルト9000）
 	LastUpdated  time.Time `json:"last_updated"`  // 最終更新日時
@@ -0,0 +1,14 @@
+package model
+
+// BodyTypeStandard 標準車両（特殊車両割増なし）の車体種別コード
+const BodyTypeStandard = "standard"
+
+// BodyTypeSurcharge 車体種別ごとの特殊車両割増率（トラ協運賃用）
+type BodyTypeSurcharge struct {
+	ID               int64  `json:"id"`
+	TariffVersionID  int64  `json:"tariff_version_id"` // 運賃版ID（0=版共通）
+	BodyType         string `json:"body_type"`         // 車体種別コード（refrigerated など）
+	Name             string `json:"name"`              // 表示名（冷蔵車・冷凍車 など）
+	SurchargePercent int    `json:"surcharge_percent"` // 割増率（%）
+	SortOrder        int    `json:"sort_order"`        // 表示順
+}
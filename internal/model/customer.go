@@ -0,0 +1,101 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 契約条件の増減方法
+const (
+	CustomerAdjustmentRate  = "rate"  // 標準運賃に対する割合（%、マイナスは値引き）
+	CustomerAdjustmentFixed = "fixed" // 定額（円、マイナスは値引き）
+)
+
+// Customer 荷主マスタ
+type Customer struct {
+	ID   int64   `json:"id"`
+	Code string  `json:"code"` // 荷主コード
+	Name string  `json:"name"` // 荷主名
+	Note *string `json:"note"` // 備考
+}
+
+// CustomerPricingRule 荷主ごとの契約条件（値引き・割増・最低運賃）
+// 車格・運輸局・適用期間で対象を絞り込み、標準的な運賃（税抜）に対して適用する
+type CustomerPricingRule struct {
+	ID                int64   `json:"id"`
+	CustomerID        int64   `json:"customer_id"`
+	Name              string  `json:"name"`               // 条件名（例: 2025年度契約）
+	AdjustmentType    string  `json:"adjustment_type"`    // 増減方法（"rate" or "fixed"）
+	AdjustmentPercent int     `json:"adjustment_percent"` // 増減率（%、rateのみ。-10で1割引）
+	AdjustmentYen     int     `json:"adjustment_yen"`     // 増減額（円、fixedのみ。-2000で2000円引）
+	MinimumFareYen    int     `json:"minimum_fare_yen"`   // 最低運賃（円、税抜。0はなし）
+	VehicleCodes      string  `json:"vehicle_codes"`      // 対象車格コード（カンマ区切り、空は全車格）
+	RegionCodes       string  `json:"region_codes"`       // 対象運輸局コード（カンマ区切り、空は全地域）
+	ValidFrom         *string `json:"valid_from"`         // 適用開始日（YYYY-MM-DD、NULLは制限なし）
+	ValidTo           *string `json:"valid_to"`           // 適用終了日（YYYY-MM-DD、NULLは制限なし）
+}
+
+// Matches 車格・運輸局・見積日が契約条件の対象か
+func (r *CustomerPricingRule) Matches(vehicleCode, regionCode int, date time.Time) bool {
+	if !codeListContains(r.VehicleCodes, vehicleCode) || !codeListContains(r.RegionCodes, regionCode) {
+		return false
+	}
+	d := date.Format(TariffDateFormat)
+	if r.ValidFrom != nil && d < *r.ValidFrom {
+		return false
+	}
+	if r.ValidTo != nil && d > *r.ValidTo {
+		return false
+	}
+	return true
+}
+
+// Specificity 対象の絞り込み度合い（車格・運輸局を指定した条件ほど優先する）
+func (r *CustomerPricingRule) Specificity() int {
+	n := 0
+	if strings.TrimSpace(r.VehicleCodes) != "" {
+		n += 2
+	}
+	if strings.TrimSpace(r.RegionCodes) != "" {
+		n++
+	}
+	return n
+}
+
+// Apply 標準運賃（税抜）に契約条件を適用した運賃（税抜）と、最低運賃を適用したかを返す
+func (r *CustomerPricingRule) Apply(fare int) (int, bool) {
+	contract := fare
+	switch r.AdjustmentType {
+	case CustomerAdjustmentRate:
+		contract += fare * r.AdjustmentPercent / 100
+	case CustomerAdjustmentFixed:
+		contract += r.AdjustmentYen
+	}
+	if r.MinimumFareYen > 0 && contract < r.MinimumFareYen {
+		return r.MinimumFareYen, true
+	}
+	return max(contract, 0), false
+}
+
+// AdjustmentLabel 増減の表示用ラベル（例: 10%引、5%増、2000円引、最低運賃30000円）
+func (r *CustomerPricingRule) AdjustmentLabel() string {
+	var labels []string
+	switch {
+	case r.AdjustmentType == CustomerAdjustmentRate && r.AdjustmentPercent < 0:
+		labels = append(labels, fmt.Sprintf("%d%%引", -r.AdjustmentPercent))
+	case r.AdjustmentType == CustomerAdjustmentRate && r.AdjustmentPercent > 0:
+		labels = append(labels, fmt.Sprintf("%d%%増", r.AdjustmentPercent))
+	case r.AdjustmentType == CustomerAdjustmentFixed && r.AdjustmentYen < 0:
+		labels = append(labels, fmt.Sprintf("%d円引", -r.AdjustmentYen))
+	case r.AdjustmentType == CustomerAdjustmentFixed && r.AdjustmentYen > 0:
+		labels = append(labels, fmt.Sprintf("%d円増", r.AdjustmentYen))
+	}
+	if r.MinimumFareYen > 0 {
+		labels = append(labels, fmt.Sprintf("最低運賃%d円", r.MinimumFareYen))
+	}
+	if len(labels) == 0 {
+		return "増減なし"
+	}
+	return strings.Join(labels, "・")
+}
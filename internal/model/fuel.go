@@ -0,0 +1,23 @@
+package model
+
+// FuelYearMonthFormat 燃料価格の対象月のフォーマット
+const FuelYearMonthFormat = "2006-01"
+
+// FuelPrice 月別の軽油価格
+type FuelPrice struct {
+	YearMonth         string  `json:"year_month"`          // 対象月（YYYY-MM）
+	PriceYen          float64 `json:"price_yen"`           // 軽油価格（円/L）
+	ReferencePriceYen float64 `json:"reference_price_yen"` // 基準価格（円/L）
+	Source            *string `json:"source"`              // 出典
+}
+
+// FuelSurcharge 燃料サーチャージ（距離帯・車格別の加算額）
+type FuelSurcharge struct {
+	ID              int64 `json:"id"`
+	TariffVersionID int64 `json:"tariff_version_id"` // 運賃版ID（0=版共通）
+	VehicleCode     int   `json:"vehicle_code"`      // 車格コード (1-4)
+	MinKm           int   `json:"min_km"`            // 最小距離（km）
+	MaxKm           *int  `json:"max_km"`            // 最大距離（km）、NULLの場合は上限なし
+	StepYen         int   `json:"step_yen"`          // 価格差の刻み（円/L）
+	AmountYen       int   `json:"amount_yen"`        // 1刻みあたりの加算額（円）
+}
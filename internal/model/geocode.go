@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// GeocodeCache ジオコーディングキャッシュ（住所ごとの都道府県・市区町村）
+type GeocodeCache struct {
+	Address          string    `json:"address"`           // 住所（表記を統一したもの）
+	Prefecture       string    `json:"prefecture"`        // 都道府県
+	City             string    `json:"city"`              // 市区町村
+	FormattedAddress string    `json:"formatted_address"` // Geocoding APIが返した住所
+	CreatedAt        time.Time `json:"created_at"`        // 作成日時
+}
@@ -0,0 +1,13 @@
+package model
+
+// HolidayMonthDayFormat 毎年の会社休日の月日フォーマット
+const HolidayMonthDayFormat = "01-02"
+
+// CompanyHoliday 会社休日（年末年始・夏季休業など）
+// 特定日（Date）または毎年の月日（MonthDay）のどちらかを指定する
+type CompanyHoliday struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name"`      // 休日名（例: 年末年始）
+	Date     *string `json:"date"`      // 特定日（YYYY-MM-DD）
+	MonthDay *string `json:"month_day"` // 毎年の月日（MM-DD）
+}
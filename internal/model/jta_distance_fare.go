@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // JtaDistanceFare トラ協距離制運賃（Supabase fare_rates テーブル）
 type JtaDistanceFare struct {
 	RegionCode  int `json:"region_code"`  // 運輸局コード (1-10)
@@ -16,3 +18,17 @@ type JtaChargeData struct {
 	ChargeYen   int  `json:"charge_yen"`   // 料金 (円)
 	Per1MinYen  *int `json:"1m_yen"`       // 1分あたり料金 (円)、nullの場合あり
 }
+
+// トラ協データの同期元テーブル（Supabase）
+const (
+	JtaTableFareRates  = "fare_rates"
+	JtaTableChargeData = "charge_data"
+)
+
+// JtaSyncStatus トラ協データのローカルミラーの同期状態（jta_sync_status テーブル）
+type JtaSyncStatus struct {
+	TableName string    // 同期元テーブル名（fare_rates / charge_data）
+	RowCount  int       // 件数
+	Checksum  string    // チェックサム（SHA-256、16進）
+	SyncedAt  time.Time // 同期日時
+}
@@ -0,0 +1,17 @@
+package model
+
+// PostalCode 郵便番号データ（日本郵便 KEN_ALL 形式から取り込む）
+type PostalCode struct {
+	PostalCode string `json:"postal_code"` // 郵便番号（7桁、ハイフンなし）
+	JisCode    string `json:"jis_code"`    // 全国地方公共団体コード（5桁）
+	Prefecture string `json:"prefecture"`  // 都道府県
+	City       string `json:"city"`        // 市区町村（政令指定都市は区まで、例: 大阪市北区）
+	Town       string `json:"town"`        // 町域（「以下に掲載がない場合」は空文字）
+}
+
+// Municipality 市区町村（郵便番号データの市区町村を重複なく集めたもの）
+type Municipality struct {
+	JisCode    string `json:"jis_code"`   // 全国地方公共団体コード（5桁）
+	Prefecture string `json:"prefecture"` // 都道府県
+	City       string `json:"city"`       // 市区町村（政令指定都市は区まで）
+}
@@ -4,9 +4,11 @@ import "time"
 
 // RouteCache ルートキャッシュ（距離・時間）
 type RouteCache struct {
-	Origin      string    `json:"origin"`       // 出発地
-	Dest        string    `json:"dest"`         // 目的地
-	DistanceKm  float64   `json:"distance_km"`  // 距離（km）
-	DurationMin int       `json:"duration_min"` // 所要時間（分）
-	CreatedAt   time.Time `json:"created_at"`   // 作成日時
+	Origin      string    `json:"origin"`                // 出発地
+	Dest        string    `json:"dest"`                  // 目的地
+	OptionsKey  string    `json:"options_key,omitempty"` // ルート検索の条件（車両の寸法・重量など、空文字は指定なし）
+	DistanceKm  float64   `json:"distance_km"`           // 距離（km）
+	DurationMin int       `json:"duration_min"`          // 所要時間（分）
+	CreatedAt   time.Time `json:"created_at"`            // 作成日時
+	Source      string    `json:"source,omitempty"`      // 取得元（google / osrm / valhalla、キャッシュには保存しない）
 }
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 割増項目の計算方法
+const (
+	SurchargeCalcTypeRate  = "rate"  // 割増前運賃に対する割合
+	SurchargeCalcTypeFixed = "fixed" // 定額
+)
+
+// SurchargeItem 任意で選択する割増項目（速達割増・手積み手降ろし・付帯作業など）
+type SurchargeItem struct {
+	ID           int64  `json:"id"`
+	Code         string `json:"code"`          // 項目コード（express など）
+	Name         string `json:"name"`          // 表示名（速達割増 など）
+	CalcType     string `json:"calc_type"`     // 計算方法（"rate" or "fixed"）
+	RatePercent  int    `json:"rate_percent"`  // 割増率（%、rateのみ）
+	AmountYen    int    `json:"amount_yen"`    // 定額（円、fixedのみ）
+	VehicleCodes string `json:"vehicle_codes"` // 適用車格コード（カンマ区切り、空は全車格）
+	SortOrder    int    `json:"sort_order"`    // 表示順
+}
+
+// AppliesTo 指定した車格に適用できるか
+func (i *SurchargeItem) AppliesTo(vehicleCode int) bool {
+	return codeListContains(i.VehicleCodes, vehicleCode)
+}
+
+// codeListContains カンマ区切りのコード一覧に指定コードが含まれるか（空は全コード）
+func codeListContains(codes string, code int) bool {
+	if strings.TrimSpace(codes) == "" {
+		return true
+	}
+	for _, s := range strings.Split(codes, ",") {
+		c, err := strconv.Atoi(strings.TrimSpace(s))
+		if err == nil && c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Amount 割増前運賃に対する割増額（円）
+func (i *SurchargeItem) Amount(fare int) int {
+	if i.CalcType == SurchargeCalcTypeRate {
+		return fare * i.RatePercent / 100
+	}
+	return i.AmountYen
+}
+
+// RateLabel 割増率・定額の表示用ラベル（例: 20%増、+3000円）
+func (i *SurchargeItem) RateLabel() string {
+	if i.CalcType == SurchargeCalcTypeRate {
+		return fmt.Sprintf("%d%%増", i.RatePercent)
+	}
+	return fmt.Sprintf("+%d円", i.AmountYen)
+}
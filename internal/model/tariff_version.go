@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// 運賃版の種別
+const (
+	TariffTypeJTA    = "jta"    // トラ協「標準的な運賃」（距離制・時間制）
+	TariffTypeAkabou = "akabou" // 赤帽運賃
+)
+
+// TariffDateFormat 運賃版の適用日の日付フォーマット
+const TariffDateFormat = "2006-01-02"
+
+// TariffVersion 運賃版（告示改定ごとの適用期間）
+type TariffVersion struct {
+	ID            int64      `json:"id"`
+	TariffType    string     `json:"tariff_type"`    // 運賃種別 ("jta" or "akabou")
+	Name          string     `json:"name"`           // 版名（例: 令和6年3月告示）
+	EffectiveFrom time.Time  `json:"effective_from"` // 適用開始日
+	EffectiveTo   *time.Time `json:"effective_to"`   // 適用終了日、NULLの場合は現行版
+	Description   *string    `json:"description"`    // 説明
+}
+
+// IsCurrent 現行版（適用終了日なし）か
+func (v *TariffVersion) IsCurrent() bool {
+	return v.EffectiveTo == nil
+}
+
+// Covers 指定日が適用期間内か
+func (v *TariffVersion) Covers(date time.Time) bool {
+	d := date.Format(TariffDateFormat)
+	if d < v.EffectiveFrom.Format(TariffDateFormat) {
+		return false
+	}
+	if v.EffectiveTo != nil && d > v.EffectiveTo.Format(TariffDateFormat) {
+		return false
+	}
+	return true
+}
+
+// Label 表示用ラベル（例: 令和6年3月告示（2024-03-22〜））
+func (v *TariffVersion) Label() string {
+	to := ""
+	if v.EffectiveTo != nil {
+		to = v.EffectiveTo.Format(TariffDateFormat)
+	}
+	return fmt.Sprintf("%s（%s〜%s）", v.Name, v.EffectiveFrom.Format(TariffDateFormat), to)
+}
@@ -21,9 +21,9 @@ func NewAkabouFareRepository(db *sql.DB) *AkabouFareRepository {
 // CreateDistanceFare 距離制運賃を作成する
 func (r *AkabouFareRepository) CreateDistanceFare(fare *model.AkabouDistanceFare) (int64, error) {
 	result, err := r.db.Exec(`
-		INSERT INTO akabou_distance_fares (min_km, max_km, base_fare, per_km_rate)
-		VALUES (?, ?, ?, ?)
-	`, fare.MinKm, fare.MaxKm, fare.BaseFare, fare.PerKmRate)
+		INSERT INTO akabou_distance_fares (tariff_version_id, min_km, max_km, base_fare, per_km_rate)
+		VALUES (?, ?, ?, ?, ?)
+	`, fare.TariffVersionID, fare.MinKm, fare.MaxKm, fare.BaseFare, fare.PerKmRate)
 	if err != nil {
 		return 0, err
 	}
@@ -34,9 +34,9 @@ func (r *AkabouFareRepository) CreateDistanceFare(fare *model.AkabouDistanceFare
 func (r *AkabouFareRepository) GetDistanceFareByID(id int64) (*model.AkabouDistanceFare, error) {
 	fare := &model.AkabouDistanceFare{}
 	err := r.db.QueryRow(`
-		SELECT id, min_km, max_km, base_fare, per_km_rate
+		SELECT id, tariff_version_id, min_km, max_km, base_fare, per_km_rate
 		FROM akabou_distance_fares WHERE id = ?
-	`, id).Scan(&fare.ID, &fare.MinKm, &fare.MaxKm, &fare.BaseFare, &fare.PerKmRate)
+	`, id).Scan(&fare.ID, &fare.TariffVersionID, &fare.MinKm, &fare.MaxKm, &fare.BaseFare, &fare.PerKmRate)
 	if err != nil {
 		return nil, err
 	}
@@ -46,8 +46,8 @@ func (r *AkabouFareRepository) GetDistanceFareByID(id int64) (*model.AkabouDista
 // GetAllDistanceFares 全距離制運賃を取得する
 func (r *AkabouFareRepository) GetAllDistanceFares() ([]*model.AkabouDistanceFare, error) {
 	rows, err := r.db.Query(`
-		SELECT id, min_km, max_km, base_fare, per_km_rate
-		FROM akabou_distance_fares ORDER BY min_km
+		SELECT id, tariff_version_id, min_km, max_km, base_fare, per_km_rate
+		FROM akabou_distance_fares ORDER BY tariff_version_id, min_km
 	`)
 	if err != nil {
 		return nil, err
@@ -57,7 +57,7 @@ func (r *AkabouFareRepository) GetAllDistanceFares() ([]*model.AkabouDistanceFar
 	var fares []*model.AkabouDistanceFare
 	for rows.Next() {
 		fare := &model.AkabouDistanceFare{}
-		if err := rows.Scan(&fare.ID, &fare.MinKm, &fare.MaxKm, &fare.BaseFare, &fare.PerKmRate); err != nil {
+		if err := rows.Scan(&fare.ID, &fare.TariffVersionID, &fare.MinKm, &fare.MaxKm, &fare.BaseFare, &fare.PerKmRate); err != nil {
 			return nil, err
 		}
 		fares = append(fares, fare)
@@ -69,9 +69,9 @@ func (r *AkabouFareRepository) GetAllDistanceFares() ([]*model.AkabouDistanceFar
 func (r *AkabouFareRepository) UpdateDistanceFare(fare *model.AkabouDistanceFare) error {
 	_, err := r.db.Exec(`
 		UPDATE akabou_distance_fares
-		SET min_km = ?, max_km = ?, base_fare = ?, per_km_rate = ?
+		SET tariff_version_id = ?, min_km = ?, max_km = ?, base_fare = ?, per_km_rate = ?
 		WHERE id = ?
-	`, fare.MinKm, fare.MaxKm, fare.BaseFare, fare.PerKmRate, fare.ID)
+	`, fare.TariffVersionID, fare.MinKm, fare.MaxKm, fare.BaseFare, fare.PerKmRate, fare.ID)
 	return err
 }
 
@@ -86,9 +86,9 @@ func (r *AkabouFareRepository) DeleteDistanceFare(id int64) error {
 // CreateTimeFare 時間制運賃を作成する
 func (r *AkabouFareRepository) CreateTimeFare(fare *model.AkabouTimeFare) (int64, error) {
 	result, err := r.db.Exec(`
-		INSERT INTO akabou_time_fares (base_hours, base_km, base_fare, overtime_rate)
-		VALUES (?, ?, ?, ?)
-	`, fare.BaseHours, fare.BaseKm, fare.BaseFare, fare.OvertimeRate)
+		INSERT INTO akabou_time_fares (tariff_version_id, base_hours, base_km, base_fare, overtime_rate, overtime_unit_minutes)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, fare.TariffVersionID, fare.BaseHours, fare.BaseKm, fare.BaseFare, fare.OvertimeRate, fare.OvertimeUnitMinutes)
 	if err != nil {
 		return 0, err
 	}
@@ -99,9 +99,9 @@ func (r *AkabouFareRepository) CreateTimeFare(fare *model.AkabouTimeFare) (int64
 func (r *AkabouFareRepository) GetTimeFareByID(id int64) (*model.AkabouTimeFare, error) {
 	fare := &model.AkabouTimeFare{}
 	err := r.db.QueryRow(`
-		SELECT id, base_hours, base_km, base_fare, overtime_rate
+		SELECT id, tariff_version_id, base_hours, base_km, base_fare, overtime_rate, overtime_unit_minutes
 		FROM akabou_time_fares WHERE id = ?
-	`, id).Scan(&fare.ID, &fare.BaseHours, &fare.BaseKm, &fare.BaseFare, &fare.OvertimeRate)
+	`, id).Scan(&fare.ID, &fare.TariffVersionID, &fare.BaseHours, &fare.BaseKm, &fare.BaseFare, &fare.OvertimeRate, &fare.OvertimeUnitMinutes)
 	if err != nil {
 		return nil, err
 	}
@@ -111,8 +111,8 @@ func (r *AkabouFareRepository) GetTimeFareByID(id int64) (*model.AkabouTimeFare,
 // GetAllTimeFares 全時間制運賃を取得する
 func (r *AkabouFareRepository) GetAllTimeFares() ([]*model.AkabouTimeFare, error) {
 	rows, err := r.db.Query(`
-		SELECT id, base_hours, base_km, base_fare, overtime_rate
-		FROM akabou_time_fares ORDER BY base_hours
+		SELECT id, tariff_version_id, base_hours, base_km, base_fare, overtime_rate, overtime_unit_minutes
+		FROM akabou_time_fares ORDER BY tariff_version_id, base_hours
 	`)
 	if err != nil {
 		return nil, err
@@ -122,7 +122,7 @@ func (r *AkabouFareRepository) GetAllTimeFares() ([]*model.AkabouTimeFare, error
 	var fares []*model.AkabouTimeFare
 	for rows.Next() {
 		fare := &model.AkabouTimeFare{}
-		if err := rows.Scan(&fare.ID, &fare.BaseHours, &fare.BaseKm, &fare.BaseFare, &fare.OvertimeRate); err != nil {
+		if err := rows.Scan(&fare.ID, &fare.TariffVersionID, &fare.BaseHours, &fare.BaseKm, &fare.BaseFare, &fare.OvertimeRate, &fare.OvertimeUnitMinutes); err != nil {
 			return nil, err
 		}
 		fares = append(fares, fare)
@@ -134,9 +134,9 @@ func (r *AkabouFareRepository) GetAllTimeFares() ([]*model.AkabouTimeFare, error
 func (r *AkabouFareRepository) UpdateTimeFare(fare *model.AkabouTimeFare) error {
 	_, err := r.db.Exec(`
 		UPDATE akabou_time_fares
-		SET base_hours = ?, base_km = ?, base_fare = ?, overtime_rate = ?
+		SET tariff_version_id = ?, base_hours = ?, base_km = ?, base_fare = ?, overtime_rate = ?, overtime_unit_minutes = ?
 		WHERE id = ?
-	`, fare.BaseHours, fare.BaseKm, fare.BaseFare, fare.OvertimeRate, fare.ID)
+	`, fare.TariffVersionID, fare.BaseHours, fare.BaseKm, fare.BaseFare, fare.OvertimeRate, fare.OvertimeUnitMinutes, fare.ID)
 	return err
 }
 
@@ -151,9 +151,9 @@ func (r *AkabouFareRepository) DeleteTimeFare(id int64) error {
 // CreateSurcharge 割増料金を作成する
 func (r *AkabouFareRepository) CreateSurcharge(surcharge *model.AkabouSurcharge) (int64, error) {
 	result, err := r.db.Exec(`
-		INSERT INTO akabou_surcharges (surcharge_type, rate_percent, description)
-		VALUES (?, ?, ?)
-	`, surcharge.SurchargeType, surcharge.RatePercent, surcharge.Description)
+		INSERT INTO akabou_surcharges (tariff_version_id, surcharge_type, rate_percent, description)
+		VALUES (?, ?, ?, ?)
+	`, surcharge.TariffVersionID, surcharge.SurchargeType, surcharge.RatePercent, surcharge.Description)
 	if err != nil {
 		return 0, err
 	}
@@ -164,9 +164,9 @@ func (r *AkabouFareRepository) CreateSurcharge(surcharge *model.AkabouSurcharge)
 func (r *AkabouFareRepository) GetSurchargeByID(id int64) (*model.AkabouSurcharge, error) {
 	surcharge := &model.AkabouSurcharge{}
 	err := r.db.QueryRow(`
-		SELECT id, surcharge_type, rate_percent, description
+		SELECT id, tariff_version_id, surcharge_type, rate_percent, description
 		FROM akabou_surcharges WHERE id = ?
-	`, id).Scan(&surcharge.ID, &surcharge.SurchargeType, &surcharge.RatePercent, &surcharge.Description)
+	`, id).Scan(&surcharge.ID, &surcharge.TariffVersionID, &surcharge.SurchargeType, &surcharge.RatePercent, &surcharge.Description)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +176,7 @@ func (r *AkabouFareRepository) GetSurchargeByID(id int64) (*model.AkabouSurcharg
 // GetAllSurcharges 全割増料金を取得する
 func (r *AkabouFareRepository) GetAllSurcharges() ([]*model.AkabouSurcharge, error) {
 	rows, err := r.db.Query(`
-		SELECT id, surcharge_type, rate_percent, description
+		SELECT id, tariff_version_id, surcharge_type, rate_percent, description
 		FROM akabou_surcharges ORDER BY id
 	`)
 	if err != nil {
@@ -187,7 +187,7 @@ func (r *AkabouFareRepository) GetAllSurcharges() ([]*model.AkabouSurcharge, err
 	var surcharges []*model.AkabouSurcharge
 	for rows.Next() {
 		s := &model.AkabouSurcharge{}
-		if err := rows.Scan(&s.ID, &s.SurchargeType, &s.RatePercent, &s.Description); err != nil {
+		if err := rows.Scan(&s.ID, &s.TariffVersionID, &s.SurchargeType, &s.RatePercent, &s.Description); err != nil {
 			return nil, err
 		}
 		surcharges = append(surcharges, s)
@@ -199,9 +199,9 @@ func (r *AkabouFareRepository) GetAllSurcharges() ([]*model.AkabouSurcharge, err
 func (r *AkabouFareRepository) UpdateSurcharge(surcharge *model.AkabouSurcharge) error {
 	_, err := r.db.Exec(`
 		UPDATE akabou_surcharges
-		SET surcharge_type = ?, rate_percent = ?, description = ?
+		SET tariff_version_id = ?, surcharge_type = ?, rate_percent = ?, description = ?
 		WHERE id = ?
-	`, surcharge.SurchargeType, surcharge.RatePercent, surcharge.Description, surcharge.ID)
+	`, surcharge.TariffVersionID, surcharge.SurchargeType, surcharge.RatePercent, surcharge.Description, surcharge.ID)
 	return err
 }
 
@@ -216,9 +216,9 @@ func (r *AkabouFareRepository) DeleteSurcharge(id int64) error {
 // CreateAreaSurcharge 地区割増を作成する
 func (r *AkabouFareRepository) CreateAreaSurcharge(area *model.AkabouAreaSurcharge) (int64, error) {
 	result, err := r.db.Exec(`
-		INSERT INTO akabou_area_surcharges (area_name, surcharge_amount)
-		VALUES (?, ?)
-	`, area.AreaName, area.SurchargeAmount)
+		INSERT INTO akabou_area_surcharges (tariff_version_id, area_name, surcharge_amount)
+		VALUES (?, ?, ?)
+	`, area.TariffVersionID, area.AreaName, area.SurchargeAmount)
 	if err != nil {
 		return 0, err
 	}
@@ -229,9 +229,9 @@ func (r *AkabouFareRepository) CreateAreaSurcharge(area *model.AkabouAreaSurchar
 func (r *AkabouFareRepository) GetAreaSurchargeByID(id int64) (*model.AkabouAreaSurcharge, error) {
 	area := &model.AkabouAreaSurcharge{}
 	err := r.db.QueryRow(`
-		SELECT id, area_name, surcharge_amount
+		SELECT id, tariff_version_id, area_name, surcharge_amount
 		FROM akabou_area_surcharges WHERE id = ?
-	`, id).Scan(&area.ID, &area.AreaName, &area.SurchargeAmount)
+	`, id).Scan(&area.ID, &area.TariffVersionID, &area.AreaName, &area.SurchargeAmount)
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +241,7 @@ func (r *AkabouFareRepository) GetAreaSurchargeByID(id int64) (*model.AkabouArea
 // GetAllAreaSurcharges 全地区割増を取得する
 func (r *AkabouFareRepository) GetAllAreaSurcharges() ([]*model.AkabouAreaSurcharge, error) {
 	rows, err := r.db.Query(`
-		SELECT id, area_name, surcharge_amount
+		SELECT id, tariff_version_id, area_name, surcharge_amount
 		FROM akabou_area_surcharges ORDER BY id
 	`)
 	if err != nil {
@@ -252,7 +252,7 @@ func (r *AkabouFareRepository) GetAllAreaSurcharges() ([]*model.AkabouAreaSurcha
 	var areas []*model.AkabouAreaSurcharge
 	for rows.Next() {
 		a := &model.AkabouAreaSurcharge{}
-		if err := rows.Scan(&a.ID, &a.AreaName, &a.SurchargeAmount); err != nil {
+		if err := rows.Scan(&a.ID, &a.TariffVersionID, &a.AreaName, &a.SurchargeAmount); err != nil {
 			return nil, err
 		}
 		areas = append(areas, a)
@@ -264,9 +264,9 @@ func (r *AkabouFareRepository) GetAllAreaSurcharges() ([]*model.AkabouAreaSurcha
 func (r *AkabouFareRepository) UpdateAreaSurcharge(area *model.AkabouAreaSurcharge) error {
 	_, err := r.db.Exec(`
 		UPDATE akabou_area_surcharges
-		SET area_name = ?, surcharge_amount = ?
+		SET tariff_version_id = ?, area_name = ?, surcharge_amount = ?
 		WHERE id = ?
-	`, area.AreaName, area.SurchargeAmount, area.ID)
+	`, area.TariffVersionID, area.AreaName, area.SurchargeAmount, area.ID)
 	return err
 }
 
@@ -281,9 +281,9 @@ func (r *AkabouFareRepository) DeleteAreaSurcharge(id int64) error {
 // CreateAdditionalFee 付帯料金を作成する
 func (r *AkabouFareRepository) CreateAdditionalFee(fee *model.AkabouAdditionalFee) (int64, error) {
 	result, err := r.db.Exec(`
-		INSERT INTO akabou_additional_fees (fee_type, free_minutes, unit_minutes, fee_amount)
-		VALUES (?, ?, ?, ?)
-	`, fee.FeeType, fee.FreeMinutes, fee.UnitMinutes, fee.FeeAmount)
+		INSERT INTO akabou_additional_fees (tariff_version_id, fee_type, free_minutes, unit_minutes, fee_amount)
+		VALUES (?, ?, ?, ?, ?)
+	`, fee.TariffVersionID, fee.FeeType, fee.FreeMinutes, fee.UnitMinutes, fee.FeeAmount)
 	if err != nil {
 		return 0, err
 	}
@@ -294,9 +294,9 @@ func (r *AkabouFareRepository) CreateAdditionalFee(fee *model.AkabouAdditionalFe
 func (r *AkabouFareRepository) GetAdditionalFeeByID(id int64) (*model.AkabouAdditionalFee, error) {
 	fee := &model.AkabouAdditionalFee{}
 	err := r.db.QueryRow(`
-		SELECT id, fee_type, free_minutes, unit_minutes, fee_amount
+		SELECT id, tariff_version_id, fee_type, free_minutes, unit_minutes, fee_amount
 		FROM akabou_additional_fees WHERE id = ?
-	`, id).Scan(&fee.ID, &fee.FeeType, &fee.FreeMinutes, &fee.UnitMinutes, &fee.FeeAmount)
+	`, id).Scan(&fee.ID, &fee.TariffVersionID, &fee.FeeType, &fee.FreeMinutes, &fee.UnitMinutes, &fee.FeeAmount)
 	if err != nil {
 		return nil, err
 	}
@@ -306,7 +306,7 @@ func (r *AkabouFareRepository) GetAdditionalFeeByID(id int64) (*model.AkabouAddi
 // GetAllAdditionalFees 全付帯料金を取得する
 func (r *AkabouFareRepository) GetAllAdditionalFees() ([]*model.AkabouAdditionalFee, error) {
 	rows, err := r.db.Query(`
-		SELECT id, fee_type, free_minutes, unit_minutes, fee_amount
+		SELECT id, tariff_version_id, fee_type, free_minutes, unit_minutes, fee_amount
 		FROM akabou_additional_fees ORDER BY id
 	`)
 	if err != nil {
@@ -317,7 +317,7 @@ func (r *AkabouFareRepository) GetAllAdditionalFees() ([]*model.AkabouAdditional
 	var fees []*model.AkabouAdditionalFee
 	for rows.Next() {
 		f := &model.AkabouAdditionalFee{}
-		if err := rows.Scan(&f.ID, &f.FeeType, &f.FreeMinutes, &f.UnitMinutes, &f.FeeAmount); err != nil {
+		if err := rows.Scan(&f.ID, &f.TariffVersionID, &f.FeeType, &f.FreeMinutes, &f.UnitMinutes, &f.FeeAmount); err != nil {
 			return nil, err
 		}
 		fees = append(fees, f)
@@ -329,9 +329,9 @@ func (r *AkabouFareRepository) GetAllAdditionalFees() ([]*model.AkabouAdditional
 func (r *AkabouFareRepository) UpdateAdditionalFee(fee *model.AkabouAdditionalFee) error {
 	_, err := r.db.Exec(`
 		UPDATE akabou_additional_fees
-		SET fee_type = ?, free_minutes = ?, unit_minutes = ?, fee_amount = ?
+		SET tariff_version_id = ?, fee_type = ?, free_minutes = ?, unit_minutes = ?, fee_amount = ?
 		WHERE id = ?
-	`, fee.FeeType, fee.FreeMinutes, fee.UnitMinutes, fee.FeeAmount, fee.ID)
+	`, fee.TariffVersionID, fee.FeeType, fee.FreeMinutes, fee.UnitMinutes, fee.FeeAmount, fee.ID)
 	return err
 }
 
@@ -340,3 +340,92 @@ func (r *AkabouFareRepository) DeleteAdditionalFee(id int64) error {
 	_, err := r.db.Exec(`DELETE FROM akabou_additional_fees WHERE id = ?`, id)
 	return err
 }
+
+// === AkabouFareGetter インターフェース実装 ===
+// 運賃版固有のデータがなければ版共通（tariff_version_id=0）のデータを返す
+
+// GetDistanceFares 運賃版の距離制運賃（距離帯）を距離順に取得（AkabouFareGetterインターフェース実装）
+func (r *AkabouFareRepository) GetDistanceFares(version *model.TariffVersion) ([]*model.AkabouDistanceFare, error) {
+	rows, err := r.db.Query(`
+		SELECT id, tariff_version_id, min_km, max_km, base_fare, per_km_rate
+		FROM akabou_distance_fares
+		WHERE tariff_version_id = (
+			SELECT MAX(tariff_version_id) FROM akabou_distance_fares WHERE tariff_version_id IN (?, 0)
+		)
+		ORDER BY min_km
+	`, tariffVersionID(version))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fares []*model.AkabouDistanceFare
+	for rows.Next() {
+		fare := &model.AkabouDistanceFare{}
+		if err := rows.Scan(&fare.ID, &fare.TariffVersionID, &fare.MinKm, &fare.MaxKm, &fare.BaseFare, &fare.PerKmRate); err != nil {
+			return nil, err
+		}
+		fares = append(fares, fare)
+	}
+	return fares, rows.Err()
+}
+
+// GetTimeFare 運賃版の時間制運賃を取得（AkabouFareGetterインターフェース実装）
+func (r *AkabouFareRepository) GetTimeFare(version *model.TariffVersion) (*model.AkabouTimeFare, error) {
+	fare := &model.AkabouTimeFare{}
+	err := r.db.QueryRow(`
+		SELECT id, tariff_version_id, base_hours, base_km, base_fare, overtime_rate, overtime_unit_minutes
+		FROM akabou_time_fares
+		WHERE tariff_version_id IN (?, 0)
+		ORDER BY tariff_version_id DESC, base_hours LIMIT 1
+	`, tariffVersionID(version)).Scan(&fare.ID, &fare.TariffVersionID, &fare.BaseHours, &fare.BaseKm, &fare.BaseFare, &fare.OvertimeRate, &fare.OvertimeUnitMinutes)
+	if err != nil {
+		return nil, err
+	}
+	return fare, nil
+}
+
+// GetSurchargeByType 運賃版・割増種別で割増料金を取得（AkabouFareGetterインターフェース実装）
+func (r *AkabouFareRepository) GetSurchargeByType(version *model.TariffVersion, surchargeType string) (*model.AkabouSurcharge, error) {
+	surcharge := &model.AkabouSurcharge{}
+	err := r.db.QueryRow(`
+		SELECT id, tariff_version_id, surcharge_type, rate_percent, description
+		FROM akabou_surcharges
+		WHERE tariff_version_id IN (?, 0) AND surcharge_type = ?
+		ORDER BY tariff_version_id DESC LIMIT 1
+	`, tariffVersionID(version), surchargeType).Scan(&surcharge.ID, &surcharge.TariffVersionID, &surcharge.SurchargeType, &surcharge.RatePercent, &surcharge.Description)
+	if err != nil {
+		return nil, err
+	}
+	return surcharge, nil
+}
+
+// GetAreaSurchargeByName 運賃版・地区名で地区割増を取得（AkabouFareGetterインターフェース実装）
+func (r *AkabouFareRepository) GetAreaSurchargeByName(version *model.TariffVersion, areaName string) (*model.AkabouAreaSurcharge, error) {
+	area := &model.AkabouAreaSurcharge{}
+	err := r.db.QueryRow(`
+		SELECT id, tariff_version_id, area_name, surcharge_amount
+		FROM akabou_area_surcharges
+		WHERE tariff_version_id IN (?, 0) AND area_name = ?
+		ORDER BY tariff_version_id DESC LIMIT 1
+	`, tariffVersionID(version), areaName).Scan(&area.ID, &area.TariffVersionID, &area.AreaName, &area.SurchargeAmount)
+	if err != nil {
+		return nil, err
+	}
+	return area, nil
+}
+
+// GetAdditionalFeeByType 運賃版・料金種別で付帯料金を取得（AkabouFareGetterインターフェース実装）
+func (r *AkabouFareRepository) GetAdditionalFeeByType(version *model.TariffVersion, feeType string) (*model.AkabouAdditionalFee, error) {
+	fee := &model.AkabouAdditionalFee{}
+	err := r.db.QueryRow(`
+		SELECT id, tariff_version_id, fee_type, free_minutes, unit_minutes, fee_amount
+		FROM akabou_additional_fees
+		WHERE tariff_version_id IN (?, 0) AND fee_type = ?
+		ORDER BY tariff_version_id DESC LIMIT 1
+	`, tariffVersionID(version), feeType).Scan(&fee.ID, &fee.TariffVersionID, &fee.FeeType, &fee.FreeMinutes, &fee.UnitMinutes, &fee.FeeAmount)
+	if err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
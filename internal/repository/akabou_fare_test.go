@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/y-suzuki/standard-truck-rate/internal/model"
@@ -367,3 +369,119 @@ func TestAkabouFareRepository_GetAllAdditionalFees(t *testing.T) {
 		t.Errorf("GetAllAdditionalFees() returned %d items, want 2", len(got))
 	}
 }
+
+// === AkabouFareGetter テスト ===
+
+func TestAkabouFareRepository_GetDistanceFares_TariffVersion(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewAkabouFareRepository(db.MainDB())
+
+	// 版共通: 2区間、運賃版1: 3区間
+	max20, max50 := 20, 50
+	base5500, base6000 := 5500, 6000
+	rate242, rate132, rate250 := 242, 132, 250
+	repo.CreateDistanceFare(&model.AkabouDistanceFare{MinKm: 0, MaxKm: &max20, BaseFare: &base5500})
+	repo.CreateDistanceFare(&model.AkabouDistanceFare{MinKm: 21, PerKmRate: &rate242})
+	repo.CreateDistanceFare(&model.AkabouDistanceFare{TariffVersionID: 1, MinKm: 21, MaxKm: &max50, PerKmRate: &rate250})
+	repo.CreateDistanceFare(&model.AkabouDistanceFare{TariffVersionID: 1, MinKm: 0, MaxKm: &max20, BaseFare: &base6000})
+	repo.CreateDistanceFare(&model.AkabouDistanceFare{TariffVersionID: 1, MinKm: 51, PerKmRate: &rate132})
+
+	common, err := repo.GetDistanceFares(nil)
+	if err != nil {
+		t.Fatalf("GetDistanceFares(nil) error = %v", err)
+	}
+	if len(common) != 2 {
+		t.Errorf("GetDistanceFares(nil) returned %d items, want 2", len(common))
+	}
+
+	versioned, err := repo.GetDistanceFares(&model.TariffVersion{ID: 1})
+	if err != nil {
+		t.Fatalf("GetDistanceFares(1) error = %v", err)
+	}
+	if len(versioned) != 3 {
+		t.Fatalf("GetDistanceFares(1) returned %d items, want 3", len(versioned))
+	}
+	// 距離順に並ぶこと
+	if versioned[0].MinKm != 0 || *versioned[0].BaseFare != 6000 || versioned[2].MinKm != 51 {
+		t.Errorf("GetDistanceFares(1) order = %d, %d, %d", versioned[0].MinKm, versioned[1].MinKm, versioned[2].MinKm)
+	}
+
+	// 版固有データがなければ版共通
+	fallback, err := repo.GetDistanceFares(&model.TariffVersion{ID: 2})
+	if err != nil {
+		t.Fatalf("GetDistanceFares(2) error = %v", err)
+	}
+	if len(fallback) != 2 {
+		t.Errorf("GetDistanceFares(2) returned %d items, want 2", len(fallback))
+	}
+}
+
+func TestAkabouFareRepository_GetTimeFare(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewAkabouFareRepository(db.MainDB())
+
+	repo.CreateTimeFare(&model.AkabouTimeFare{BaseHours: 2, BaseKm: 20, BaseFare: 6050, OvertimeRate: 1375, OvertimeUnitMinutes: 30})
+
+	got, err := repo.GetTimeFare(nil)
+	if err != nil {
+		t.Fatalf("GetTimeFare() error = %v", err)
+	}
+	if got.BaseFare != 6050 || got.OvertimeUnitMinutes != 30 {
+		t.Errorf("GetTimeFare() = %+v, want BaseFare=6050, OvertimeUnitMinutes=30", got)
+	}
+}
+
+func TestAkabouFareRepository_GetAreaSurchargeByName(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewAkabouFareRepository(db.MainDB())
+
+	repo.CreateAreaSurcharge(&model.AkabouAreaSurcharge{AreaName: "東京23区", SurchargeAmount: 440})
+	repo.CreateAreaSurcharge(&model.AkabouAreaSurcharge{AreaName: "名古屋市内", SurchargeAmount: 330})
+
+	got, err := repo.GetAreaSurchargeByName(nil, "名古屋市内")
+	if err != nil {
+		t.Fatalf("GetAreaSurchargeByName() error = %v", err)
+	}
+	if got.SurchargeAmount != 330 {
+		t.Errorf("GetAreaSurchargeByName() SurchargeAmount = %d, want 330", got.SurchargeAmount)
+	}
+
+	// 未登録の地区
+	_, err = repo.GetAreaSurchargeByName(nil, "札幌市内")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetAreaSurchargeByName() error = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestAkabouFareRepository_GetSurchargeAndAdditionalFeeByType(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewAkabouFareRepository(db.MainDB())
+
+	repo.CreateSurcharge(&model.AkabouSurcharge{SurchargeType: "night", RatePercent: 30})
+	repo.CreateSurcharge(&model.AkabouSurcharge{TariffVersionID: 1, SurchargeType: "night", RatePercent: 35})
+	repo.CreateAdditionalFee(&model.AkabouAdditionalFee{FeeType: "work", FreeMinutes: 30, UnitMinutes: 15, FeeAmount: 550})
+
+	surcharge, err := repo.GetSurchargeByType(&model.TariffVersion{ID: 1}, "night")
+	if err != nil {
+		t.Fatalf("GetSurchargeByType() error = %v", err)
+	}
+	if surcharge.RatePercent != 35 {
+		t.Errorf("GetSurchargeByType() RatePercent = %d, want 35", surcharge.RatePercent)
+	}
+
+	fee, err := repo.GetAdditionalFeeByType(&model.TariffVersion{ID: 1}, "work")
+	if err != nil {
+		t.Fatalf("GetAdditionalFeeByType() error = %v", err)
+	}
+	if fee.FeeAmount != 550 || fee.TariffVersionID != 0 {
+		t.Errorf("GetAdditionalFeeByType() = %+v, want FeeAmount=550, TariffVersionID=0", fee)
+	}
+}
@@ -7,6 +7,12 @@ import (
 	"github.com/y-suzuki/standard-truck-rate/internal/model"
 )
 
+// defaultLimitCounts API種別ごとの月間上限のデフォルト（無料枠10,000件に余裕を持たせる）
+var defaultLimitCounts = map[string]int{
+	model.ApiTypeRoutes:    9000,
+	model.ApiTypeGeocoding: 9000,
+}
+
 // ApiUsageRepository API使用量のリポジトリ
 type ApiUsageRepository struct {
 	db *sql.DB
@@ -17,22 +23,30 @@ func NewApiUsageRepository(db *sql.DB) *ApiUsageRepository {
 	return &ApiUsageRepository{db: db}
 }
 
+// apiTypeOrDefault API種別が空の場合は Routes API とする
+func apiTypeOrDefault(apiType string) string {
+	if apiType == "" {
+		return model.ApiTypeRoutes
+	}
+	return apiType
+}
+
 // Create API使用量を作成する
 func (r *ApiUsageRepository) Create(usage *model.ApiUsage) error {
 	_, err := r.db.Exec(`
-		INSERT INTO api_usage (year_month, request_count, limit_count, last_updated)
-		VALUES (?, ?, ?, ?)
-	`, usage.YearMonth, usage.RequestCount, usage.LimitCount, time.Now())
+		INSERT INTO api_usage (year_month, api_type, request_count, limit_count, last_updated)
+		VALUES (?, ?, ?, ?, ?)
+	`, usage.YearMonth, apiTypeOrDefault(usage.ApiType), usage.RequestCount, usage.LimitCount, time.Now())
 	return err
 }
 
-// GetByYearMonth 年月でAPI使用量を取得する
-func (r *ApiUsageRepository) GetByYearMonth(yearMonth string) (*model.ApiUsage, error) {
+// GetByYearMonth 年月・API種別でAPI使用量を取得する
+func (r *ApiUsageRepository) GetByYearMonth(yearMonth, apiType string) (*model.ApiUsage, error) {
 	usage := &model.ApiUsage{}
 	err := r.db.QueryRow(`
-		SELECT year_month, request_count, limit_count, last_updated
-		FROM api_usage WHERE year_month = ?
-	`, yearMonth).Scan(&usage.YearMonth, &usage.RequestCount, &usage.LimitCount, &usage.LastUpdated)
+		SELECT year_month, api_type, request_count, limit_count, last_updated
+		FROM api_usage WHERE year_month = ? AND api_type = ?
+	`, yearMonth, apiTypeOrDefault(apiType)).Scan(&usage.YearMonth, &usage.ApiType, &usage.RequestCount, &usage.LimitCount, &usage.LastUpdated)
 	if err != nil {
 		return nil, err
 	}
@@ -40,43 +54,49 @@ func (r *ApiUsageRepository) GetByYearMonth(yearMonth string) (*model.ApiUsage,
 }
 
 // GetCurrent 現在月のAPI使用量を取得する
-func (r *ApiUsageRepository) GetCurrent() (*model.ApiUsage, error) {
+func (r *ApiUsageRepository) GetCurrent(apiType string) (*model.ApiUsage, error) {
 	currentYearMonth := time.Now().Format("2006-01")
-	return r.GetByYearMonth(currentYearMonth)
+	return r.GetByYearMonth(currentYearMonth, apiType)
 }
 
 // GetOrCreateCurrent 現在月のAPI使用量を取得、なければ作成する
-func (r *ApiUsageRepository) GetOrCreateCurrent() (*model.ApiUsage, error) {
+func (r *ApiUsageRepository) GetOrCreateCurrent(apiType string) (*model.ApiUsage, error) {
 	currentYearMonth := time.Now().Format("2006-01")
+	apiType = apiTypeOrDefault(apiType)
 
-	usage, err := r.GetByYearMonth(currentYearMonth)
+	usage, err := r.GetByYearMonth(currentYearMonth, apiType)
 	if err == nil {
 		return usage, nil
 	}
 
 	// 存在しない場合は作成
 	if err == sql.ErrNoRows {
+		limitCount, ok := defaultLimitCounts[apiType]
+		if !ok {
+			limitCount = defaultLimitCounts[model.ApiTypeRoutes]
+		}
 		newUsage := &model.ApiUsage{
 			YearMonth:    currentYearMonth,
+			ApiType:      apiType,
 			RequestCount: 0,
-			LimitCount:   9000, // デフォルト上限
+			LimitCount:   limitCount, // デフォルト上限
 		}
 		if err := r.Create(newUsage); err != nil {
 			return nil, err
 		}
-		return r.GetByYearMonth(currentYearMonth)
+		return r.GetByYearMonth(currentYearMonth, apiType)
 	}
 
 	return nil, err
 }
 
-// IncrementCount 指定年月のリクエスト数を1増やす
-func (r *ApiUsageRepository) IncrementCount(yearMonth string) error {
+// IncrementCount 指定年月・API種別のリクエスト数を1増やす
+func (r *ApiUsageRepository) IncrementCount(yearMonth, apiType string) error {
 	_, err := r.db.Exec(`
 		UPDATE api_usage
 		SET request_count = request_count + 1, last_updated = ?
-		WHERE year_month = ?
-	`, time.Now(), yearMonth)
+		WHERE year_month = ? AND api_type = ?
+	`, time.Now(), yearMonth, apiTypeOrDefault(apiType))
 	return err
 }
 
@@ -85,16 +105,16 @@ func (r *ApiUsageRepository) Update(usage *model.ApiUsage) error {
 	_, err := r.db.Exec(`
 		UPDATE api_usage
 		SET request_count = ?, limit_count = ?, last_updated = ?
-		WHERE year_month = ?
-	`, usage.RequestCount, usage.LimitCount, time.Now(), usage.YearMonth)
+		WHERE year_month = ? AND api_type = ?
+	`, usage.RequestCount, usage.LimitCount, time.Now(), usage.YearMonth, apiTypeOrDefault(usage.ApiType))
 	return err
 }
 
 // GetAll 全API使用量を取得する
 func (r *ApiUsageRepository) GetAll() ([]*model.ApiUsage, error) {
 	rows, err := r.db.Query(`
-		SELECT year_month, request_count, limit_count, last_updated
-		FROM api_usage ORDER BY year_month DESC
+		SELECT year_month, api_type, request_count, limit_count, last_updated
+		FROM api_usage ORDER BY year_month DESC, api_type
 	`)
 	if err != nil {
 		return nil, err
@@ -104,7 +124,7 @@ func (r *ApiUsageRepository) GetAll() ([]*model.ApiUsage, error) {
 	var usages []*model.ApiUsage
 	for rows.Next() {
 		u := &model.ApiUsage{}
-		if err := rows.Scan(&u.YearMonth, &u.RequestCount, &u.LimitCount, &u.LastUpdated); err != nil {
+		if err := rows.Scan(&u.YearMonth, &u.ApiType, &u.RequestCount, &u.LimitCount, &u.LastUpdated); err != nil {
 			return nil, err
 		}
 		usages = append(usages, u)
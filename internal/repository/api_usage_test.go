@@ -40,7 +40,7 @@ func TestApiUsageRepository_GetByYearMonth(t *testing.T) {
 	repo.Create(usage)
 
 	// 取得テスト
-	got, err := repo.GetByYearMonth("2026-01")
+	got, err := repo.GetByYearMonth("2026-01", model.ApiTypeRoutes)
 	if err != nil {
 		t.Fatalf("GetByYearMonth() error = %v", err)
 	}
@@ -55,7 +55,7 @@ func TestApiUsageRepository_GetByYearMonth_NotFound(t *testing.T) {
 
 	repo := NewApiUsageRepository(db.MainDB())
 
-	_, err := repo.GetByYearMonth("9999-12")
+	_, err := repo.GetByYearMonth("9999-12", model.ApiTypeRoutes)
 	if err == nil {
 		t.Error("GetByYearMonth() 存在しない年月でエラーが返らない")
 	}
@@ -77,7 +77,7 @@ func TestApiUsageRepository_GetCurrent(t *testing.T) {
 	repo.Create(usage)
 
 	// 取得テスト
-	got, err := repo.GetCurrent()
+	got, err := repo.GetCurrent(model.ApiTypeRoutes)
 	if err != nil {
 		t.Fatalf("GetCurrent() error = %v", err)
 	}
@@ -93,7 +93,7 @@ func TestApiUsageRepository_GetOrCreateCurrent(t *testing.T) {
 	repo := NewApiUsageRepository(db.MainDB())
 
 	// データがない状態で呼び出し
-	got, err := repo.GetOrCreateCurrent()
+	got, err := repo.GetOrCreateCurrent(model.ApiTypeRoutes)
 	if err != nil {
 		t.Fatalf("GetOrCreateCurrent() error = %v", err)
 	}
@@ -125,13 +125,13 @@ func TestApiUsageRepository_IncrementCount(t *testing.T) {
 	repo.Create(usage)
 
 	// インクリメント
-	err := repo.IncrementCount("2026-01")
+	err := repo.IncrementCount("2026-01", model.ApiTypeRoutes)
 	if err != nil {
 		t.Fatalf("IncrementCount() error = %v", err)
 	}
 
 	// 確認
-	got, _ := repo.GetByYearMonth("2026-01")
+	got, _ := repo.GetByYearMonth("2026-01", model.ApiTypeRoutes)
 	if got.RequestCount != 101 {
 		t.Errorf("IncrementCount() RequestCount = %d, want 101", got.RequestCount)
 	}
@@ -160,7 +160,7 @@ func TestApiUsageRepository_Update(t *testing.T) {
 	}
 
 	// 確認
-	got, _ := repo.GetByYearMonth("2026-01")
+	got, _ := repo.GetByYearMonth("2026-01", model.ApiTypeRoutes)
 	if got.RequestCount != 500 || got.LimitCount != 10000 {
 		t.Errorf("Update() = %+v", got)
 	}
@@ -184,3 +184,35 @@ func TestApiUsageRepository_GetAll(t *testing.T) {
 		t.Errorf("GetAll() returned %d items, want 2", len(got))
 	}
 }
+
+// TestApiUsageRepository_ApiType API種別ごとに別の使用量としてカウントすること
+func TestApiUsageRepository_ApiType(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewApiUsageRepository(db.MainDB())
+
+	routes, err := repo.GetOrCreateCurrent(model.ApiTypeRoutes)
+	if err != nil {
+		t.Fatalf("GetOrCreateCurrent(routes) error = %v", err)
+	}
+	geocoding, err := repo.GetOrCreateCurrent(model.ApiTypeGeocoding)
+	if err != nil {
+		t.Fatalf("GetOrCreateCurrent(geocoding) error = %v", err)
+	}
+	if geocoding.ApiType != model.ApiTypeGeocoding || geocoding.LimitCount != 9000 {
+		t.Errorf("GetOrCreateCurrent(geocoding) = %+v", geocoding)
+	}
+
+	repo.IncrementCount(geocoding.YearMonth, model.ApiTypeGeocoding)
+	repo.IncrementCount(geocoding.YearMonth, model.ApiTypeGeocoding)
+
+	got, _ := repo.GetCurrent(model.ApiTypeGeocoding)
+	if got.RequestCount != 2 {
+		t.Errorf("geocoding RequestCount = %d, want 2", got.RequestCount)
+	}
+	got, _ = repo.GetCurrent(model.ApiTypeRoutes)
+	if got.RequestCount != routes.RequestCount {
+		t.Errorf("routes RequestCount = %d, want %d", got.RequestCount, routes.RequestCount)
+	}
+}
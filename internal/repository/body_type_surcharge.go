@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// BodyTypeSurchargeRepository 特殊車両割増（車体種別）のリポジトリ
+type BodyTypeSurchargeRepository struct {
+	db *sql.DB
+}
+
+// NewBodyTypeSurchargeRepository リポジトリを作成する
+func NewBodyTypeSurchargeRepository(db *sql.DB) *BodyTypeSurchargeRepository {
+	return &BodyTypeSurchargeRepository{db: db}
+}
+
+// Upsert 特殊車両割増を登録する（同じ運賃版・車体種別のデータがあれば更新）
+func (r *BodyTypeSurchargeRepository) Upsert(s *model.BodyTypeSurcharge) error {
+	_, err := r.db.Exec(`
+		INSERT INTO jta_body_type_surcharges (tariff_version_id, body_type, name, surcharge_percent, sort_order)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(tariff_version_id, body_type) DO UPDATE SET
+			name = excluded.name,
+			surcharge_percent = excluded.surcharge_percent,
+			sort_order = excluded.sort_order
+	`, s.TariffVersionID, s.BodyType, s.Name, s.SurchargePercent, s.SortOrder)
+	return err
+}
+
+// GetBodyTypeSurcharge 運賃版・車体種別で特殊車両割増を取得する（BodyTypeSurchargeGetterインターフェース実装）
+// 運賃版固有のデータがなければ版共通（tariff_version_id=0）のデータを返す
+func (r *BodyTypeSurchargeRepository) GetBodyTypeSurcharge(version *model.TariffVersion, bodyType string) (*model.BodyTypeSurcharge, error) {
+	s := &model.BodyTypeSurcharge{}
+	err := r.db.QueryRow(`
+		SELECT id, tariff_version_id, body_type, name, surcharge_percent, sort_order
+		FROM jta_body_type_surcharges
+		WHERE tariff_version_id IN (?, 0) AND body_type = ?
+		ORDER BY tariff_version_id DESC LIMIT 1
+	`, tariffVersionID(version), bodyType).Scan(&s.ID, &s.TariffVersionID, &s.BodyType, &s.Name, &s.SurchargePercent, &s.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetBodyTypeSurcharges 運賃版で選択できる特殊車両割増を表示順に取得する
+// 同じ車体種別は運賃版固有のデータを優先する
+func (r *BodyTypeSurchargeRepository) GetBodyTypeSurcharges(version *model.TariffVersion) ([]*model.BodyTypeSurcharge, error) {
+	rows, err := r.db.Query(`
+		SELECT id, tariff_version_id, body_type, name, surcharge_percent, sort_order
+		FROM jta_body_type_surcharges
+		WHERE tariff_version_id IN (?, 0)
+		ORDER BY sort_order, body_type, tariff_version_id DESC
+	`, tariffVersionID(version))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var surcharges []*model.BodyTypeSurcharge
+	seen := make(map[string]bool)
+	for rows.Next() {
+		s := &model.BodyTypeSurcharge{}
+		if err := rows.Scan(&s.ID, &s.TariffVersionID, &s.BodyType, &s.Name, &s.SurchargePercent, &s.SortOrder); err != nil {
+			return nil, err
+		}
+		if seen[s.BodyType] {
+			continue
+		}
+		seen[s.BodyType] = true
+		surcharges = append(surcharges, s)
+	}
+	return surcharges, rows.Err()
+}
+
+// Delete 特殊車両割増を削除する
+func (r *BodyTypeSurchargeRepository) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM jta_body_type_surcharges WHERE id = ?`, id)
+	return err
+}
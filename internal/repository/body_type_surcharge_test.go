@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+func TestBodyTypeSurchargeRepository_GetBodyTypeSurcharge(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewBodyTypeSurchargeRepository(db.MainDB())
+	for _, s := range []*model.BodyTypeSurcharge{
+		{BodyType: model.BodyTypeStandard, Name: "標準", SurchargePercent: 0, SortOrder: 0},
+		{BodyType: "refrigerated", Name: "冷蔵車・冷凍車", SurchargePercent: 20, SortOrder: 1},
+		{TariffVersionID: 2, BodyType: "refrigerated", Name: "冷蔵車・冷凍車", SurchargePercent: 25, SortOrder: 1},
+	} {
+		if err := repo.Upsert(s); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+	}
+
+	// 版共通
+	got, err := repo.GetBodyTypeSurcharge(nil, "refrigerated")
+	if err != nil {
+		t.Fatalf("GetBodyTypeSurcharge() error = %v", err)
+	}
+	if got.SurchargePercent != 20 {
+		t.Errorf("SurchargePercent = %d, want 20", got.SurchargePercent)
+	}
+
+	// 運賃版固有のデータを優先
+	got, err = repo.GetBodyTypeSurcharge(&model.TariffVersion{ID: 2}, "refrigerated")
+	if err != nil {
+		t.Fatalf("GetBodyTypeSurcharge() error = %v", err)
+	}
+	if got.SurchargePercent != 25 {
+		t.Errorf("SurchargePercent = %d, want 25", got.SurchargePercent)
+	}
+
+	// 未登録
+	if _, err := repo.GetBodyTypeSurcharge(nil, "unknown"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetBodyTypeSurcharge() error = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestBodyTypeSurchargeRepository_GetBodyTypeSurcharges(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewBodyTypeSurchargeRepository(db.MainDB())
+	for _, s := range []*model.BodyTypeSurcharge{
+		{BodyType: "marine_container", Name: "海上コンテナ輸送車", SurchargePercent: 40, SortOrder: 2},
+		{BodyType: model.BodyTypeStandard, Name: "標準", SurchargePercent: 0, SortOrder: 0},
+		{BodyType: "refrigerated", Name: "冷蔵車・冷凍車", SurchargePercent: 20, SortOrder: 1},
+		{TariffVersionID: 2, BodyType: "refrigerated", Name: "冷蔵車・冷凍車", SurchargePercent: 25, SortOrder: 1},
+	} {
+		if err := repo.Upsert(s); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+	}
+
+	got, err := repo.GetBodyTypeSurcharges(&model.TariffVersion{ID: 2})
+	if err != nil {
+		t.Fatalf("GetBodyTypeSurcharges() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("GetBodyTypeSurcharges() returned %d items, want 3", len(got))
+	}
+	wantOrder := []string{model.BodyTypeStandard, "refrigerated", "marine_container"}
+	for i, s := range got {
+		if s.BodyType != wantOrder[i] {
+			t.Errorf("[%d] BodyType = %s, want %s", i, s.BodyType, wantOrder[i])
+		}
+	}
+	if got[1].SurchargePercent != 25 {
+		t.Errorf("冷蔵車の割増率 = %d, want 25（運賃版固有を優先）", got[1].SurchargePercent)
+	}
+
+	// 更新
+	if err := repo.Upsert(&model.BodyTypeSurcharge{BodyType: "marine_container", Name: "海上コンテナ輸送車", SurchargePercent: 45, SortOrder: 2}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	s, err := repo.GetBodyTypeSurcharge(nil, "marine_container")
+	if err != nil || s.SurchargePercent != 45 {
+		t.Errorf("更新後 = %+v, %v, want 45%%", s, err)
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// CompanyHolidayRepository 会社休日のリポジトリ
+type CompanyHolidayRepository struct {
+	db *sql.DB
+}
+
+// NewCompanyHolidayRepository リポジトリを作成する
+func NewCompanyHolidayRepository(db *sql.DB) *CompanyHolidayRepository {
+	return &CompanyHolidayRepository{db: db}
+}
+
+// Create 会社休日を作成する
+func (r *CompanyHolidayRepository) Create(h *model.CompanyHoliday) (int64, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO company_holidays (name, date, month_day)
+		VALUES (?, ?, ?)
+	`, h.Name, h.Date, h.MonthDay)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetAll 全会社休日を取得する
+func (r *CompanyHolidayRepository) GetAll() ([]*model.CompanyHoliday, error) {
+	return r.query(`
+		SELECT id, name, date, month_day FROM company_holidays
+		ORDER BY month_day, date
+	`)
+}
+
+// GetCompanyHolidays 指定年に適用される会社休日を取得する（CompanyHolidayGetterインターフェース実装）
+// 指定年の特定日と、毎年の月日指定をすべて返す
+func (r *CompanyHolidayRepository) GetCompanyHolidays(year int) ([]*model.CompanyHoliday, error) {
+	return r.query(`
+		SELECT id, name, date, month_day FROM company_holidays
+		WHERE month_day IS NOT NULL OR date LIKE ?
+		ORDER BY month_day, date
+	`, fmt.Sprintf("%04d-%%", year))
+}
+
+// Delete 会社休日を削除する
+func (r *CompanyHolidayRepository) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM company_holidays WHERE id = ?`, id)
+	return err
+}
+
+// query 会社休日を検索する
+func (r *CompanyHolidayRepository) query(query string, args ...interface{}) ([]*model.CompanyHoliday, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holidays []*model.CompanyHoliday
+	for rows.Next() {
+		h := &model.CompanyHoliday{}
+		if err := rows.Scan(&h.ID, &h.Name, &h.Date, &h.MonthDay); err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, h)
+	}
+	return holidays, rows.Err()
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+func TestCompanyHolidayRepository_GetCompanyHolidays(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewCompanyHolidayRepository(db.MainDB())
+
+	newYear := "12-31"
+	summer2026 := "2026-08-14"
+	summer2025 := "2025-08-15"
+	for _, h := range []*model.CompanyHoliday{
+		{Name: "年末年始", MonthDay: &newYear},
+		{Name: "夏季休業", Date: &summer2026},
+		{Name: "夏季休業", Date: &summer2025},
+	} {
+		if _, err := repo.Create(h); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	got, err := repo.GetCompanyHolidays(2026)
+	if err != nil {
+		t.Fatalf("GetCompanyHolidays() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("GetCompanyHolidays() returned %d items, want 2", len(got))
+	}
+	for _, h := range got {
+		if h.Date != nil && *h.Date != summer2026 {
+			t.Errorf("GetCompanyHolidays() に他年の特定日が含まれている: %s", *h.Date)
+		}
+	}
+
+	all, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("GetAll() returned %d items, want 3", len(all))
+	}
+}
+
+func TestCompanyHolidayRepository_CreateInvalid(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewCompanyHolidayRepository(db.MainDB())
+
+	// 特定日・月日のどちらも未指定はエラー
+	if _, err := repo.Create(&model.CompanyHoliday{Name: "休業日"}); err == nil {
+		t.Error("エラーが期待されたが、発生しなかった")
+	}
+}
+
+func TestCompanyHolidayRepository_Delete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewCompanyHolidayRepository(db.MainDB())
+
+	date := "2026-08-14"
+	id, err := repo.Create(&model.CompanyHoliday{Name: "夏季休業", Date: &date})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Delete(id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	all, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("GetAll() returned %d items, want 0", len(all))
+	}
+}
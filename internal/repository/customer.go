@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// CustomerRepository 荷主マスタ・契約条件のリポジトリ
+type CustomerRepository struct {
+	db *sql.DB
+}
+
+// NewCustomerRepository リポジトリを作成する
+func NewCustomerRepository(db *sql.DB) *CustomerRepository {
+	return &CustomerRepository{db: db}
+}
+
+// Upsert 荷主を登録する（同じ荷主コードのデータがあれば更新）し、IDを返す
+func (r *CustomerRepository) Upsert(c *model.Customer) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(`
+		INSERT INTO customers (code, name, note)
+		VALUES (?, ?, ?)
+		ON CONFLICT(code) DO UPDATE SET
+			name = excluded.name,
+			note = excluded.note
+		RETURNING id
+	`, c.Code, c.Name, c.Note).Scan(&id)
+	return id, err
+}
+
+// GetCustomer IDで荷主を取得する（CustomerPricingGetterインターフェース実装）
+func (r *CustomerRepository) GetCustomer(id int64) (*model.Customer, error) {
+	c := &model.Customer{}
+	err := r.db.QueryRow(`
+		SELECT id, code, name, note FROM customers WHERE id = ?
+	`, id).Scan(&c.ID, &c.Code, &c.Name, &c.Note)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetCustomerByCode 荷主コードで荷主を取得する
+func (r *CustomerRepository) GetCustomerByCode(code string) (*model.Customer, error) {
+	c := &model.Customer{}
+	err := r.db.QueryRow(`
+		SELECT id, code, name, note FROM customers WHERE code = ?
+	`, code).Scan(&c.ID, &c.Code, &c.Name, &c.Note)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetAll 全荷主を荷主コード順に取得する
+func (r *CustomerRepository) GetAll() ([]*model.Customer, error) {
+	rows, err := r.db.Query(`
+		SELECT id, code, name, note FROM customers
+		ORDER BY code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var customers []*model.Customer
+	for rows.Next() {
+		c := &model.Customer{}
+		if err := rows.Scan(&c.ID, &c.Code, &c.Name, &c.Note); err != nil {
+			return nil, err
+		}
+		customers = append(customers, c)
+	}
+	return customers, rows.Err()
+}
+
+// Delete 荷主と契約条件を削除する
+func (r *CustomerRepository) Delete(id int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM customer_pricing_rules WHERE customer_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM customers WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreatePricingRule 契約条件を作成する
+func (r *CustomerRepository) CreatePricingRule(rule *model.CustomerPricingRule) (int64, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO customer_pricing_rules (
+			customer_id, name, adjustment_type, adjustment_percent, adjustment_yen,
+			minimum_fare_yen, vehicle_codes, region_codes, valid_from, valid_to
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, rule.CustomerID, rule.Name, rule.AdjustmentType, rule.AdjustmentPercent, rule.AdjustmentYen,
+		rule.MinimumFareYen, rule.VehicleCodes, rule.RegionCodes, rule.ValidFrom, rule.ValidTo)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetPricingRules 荷主の契約条件を取得する（CustomerPricingGetterインターフェース実装）
+func (r *CustomerRepository) GetPricingRules(customerID int64) ([]*model.CustomerPricingRule, error) {
+	rows, err := r.db.Query(`
+		SELECT id, customer_id, name, adjustment_type, adjustment_percent, adjustment_yen,
+			minimum_fare_yen, vehicle_codes, region_codes, valid_from, valid_to
+		FROM customer_pricing_rules
+		WHERE customer_id = ?
+		ORDER BY valid_from, id
+	`, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*model.CustomerPricingRule
+	for rows.Next() {
+		rule := &model.CustomerPricingRule{}
+		if err := rows.Scan(
+			&rule.ID, &rule.CustomerID, &rule.Name, &rule.AdjustmentType, &rule.AdjustmentPercent, &rule.AdjustmentYen,
+			&rule.MinimumFareYen, &rule.VehicleCodes, &rule.RegionCodes, &rule.ValidFrom, &rule.ValidTo,
+		); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// DeletePricingRule 契約条件を削除する
+func (r *CustomerRepository) DeletePricingRule(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM customer_pricing_rules WHERE id = ?`, id)
+	return err
+}
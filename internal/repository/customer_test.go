@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+func TestCustomerRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewCustomerRepository(db.MainDB())
+	id, err := repo.Upsert(&model.Customer{Code: "C002", Name: "東信物産"})
+	if err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if _, err := repo.Upsert(&model.Customer{Code: "C001", Name: "山田商事"}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	// 同じ荷主コードは更新（IDは変わらない）
+	note := "月末締め"
+	updatedID, err := repo.Upsert(&model.Customer{Code: "C002", Name: "東信物産株式会社", Note: &note})
+	if err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if updatedID != id {
+		t.Errorf("更新時のID = %d, want %d", updatedID, id)
+	}
+
+	got, err := repo.GetCustomer(id)
+	if err != nil {
+		t.Fatalf("GetCustomer() error = %v", err)
+	}
+	if got.Name != "東信物産株式会社" || got.Note == nil || *got.Note != note {
+		t.Errorf("GetCustomer() = %+v", got)
+	}
+	if byCode, err := repo.GetCustomerByCode("C002"); err != nil || byCode.ID != id {
+		t.Errorf("GetCustomerByCode() = %+v, %v", byCode, err)
+	}
+
+	// 荷主コード順
+	all, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 2 || all[0].Code != "C001" {
+		t.Errorf("GetAll() = %+v", all)
+	}
+
+	// 契約条件
+	from := "2025-04-01"
+	for _, rule := range []*model.CustomerPricingRule{
+		{CustomerID: id, Name: "2025年度契約", AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: -10, ValidFrom: &from},
+		{CustomerID: id, Name: "大型車最低運賃", AdjustmentType: model.CustomerAdjustmentFixed, MinimumFareYen: 30000, VehicleCodes: "3,4", RegionCodes: "3"},
+	} {
+		if _, err := repo.CreatePricingRule(rule); err != nil {
+			t.Fatalf("CreatePricingRule() error = %v", err)
+		}
+	}
+	rules, err := repo.GetPricingRules(id)
+	if err != nil {
+		t.Fatalf("GetPricingRules() error = %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("GetPricingRules() = %d件, want 2", len(rules))
+	}
+	if rules[0].ValidFrom != nil || rules[0].VehicleCodes != "3,4" || rules[0].MinimumFareYen != 30000 {
+		t.Errorf("rules[0] = %+v", rules[0])
+	}
+	if rules[1].ValidFrom == nil || *rules[1].ValidFrom != from || rules[1].AdjustmentPercent != -10 {
+		t.Errorf("rules[1] = %+v", rules[1])
+	}
+
+	// 増減方法の制約
+	if _, err := repo.CreatePricingRule(&model.CustomerPricingRule{CustomerID: id, AdjustmentType: "percent"}); err == nil {
+		t.Error("不正な増減方法で登録できてしまった")
+	}
+
+	// 荷主の削除で契約条件も削除
+	if err := repo.Delete(id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetCustomer(id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("削除後の GetCustomer() error = %v, want sql.ErrNoRows", err)
+	}
+	if rules, _ := repo.GetPricingRules(id); len(rules) != 0 {
+		t.Errorf("削除後の GetPricingRules() = %d件, want 0", len(rules))
+	}
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// FuelSurchargeRepository 燃料価格・燃料サーチャージのリポジトリ
+type FuelSurchargeRepository struct {
+	db *sql.DB
+}
+
+// NewFuelSurchargeRepository リポジトリを作成する
+func NewFuelSurchargeRepository(db *sql.DB) *FuelSurchargeRepository {
+	return &FuelSurchargeRepository{db: db}
+}
+
+// === FuelPrice (燃料価格) ===
+
+// UpsertFuelPrice 月別の燃料価格を登録する（同月のデータがあれば更新）
+func (r *FuelSurchargeRepository) UpsertFuelPrice(price *model.FuelPrice) error {
+	_, err := r.db.Exec(`
+		INSERT INTO fuel_prices (year_month, price_yen, reference_price_yen, source)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(year_month) DO UPDATE SET
+			price_yen = excluded.price_yen,
+			reference_price_yen = excluded.reference_price_yen,
+			source = excluded.source
+	`, price.YearMonth, price.PriceYen, price.ReferencePriceYen, price.Source)
+	return err
+}
+
+// GetFuelPrice 指定日に適用される燃料価格を取得する（FuelSurchargeGetterインターフェース実装）
+// 当月のデータがなければ直近の過去月のデータを返す。該当なしは sql.ErrNoRows
+func (r *FuelSurchargeRepository) GetFuelPrice(date time.Time) (*model.FuelPrice, error) {
+	price := &model.FuelPrice{}
+	err := r.db.QueryRow(`
+		SELECT year_month, price_yen, reference_price_yen, source
+		FROM fuel_prices WHERE year_month <= ?
+		ORDER BY year_month DESC LIMIT 1
+	`, date.Format(model.FuelYearMonthFormat)).Scan(&price.YearMonth, &price.PriceYen, &price.ReferencePriceYen, &price.Source)
+	if err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// GetAllFuelPrices 全燃料価格を取得する（対象月順）
+func (r *FuelSurchargeRepository) GetAllFuelPrices() ([]*model.FuelPrice, error) {
+	rows, err := r.db.Query(`
+		SELECT year_month, price_yen, reference_price_yen, source
+		FROM fuel_prices ORDER BY year_month
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prices []*model.FuelPrice
+	for rows.Next() {
+		price := &model.FuelPrice{}
+		if err := rows.Scan(&price.YearMonth, &price.PriceYen, &price.ReferencePriceYen, &price.Source); err != nil {
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+	return prices, rows.Err()
+}
+
+// DeleteFuelPrice 燃料価格を削除する
+func (r *FuelSurchargeRepository) DeleteFuelPrice(yearMonth string) error {
+	_, err := r.db.Exec(`DELETE FROM fuel_prices WHERE year_month = ?`, yearMonth)
+	return err
+}
+
+// === FuelSurcharge (燃料サーチャージ) ===
+
+// CreateSurcharge 燃料サーチャージを作成する
+func (r *FuelSurchargeRepository) CreateSurcharge(s *model.FuelSurcharge) (int64, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO fuel_surcharges (tariff_version_id, vehicle_code, min_km, max_km, step_yen, amount_yen)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, s.TariffVersionID, s.VehicleCode, s.MinKm, s.MaxKm, s.StepYen, s.AmountYen)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetAllSurcharges 全燃料サーチャージを取得する
+func (r *FuelSurchargeRepository) GetAllSurcharges() ([]*model.FuelSurcharge, error) {
+	rows, err := r.db.Query(`
+		SELECT id, tariff_version_id, vehicle_code, min_km, max_km, step_yen, amount_yen
+		FROM fuel_surcharges ORDER BY tariff_version_id, vehicle_code, min_km
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var surcharges []*model.FuelSurcharge
+	for rows.Next() {
+		s := &model.FuelSurcharge{}
+		if err := rows.Scan(&s.ID, &s.TariffVersionID, &s.VehicleCode, &s.MinKm, &s.MaxKm, &s.StepYen, &s.AmountYen); err != nil {
+			return nil, err
+		}
+		surcharges = append(surcharges, s)
+	}
+	return surcharges, rows.Err()
+}
+
+// UpdateSurcharge 燃料サーチャージを更新する
+func (r *FuelSurchargeRepository) UpdateSurcharge(s *model.FuelSurcharge) error {
+	_, err := r.db.Exec(`
+		UPDATE fuel_surcharges
+		SET tariff_version_id = ?, vehicle_code = ?, min_km = ?, max_km = ?, step_yen = ?, amount_yen = ?
+		WHERE id = ?
+	`, s.TariffVersionID, s.VehicleCode, s.MinKm, s.MaxKm, s.StepYen, s.AmountYen, s.ID)
+	return err
+}
+
+// DeleteSurcharge 燃料サーチャージを削除する
+func (r *FuelSurchargeRepository) DeleteSurcharge(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM fuel_surcharges WHERE id = ?`, id)
+	return err
+}
+
+// GetSurcharge 運賃版・車格・距離で燃料サーチャージを取得する（FuelSurchargeGetterインターフェース実装）
+// 運賃版固有のデータがなければ版共通（tariff_version_id=0）のデータを返す
+func (r *FuelSurchargeRepository) GetSurcharge(version *model.TariffVersion, vehicleCode, distanceKm int) (*model.FuelSurcharge, error) {
+	s := &model.FuelSurcharge{}
+	err := r.db.QueryRow(`
+		SELECT id, tariff_version_id, vehicle_code, min_km, max_km, step_yen, amount_yen
+		FROM fuel_surcharges
+		WHERE tariff_version_id IN (?, 0) AND vehicle_code = ? AND min_km <= ? AND (max_km IS NULL OR max_km >= ?)
+		ORDER BY tariff_version_id DESC LIMIT 1
+	`, tariffVersionID(version), vehicleCode, distanceKm, distanceKm).Scan(&s.ID, &s.TariffVersionID, &s.VehicleCode, &s.MinKm, &s.MaxKm, &s.StepYen, &s.AmountYen)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// === FuelPrice テスト ===
+
+func TestFuelSurchargeRepository_UpsertFuelPrice(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewFuelSurchargeRepository(db.MainDB())
+
+	if err := repo.UpsertFuelPrice(&model.FuelPrice{YearMonth: "2026-09", PriceYen: 150.5, ReferencePriceYen: 120}); err != nil {
+		t.Fatalf("UpsertFuelPrice() error = %v", err)
+	}
+	// 同月は更新される
+	source := "資源エネルギー庁 石油製品価格調査"
+	if err := repo.UpsertFuelPrice(&model.FuelPrice{YearMonth: "2026-09", PriceYen: 152.0, ReferencePriceYen: 120, Source: &source}); err != nil {
+		t.Fatalf("UpsertFuelPrice() error = %v", err)
+	}
+
+	prices, err := repo.GetAllFuelPrices()
+	if err != nil {
+		t.Fatalf("GetAllFuelPrices() error = %v", err)
+	}
+	if len(prices) != 1 {
+		t.Fatalf("GetAllFuelPrices() returned %d items, want 1", len(prices))
+	}
+	if prices[0].PriceYen != 152.0 || prices[0].Source == nil || *prices[0].Source != source {
+		t.Errorf("GetAllFuelPrices()[0] = %+v", prices[0])
+	}
+}
+
+func TestFuelSurchargeRepository_GetFuelPrice(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewFuelSurchargeRepository(db.MainDB())
+
+	for _, p := range []*model.FuelPrice{
+		{YearMonth: "2026-07", PriceYen: 140, ReferencePriceYen: 120},
+		{YearMonth: "2026-09", PriceYen: 150, ReferencePriceYen: 120},
+	} {
+		if err := repo.UpsertFuelPrice(p); err != nil {
+			t.Fatalf("UpsertFuelPrice() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		date          time.Time
+		wantYearMonth string
+	}{
+		{"当月のデータ", time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local), "2026-09"},
+		{"当月なしは直近の過去月", time.Date(2026, 8, 1, 0, 0, 0, 0, time.Local), "2026-07"},
+		{"未来月は最新月", time.Date(2026, 12, 1, 0, 0, 0, 0, time.Local), "2026-09"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetFuelPrice(tt.date)
+			if err != nil {
+				t.Fatalf("GetFuelPrice() error = %v", err)
+			}
+			if got.YearMonth != tt.wantYearMonth {
+				t.Errorf("GetFuelPrice().YearMonth = %s, want %s", got.YearMonth, tt.wantYearMonth)
+			}
+		})
+	}
+
+	// 登録前の月は該当なし
+	if _, err := repo.GetFuelPrice(time.Date(2026, 6, 30, 0, 0, 0, 0, time.Local)); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetFuelPrice() error = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestFuelSurchargeRepository_DeleteFuelPrice(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewFuelSurchargeRepository(db.MainDB())
+
+	if err := repo.UpsertFuelPrice(&model.FuelPrice{YearMonth: "2026-09", PriceYen: 150, ReferencePriceYen: 120}); err != nil {
+		t.Fatalf("UpsertFuelPrice() error = %v", err)
+	}
+	if err := repo.DeleteFuelPrice("2026-09"); err != nil {
+		t.Fatalf("DeleteFuelPrice() error = %v", err)
+	}
+	prices, err := repo.GetAllFuelPrices()
+	if err != nil {
+		t.Fatalf("GetAllFuelPrices() error = %v", err)
+	}
+	if len(prices) != 0 {
+		t.Errorf("GetAllFuelPrices() returned %d items, want 0", len(prices))
+	}
+}
+
+// === FuelSurcharge テスト ===
+
+func TestFuelSurchargeRepository_GetSurcharge(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewFuelSurchargeRepository(db.MainDB())
+
+	max100 := 100
+	surcharges := []*model.FuelSurcharge{
+		{VehicleCode: 3, MinKm: 0, MaxKm: &max100, StepYen: 5, AmountYen: 130},
+		{VehicleCode: 3, MinKm: 101, StepYen: 5, AmountYen: 260},
+		{VehicleCode: 1, MinKm: 0, StepYen: 5, AmountYen: 60},
+		// 運賃版2は大型車・100km迄のみ版固有の加算額
+		{TariffVersionID: 2, VehicleCode: 3, MinKm: 0, MaxKm: &max100, StepYen: 5, AmountYen: 150},
+	}
+	for _, s := range surcharges {
+		if _, err := repo.CreateSurcharge(s); err != nil {
+			t.Fatalf("CreateSurcharge() error = %v", err)
+		}
+	}
+
+	version := &model.TariffVersion{ID: 2}
+	tests := []struct {
+		name        string
+		version     *model.TariffVersion
+		vehicleCode int
+		distanceKm  int
+		wantAmount  int
+	}{
+		{"版共通・100km迄", nil, 3, 100, 130},
+		{"版共通・上限なし", nil, 3, 101, 260},
+		{"別車格", nil, 1, 500, 60},
+		{"版固有", version, 3, 50, 150},
+		{"版固有なしは版共通", version, 3, 300, 260},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetSurcharge(tt.version, tt.vehicleCode, tt.distanceKm)
+			if err != nil {
+				t.Fatalf("GetSurcharge() error = %v", err)
+			}
+			if got.AmountYen != tt.wantAmount {
+				t.Errorf("GetSurcharge().AmountYen = %d, want %d", got.AmountYen, tt.wantAmount)
+			}
+		})
+	}
+
+	if _, err := repo.GetSurcharge(nil, 4, 100); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetSurcharge() error = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestFuelSurchargeRepository_UpdateAndDeleteSurcharge(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewFuelSurchargeRepository(db.MainDB())
+
+	id, err := repo.CreateSurcharge(&model.FuelSurcharge{VehicleCode: 2, MinKm: 0, StepYen: 5, AmountYen: 80})
+	if err != nil {
+		t.Fatalf("CreateSurcharge() error = %v", err)
+	}
+
+	if err := repo.UpdateSurcharge(&model.FuelSurcharge{ID: id, VehicleCode: 2, MinKm: 0, StepYen: 5, AmountYen: 90}); err != nil {
+		t.Fatalf("UpdateSurcharge() error = %v", err)
+	}
+	got, err := repo.GetSurcharge(nil, 2, 10)
+	if err != nil {
+		t.Fatalf("GetSurcharge() error = %v", err)
+	}
+	if got.AmountYen != 90 {
+		t.Errorf("AmountYen = %d, want 90", got.AmountYen)
+	}
+
+	if err := repo.DeleteSurcharge(id); err != nil {
+		t.Fatalf("DeleteSurcharge() error = %v", err)
+	}
+	all, err := repo.GetAllSurcharges()
+	if err != nil {
+		t.Fatalf("GetAllSurcharges() error = %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("GetAllSurcharges() returned %d items, want 0", len(all))
+	}
+}
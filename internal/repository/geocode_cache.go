@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// GeocodeCacheRepository ジオコーディングキャッシュのリポジトリ
+type GeocodeCacheRepository struct {
+	db *sql.DB
+}
+
+// NewGeocodeCacheRepository リポジトリを作成する
+func NewGeocodeCacheRepository(db *sql.DB) *GeocodeCacheRepository {
+	return &GeocodeCacheRepository{db: db}
+}
+
+// Get 住所でジオコーディングキャッシュを取得する
+func (r *GeocodeCacheRepository) Get(address string) (*model.GeocodeCache, error) {
+	cache := &model.GeocodeCache{}
+	err := r.db.QueryRow(`
+		SELECT address, prefecture, city, formatted_address, created_at
+		FROM geocode_cache WHERE address = ?
+	`, address).Scan(&cache.Address, &cache.Prefecture, &cache.City, &cache.FormattedAddress, &cache.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// Upsert ジオコーディングキャッシュを作成または更新する
+func (r *GeocodeCacheRepository) Upsert(cache *model.GeocodeCache) error {
+	_, err := r.db.Exec(`
+		INSERT INTO geocode_cache (address, prefecture, city, formatted_address, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(address) DO UPDATE SET
+			prefecture = excluded.prefecture,
+			city = excluded.city,
+			formatted_address = excluded.formatted_address,
+			created_at = excluded.created_at
+	`, cache.Address, cache.Prefecture, cache.City, cache.FormattedAddress, time.Now())
+	return err
+}
+
+// Delete ジオコーディングキャッシュを削除する
+func (r *GeocodeCacheRepository) Delete(address string) error {
+	_, err := r.db.Exec(`DELETE FROM geocode_cache WHERE address = ?`, address)
+	return err
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+func TestGeocodeCacheRepository_Upsert(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewGeocodeCacheRepository(db.CacheDB())
+
+	cache := &model.GeocodeCache{
+		Address:          "東京都千代田区丸の内1-1",
+		Prefecture:       "東京都",
+		City:             "千代田区",
+		FormattedAddress: "日本、〒100-0005 東京都千代田区丸の内１丁目１",
+	}
+	if err := repo.Upsert(cache); err != nil {
+		t.Fatalf("Upsert() 初回 error = %v", err)
+	}
+
+	got, err := repo.Get("東京都千代田区丸の内1-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Prefecture != "東京都" || got.City != "千代田区" || got.FormattedAddress != cache.FormattedAddress {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	// 同じ住所で更新
+	cache.City = "千代田区丸の内"
+	if err := repo.Upsert(cache); err != nil {
+		t.Fatalf("Upsert() 更新 error = %v", err)
+	}
+	got, _ = repo.Get("東京都千代田区丸の内1-1")
+	if got.City != "千代田区丸の内" {
+		t.Errorf("Upsert() 更新後 City = %s", got.City)
+	}
+}
+
+func TestGeocodeCacheRepository_Delete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewGeocodeCacheRepository(db.CacheDB())
+	repo.Upsert(&model.GeocodeCache{Address: "大阪府大阪市北区梅田3-1-1", Prefecture: "大阪府", City: "大阪市"})
+
+	if err := repo.Delete("大阪府大阪市北区梅田3-1-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Get("大阪府大阪市北区梅田3-1-1"); err == nil {
+		t.Error("Delete() 削除後もデータが取得できる")
+	}
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// JtaMirrorRepository トラ協データ（距離制運賃・付帯料金）のローカルミラーのリポジトリ
+type JtaMirrorRepository struct {
+	db *sql.DB
+}
+
+// NewJtaMirrorRepository リポジトリを作成する
+func NewJtaMirrorRepository(db *sql.DB) *JtaMirrorRepository {
+	return &JtaMirrorRepository{db: db}
+}
+
+// ReplaceFareRates 距離制運賃を全件入れ替え、同期状態を記録する
+func (r *JtaMirrorRepository) ReplaceFareRates(fares []model.JtaDistanceFare, status *model.JtaSyncStatus) error {
+	return r.replace(`DELETE FROM jta_fare_rates`, `
+		INSERT INTO jta_fare_rates (region_code, vehicle_code, upto_km, fare_yen)
+		VALUES (?, ?, ?, ?)
+	`, len(fares), func(stmt *sql.Stmt, i int) error {
+		f := fares[i]
+		_, err := stmt.Exec(f.RegionCode, f.VehicleCode, f.UptoKm, f.FareYen)
+		return err
+	}, status)
+}
+
+// ReplaceChargeData 付帯料金を全件入れ替え、同期状態を記録する
+func (r *JtaMirrorRepository) ReplaceChargeData(charges []model.JtaChargeData, status *model.JtaSyncStatus) error {
+	return r.replace(`DELETE FROM jta_charge_data`, `
+		INSERT INTO jta_charge_data (id_code, vehicle_code, time_code, charge_yen, per_1m_yen)
+		VALUES (?, ?, ?, ?, ?)
+	`, len(charges), func(stmt *sql.Stmt, i int) error {
+		c := charges[i]
+		_, err := stmt.Exec(c.IDCode, c.VehicleCode, c.TimeCode, c.ChargeYen, c.Per1MinYen)
+		return err
+	}, status)
+}
+
+// replace ミラーのテーブルを1トランザクションで入れ替える（途中で失敗した場合は同期前のデータを残す）
+func (r *JtaMirrorRepository) replace(deleteSQL, insertSQL string, n int, insert func(stmt *sql.Stmt, i int) error, status *model.JtaSyncStatus) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(deleteSQL); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(insertSQL)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i := 0; i < n; i++ {
+		if err := insert(stmt, i); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO jta_sync_status (table_name, row_count, checksum, synced_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(table_name) DO UPDATE SET
+			row_count = excluded.row_count,
+			checksum = excluded.checksum,
+			synced_at = excluded.synced_at
+	`, status.TableName, status.RowCount, status.Checksum, status.SyncedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetFareRate 運輸局・車格・距離上限（丸め後の距離）で距離制運賃を取得する
+func (r *JtaMirrorRepository) GetFareRate(regionCode, vehicleCode, uptoKm int) (*model.JtaDistanceFare, error) {
+	fare := &model.JtaDistanceFare{}
+	err := r.db.QueryRow(`
+		SELECT region_code, vehicle_code, upto_km, fare_yen
+		FROM jta_fare_rates
+		WHERE region_code = ? AND vehicle_code = ? AND upto_km = ?
+	`, regionCode, vehicleCode, uptoKm).Scan(&fare.RegionCode, &fare.VehicleCode, &fare.UptoKm, &fare.FareYen)
+	if err != nil {
+		return nil, err
+	}
+	return fare, nil
+}
+
+// GetChargeData 付帯料金を全件取得する
+func (r *JtaMirrorRepository) GetChargeData() ([]model.JtaChargeData, error) {
+	rows, err := r.db.Query(`
+		SELECT id_code, vehicle_code, time_code, charge_yen, per_1m_yen
+		FROM jta_charge_data ORDER BY vehicle_code, time_code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var charges []model.JtaChargeData
+	for rows.Next() {
+		var c model.JtaChargeData
+		var per1Min sql.NullInt64
+		if err := rows.Scan(&c.IDCode, &c.VehicleCode, &c.TimeCode, &c.ChargeYen, &per1Min); err != nil {
+			return nil, err
+		}
+		if per1Min.Valid {
+			v := int(per1Min.Int64)
+			c.Per1MinYen = &v
+		}
+		charges = append(charges, c)
+	}
+	return charges, rows.Err()
+}
+
+// GetSyncStatus 同期元テーブルの同期状態を取得する（未同期の場合は sql.ErrNoRows）
+func (r *JtaMirrorRepository) GetSyncStatus(tableName string) (*model.JtaSyncStatus, error) {
+	status := &model.JtaSyncStatus{}
+	err := r.db.QueryRow(`
+		SELECT table_name, row_count, checksum, synced_at
+		FROM jta_sync_status WHERE table_name = ?
+	`, tableName).Scan(&status.TableName, &status.RowCount, &status.Checksum, &status.SyncedAt)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
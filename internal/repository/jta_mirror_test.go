@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+func TestJtaMirrorRepository_FareRates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewJtaMirrorRepository(db.MainDB())
+
+	// 未同期
+	if _, err := repo.GetSyncStatus(model.JtaTableFareRates); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetSyncStatus() error = %v, want sql.ErrNoRows", err)
+	}
+
+	syncedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)
+	fares := []model.JtaDistanceFare{
+		{RegionCode: 3, VehicleCode: 3, UptoKm: 100, FareYen: 35000},
+		{RegionCode: 3, VehicleCode: 3, UptoKm: 110, FareYen: 37000},
+	}
+	if err := repo.ReplaceFareRates(fares, &model.JtaSyncStatus{
+		TableName: model.JtaTableFareRates, RowCount: 2, Checksum: "abc", SyncedAt: syncedAt,
+	}); err != nil {
+		t.Fatalf("ReplaceFareRates() error = %v", err)
+	}
+
+	fare, err := repo.GetFareRate(3, 3, 110)
+	if err != nil {
+		t.Fatalf("GetFareRate() error = %v", err)
+	}
+	if fare.FareYen != 37000 {
+		t.Errorf("FareYen = %d, want 37000", fare.FareYen)
+	}
+
+	// 再同期は全件入れ替え
+	if err := repo.ReplaceFareRates(fares[:1], &model.JtaSyncStatus{
+		TableName: model.JtaTableFareRates, RowCount: 1, Checksum: "def", SyncedAt: syncedAt.Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("ReplaceFareRates() error = %v", err)
+	}
+	if _, err := repo.GetFareRate(3, 3, 110); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetFareRate() error = %v, want sql.ErrNoRows", err)
+	}
+
+	status, err := repo.GetSyncStatus(model.JtaTableFareRates)
+	if err != nil {
+		t.Fatalf("GetSyncStatus() error = %v", err)
+	}
+	if status.RowCount != 1 || status.Checksum != "def" || !status.SyncedAt.Equal(syncedAt.Add(24*time.Hour)) {
+		t.Errorf("GetSyncStatus() = %+v", status)
+	}
+}
+
+func TestJtaMirrorRepository_ChargeData(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewJtaMirrorRepository(db.MainDB())
+	per1Min := 30
+	charges := []model.JtaChargeData{
+		{IDCode: 2, VehicleCode: 1, TimeCode: 60, ChargeYen: 1800, Per1MinYen: &per1Min},
+		{IDCode: 1, VehicleCode: 1, TimeCode: 30, ChargeYen: 900},
+	}
+	if err := repo.ReplaceChargeData(charges, &model.JtaSyncStatus{
+		TableName: model.JtaTableChargeData, RowCount: 2, Checksum: "abc", SyncedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("ReplaceChargeData() error = %v", err)
+	}
+
+	got, err := repo.GetChargeData()
+	if err != nil {
+		t.Fatalf("GetChargeData() error = %v", err)
+	}
+	if len(got) != 2 || got[0].TimeCode != 30 || got[0].Per1MinYen != nil {
+		t.Fatalf("GetChargeData() = %+v", got)
+	}
+	if got[1].Per1MinYen == nil || *got[1].Per1MinYen != 30 {
+		t.Errorf("Per1MinYen = %v, want 30", got[1].Per1MinYen)
+	}
+}
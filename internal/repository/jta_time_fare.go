@@ -21,9 +21,9 @@ func NewJtaTimeFareRepository(db *sql.DB) *JtaTimeFareRepository {
 // CreateBaseFare 基礎額を作成する
 func (r *JtaTimeFareRepository) CreateBaseFare(fare *model.JtaTimeBaseFare) (int64, error) {
 	result, err := r.db.Exec(`
-		INSERT INTO jta_time_base_fares (region_code, vehicle_code, hours, base_km, fare_yen)
-		VALUES (?, ?, ?, ?, ?)
-	`, fare.RegionCode, fare.VehicleCode, fare.Hours, fare.BaseKm, fare.FareYen)
+		INSERT INTO jta_time_base_fares (tariff_version_id, region_code, vehicle_code, hours, base_km, fare_yen)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, fare.TariffVersionID, fare.RegionCode, fare.VehicleCode, fare.Hours, fare.BaseKm, fare.FareYen)
 	if err != nil {
 		return 0, err
 	}
@@ -34,9 +34,9 @@ func (r *JtaTimeFareRepository) CreateBaseFare(fare *model.JtaTimeBaseFare) (int
 func (r *JtaTimeFareRepository) GetBaseFareByID(id int64) (*model.JtaTimeBaseFare, error) {
 	fare := &model.JtaTimeBaseFare{}
 	err := r.db.QueryRow(`
-		SELECT id, region_code, vehicle_code, hours, base_km, fare_yen
+		SELECT id, tariff_version_id, region_code, vehicle_code, hours, base_km, fare_yen
 		FROM jta_time_base_fares WHERE id = ?
-	`, id).Scan(&fare.ID, &fare.RegionCode, &fare.VehicleCode, &fare.Hours, &fare.BaseKm, &fare.FareYen)
+	`, id).Scan(&fare.ID, &fare.TariffVersionID, &fare.RegionCode, &fare.VehicleCode, &fare.Hours, &fare.BaseKm, &fare.FareYen)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +46,7 @@ func (r *JtaTimeFareRepository) GetBaseFareByID(id int64) (*model.JtaTimeBaseFar
 // GetAllBaseFares 全基礎額を取得する
 func (r *JtaTimeFareRepository) GetAllBaseFares() ([]*model.JtaTimeBaseFare, error) {
 	rows, err := r.db.Query(`
-		SELECT id, region_code, vehicle_code, hours, base_km, fare_yen
+		SELECT id, tariff_version_id, region_code, vehicle_code, hours, base_km, fare_yen
 		FROM jta_time_base_fares ORDER BY id
 	`)
 	if err != nil {
@@ -57,7 +57,7 @@ func (r *JtaTimeFareRepository) GetAllBaseFares() ([]*model.JtaTimeBaseFare, err
 	var fares []*model.JtaTimeBaseFare
 	for rows.Next() {
 		fare := &model.JtaTimeBaseFare{}
-		if err := rows.Scan(&fare.ID, &fare.RegionCode, &fare.VehicleCode, &fare.Hours, &fare.BaseKm, &fare.FareYen); err != nil {
+		if err := rows.Scan(&fare.ID, &fare.TariffVersionID, &fare.RegionCode, &fare.VehicleCode, &fare.Hours, &fare.BaseKm, &fare.FareYen); err != nil {
 			return nil, err
 		}
 		fares = append(fares, fare)
@@ -69,9 +69,9 @@ func (r *JtaTimeFareRepository) GetAllBaseFares() ([]*model.JtaTimeBaseFare, err
 func (r *JtaTimeFareRepository) UpdateBaseFare(fare *model.JtaTimeBaseFare) error {
 	_, err := r.db.Exec(`
 		UPDATE jta_time_base_fares
-		SET region_code = ?, vehicle_code = ?, hours = ?, base_km = ?, fare_yen = ?
+		SET tariff_version_id = ?, region_code = ?, vehicle_code = ?, hours = ?, base_km = ?, fare_yen = ?
 		WHERE id = ?
-	`, fare.RegionCode, fare.VehicleCode, fare.Hours, fare.BaseKm, fare.FareYen, fare.ID)
+	`, fare.TariffVersionID, fare.RegionCode, fare.VehicleCode, fare.Hours, fare.BaseKm, fare.FareYen, fare.ID)
 	return err
 }
 
@@ -86,9 +86,9 @@ func (r *JtaTimeFareRepository) DeleteBaseFare(id int64) error {
 // CreateSurcharge 加算額を作成する
 func (r *JtaTimeFareRepository) CreateSurcharge(surcharge *model.JtaTimeSurcharge) (int64, error) {
 	result, err := r.db.Exec(`
-		INSERT INTO jta_time_surcharges (region_code, vehicle_code, surcharge_type, fare_yen)
-		VALUES (?, ?, ?, ?)
-	`, surcharge.RegionCode, surcharge.VehicleCode, surcharge.SurchargeType, surcharge.FareYen)
+		INSERT INTO jta_time_surcharges (tariff_version_id, region_code, vehicle_code, surcharge_type, fare_yen)
+		VALUES (?, ?, ?, ?, ?)
+	`, surcharge.TariffVersionID, surcharge.RegionCode, surcharge.VehicleCode, surcharge.SurchargeType, surcharge.FareYen)
 	if err != nil {
 		return 0, err
 	}
@@ -99,9 +99,9 @@ func (r *JtaTimeFareRepository) CreateSurcharge(surcharge *model.JtaTimeSurcharg
 func (r *JtaTimeFareRepository) GetSurchargeByID(id int64) (*model.JtaTimeSurcharge, error) {
 	surcharge := &model.JtaTimeSurcharge{}
 	err := r.db.QueryRow(`
-		SELECT id, region_code, vehicle_code, surcharge_type, fare_yen
+		SELECT id, tariff_version_id, region_code, vehicle_code, surcharge_type, fare_yen
 		FROM jta_time_surcharges WHERE id = ?
-	`, id).Scan(&surcharge.ID, &surcharge.RegionCode, &surcharge.VehicleCode, &surcharge.SurchargeType, &surcharge.FareYen)
+	`, id).Scan(&surcharge.ID, &surcharge.TariffVersionID, &surcharge.RegionCode, &surcharge.VehicleCode, &surcharge.SurchargeType, &surcharge.FareYen)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +111,7 @@ func (r *JtaTimeFareRepository) GetSurchargeByID(id int64) (*model.JtaTimeSurcha
 // GetAllSurcharges 全加算額を取得する
 func (r *JtaTimeFareRepository) GetAllSurcharges() ([]*model.JtaTimeSurcharge, error) {
 	rows, err := r.db.Query(`
-		SELECT id, region_code, vehicle_code, surcharge_type, fare_yen
+		SELECT id, tariff_version_id, region_code, vehicle_code, surcharge_type, fare_yen
 		FROM jta_time_surcharges ORDER BY id
 	`)
 	if err != nil {
@@ -122,7 +122,7 @@ func (r *JtaTimeFareRepository) GetAllSurcharges() ([]*model.JtaTimeSurcharge, e
 	var surcharges []*model.JtaTimeSurcharge
 	for rows.Next() {
 		s := &model.JtaTimeSurcharge{}
-		if err := rows.Scan(&s.ID, &s.RegionCode, &s.VehicleCode, &s.SurchargeType, &s.FareYen); err != nil {
+		if err := rows.Scan(&s.ID, &s.TariffVersionID, &s.RegionCode, &s.VehicleCode, &s.SurchargeType, &s.FareYen); err != nil {
 			return nil, err
 		}
 		surcharges = append(surcharges, s)
@@ -134,9 +134,9 @@ func (r *JtaTimeFareRepository) GetAllSurcharges() ([]*model.JtaTimeSurcharge, e
 func (r *JtaTimeFareRepository) UpdateSurcharge(surcharge *model.JtaTimeSurcharge) error {
 	_, err := r.db.Exec(`
 		UPDATE jta_time_surcharges
-		SET region_code = ?, vehicle_code = ?, surcharge_type = ?, fare_yen = ?
+		SET tariff_version_id = ?, region_code = ?, vehicle_code = ?, surcharge_type = ?, fare_yen = ?
 		WHERE id = ?
-	`, surcharge.RegionCode, surcharge.VehicleCode, surcharge.SurchargeType, surcharge.FareYen, surcharge.ID)
+	`, surcharge.TariffVersionID, surcharge.RegionCode, surcharge.VehicleCode, surcharge.SurchargeType, surcharge.FareYen, surcharge.ID)
 	return err
 }
 
@@ -148,28 +148,32 @@ func (r *JtaTimeFareRepository) DeleteSurcharge(id int64) error {
 
 // === TimeFareGetter インターフェース実装 ===
 
-// GetBaseFare 運輸局・車格・時間制で基礎額を取得（TimeFareGetterインターフェース実装）
-func (r *JtaTimeFareRepository) GetBaseFare(regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
+// GetBaseFare 運賃版・運輸局・車格・時間制で基礎額を取得（TimeFareGetterインターフェース実装）
+// 運賃版固有のデータがなければ版共通（tariff_version_id=0）のデータを返す
+func (r *JtaTimeFareRepository) GetBaseFare(version *model.TariffVersion, regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
 	fare := &model.JtaTimeBaseFare{}
 	err := r.db.QueryRow(`
-		SELECT id, region_code, vehicle_code, hours, base_km, fare_yen
+		SELECT id, tariff_version_id, region_code, vehicle_code, hours, base_km, fare_yen
 		FROM jta_time_base_fares
-		WHERE region_code = ? AND vehicle_code = ? AND hours = ?
-	`, regionCode, vehicleCode, hours).Scan(&fare.ID, &fare.RegionCode, &fare.VehicleCode, &fare.Hours, &fare.BaseKm, &fare.FareYen)
+		WHERE tariff_version_id IN (?, 0) AND region_code = ? AND vehicle_code = ? AND hours = ?
+		ORDER BY tariff_version_id DESC LIMIT 1
+	`, tariffVersionID(version), regionCode, vehicleCode, hours).Scan(&fare.ID, &fare.TariffVersionID, &fare.RegionCode, &fare.VehicleCode, &fare.Hours, &fare.BaseKm, &fare.FareYen)
 	if err != nil {
 		return nil, err
 	}
 	return fare, nil
 }
 
-// GetSurcharge 運輸局・車格・種別で加算額を取得（TimeFareGetterインターフェース実装）
-func (r *JtaTimeFareRepository) GetSurcharge(regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
+// GetSurcharge 運賃版・運輸局・車格・種別で加算額を取得（TimeFareGetterインターフェース実装）
+// 運賃版固有のデータがなければ版共通（tariff_version_id=0）のデータを返す
+func (r *JtaTimeFareRepository) GetSurcharge(version *model.TariffVersion, regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
 	surcharge := &model.JtaTimeSurcharge{}
 	err := r.db.QueryRow(`
-		SELECT id, region_code, vehicle_code, surcharge_type, fare_yen
+		SELECT id, tariff_version_id, region_code, vehicle_code, surcharge_type, fare_yen
 		FROM jta_time_surcharges
-		WHERE region_code = ? AND vehicle_code = ? AND surcharge_type = ?
-	`, regionCode, vehicleCode, surchargeType).Scan(&surcharge.ID, &surcharge.RegionCode, &surcharge.VehicleCode, &surcharge.SurchargeType, &surcharge.FareYen)
+		WHERE tariff_version_id IN (?, 0) AND region_code = ? AND vehicle_code = ? AND surcharge_type = ?
+		ORDER BY tariff_version_id DESC LIMIT 1
+	`, tariffVersionID(version), regionCode, vehicleCode, surchargeType).Scan(&surcharge.ID, &surcharge.TariffVersionID, &surcharge.RegionCode, &surcharge.VehicleCode, &surcharge.SurchargeType, &surcharge.FareYen)
 	if err != nil {
 		return nil, err
 	}
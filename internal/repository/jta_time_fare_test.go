@@ -297,3 +297,64 @@ func TestJtaTimeFareRepository_DeleteSurcharge(t *testing.T) {
 		t.Error("DeleteSurcharge() 削除後もデータが取得できる")
 	}
 }
+
+// === TimeFareGetter テスト ===
+
+func TestJtaTimeFareRepository_GetBaseFare_TariffVersion(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewJtaTimeFareRepository(db.MainDB())
+
+	// 版共通と運賃版1のデータを作成
+	repo.CreateBaseFare(&model.JtaTimeBaseFare{RegionCode: 3, VehicleCode: 2, Hours: 8, BaseKm: 130, FareYen: 40000})
+	repo.CreateBaseFare(&model.JtaTimeBaseFare{TariffVersionID: 1, RegionCode: 3, VehicleCode: 2, Hours: 8, BaseKm: 130, FareYen: 45000})
+
+	tests := []struct {
+		name    string
+		version *model.TariffVersion
+		want    int
+	}{
+		{name: "版指定なし → 版共通", version: nil, want: 40000},
+		{name: "運賃版1 → 版固有", version: &model.TariffVersion{ID: 1}, want: 45000},
+		{name: "運賃版2（データなし） → 版共通", version: &model.TariffVersion{ID: 2}, want: 40000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetBaseFare(tt.version, 3, 2, 8)
+			if err != nil {
+				t.Fatalf("GetBaseFare() error = %v", err)
+			}
+			if got.FareYen != tt.want {
+				t.Errorf("GetBaseFare() FareYen = %d, want %d", got.FareYen, tt.want)
+			}
+		})
+	}
+}
+
+func TestJtaTimeFareRepository_GetSurcharge_TariffVersion(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewJtaTimeFareRepository(db.MainDB())
+
+	repo.CreateSurcharge(&model.JtaTimeSurcharge{RegionCode: 3, VehicleCode: 2, SurchargeType: "time", FareYen: 3000})
+	repo.CreateSurcharge(&model.JtaTimeSurcharge{TariffVersionID: 1, RegionCode: 3, VehicleCode: 2, SurchargeType: "time", FareYen: 3300})
+
+	got, err := repo.GetSurcharge(&model.TariffVersion{ID: 1}, 3, 2, "time")
+	if err != nil {
+		t.Fatalf("GetSurcharge() error = %v", err)
+	}
+	if got.FareYen != 3300 || got.TariffVersionID != 1 {
+		t.Errorf("GetSurcharge() = %+v, want FareYen=3300, TariffVersionID=1", got)
+	}
+
+	got, err = repo.GetSurcharge(nil, 3, 2, "time")
+	if err != nil {
+		t.Fatalf("GetSurcharge() error = %v", err)
+	}
+	if got.FareYen != 3000 {
+		t.Errorf("GetSurcharge() FareYen = %d, want 3000", got.FareYen)
+	}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// PostalCodeRepository 郵便番号データのリポジトリ
+type PostalCodeRepository struct {
+	db *sql.DB
+}
+
+// NewPostalCodeRepository リポジトリを作成する
+func NewPostalCodeRepository(db *sql.DB) *PostalCodeRepository {
+	return &PostalCodeRepository{db: db}
+}
+
+// ReplaceAll 郵便番号データを全件入れ替える（1トランザクション、同じ郵便番号・町域の重複は1件にまとめる）
+func (r *PostalCodeRepository) ReplaceAll(codes []*model.PostalCode) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM postal_codes`); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT OR IGNORE INTO postal_codes (postal_code, jis_code, prefecture, city, town)
+		VALUES (?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, c := range codes {
+		if _, err := stmt.Exec(c.PostalCode, c.JisCode, c.Prefecture, c.City, c.Town); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByPostalCode 郵便番号（7桁、ハイフンなし）で検索する
+func (r *PostalCodeRepository) FindByPostalCode(postalCode string) ([]*model.PostalCode, error) {
+	rows, err := r.db.Query(`
+		SELECT postal_code, jis_code, prefecture, city, town
+		FROM postal_codes WHERE postal_code = ? ORDER BY jis_code, town
+	`, postalCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []*model.PostalCode
+	for rows.Next() {
+		c := &model.PostalCode{}
+		if err := rows.Scan(&c.PostalCode, &c.JisCode, &c.Prefecture, &c.City, &c.Town); err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+	}
+	return codes, rows.Err()
+}
+
+// GetMunicipalities 市区町村の一覧を取得する（全国地方公共団体コード順）
+func (r *PostalCodeRepository) GetMunicipalities() ([]*model.Municipality, error) {
+	rows, err := r.db.Query(`
+		SELECT DISTINCT jis_code, prefecture, city
+		FROM postal_codes ORDER BY jis_code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var municipalities []*model.Municipality
+	for rows.Next() {
+		m := &model.Municipality{}
+		if err := rows.Scan(&m.JisCode, &m.Prefecture, &m.City); err != nil {
+			return nil, err
+		}
+		municipalities = append(municipalities, m)
+	}
+	return municipalities, rows.Err()
+}
+
+// Count 郵便番号データの件数を取得する
+func (r *PostalCodeRepository) Count() (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM postal_codes`).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+func TestPostalCodeRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewPostalCodeRepository(db.MainDB())
+
+	codes := []*model.PostalCode{
+		{PostalCode: "1000005", JisCode: "13101", Prefecture: "東京都", City: "千代田区", Town: "丸の内"},
+		{PostalCode: "5300001", JisCode: "27127", Prefecture: "大阪府", City: "大阪市北区", Town: "梅田"},
+		{PostalCode: "5300001", JisCode: "27127", Prefecture: "大阪府", City: "大阪市北区", Town: "梅田"}, // 重複
+		{PostalCode: "5300002", JisCode: "27127", Prefecture: "大阪府", City: "大阪市北区", Town: "曽根崎新地"},
+	}
+	if err := repo.ReplaceAll(codes); err != nil {
+		t.Fatalf("ReplaceAll() error = %v", err)
+	}
+
+	count, err := repo.Count()
+	if err != nil || count != 3 {
+		t.Errorf("Count() = %d, %v, want 3", count, err)
+	}
+
+	got, err := repo.FindByPostalCode("5300001")
+	if err != nil || len(got) != 1 || got[0].City != "大阪市北区" || got[0].Town != "梅田" {
+		t.Errorf("FindByPostalCode() = %+v, %v", got, err)
+	}
+
+	municipalities, err := repo.GetMunicipalities()
+	if err != nil || len(municipalities) != 2 {
+		t.Fatalf("GetMunicipalities() = %d件, %v, want 2", len(municipalities), err)
+	}
+	if municipalities[0].City != "千代田区" || municipalities[1].City != "大阪市北区" {
+		t.Errorf("GetMunicipalities() = %+v, %+v", municipalities[0], municipalities[1])
+	}
+
+	// 全件入れ替え
+	if err := repo.ReplaceAll(codes[:1]); err != nil {
+		t.Fatalf("ReplaceAll() 入れ替え error = %v", err)
+	}
+	if count, _ := repo.Count(); count != 1 {
+		t.Errorf("Count() 入れ替え後 = %d, want 1", count)
+	}
+}
@@ -20,19 +20,19 @@ func NewRouteCacheRepository(db *sql.DB) *RouteCacheRepository {
 // Create ルートキャッシュを作成する
 func (r *RouteCacheRepository) Create(cache *model.RouteCache) error {
 	_, err := r.db.Exec(`
-		INSERT INTO route_cache (origin, dest, distance_km, duration_min, created_at)
-		VALUES (?, ?, ?, ?, ?)
-	`, cache.Origin, cache.Dest, cache.DistanceKm, cache.DurationMin, time.Now())
+		INSERT INTO route_cache (origin, dest, options_key, distance_km, duration_min, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, cache.Origin, cache.Dest, cache.OptionsKey, cache.DistanceKm, cache.DurationMin, time.Now())
 	return err
 }
 
-// Get origin/destでルートキャッシュを取得する
-func (r *RouteCacheRepository) Get(origin, dest string) (*model.RouteCache, error) {
+// Get origin/dest/ルート検索の条件でルートキャッシュを取得する
+func (r *RouteCacheRepository) Get(origin, dest, optionsKey string) (*model.RouteCache, error) {
 	cache := &model.RouteCache{}
 	err := r.db.QueryRow(`
-		SELECT origin, dest, distance_km, duration_min, created_at
-		FROM route_cache WHERE origin = ? AND dest = ?
-	`, origin, dest).Scan(&cache.Origin, &cache.Dest, &cache.DistanceKm, &cache.DurationMin, &cache.CreatedAt)
+		SELECT origin, dest, options_key, distance_km, duration_min, created_at
+		FROM route_cache WHERE origin = ? AND dest = ? AND options_key = ?
+	`, origin, dest, optionsKey).Scan(&cache.Origin, &cache.Dest, &cache.OptionsKey, &cache.DistanceKm, &cache.DurationMin, &cache.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,7 @@ func (r *RouteCacheRepository) Get(origin, dest string) (*model.RouteCache, erro
 // GetAll 全ルートキャッシュを取得する
 func (r *RouteCacheRepository) GetAll() ([]*model.RouteCache, error) {
 	rows, err := r.db.Query(`
-		SELECT origin, dest, distance_km, duration_min, created_at
+		SELECT origin, dest, options_key, distance_km, duration_min, created_at
 		FROM route_cache ORDER BY created_at DESC
 	`)
 	if err != nil {
@@ -53,7 +53,7 @@ func (r *RouteCacheRepository) GetAll() ([]*model.RouteCache, error) {
 	var caches []*model.RouteCache
 	for rows.Next() {
 		c := &model.RouteCache{}
-		if err := rows.Scan(&c.Origin, &c.Dest, &c.DistanceKm, &c.DurationMin, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.Origin, &c.Dest, &c.OptionsKey, &c.DistanceKm, &c.DurationMin, &c.CreatedAt); err != nil {
 			return nil, err
 		}
 		caches = append(caches, c)
@@ -64,30 +64,129 @@ func (r *RouteCacheRepository) GetAll() ([]*model.RouteCache, error) {
 // Upsert ルートキャッシュを作成または更新する
 func (r *RouteCacheRepository) Upsert(cache *model.RouteCache) error {
 	_, err := r.db.Exec(`
-		INSERT INTO route_cache (origin, dest, distance_km, duration_min, created_at)
-		VALUES (?, ?, ?, ?, ?)
-		ON CONFLICT(origin, dest) DO UPDATE SET
+		INSERT INTO route_cache (origin, dest, options_key, distance_km, duration_min, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(origin, dest, options_key) DO UPDATE SET
 			distance_km = excluded.distance_km,
 			duration_min = excluded.duration_min,
 			created_at = excluded.created_at
-	`, cache.Origin, cache.Dest, cache.DistanceKm, cache.DurationMin, time.Now())
+	`, cache.Origin, cache.Dest, cache.OptionsKey, cache.DistanceKm, cache.DurationMin, time.Now())
 	return err
 }
 
 // Delete ルートキャッシュを削除する
-func (r *RouteCacheRepository) Delete(origin, dest string) error {
-	_, err := r.db.Exec(`DELETE FROM route_cache WHERE origin = ? AND dest = ?`, origin, dest)
+func (r *RouteCacheRepository) Delete(origin, dest, optionsKey string) error {
+	_, err := r.db.Exec(`DELETE FROM route_cache WHERE origin = ? AND dest = ? AND options_key = ?`, origin, dest, optionsKey)
 	return err
 }
 
 // Exists ルートキャッシュが存在するか確認する
-func (r *RouteCacheRepository) Exists(origin, dest string) bool {
+func (r *RouteCacheRepository) Exists(origin, dest, optionsKey string) bool {
 	var count int
 	err := r.db.QueryRow(`
-		SELECT COUNT(*) FROM route_cache WHERE origin = ? AND dest = ?
-	`, origin, dest).Scan(&count)
+		SELECT COUNT(*) FROM route_cache WHERE origin = ? AND dest = ? AND options_key = ?
+	`, origin, dest, optionsKey).Scan(&count)
 	if err != nil {
 		return false
 	}
 	return count > 0
 }
+
+// DeleteByOptionsKey ルート検索の条件のキーが match に該当するルートキャッシュを削除し、削除した行数を返す
+func (r *RouteCacheRepository) DeleteByOptionsKey(match func(optionsKey string) bool) (int, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT options_key FROM route_cache`)
+	if err != nil {
+		return 0, err
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if match(key) {
+			keys = append(keys, key)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		result, err := r.db.Exec(`DELETE FROM route_cache WHERE options_key = ?`, key)
+		if err != nil {
+			return deleted, err
+		}
+		n, _ := result.RowsAffected()
+		deleted += int(n)
+	}
+	return deleted, nil
+}
+
+// MergeNormalizedKeys 既存のルートキャッシュの origin/dest を normalize で表記を統一したキーに置き換える
+// 統一後に同じキーになる行は、作成日時が最も新しい行を残して1行にまとめる。置き換え・統合した行数を返す
+func (r *RouteCacheRepository) MergeNormalizedKeys(normalize func(string) string) (int, error) {
+	caches, err := r.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	// GetAll は作成日時の新しい順なので、各キーで最初に見つかった行を残す
+	type cacheKey struct{ origin, dest, optionsKey string }
+	keep := make(map[cacheKey]*model.RouteCache)
+	var order []cacheKey
+	var changed []*model.RouteCache
+	for _, c := range caches {
+		key := cacheKey{normalize(c.Origin), normalize(c.Dest), c.OptionsKey}
+		if key.origin == c.Origin && key.dest == c.Dest {
+			if _, ok := keep[key]; !ok {
+				keep[key] = c
+				order = append(order, key)
+			}
+			continue
+		}
+		changed = append(changed, c)
+		if _, ok := keep[key]; !ok {
+			keep[key] = c
+			order = append(order, key)
+		}
+	}
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for _, c := range changed {
+		if _, err := tx.Exec(`DELETE FROM route_cache WHERE origin = ? AND dest = ? AND options_key = ?`, c.Origin, c.Dest, c.OptionsKey); err != nil {
+			return 0, err
+		}
+	}
+	for _, key := range order {
+		c := keep[key]
+		if c.Origin == key.origin && c.Dest == key.dest {
+			continue
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO route_cache (origin, dest, options_key, distance_km, duration_min, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(origin, dest, options_key) DO UPDATE SET
+				distance_km = excluded.distance_km,
+				duration_min = excluded.duration_min,
+				created_at = excluded.created_at
+		`, key.origin, key.dest, key.optionsKey, c.DistanceKm, c.DurationMin, c.CreatedAt); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(changed), nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/y-suzuki/standard-truck-rate/internal/model"
@@ -41,7 +42,7 @@ func TestRouteCacheRepository_Get(t *testing.T) {
 	repo.Create(cache)
 
 	// 取得テスト
-	got, err := repo.Get("東京都新宿区", "神奈川県横浜市")
+	got, err := repo.Get("東京都新宿区", "神奈川県横浜市", "")
 	if err != nil {
 		t.Fatalf("Get() error = %v", err)
 	}
@@ -56,7 +57,7 @@ func TestRouteCacheRepository_Get_NotFound(t *testing.T) {
 
 	repo := NewRouteCacheRepository(db.CacheDB())
 
-	_, err := repo.Get("存在しない場所", "存在しない場所")
+	_, err := repo.Get("存在しない場所", "存在しない場所", "")
 	if err == nil {
 		t.Error("Get() 存在しないキーでエラーが返らない")
 	}
@@ -108,7 +109,7 @@ func TestRouteCacheRepository_Upsert(t *testing.T) {
 	}
 
 	// 確認
-	got, _ := repo.Get("東京", "横浜")
+	got, _ := repo.Get("東京", "横浜", "")
 	if got.DistanceKm != 35 || got.DurationMin != 50 {
 		t.Errorf("Upsert() 更新後 = %+v, want DistanceKm=35, DurationMin=50", got)
 	}
@@ -125,13 +126,13 @@ func TestRouteCacheRepository_Delete(t *testing.T) {
 	repo.Create(cache)
 
 	// 削除
-	err := repo.Delete("東京", "横浜")
+	err := repo.Delete("東京", "横浜", "")
 	if err != nil {
 		t.Fatalf("Delete() error = %v", err)
 	}
 
 	// 確認
-	_, err = repo.Get("東京", "横浜")
+	_, err = repo.Get("東京", "横浜", "")
 	if err == nil {
 		t.Error("Delete() 削除後もデータが取得できる")
 	}
@@ -144,14 +145,111 @@ func TestRouteCacheRepository_Exists(t *testing.T) {
 	repo := NewRouteCacheRepository(db.CacheDB())
 
 	// 存在しない場合
-	if repo.Exists("東京", "横浜") {
+	if repo.Exists("東京", "横浜", "") {
 		t.Error("Exists() 存在しないのにtrueを返した")
 	}
 
 	// データ作成後
 	repo.Create(&model.RouteCache{Origin: "東京", Dest: "横浜", DistanceKm: 30, DurationMin: 40})
 
-	if !repo.Exists("東京", "横浜") {
+	if !repo.Exists("東京", "横浜", "") {
 		t.Error("Exists() 存在するのにfalseを返した")
 	}
 }
+
+// TestRouteCacheRepository_OptionsKey ルート検索の条件ごとに別のキャッシュとして保存すること
+func TestRouteCacheRepository_OptionsKey(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRouteCacheRepository(db.CacheDB())
+
+	repo.Upsert(&model.RouteCache{Origin: "東京", Dest: "大阪", DistanceKm: 500, DurationMin: 360})
+	repo.Upsert(&model.RouteCache{Origin: "東京", Dest: "大阪", OptionsKey: "h3.8,w2.5,l12,t25", DistanceKm: 520, DurationMin: 420})
+
+	car, err := repo.Get("東京", "大阪", "")
+	if err != nil || car.DistanceKm != 500 {
+		t.Errorf("Get() 条件なし = %+v, %v", car, err)
+	}
+	truck, err := repo.Get("東京", "大阪", "h3.8,w2.5,l12,t25")
+	if err != nil || truck.DistanceKm != 520 || truck.OptionsKey != "h3.8,w2.5,l12,t25" {
+		t.Errorf("Get() 大型車 = %+v, %v", truck, err)
+	}
+	if repo.Exists("東京", "大阪", "avoid_tolls") {
+		t.Error("Exists() 別の条件でtrueを返した")
+	}
+}
+
+// TestRouteCacheRepository_MergeNormalizedKeys 表記ゆれのあるキャッシュを統一したキーの1行にまとめること
+func TestRouteCacheRepository_MergeNormalizedKeys(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRouteCacheRepository(db.CacheDB())
+
+	repo.Create(&model.RouteCache{Origin: "東京都千代田区丸の内1-1", Dest: "大阪", DistanceKm: 500, DurationMin: 360})
+	repo.Create(&model.RouteCache{Origin: "東京都千代田区丸の内１－１", Dest: "大阪", DistanceKm: 505, DurationMin: 365})
+	repo.Create(&model.RouteCache{Origin: "千代田区丸の内1丁目1", Dest: "大阪", DistanceKm: 510, DurationMin: 370})
+	repo.Create(&model.RouteCache{Origin: "千代田区丸の内1丁目1", Dest: "大阪", OptionsKey: "avoid_tolls", DistanceKm: 520, DurationMin: 420})
+	db.CacheDB().Exec(`UPDATE route_cache SET created_at = datetime('now', '+1 hour') WHERE distance_km = 505`)
+
+	normalize := func(s string) string {
+		switch s {
+		case "東京都千代田区丸の内１－１", "千代田区丸の内1丁目1":
+			return "東京都千代田区丸の内1-1"
+		}
+		return s
+	}
+	merged, err := repo.MergeNormalizedKeys(normalize)
+	if err != nil {
+		t.Fatalf("MergeNormalizedKeys() error = %v", err)
+	}
+	if merged != 3 {
+		t.Errorf("MergeNormalizedKeys() = %d, want 3", merged)
+	}
+
+	all, _ := repo.GetAll()
+	if len(all) != 2 {
+		t.Fatalf("GetAll() returned %d items, want 2", len(all))
+	}
+	got, err := repo.Get("東京都千代田区丸の内1-1", "大阪", "")
+	if err != nil || got.DistanceKm != 505 {
+		t.Errorf("Get() 条件なし = %+v, %v, want 最新の DistanceKm=505", got, err)
+	}
+	got, err = repo.Get("東京都千代田区丸の内1-1", "大阪", "avoid_tolls")
+	if err != nil || got.DistanceKm != 520 {
+		t.Errorf("Get() 有料道路回避 = %+v, %v", got, err)
+	}
+
+	// 2回目は変更なし
+	if merged, _ := repo.MergeNormalizedKeys(normalize); merged != 0 {
+		t.Errorf("MergeNormalizedKeys() 2回目 = %d, want 0", merged)
+	}
+}
+
+// TestRouteCacheRepository_DeleteByOptionsKey 条件のキーが該当するキャッシュのみ削除すること
+func TestRouteCacheRepository_DeleteByOptionsKey(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRouteCacheRepository(db.CacheDB())
+
+	repo.Create(&model.RouteCache{Origin: "東京", Dest: "大阪", DistanceKm: 500, DurationMin: 360})
+	repo.Create(&model.RouteCache{Origin: "東京", Dest: "大阪", OptionsKey: "avoid_tolls", DistanceKm: 510, DurationMin: 420})
+	repo.Create(&model.RouteCache{Origin: "東京", Dest: "大阪", OptionsKey: "h3.8,w2.5,l12,t25", DistanceKm: 520, DurationMin: 420})
+	repo.Create(&model.RouteCache{Origin: "東京", Dest: "横浜", OptionsKey: "h3.8,w2.5,l12,t25", DistanceKm: 30, DurationMin: 40})
+
+	deleted, err := repo.DeleteByOptionsKey(func(key string) bool { return strings.HasPrefix(key, "h") })
+	if err != nil {
+		t.Fatalf("DeleteByOptionsKey() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("DeleteByOptionsKey() = %d, want 2", deleted)
+	}
+	if !repo.Exists("東京", "大阪", "") || !repo.Exists("東京", "大阪", "avoid_tolls") {
+		t.Error("該当しないキャッシュが削除されました")
+	}
+	if repo.Exists("東京", "大阪", "h3.8,w2.5,l12,t25") {
+		t.Error("該当するキャッシュが削除されていません")
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// SurchargeItemRepository 割増項目（速達割増・付帯作業など）のリポジトリ
+type SurchargeItemRepository struct {
+	db *sql.DB
+}
+
+// NewSurchargeItemRepository リポジトリを作成する
+func NewSurchargeItemRepository(db *sql.DB) *SurchargeItemRepository {
+	return &SurchargeItemRepository{db: db}
+}
+
+// Upsert 割増項目を登録する（同じ項目コードのデータがあれば更新）
+func (r *SurchargeItemRepository) Upsert(item *model.SurchargeItem) error {
+	_, err := r.db.Exec(`
+		INSERT INTO surcharge_items (code, name, calc_type, rate_percent, amount_yen, vehicle_codes, sort_order)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(code) DO UPDATE SET
+			name = excluded.name,
+			calc_type = excluded.calc_type,
+			rate_percent = excluded.rate_percent,
+			amount_yen = excluded.amount_yen,
+			vehicle_codes = excluded.vehicle_codes,
+			sort_order = excluded.sort_order
+	`, item.Code, item.Name, item.CalcType, item.RatePercent, item.AmountYen, item.VehicleCodes, item.SortOrder)
+	return err
+}
+
+// GetSurchargeItem 項目コードで割増項目を取得する（SurchargeItemGetterインターフェース実装）
+func (r *SurchargeItemRepository) GetSurchargeItem(code string) (*model.SurchargeItem, error) {
+	item := &model.SurchargeItem{}
+	err := r.db.QueryRow(`
+		SELECT id, code, name, calc_type, rate_percent, amount_yen, vehicle_codes, sort_order
+		FROM surcharge_items WHERE code = ?
+	`, code).Scan(&item.ID, &item.Code, &item.Name, &item.CalcType, &item.RatePercent, &item.AmountYen, &item.VehicleCodes, &item.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetAll 全割増項目を表示順に取得する
+func (r *SurchargeItemRepository) GetAll() ([]*model.SurchargeItem, error) {
+	rows, err := r.db.Query(`
+		SELECT id, code, name, calc_type, rate_percent, amount_yen, vehicle_codes, sort_order
+		FROM surcharge_items
+		ORDER BY sort_order, code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*model.SurchargeItem
+	for rows.Next() {
+		item := &model.SurchargeItem{}
+		if err := rows.Scan(&item.ID, &item.Code, &item.Name, &item.CalcType, &item.RatePercent, &item.AmountYen, &item.VehicleCodes, &item.SortOrder); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Delete 割増項目を削除する
+func (r *SurchargeItemRepository) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM surcharge_items WHERE id = ?`, id)
+	return err
+}
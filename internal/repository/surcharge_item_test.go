@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+func TestSurchargeItemRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSurchargeItemRepository(db.MainDB())
+	for _, item := range []*model.SurchargeItem{
+		{Code: "hand_loading", Name: "手積み・手降ろし", CalcType: model.SurchargeCalcTypeFixed, AmountYen: 3000, VehicleCodes: "1,2,3,4", SortOrder: 2},
+		{Code: "express", Name: "速達割増", CalcType: model.SurchargeCalcTypeRate, RatePercent: 20, SortOrder: 1},
+	} {
+		if err := repo.Upsert(item); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+	}
+
+	// 同じ項目コードは更新
+	if err := repo.Upsert(&model.SurchargeItem{Code: "express", Name: "速達割増", CalcType: model.SurchargeCalcTypeRate, RatePercent: 10, SortOrder: 1}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	got, err := repo.GetSurchargeItem("express")
+	if err != nil {
+		t.Fatalf("GetSurchargeItem() error = %v", err)
+	}
+	if got.RatePercent != 10 || got.CalcType != model.SurchargeCalcTypeRate {
+		t.Errorf("GetSurchargeItem() = %+v", got)
+	}
+
+	// 表示順
+	all, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 2 || all[0].Code != "express" || all[1].VehicleCodes != "1,2,3,4" {
+		t.Errorf("GetAll() = %+v", all)
+	}
+
+	// 削除後は取得できない
+	if err := repo.Delete(got.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetSurchargeItem("express"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("削除後の GetSurchargeItem() error = %v, want sql.ErrNoRows", err)
+	}
+
+	// 計算方法の制約
+	if err := repo.Upsert(&model.SurchargeItem{Code: "invalid", Name: "不正", CalcType: "percent"}); err == nil {
+		t.Error("不正な計算方法で登録できてしまった")
+	}
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// TariffVersionRepository 運賃版のリポジトリ
+type TariffVersionRepository struct {
+	db *sql.DB
+}
+
+// NewTariffVersionRepository リポジトリを作成する
+func NewTariffVersionRepository(db *sql.DB) *TariffVersionRepository {
+	return &TariffVersionRepository{db: db}
+}
+
+// Create 運賃版を作成する
+func (r *TariffVersionRepository) Create(v *model.TariffVersion) (int64, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO tariff_versions (tariff_type, name, effective_from, effective_to, description)
+		VALUES (?, ?, ?, ?, ?)
+	`, v.TariffType, v.Name, v.EffectiveFrom.Format(model.TariffDateFormat), formatTariffDate(v.EffectiveTo), v.Description)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetByID IDで運賃版を取得する
+func (r *TariffVersionRepository) GetByID(id int64) (*model.TariffVersion, error) {
+	return scanTariffVersion(r.db.QueryRow(`
+		SELECT id, tariff_type, name, effective_from, effective_to, description
+		FROM tariff_versions WHERE id = ?
+	`, id))
+}
+
+// GetAll 全運賃版を取得する（種別・適用開始日順）
+func (r *TariffVersionRepository) GetAll() ([]*model.TariffVersion, error) {
+	rows, err := r.db.Query(`
+		SELECT id, tariff_type, name, effective_from, effective_to, description
+		FROM tariff_versions ORDER BY tariff_type, effective_from
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*model.TariffVersion
+	for rows.Next() {
+		v, err := scanTariffVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// GetEffective 指定日に適用される運賃版を取得する
+// 該当する版がなければ sql.ErrNoRows を返す
+func (r *TariffVersionRepository) GetEffective(tariffType string, date time.Time) (*model.TariffVersion, error) {
+	d := date.Format(model.TariffDateFormat)
+	return scanTariffVersion(r.db.QueryRow(`
+		SELECT id, tariff_type, name, effective_from, effective_to, description
+		FROM tariff_versions
+		WHERE tariff_type = ? AND effective_from <= ? AND (effective_to IS NULL OR effective_to >= ?)
+		ORDER BY effective_from DESC LIMIT 1
+	`, tariffType, d, d))
+}
+
+// Update 運賃版を更新する
+func (r *TariffVersionRepository) Update(v *model.TariffVersion) error {
+	_, err := r.db.Exec(`
+		UPDATE tariff_versions
+		SET tariff_type = ?, name = ?, effective_from = ?, effective_to = ?, description = ?
+		WHERE id = ?
+	`, v.TariffType, v.Name, v.EffectiveFrom.Format(model.TariffDateFormat), formatTariffDate(v.EffectiveTo), v.Description, v.ID)
+	return err
+}
+
+// Delete 運賃版を削除する
+func (r *TariffVersionRepository) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM tariff_versions WHERE id = ?`, id)
+	return err
+}
+
+// rowScanner *sql.Row と *sql.Rows の共通インターフェース
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTariffVersion 1行分の運賃版を読み取る
+func scanTariffVersion(row rowScanner) (*model.TariffVersion, error) {
+	v := &model.TariffVersion{}
+	var from string
+	var to sql.NullString
+	if err := row.Scan(&v.ID, &v.TariffType, &v.Name, &from, &to, &v.Description); err != nil {
+		return nil, err
+	}
+
+	effectiveFrom, err := time.Parse(model.TariffDateFormat, from)
+	if err != nil {
+		return nil, err
+	}
+	v.EffectiveFrom = effectiveFrom
+
+	if to.Valid {
+		effectiveTo, err := time.Parse(model.TariffDateFormat, to.String)
+		if err != nil {
+			return nil, err
+		}
+		v.EffectiveTo = &effectiveTo
+	}
+	return v, nil
+}
+
+// formatTariffDate 適用日をDB保存用の文字列に変換する（nilはNULL）
+func formatTariffDate(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(model.TariffDateFormat)
+}
+
+// tariffVersionID 運賃版のIDを返す（nilは版共通の0）
+func tariffVersionID(v *model.TariffVersion) int64 {
+	if v == nil {
+		return 0
+	}
+	return v.ID
+}
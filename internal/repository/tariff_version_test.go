@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+func tariffDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse(model.TariffDateFormat, s)
+	if err != nil {
+		t.Fatalf("日付の解析失敗: %v", err)
+	}
+	return d
+}
+
+func TestTariffVersionRepository_CreateAndGetByID(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewTariffVersionRepository(db.MainDB())
+
+	desc := "令和6年3月22日国土交通省告示"
+	id, err := repo.Create(&model.TariffVersion{
+		TariffType:    model.TariffTypeJTA,
+		Name:          "令和6年3月告示",
+		EffectiveFrom: tariffDate(t, "2024-03-22"),
+		Description:   &desc,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetByID(id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Name != "令和6年3月告示" || got.EffectiveFrom.Format(model.TariffDateFormat) != "2024-03-22" {
+		t.Errorf("GetByID() = %+v", got)
+	}
+	if got.EffectiveTo != nil {
+		t.Errorf("GetByID() EffectiveTo = %v, want nil", got.EffectiveTo)
+	}
+	if !got.IsCurrent() {
+		t.Error("IsCurrent() = false, want true")
+	}
+}
+
+func TestTariffVersionRepository_GetEffective(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewTariffVersionRepository(db.MainDB())
+
+	oldTo := tariffDate(t, "2024-03-21")
+	repo.Create(&model.TariffVersion{TariffType: model.TariffTypeJTA, Name: "旧版", EffectiveFrom: tariffDate(t, "2020-04-24"), EffectiveTo: &oldTo})
+	repo.Create(&model.TariffVersion{TariffType: model.TariffTypeJTA, Name: "新版", EffectiveFrom: tariffDate(t, "2024-03-22")})
+	repo.Create(&model.TariffVersion{TariffType: model.TariffTypeAkabou, Name: "赤帽版", EffectiveFrom: tariffDate(t, "2019-10-01")})
+
+	tests := []struct {
+		name       string
+		tariffType string
+		date       string
+		want       string
+	}{
+		{name: "旧版の期間内", tariffType: model.TariffTypeJTA, date: "2023-12-01", want: "旧版"},
+		{name: "旧版の終了日", tariffType: model.TariffTypeJTA, date: "2024-03-21", want: "旧版"},
+		{name: "新版の開始日", tariffType: model.TariffTypeJTA, date: "2024-03-22", want: "新版"},
+		{name: "新版（終了日なし）", tariffType: model.TariffTypeJTA, date: "2030-01-01", want: "新版"},
+		{name: "種別で区別", tariffType: model.TariffTypeAkabou, date: "2024-03-22", want: "赤帽版"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetEffective(tt.tariffType, tariffDate(t, tt.date))
+			if err != nil {
+				t.Fatalf("GetEffective() error = %v", err)
+			}
+			if got.Name != tt.want {
+				t.Errorf("GetEffective() Name = %s, want %s", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestTariffVersionRepository_GetEffective_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewTariffVersionRepository(db.MainDB())
+	repo.Create(&model.TariffVersion{TariffType: model.TariffTypeJTA, Name: "新版", EffectiveFrom: tariffDate(t, "2024-03-22")})
+
+	_, err := repo.GetEffective(model.TariffTypeJTA, tariffDate(t, "2020-01-01"))
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetEffective() error = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestTariffVersionRepository_UpdateAndDelete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewTariffVersionRepository(db.MainDB())
+
+	v := &model.TariffVersion{TariffType: model.TariffTypeJTA, Name: "新版", EffectiveFrom: tariffDate(t, "2024-03-22")}
+	id, _ := repo.Create(v)
+
+	// 適用終了日を設定
+	to := tariffDate(t, "2026-03-31")
+	v.ID = id
+	v.EffectiveTo = &to
+	if err := repo.Update(v); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, _ := repo.GetByID(id)
+	if got.EffectiveTo == nil || got.EffectiveTo.Format(model.TariffDateFormat) != "2026-03-31" {
+		t.Errorf("Update() EffectiveTo = %v, want 2026-03-31", got.EffectiveTo)
+	}
+
+	if err := repo.Delete(id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetByID(id); err == nil {
+		t.Error("Delete() 削除後もデータが取得できる")
+	}
+
+	all, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("GetAll() returned %d items, want 0", len(all))
+	}
+}
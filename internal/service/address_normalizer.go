@@ -0,0 +1,91 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// addressHyphens 住所の番地区切りとして使われるハイフン類（半角ハイフンに統一する）
+const addressHyphens = "‐‑‒–—―−﹣"
+
+// addressNotationRules 丁目・番地・番・号の表記を「1-2-3」形式に統一する置換（順に適用）
+var addressNotationRules = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`(\d+)丁目(\d)`), "$1-$2"},  // 1丁目2 → 1-2
+	{regexp.MustCompile(`(\d+)丁目`), "$1"},         // 1丁目 → 1
+	{regexp.MustCompile(`(\d+)番地(\d)`), "$1-$2"},  // 2番地3 → 2-3
+	{regexp.MustCompile(`(\d+)番地`), "$1"},         // 2番地 → 2
+	{regexp.MustCompile(`(\d+)番(\d+)号`), "$1-$2"}, // 2番3号 → 2-3
+	{regexp.MustCompile(`(\d+)番(\d)`), "$1-$2"},   // 2番3 → 2-3
+	{regexp.MustCompile(`(\d+-\d+)号`), "$1"},      // 1-2号 → 1-2
+	{regexp.MustCompile(`(\d+)番$`), "$1"},         // 末尾の2番 → 2
+}
+
+// kanjiChomePattern 漢数字の丁目（一丁目〜九十九丁目）
+var kanjiChomePattern = regexp.MustCompile(`([一二三四五六七八九十]+)丁目`)
+
+// NormalizeAddress ルートキャッシュ・ジオコーディングのキーに使う住所の表記を統一する
+// 全角英数字・記号を半角に、ハイフン類を「-」に、丁目・番地・番・号を「1-2-3」形式に揃え、空白を除く。
+// 文字・表記のみを揃え、都道府県は補わない（北区・中央区などは複数の市にあるため、判定は Gazetteer・Geocodingに任せる）
+func NormalizeAddress(address string) string {
+	s := width.Fold.String(address)
+
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case strings.ContainsRune(addressHyphens, r):
+			b.WriteRune('-')
+		case r == 'ー' && i > 0 && unicode.IsDigit(runes[i-1]):
+			// 数字の後の長音記号は番地の区切り（1ー2）
+			b.WriteRune('-')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	s = b.String()
+
+	s = kanjiChomePattern.ReplaceAllStringFunc(s, func(m string) string {
+		n := kanjiNumber(strings.TrimSuffix(m, "丁目"))
+		if n == 0 {
+			return m
+		}
+		return strconv.Itoa(n) + "丁目"
+	})
+	for _, rule := range addressNotationRules {
+		s = rule.pattern.ReplaceAllString(s, rule.replace)
+	}
+	return s
+}
+
+// kanjiNumber 漢数字（一〜九十九）を数値に変換する（変換できない場合は0）
+func kanjiNumber(s string) int {
+	digits := map[rune]int{'一': 1, '二': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+	tens, ones := 0, 0
+	seenTen := false
+	for _, r := range s {
+		if r == '十' {
+			if seenTen {
+				return 0
+			}
+			seenTen = true
+			tens = max(ones, 1)
+			ones = 0
+			continue
+		}
+		d, ok := digits[r]
+		if !ok || ones != 0 {
+			return 0
+		}
+		ones = d
+	}
+	return tens*10 + ones
+}
@@ -0,0 +1,34 @@
+package service
+
+import "testing"
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{"統一済み", "東京都千代田区丸の内1-1", "東京都千代田区丸の内1-1"},
+		{"全角数字・全角ハイフン", "東京都千代田区丸の内１－１", "東京都千代田区丸の内1-1"},
+		{"都道府県なし・丁目", "千代田区丸の内1丁目1", "千代田区丸の内1-1"},
+		{"空白", " 東京都 千代田区　丸の内 1-1 ", "東京都千代田区丸の内1-1"},
+		{"長音記号", "大阪府大阪市北区梅田3ー1ー1", "大阪府大阪市北区梅田3-1-1"},
+		{"ダッシュ類", "大阪府大阪市北区梅田3‐1−1", "大阪府大阪市北区梅田3-1-1"},
+		{"番地・号", "愛知県名古屋市中村区名駅1丁目1番4号", "愛知県名古屋市中村区名駅1-1-4"},
+		{"番地のみ", "北海道札幌市中央区北5条西2丁目5番地", "北海道札幌市中央区北5条西2-5"},
+		{"漢数字の丁目", "東京都千代田区丸の内一丁目1番1号", "東京都千代田区丸の内1-1-1"},
+		{"二桁の漢数字の丁目", "東京都港区芝浦十二丁目3", "東京都港区芝浦12-3"},
+		{"地名の番町は変えない", "東京都千代田区一番町", "東京都千代田区一番町"},
+		{"地名の長音は変えない", "東京都港区六本木ヒルズ", "東京都港区六本木ヒルズ"},
+		{"23区と同名の区にも都道府県を補わない", "北区梅田1-1", "北区梅田1-1"},
+		{"都道府県を補わない", "横浜市西区", "横浜市西区"},
+		{"空文字", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeAddress(tt.address); got != tt.want {
+				t.Errorf("NormalizeAddress(%q) = %q, want %q", tt.address, got, tt.want)
+			}
+		})
+	}
+}
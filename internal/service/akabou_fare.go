@@ -1,85 +1,97 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
 )
 
-// 赤帽運賃定数（税込）
+// 赤帽マスタの割増種別・付帯料金種別
 const (
-	// 距離制運賃
-	AkabouDistanceBaseFare    = 5500 // 基本料金（20km以内）
-	AkabouDistanceRate21to50  = 242  // 21-50km: 円/km
-	AkabouDistanceRate51to100 = 187  // 51-100km: 円/km
-	AkabouDistanceRate101to150 = 154 // 101-150km: 円/km
-	AkabouDistanceRate151plus = 132  // 151km以上: 円/km
-
-	// 時間制運賃
-	AkabouTimeBaseFare     = 6050 // 基本料金（2時間・20km以内）
-	AkabouTimeBaseMinutes  = 120  // 基本時間（分）
-	AkabouTimeOvertimeRate = 1375 // 超過料金（30分ごと）
-	AkabouTimeOvertimeUnit = 30   // 超過単位（分）
-
-	// 割増率
-	AkabouNightSurchargeRate   = 1.3 // 深夜割増: +30%
-	AkabouHolidaySurchargeRate = 1.2 // 休日割増: +20%
-
-	// 地区割増
-	AkabouAreaSurcharge = 440 // 東京23区・大阪市内
-
-	// 付帯料金
-	AkabouWorkFreeMinutes = 30   // 作業料金無料時間（分）
-	AkabouWorkUnitMinutes = 15   // 作業料金課金単位（分）
-	AkabouWorkFeePerUnit  = 550  // 作業料金（円/15分）
-	AkabouWaitFreeMinutes = 30   // 待機時間無料時間（分）
-	AkabouWaitUnitMinutes = 30   // 待機時間課金単位（分）
-	AkabouWaitFeePerUnit  = 1100 // 待機時間料（円/30分）
+	AkabouSurchargeTypeNight   = "night"   // 深夜・早朝割増
+	AkabouSurchargeTypeHoliday = "holiday" // 休日割増
+	AkabouFeeTypeWork          = "work"    // 作業料金
+	AkabouFeeTypeWaiting       = "waiting" // 待機時間料
 )
 
-// 地区割増対象エリア
-var akabouSurchargeAreas = map[string]bool{
-	"東京23区": true,
-	"大阪市内": true,
+// AkabouFareGetter 赤帽運賃マスタ取得インターフェース（テスト用にモック可能）
+// version が nil の場合は版指定なし（版共通データ）
+type AkabouFareGetter interface {
+	GetDistanceFares(version *model.TariffVersion) ([]*model.AkabouDistanceFare, error)
+	GetTimeFare(version *model.TariffVersion) (*model.AkabouTimeFare, error)
+	GetSurchargeByType(version *model.TariffVersion, surchargeType string) (*model.AkabouSurcharge, error)
+	GetAreaSurchargeByName(version *model.TariffVersion, areaName string) (*model.AkabouAreaSurcharge, error)
+	GetAdditionalFeeByType(version *model.TariffVersion, feeType string) (*model.AkabouAdditionalFee, error)
 }
 
 // AkabouFareService 赤帽運賃計算サービス
-type AkabouFareService struct{}
+// 料金はすべて赤帽マスタ（akabou_*テーブル）から取得する
+type AkabouFareService struct {
+	fareGetter AkabouFareGetter
+}
 
 // NewAkabouFareService 新しいAkabouFareServiceを作成
-func NewAkabouFareService() *AkabouFareService {
-	return &AkabouFareService{}
+func NewAkabouFareService(fareGetter AkabouFareGetter) *AkabouFareService {
+	return &AkabouFareService{
+		fareGetter: fareGetter,
+	}
 }
 
 // AkabouDistanceFareResult 赤帽距離制運賃計算結果
 type AkabouDistanceFareResult struct {
 	DistanceKm       int     // 距離 (km)
+	BaseKm           int     // 基本料金に含まれる距離 (km)
 	BaseFare         int     // 基本料金（円）
 	DistanceCharge   int     // 距離加算（円）
 	AreaSurcharge    int     // 地区割増（円）
 	NightSurcharge   int     // 深夜割増額（円）
 	HolidaySurcharge int     // 休日割増額（円）
-	TotalFare        int     // 合計運賃（円）
+	TotalFare        int     // 合計運賃（円、税込）
 	IsNight          bool    // 深夜適用
 	IsHoliday        bool    // 休日適用
 	Area             string  // 地区
 	NightRate        float64 // 深夜割増率
 	HolidayRate      float64 // 休日割増率
+
+	// 割増項目（速達割増・付帯作業など、TotalFareに含む）
+	SurchargeItems      []AppliedSurchargeItem // 項目ごとの割増額
+	SurchargeItemsTotal int                    // 割増項目の合計（円、税込）
+
+	// 適用運賃版（nilは版指定なし）
+	TariffVersion *model.TariffVersion
+
+	// 消費税（FareCalculatorServiceが設定、nilは未計算）
+	Tax *TaxAmount
 }
 
 // AkabouTimeFareResult 赤帽時間制運賃計算結果
 type AkabouTimeFareResult struct {
 	DurationMin      int     // 作業時間（分）
+	BaseMinutes      int     // 基本時間（分）
 	BaseFare         int     // 基本料金（円）
 	OvertimeCharge   int     // 超過料金（円）
 	OvertimeMin      int     // 超過時間（分）
 	AreaSurcharge    int     // 地区割増（円）
 	NightSurcharge   int     // 深夜割増額（円）
 	HolidaySurcharge int     // 休日割増額（円）
-	TotalFare        int     // 合計運賃（円）
+	TotalFare        int     // 合計運賃（円、税込）
 	IsNight          bool    // 深夜適用
 	IsHoliday        bool    // 休日適用
 	Area             string  // 地区
 	NightRate        float64 // 深夜割増率
 	HolidayRate      float64 // 休日割増率
+
+	// 割増項目（速達割増・付帯作業など、TotalFareに含む）
+	SurchargeItems      []AppliedSurchargeItem // 項目ごとの割増額
+	SurchargeItemsTotal int                    // 割増項目の合計（円、税込）
+
+	// 適用運賃版（nilは版指定なし）
+	TariffVersion *model.TariffVersion
+
+	// 消費税（FareCalculatorServiceが設定、nilは未計算）
+	Tax *TaxAmount
 }
 
 // CalculateDistanceFare 距離制運賃を計算
@@ -87,97 +99,152 @@ func (s *AkabouFareService) CalculateDistanceFare(
 	distanceKm int,
 	isNight, isHoliday bool,
 	area string,
+	opts ...FareOption,
 ) (*AkabouDistanceFareResult, error) {
+	o := newFareOptions(opts)
+
 	// 入力値検証
 	if distanceKm < 1 {
 		return nil, fmt.Errorf("無効な距離: %d（1km以上を指定）", distanceKm)
 	}
 
-	// 基本料金
-	baseFare := AkabouDistanceBaseFare
+	// 距離帯を取得
+	bands, err := s.fareGetter.GetDistanceFares(o.tariffVersion)
+	if err != nil {
+		return nil, fmt.Errorf("赤帽距離制運賃取得エラー: %w", err)
+	}
+	if len(bands) == 0 {
+		return nil, fmt.Errorf("赤帽距離制運賃が未登録です")
+	}
 
-	// 距離加算を計算
-	distanceCharge := calculateDistanceCharge(distanceKm)
+	// 基本料金・距離加算を計算
+	baseFare, baseKm, distanceCharge := calculateDistanceCharge(bands, distanceKm)
 
 	// 地区割増
-	areaSurcharge := 0
-	if akabouSurchargeAreas[area] {
-		areaSurcharge = AkabouAreaSurcharge
+	areaSurcharge, err := s.areaSurcharge(o.tariffVersion, area)
+	if err != nil {
+		return nil, err
 	}
 
 	// 小計（割増前）
 	subtotal := baseFare + distanceCharge + areaSurcharge
 
 	// 割増計算
-	nightRate := 1.0
-	holidayRate := 1.0
+	nightRate, holidayRate, err := s.surchargeRates(o.tariffVersion, isNight, isHoliday)
+	if err != nil {
+		return nil, err
+	}
 	nightSurcharge := 0
 	holidaySurcharge := 0
 	totalFare := subtotal
 
-	// 深夜割増（+30%）
+	// 深夜割増
 	if isNight {
-		nightRate = AkabouNightSurchargeRate
-		nightSurcharge = int(float64(subtotal)*(AkabouNightSurchargeRate-1.0))
-		totalFare = int(float64(subtotal) * AkabouNightSurchargeRate)
+		nightSurcharge = int(float64(subtotal) * (nightRate - 1.0))
+		totalFare = int(float64(subtotal) * nightRate)
 	}
 
-	// 休日割増（+20%）- 深夜割増後に適用
+	// 休日割増 - 深夜割増後に適用
 	if isHoliday {
-		holidayRate = AkabouHolidaySurchargeRate
-		holidaySurcharge = int(float64(totalFare) * (AkabouHolidaySurchargeRate - 1.0))
-		totalFare = int(float64(totalFare) * AkabouHolidaySurchargeRate)
+		holidaySurcharge = int(float64(totalFare) * (holidayRate - 1.0))
+		totalFare = int(float64(totalFare) * holidayRate)
 	}
 
+	// 割増項目（速達割増・付帯作業など）- 割増前の小計に対して計算し、深夜・休日割増の対象外
+	surchargeItems, surchargeItemsTotal := o.surchargeItems(subtotal)
+	totalFare += surchargeItemsTotal
+
 	return &AkabouDistanceFareResult{
-		DistanceKm:       distanceKm,
-		BaseFare:         baseFare,
-		DistanceCharge:   distanceCharge,
-		AreaSurcharge:    areaSurcharge,
-		NightSurcharge:   nightSurcharge,
-		HolidaySurcharge: holidaySurcharge,
-		TotalFare:        totalFare,
-		IsNight:          isNight,
-		IsHoliday:        isHoliday,
-		Area:             area,
-		NightRate:        nightRate,
-		HolidayRate:      holidayRate,
+		DistanceKm:          distanceKm,
+		BaseKm:              baseKm,
+		BaseFare:            baseFare,
+		DistanceCharge:      distanceCharge,
+		AreaSurcharge:       areaSurcharge,
+		NightSurcharge:      nightSurcharge,
+		HolidaySurcharge:    holidaySurcharge,
+		TotalFare:           totalFare,
+		IsNight:             isNight,
+		IsHoliday:           isHoliday,
+		Area:                area,
+		NightRate:           nightRate,
+		HolidayRate:         holidayRate,
+		SurchargeItems:      surchargeItems,
+		SurchargeItemsTotal: surchargeItemsTotal,
+		TariffVersion:       o.tariffVersion,
 	}, nil
 }
 
-// calculateDistanceCharge 距離加算を計算
-func calculateDistanceCharge(distanceKm int) int {
-	if distanceKm <= 20 {
-		return 0
+// calculateDistanceCharge 距離帯ごとの基本料金・距離加算を計算
+// 基本料金（base_fare）は距離を含む距離帯の定額を1つだけ適用する（基本料金の距離帯を超える距離は最も遠い基本料金の距離帯）。
+// 1kmあたり運賃（per_km_rate）を持つ距離帯は帯内の走行距離に応じて加算する（例: 21〜50km帯で30km走行 → 10km分）
+func calculateDistanceCharge(bands []*model.AkabouDistanceFare, distanceKm int) (baseFare, baseKm, distanceCharge int) {
+	var baseBand *model.AkabouDistanceFare
+	for _, band := range bands {
+		if band.BaseFare != nil && distanceKm >= band.MinKm && (baseBand == nil || band.MinKm > baseBand.MinKm) {
+			baseBand = band
+		}
+	}
+	if baseBand != nil {
+		baseFare = *baseBand.BaseFare
+		if baseBand.MaxKm != nil {
+			baseKm = *baseBand.MaxKm
+		}
 	}
 
-	charge := 0
-
-	// 21-50km区間
-	if distanceKm > 20 {
-		km := min(distanceKm, 50) - 20
-		charge += km * AkabouDistanceRate21to50
+	for _, band := range bands {
+		if band.PerKmRate == nil || distanceKm < band.MinKm {
+			continue
+		}
+		upper := distanceKm
+		if band.MaxKm != nil {
+			upper = min(distanceKm, *band.MaxKm)
+		}
+		km := upper - max(band.MinKm-1, 0)
+		if km > 0 {
+			distanceCharge += km * *band.PerKmRate
+		}
 	}
 
-	// 51-100km区間
-	if distanceKm > 50 {
-		km := min(distanceKm, 100) - 50
-		charge += km * AkabouDistanceRate51to100
+	return baseFare, baseKm, distanceCharge
+}
+
+// areaSurcharge 地区割増額を取得（マスタ未登録の地区は割増なし）
+func (s *AkabouFareService) areaSurcharge(version *model.TariffVersion, area string) (int, error) {
+	if area == "" {
+		return 0, nil
+	}
+	areaSurcharge, err := s.fareGetter.GetAreaSurchargeByName(version, area)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("赤帽地区割増取得エラー: %w", err)
 	}
+	return areaSurcharge.SurchargeAmount, nil
+}
+
+// surchargeRates 深夜・休日割増率を取得（適用しない場合は1.0）
+func (s *AkabouFareService) surchargeRates(version *model.TariffVersion, isNight, isHoliday bool) (nightRate, holidayRate float64, err error) {
+	nightRate = 1.0
+	holidayRate = 1.0
 
-	// 101-150km区間
-	if distanceKm > 100 {
-		km := min(distanceKm, 150) - 100
-		charge += km * AkabouDistanceRate101to150
+	if isNight {
+		surcharge, err := s.fareGetter.GetSurchargeByType(version, AkabouSurchargeTypeNight)
+		if err != nil {
+			return 0, 0, fmt.Errorf("赤帽深夜割増率取得エラー: %w", err)
+		}
+		nightRate = 1.0 + float64(surcharge.RatePercent)/100
 	}
 
-	// 151km以上区間
-	if distanceKm > 150 {
-		km := distanceKm - 150
-		charge += km * AkabouDistanceRate151plus
+	if isHoliday {
+		surcharge, err := s.fareGetter.GetSurchargeByType(version, AkabouSurchargeTypeHoliday)
+		if err != nil {
+			return 0, 0, fmt.Errorf("赤帽休日割増率取得エラー: %w", err)
+		}
+		holidayRate = 1.0 + float64(surcharge.RatePercent)/100
 	}
 
-	return charge
+	return nightRate, holidayRate, nil
 }
 
 // CalculateTimeFare 時間制運賃を計算
@@ -185,69 +252,87 @@ func (s *AkabouFareService) CalculateTimeFare(
 	durationMin int,
 	isNight, isHoliday bool,
 	area string,
+	opts ...FareOption,
 ) (*AkabouTimeFareResult, error) {
+	o := newFareOptions(opts)
+
 	// 入力値検証
 	if durationMin < 1 {
 		return nil, fmt.Errorf("無効な時間: %d（1分以上を指定）", durationMin)
 	}
 
-	// 基本料金
-	baseFare := AkabouTimeBaseFare
+	// 時間制運賃を取得
+	timeFare, err := s.fareGetter.GetTimeFare(o.tariffVersion)
+	if err != nil {
+		return nil, fmt.Errorf("赤帽時間制運賃取得エラー: %w", err)
+	}
+	if timeFare.OvertimeUnitMinutes <= 0 {
+		return nil, fmt.Errorf("赤帽時間制運賃の超過単位が不正です: %d分", timeFare.OvertimeUnitMinutes)
+	}
+	baseFare := timeFare.BaseFare
+	baseMinutes := timeFare.BaseHours * 60
 
-	// 超過時間を計算（30分単位で切り上げ）
+	// 超過時間を計算（超過単位で切り上げ）
 	overtimeMin := 0
 	overtimeCharge := 0
-	if durationMin > AkabouTimeBaseMinutes {
-		overtimeMin = durationMin - AkabouTimeBaseMinutes
-		// 30分単位で切り上げ
-		overtimeUnits := (overtimeMin + AkabouTimeOvertimeUnit - 1) / AkabouTimeOvertimeUnit
-		overtimeCharge = overtimeUnits * AkabouTimeOvertimeRate
+	if durationMin > baseMinutes {
+		overtimeMin = durationMin - baseMinutes
+		overtimeUnits := (overtimeMin + timeFare.OvertimeUnitMinutes - 1) / timeFare.OvertimeUnitMinutes
+		overtimeCharge = overtimeUnits * timeFare.OvertimeRate
 	}
 
 	// 地区割増
-	areaSurcharge := 0
-	if akabouSurchargeAreas[area] {
-		areaSurcharge = AkabouAreaSurcharge
+	areaSurcharge, err := s.areaSurcharge(o.tariffVersion, area)
+	if err != nil {
+		return nil, err
 	}
 
 	// 小計（割増前）
 	subtotal := baseFare + overtimeCharge + areaSurcharge
 
 	// 割増計算
-	nightRate := 1.0
-	holidayRate := 1.0
+	nightRate, holidayRate, err := s.surchargeRates(o.tariffVersion, isNight, isHoliday)
+	if err != nil {
+		return nil, err
+	}
 	nightSurcharge := 0
 	holidaySurcharge := 0
 	totalFare := subtotal
 
-	// 深夜割増（+30%）
+	// 深夜割増
 	if isNight {
-		nightRate = AkabouNightSurchargeRate
-		nightSurcharge = int(float64(subtotal) * (AkabouNightSurchargeRate - 1.0))
-		totalFare = int(float64(subtotal) * AkabouNightSurchargeRate)
+		nightSurcharge = int(float64(subtotal) * (nightRate - 1.0))
+		totalFare = int(float64(subtotal) * nightRate)
 	}
 
-	// 休日割増（+20%）- 深夜割増後に適用
+	// 休日割増 - 深夜割増後に適用
 	if isHoliday {
-		holidayRate = AkabouHolidaySurchargeRate
-		holidaySurcharge = int(float64(totalFare) * (AkabouHolidaySurchargeRate - 1.0))
-		totalFare = int(float64(totalFare) * AkabouHolidaySurchargeRate)
+		holidaySurcharge = int(float64(totalFare) * (holidayRate - 1.0))
+		totalFare = int(float64(totalFare) * holidayRate)
 	}
 
+	// 割増項目（速達割増・付帯作業など）- 割増前の小計に対して計算し、深夜・休日割増の対象外
+	surchargeItems, surchargeItemsTotal := o.surchargeItems(subtotal)
+	totalFare += surchargeItemsTotal
+
 	return &AkabouTimeFareResult{
-		DurationMin:      durationMin,
-		BaseFare:         baseFare,
-		OvertimeCharge:   overtimeCharge,
-		OvertimeMin:      overtimeMin,
-		AreaSurcharge:    areaSurcharge,
-		NightSurcharge:   nightSurcharge,
-		HolidaySurcharge: holidaySurcharge,
-		TotalFare:        totalFare,
-		IsNight:          isNight,
-		IsHoliday:        isHoliday,
-		Area:             area,
-		NightRate:        nightRate,
-		HolidayRate:      holidayRate,
+		DurationMin:         durationMin,
+		BaseMinutes:         baseMinutes,
+		BaseFare:            baseFare,
+		OvertimeCharge:      overtimeCharge,
+		OvertimeMin:         overtimeMin,
+		AreaSurcharge:       areaSurcharge,
+		NightSurcharge:      nightSurcharge,
+		HolidaySurcharge:    holidaySurcharge,
+		TotalFare:           totalFare,
+		IsNight:             isNight,
+		IsHoliday:           isHoliday,
+		Area:                area,
+		NightRate:           nightRate,
+		HolidayRate:         holidayRate,
+		SurchargeItems:      surchargeItems,
+		SurchargeItemsTotal: surchargeItemsTotal,
+		TariffVersion:       o.tariffVersion,
 	}, nil
 }
 
@@ -261,35 +346,60 @@ type AkabouAdditionalFeesResult struct {
 }
 
 // CalculateAdditionalFees 付帯料金を計算
-func (s *AkabouFareService) CalculateAdditionalFees(workMinutes, waitingMinutes int) *AkabouAdditionalFeesResult {
+func (s *AkabouFareService) CalculateAdditionalFees(workMinutes, waitingMinutes int, opts ...FareOption) (*AkabouAdditionalFeesResult, error) {
+	o := newFareOptions(opts)
+
 	result := &AkabouAdditionalFeesResult{
 		WorkMinutes:    workMinutes,
 		WaitingMinutes: waitingMinutes,
 	}
 
-	// 作業料金: 30分まで無料、超過15分ごとに550円（切り上げ）
-	if workMinutes > AkabouWorkFreeMinutes {
-		excessMin := workMinutes - AkabouWorkFreeMinutes
-		units := (excessMin + AkabouWorkUnitMinutes - 1) / AkabouWorkUnitMinutes
-		result.WorkFee = units * AkabouWorkFeePerUnit
+	// 作業料金: 無料時間を超過した分を単位時間ごとに課金（切り上げ）
+	workFee, err := s.additionalFee(o.tariffVersion, AkabouFeeTypeWork, workMinutes)
+	if err != nil {
+		return nil, err
 	}
+	result.WorkFee = workFee
 
-	// 待機時間料: 30分まで無料、超過30分ごとに1,100円（切り上げ）
-	if waitingMinutes > AkabouWaitFreeMinutes {
-		excessMin := waitingMinutes - AkabouWaitFreeMinutes
-		units := (excessMin + AkabouWaitUnitMinutes - 1) / AkabouWaitUnitMinutes
-		result.WaitingFee = units * AkabouWaitFeePerUnit
+	// 待機時間料: 無料時間を超過した分を単位時間ごとに課金（切り上げ）
+	waitingFee, err := s.additionalFee(o.tariffVersion, AkabouFeeTypeWaiting, waitingMinutes)
+	if err != nil {
+		return nil, err
 	}
+	result.WaitingFee = waitingFee
 
 	result.TotalFee = result.WorkFee + result.WaitingFee
-	return result
+	return result, nil
+}
+
+// additionalFee 付帯料金1種別分を計算
+func (s *AkabouFareService) additionalFee(version *model.TariffVersion, feeType string, minutes int) (int, error) {
+	if minutes <= 0 {
+		return 0, nil
+	}
+	fee, err := s.fareGetter.GetAdditionalFeeByType(version, feeType)
+	if err != nil {
+		return 0, fmt.Errorf("赤帽付帯料金取得エラー（%s）: %w", feeType, err)
+	}
+	if fee.UnitMinutes <= 0 {
+		return 0, fmt.Errorf("赤帽付帯料金の単位時間が不正です（%s）: %d分", feeType, fee.UnitMinutes)
+	}
+	if minutes <= fee.FreeMinutes {
+		return 0, nil
+	}
+	excessMin := minutes - fee.FreeMinutes
+	units := (excessMin + fee.UnitMinutes - 1) / fee.UnitMinutes
+	return units * fee.FeeAmount, nil
 }
 
 // Breakdown 計算根拠を文字列で返す（距離制）
 func (r *AkabouDistanceFareResult) Breakdown() string {
 	result := fmt.Sprintf("【赤帽運賃・距離制】\n")
+	if r.TariffVersion != nil {
+		result += fmt.Sprintf("  適用運賃版: %s\n", r.TariffVersion.Label())
+	}
 	result += fmt.Sprintf("  距離: %dkm\n", r.DistanceKm)
-	result += fmt.Sprintf("  基本料金: %d円\n", r.BaseFare)
+	result += fmt.Sprintf("  基本料金: %d円（%dkm迄）\n", r.BaseFare, r.BaseKm)
 
 	if r.DistanceCharge > 0 {
 		result += fmt.Sprintf("  距離加算: +%d円\n", r.DistanceCharge)
@@ -307,7 +417,12 @@ func (r *AkabouDistanceFareResult) Breakdown() string {
 		result += fmt.Sprintf("  休日割増: +%d円（%.0f%%増）\n", r.HolidaySurcharge, (r.HolidayRate-1.0)*100)
 	}
 
+	result += surchargeItemsBreakdown(r.SurchargeItems)
+
 	result += fmt.Sprintf("  合計運賃: %d円\n", r.TotalFare)
+	if r.Tax != nil {
+		result += r.Tax.Breakdown()
+	}
 
 	return result
 }
@@ -315,8 +430,11 @@ func (r *AkabouDistanceFareResult) Breakdown() string {
 // Breakdown 計算根拠を文字列で返す（時間制）
 func (r *AkabouTimeFareResult) Breakdown() string {
 	result := fmt.Sprintf("【赤帽運賃・時間制】\n")
+	if r.TariffVersion != nil {
+		result += fmt.Sprintf("  適用運賃版: %s\n", r.TariffVersion.Label())
+	}
 	result += fmt.Sprintf("  作業時間: %d分（%d時間%d分）\n", r.DurationMin, r.DurationMin/60, r.DurationMin%60)
-	result += fmt.Sprintf("  基本料金: %d円（2時間まで）\n", r.BaseFare)
+	result += fmt.Sprintf("  基本料金: %d円（%s）\n", r.BaseFare, formatAkabouBaseMinutes(r.BaseMinutes))
 
 	if r.OvertimeCharge > 0 {
 		result += fmt.Sprintf("  超過料金: +%d円（%d分超過）\n", r.OvertimeCharge, r.OvertimeMin)
@@ -334,7 +452,25 @@ func (r *AkabouTimeFareResult) Breakdown() string {
 		result += fmt.Sprintf("  休日割増: +%d円（%.0f%%増）\n", r.HolidaySurcharge, (r.HolidayRate-1.0)*100)
 	}
 
+	result += surchargeItemsBreakdown(r.SurchargeItems)
+
 	result += fmt.Sprintf("  合計運賃: %d円\n", r.TotalFare)
+	if r.Tax != nil {
+		result += r.Tax.Breakdown()
+	}
 
 	return result
 }
+
+// BaseTimeLabel 基本時間の表示用ラベル（例: 2時間まで）
+func (r *AkabouTimeFareResult) BaseTimeLabel() string {
+	return formatAkabouBaseMinutes(r.BaseMinutes)
+}
+
+// formatAkabouBaseMinutes 基本時間を表示用に整形（例: 2時間まで）
+func formatAkabouBaseMinutes(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d時間まで", minutes/60)
+	}
+	return fmt.Sprintf("%d分まで", minutes)
+}
@@ -1,15 +1,98 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
 )
 
+// mockAkabouFareGetter 赤帽運賃マスタのモック（cmd/seed と同じ料金表）
+type mockAkabouFareGetter struct {
+	distanceFares  []*model.AkabouDistanceFare // nilの場合は標準の距離帯
+	areaSurcharges map[string]int              // nilの場合は東京23区・大阪市内
+	timeFare       *model.AkabouTimeFare       // nilの場合は標準の時間制運賃
+	unitMinutes    int                         // 0以外の場合は付帯料金の単位時間をこの値にする
+	err            error
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func (m *mockAkabouFareGetter) GetDistanceFares(version *model.TariffVersion) ([]*model.AkabouDistanceFare, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.distanceFares != nil {
+		return m.distanceFares, nil
+	}
+	return []*model.AkabouDistanceFare{
+		{MinKm: 0, MaxKm: intPtr(20), BaseFare: intPtr(5500)},
+		{MinKm: 21, MaxKm: intPtr(50), PerKmRate: intPtr(242)},
+		{MinKm: 51, MaxKm: intPtr(100), PerKmRate: intPtr(187)},
+		{MinKm: 101, MaxKm: intPtr(150), PerKmRate: intPtr(154)},
+		{MinKm: 151, PerKmRate: intPtr(132)},
+	}, nil
+}
+
+func (m *mockAkabouFareGetter) GetTimeFare(version *model.TariffVersion) (*model.AkabouTimeFare, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.timeFare != nil {
+		return m.timeFare, nil
+	}
+	return &model.AkabouTimeFare{BaseHours: 2, BaseKm: 20, BaseFare: 6050, OvertimeRate: 1375, OvertimeUnitMinutes: 30}, nil
+}
+
+func (m *mockAkabouFareGetter) GetSurchargeByType(version *model.TariffVersion, surchargeType string) (*model.AkabouSurcharge, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	ratePercent := 20
+	if surchargeType == AkabouSurchargeTypeNight {
+		ratePercent = 30
+	}
+	return &model.AkabouSurcharge{SurchargeType: surchargeType, RatePercent: ratePercent}, nil
+}
+
+func (m *mockAkabouFareGetter) GetAreaSurchargeByName(version *model.TariffVersion, areaName string) (*model.AkabouAreaSurcharge, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	areas := m.areaSurcharges
+	if areas == nil {
+		areas = map[string]int{"東京23区": 440, "大阪市内": 440}
+	}
+	amount, ok := areas[areaName]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &model.AkabouAreaSurcharge{AreaName: areaName, SurchargeAmount: amount}, nil
+}
+
+func (m *mockAkabouFareGetter) GetAdditionalFeeByType(version *model.TariffVersion, feeType string) (*model.AkabouAdditionalFee, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	fee := &model.AkabouAdditionalFee{FeeType: feeType, FreeMinutes: 30, UnitMinutes: 30, FeeAmount: 1100}
+	if feeType == AkabouFeeTypeWork {
+		fee = &model.AkabouAdditionalFee{FeeType: feeType, FreeMinutes: 30, UnitMinutes: 15, FeeAmount: 550}
+	}
+	if m.unitMinutes != 0 {
+		fee.UnitMinutes = m.unitMinutes
+	}
+	return fee, nil
+}
+
 // =============================================================================
 // 距離制運賃テスト
 // =============================================================================
 
 func TestAkabouDistanceFare_Basic(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	tests := []struct {
 		name       string
@@ -22,23 +105,23 @@ func TestAkabouDistanceFare_Basic(t *testing.T) {
 		{"20km", 20, 5500},
 
 		// 21-50km区間（+242円/km）
-		{"21km", 21, 5500 + 242*1},       // 5,742
-		{"30km", 30, 5500 + 242*10},      // 7,920
-		{"50km", 50, 5500 + 242*30},      // 12,760
+		{"21km", 21, 5500 + 242*1},  // 5,742
+		{"30km", 30, 5500 + 242*10}, // 7,920
+		{"50km", 50, 5500 + 242*30}, // 12,760
 
 		// 51-100km区間（+187円/km）
-		{"51km", 51, 5500 + 242*30 + 187*1},   // 12,947
-		{"75km", 75, 5500 + 242*30 + 187*25},  // 17,435
+		{"51km", 51, 5500 + 242*30 + 187*1},    // 12,947
+		{"75km", 75, 5500 + 242*30 + 187*25},   // 17,435
 		{"100km", 100, 5500 + 242*30 + 187*50}, // 22,110
 
 		// 101-150km区間（+154円/km）
-		{"101km", 101, 5500 + 242*30 + 187*50 + 154*1},   // 22,264
-		{"125km", 125, 5500 + 242*30 + 187*50 + 154*25},  // 25,960
-		{"150km", 150, 5500 + 242*30 + 187*50 + 154*50},  // 29,810
+		{"101km", 101, 5500 + 242*30 + 187*50 + 154*1},  // 22,264
+		{"125km", 125, 5500 + 242*30 + 187*50 + 154*25}, // 25,960
+		{"150km", 150, 5500 + 242*30 + 187*50 + 154*50}, // 29,810
 
 		// 151km以上区間（+132円/km）
-		{"151km", 151, 5500 + 242*30 + 187*50 + 154*50 + 132*1},   // 29,942
-		{"200km", 200, 5500 + 242*30 + 187*50 + 154*50 + 132*50},  // 36,410
+		{"151km", 151, 5500 + 242*30 + 187*50 + 154*50 + 132*1},  // 29,942
+		{"200km", 200, 5500 + 242*30 + 187*50 + 154*50 + 132*50}, // 36,410
 	}
 
 	for _, tt := range tests {
@@ -55,7 +138,7 @@ func TestAkabouDistanceFare_Basic(t *testing.T) {
 }
 
 func TestAkabouDistanceFare_WithSurcharge(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	// 基本料金 5,500円 で検証
 	baseFare := 5500
@@ -93,7 +176,7 @@ func TestAkabouDistanceFare_WithSurcharge(t *testing.T) {
 }
 
 func TestAkabouDistanceFare_WithAreaSurcharge(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	baseFare := 5500
 	areaSurcharge := 440
@@ -123,7 +206,7 @@ func TestAkabouDistanceFare_WithAreaSurcharge(t *testing.T) {
 }
 
 func TestAkabouDistanceFare_Invalid(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	tests := []struct {
 		name       string
@@ -148,7 +231,7 @@ func TestAkabouDistanceFare_Invalid(t *testing.T) {
 // =============================================================================
 
 func TestAkabouTimeFare_Basic(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	// 基本料金: 6,050円（2時間・20km迄）
 	// 超過30分ごと: +1,375円
@@ -165,11 +248,11 @@ func TestAkabouTimeFare_Basic(t *testing.T) {
 		{"120分", 120, 6050},
 
 		// 超過（30分単位で切り上げ）
-		{"121分（+30分超過）", 121, 6050 + 1375},       // 7,425
-		{"150分（+30分超過）", 150, 6050 + 1375},       // 7,425
-		{"151分（+60分超過）", 151, 6050 + 1375*2},     // 8,800
-		{"180分（+60分超過）", 180, 6050 + 1375*2},     // 8,800
-		{"240分（+120分超過）", 240, 6050 + 1375*4},    // 11,550
+		{"121分（+30分超過）", 121, 6050 + 1375},    // 7,425
+		{"150分（+30分超過）", 150, 6050 + 1375},    // 7,425
+		{"151分（+60分超過）", 151, 6050 + 1375*2},  // 8,800
+		{"180分（+60分超過）", 180, 6050 + 1375*2},  // 8,800
+		{"240分（+120分超過）", 240, 6050 + 1375*4}, // 11,550
 	}
 
 	for _, tt := range tests {
@@ -186,7 +269,7 @@ func TestAkabouTimeFare_Basic(t *testing.T) {
 }
 
 func TestAkabouTimeFare_WithSurcharge(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	// 基本料金 6,050円 で検証
 	baseFare := 6050
@@ -224,7 +307,7 @@ func TestAkabouTimeFare_WithSurcharge(t *testing.T) {
 }
 
 func TestAkabouTimeFare_WithAreaSurcharge(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	baseFare := 6050
 	areaSurcharge := 440
@@ -253,7 +336,7 @@ func TestAkabouTimeFare_WithAreaSurcharge(t *testing.T) {
 }
 
 func TestAkabouTimeFare_Invalid(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	tests := []struct {
 		name        string
@@ -278,7 +361,7 @@ func TestAkabouTimeFare_Invalid(t *testing.T) {
 // =============================================================================
 
 func TestAkabouDistanceFareResult_Breakdown(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	result, err := service.CalculateDistanceFare(100, true, false, "東京23区")
 	if err != nil {
@@ -303,7 +386,7 @@ func TestAkabouDistanceFareResult_Breakdown(t *testing.T) {
 }
 
 func TestAkabouTimeFareResult_Breakdown(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	result, err := service.CalculateTimeFare(180, false, true, "大阪市内")
 	if err != nil {
@@ -329,7 +412,7 @@ func TestAkabouTimeFareResult_Breakdown(t *testing.T) {
 // =============================================================================
 
 func TestAkabouAdditionalFees_WorkFee(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	// 作業料金: 30分まで無料、超過15分ごとに550円
 	tests := []struct {
@@ -353,7 +436,10 @@ func TestAkabouAdditionalFees_WorkFee(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := service.CalculateAdditionalFees(tt.workMinutes, tt.waitingMinutes)
+			result, err := service.CalculateAdditionalFees(tt.workMinutes, tt.waitingMinutes)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
 			if result.WorkFee != tt.wantWorkFee {
 				t.Errorf("作業料金: 期待値 %d円, 実際 %d円", tt.wantWorkFee, result.WorkFee)
 			}
@@ -368,7 +454,7 @@ func TestAkabouAdditionalFees_WorkFee(t *testing.T) {
 }
 
 func TestAkabouAdditionalFees_WaitingFee(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	// 待機時間料: 30分まで無料、超過30分ごとに1,100円
 	tests := []struct {
@@ -392,7 +478,10 @@ func TestAkabouAdditionalFees_WaitingFee(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := service.CalculateAdditionalFees(tt.workMinutes, tt.waitingMinutes)
+			result, err := service.CalculateAdditionalFees(tt.workMinutes, tt.waitingMinutes)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
 			if result.WorkFee != tt.wantWorkFee {
 				t.Errorf("作業料金: 期待値 %d円, 実際 %d円", tt.wantWorkFee, result.WorkFee)
 			}
@@ -407,7 +496,7 @@ func TestAkabouAdditionalFees_WaitingFee(t *testing.T) {
 }
 
 func TestAkabouAdditionalFees_Combined(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	// 作業料金+待機時間料の組み合わせ
 	tests := []struct {
@@ -425,7 +514,10 @@ func TestAkabouAdditionalFees_Combined(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := service.CalculateAdditionalFees(tt.workMinutes, tt.waitingMinutes)
+			result, err := service.CalculateAdditionalFees(tt.workMinutes, tt.waitingMinutes)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
 			if result.WorkFee != tt.wantWorkFee {
 				t.Errorf("作業料金: 期待値 %d円, 実際 %d円", tt.wantWorkFee, result.WorkFee)
 			}
@@ -440,15 +532,149 @@ func TestAkabouAdditionalFees_Combined(t *testing.T) {
 }
 
 func TestAkabouAdditionalFees_NegativeInput(t *testing.T) {
-	service := NewAkabouFareService()
+	service := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	// 負の入力は0として扱う
-	result := service.CalculateAdditionalFees(-10, -20)
+	result, err := service.CalculateAdditionalFees(-10, -20)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
 	if result.WorkFee != 0 || result.WaitingFee != 0 || result.TotalFee != 0 {
 		t.Errorf("負の入力で料金が発生: work=%d, wait=%d, total=%d", result.WorkFee, result.WaitingFee, result.TotalFee)
 	}
 }
 
+// =============================================================================
+// マスタ駆動テスト
+// =============================================================================
+
+func TestAkabouDistanceFare_CustomBands(t *testing.T) {
+	// マスタの距離帯がそのまま計算に使われること（30km迄 6,000円、31km以上 +200円/km）
+	service := NewAkabouFareService(&mockAkabouFareGetter{
+		distanceFares: []*model.AkabouDistanceFare{
+			{MinKm: 0, MaxKm: intPtr(30), BaseFare: intPtr(6000)},
+			{MinKm: 31, PerKmRate: intPtr(200)},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		distanceKm int
+		wantFare   int
+	}{
+		{"30km", 30, 6000},
+		{"31km", 31, 6000 + 200*1},
+		{"100km", 100, 6000 + 200*70},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CalculateDistanceFare(tt.distanceKm, false, false, "")
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if result.TotalFare != tt.wantFare {
+				t.Errorf("距離 %dkm: 期待値 %d円, 実際 %d円", tt.distanceKm, tt.wantFare, result.TotalFare)
+			}
+			if result.BaseKm != 30 {
+				t.Errorf("BaseKm: 期待値 30km, 実際 %dkm", result.BaseKm)
+			}
+		})
+	}
+}
+
+func TestAkabouDistanceFare_BaseFareBands(t *testing.T) {
+	// 基本料金の距離帯が複数ある場合は距離を含む距離帯の基本料金のみ適用すること
+	// （10km迄 3,300円、20km迄 5,500円、21km以上 +242円/km）
+	service := NewAkabouFareService(&mockAkabouFareGetter{
+		distanceFares: []*model.AkabouDistanceFare{
+			{MinKm: 0, MaxKm: intPtr(10), BaseFare: intPtr(3300)},
+			{MinKm: 11, MaxKm: intPtr(20), BaseFare: intPtr(5500)},
+			{MinKm: 21, PerKmRate: intPtr(242)},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		distanceKm int
+		wantFare   int
+		wantBaseKm int
+	}{
+		{"10km", 10, 3300, 10},
+		{"11km", 11, 5500, 20},
+		{"20km", 20, 5500, 20},
+		{"30km", 30, 5500 + 242*10, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CalculateDistanceFare(tt.distanceKm, false, false, "")
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if result.TotalFare != tt.wantFare {
+				t.Errorf("距離 %dkm: 期待値 %d円, 実際 %d円", tt.distanceKm, tt.wantFare, result.TotalFare)
+			}
+			if result.BaseKm != tt.wantBaseKm {
+				t.Errorf("BaseKm: 期待値 %dkm, 実際 %dkm", tt.wantBaseKm, result.BaseKm)
+			}
+		})
+	}
+}
+
+func TestAkabouFare_InvalidUnitMinutes(t *testing.T) {
+	// マスタの超過単位・単位時間が0以下の場合はエラーにすること（0除算を起こさない）
+	service := NewAkabouFareService(&mockAkabouFareGetter{
+		timeFare: &model.AkabouTimeFare{BaseHours: 2, BaseKm: 20, BaseFare: 6050, OvertimeRate: 1375, OvertimeUnitMinutes: 0},
+	})
+	if _, err := service.CalculateTimeFare(180, false, false, ""); err == nil {
+		t.Error("時間制: 超過単位0分でエラーが発生しなかった")
+	}
+
+	service = NewAkabouFareService(&mockAkabouFareGetter{unitMinutes: -15})
+	if _, err := service.CalculateAdditionalFees(60, 0); err == nil {
+		t.Error("付帯料金: 単位時間が負でエラーが発生しなかった")
+	}
+}
+
+func TestAkabouDistanceFare_NewArea(t *testing.T) {
+	// マスタに追加した地区がコード変更なしで割増対象になること
+	service := NewAkabouFareService(&mockAkabouFareGetter{
+		areaSurcharges: map[string]int{"名古屋市内": 330},
+	})
+
+	result, err := service.CalculateDistanceFare(10, false, false, "名古屋市内")
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if result.AreaSurcharge != 330 {
+		t.Errorf("地区割増: 期待値 330円, 実際 %d円", result.AreaSurcharge)
+	}
+
+	// 未登録の地区は割増なし
+	result, err = service.CalculateDistanceFare(10, false, false, "東京23区")
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if result.AreaSurcharge != 0 {
+		t.Errorf("未登録地区の割増: 期待値 0円, 実際 %d円", result.AreaSurcharge)
+	}
+}
+
+func TestAkabouFare_GetterError(t *testing.T) {
+	service := NewAkabouFareService(&mockAkabouFareGetter{err: errors.New("DB接続エラー")})
+
+	if _, err := service.CalculateDistanceFare(10, false, false, ""); err == nil {
+		t.Error("距離制: エラーが期待されたが、発生しなかった")
+	}
+	if _, err := service.CalculateTimeFare(60, false, false, ""); err == nil {
+		t.Error("時間制: エラーが期待されたが、発生しなかった")
+	}
+	if _, err := service.CalculateAdditionalFees(60, 60); err == nil {
+		t.Error("付帯料金: エラーが期待されたが、発生しなかった")
+	}
+}
+
 // ヘルパー関数
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
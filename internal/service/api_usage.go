@@ -10,15 +10,16 @@ import (
 // ErrApiLimitExceeded API使用量制限超過エラー
 var ErrApiLimitExceeded = errors.New("API使用量制限を超過しました")
 
-// ApiUsageStore API使用量ストアインターフェース
+// ApiUsageStore API使用量ストアインターフェース（API種別ごとに月別の使用量を持つ）
 type ApiUsageStore interface {
-	GetOrCreateCurrent() (*model.ApiUsage, error)
-	IncrementCount(yearMonth string) error
+	GetOrCreateCurrent(apiType string) (*model.ApiUsage, error)
+	IncrementCount(yearMonth, apiType string) error
 }
 
 // ApiUsageService API使用量管理サービス
 type ApiUsageService struct {
-	store ApiUsageStore
+	store          ApiUsageStore
+	fallbackEngine string // 上限到達後に切り替えるセルフホストのルーティングエンジン（未設定の場合は空）
 }
 
 // NewApiUsageService 新しいAPI使用量管理サービスを作成
@@ -28,9 +29,19 @@ func NewApiUsageService(store ApiUsageStore) *ApiUsageService {
 	}
 }
 
-// CheckLimit 使用量が制限内かチェック
+// SetFallbackEngine 上限到達後に切り替えるセルフホストのルーティングエンジンを設定（画面で手入力モードにしないため）
+func (s *ApiUsageService) SetFallbackEngine(engine string) {
+	s.fallbackEngine = engine
+}
+
+// CheckLimit Routes APIの使用量が制限内かチェック
 func (s *ApiUsageService) CheckLimit() error {
-	usage, err := s.store.GetOrCreateCurrent()
+	return s.CheckLimitFor(model.ApiTypeRoutes)
+}
+
+// CheckLimitFor 指定したAPI種別の使用量が制限内かチェック
+func (s *ApiUsageService) CheckLimitFor(apiType string) error {
+	usage, err := s.store.GetOrCreateCurrent(apiType)
 	if err != nil {
 		return err
 	}
@@ -42,10 +53,15 @@ func (s *ApiUsageService) CheckLimit() error {
 	return nil
 }
 
-// IncrementAndCheck 使用量をインクリメントし、制限をチェック
+// IncrementAndCheck Routes APIの使用量をインクリメントし、制限をチェック
 func (s *ApiUsageService) IncrementAndCheck() error {
+	return s.IncrementAndCheckFor(model.ApiTypeRoutes)
+}
+
+// IncrementAndCheckFor 指定したAPI種別の使用量をインクリメントし、制限をチェック
+func (s *ApiUsageService) IncrementAndCheckFor(apiType string) error {
 	// まず制限をチェック
-	usage, err := s.store.GetOrCreateCurrent()
+	usage, err := s.store.GetOrCreateCurrent(apiType)
 	if err != nil {
 		return err
 	}
@@ -55,10 +71,10 @@ func (s *ApiUsageService) IncrementAndCheck() error {
 	}
 
 	// インクリメント
-	return s.store.IncrementCount(usage.YearMonth)
+	return s.store.IncrementCount(usage.YearMonth, apiType)
 }
 
-// UsageStats 使用量統計
+// UsageStats 使用量統計（トップレベルは Routes API、Geocoding API は Geocoding に入れる）
 type UsageStats struct {
 	YearMonth    string  `json:"year_month"`
 	RequestCount int     `json:"request_count"`
@@ -66,15 +82,32 @@ type UsageStats struct {
 	Remaining    int     `json:"remaining"`
 	UsagePercent float64 `json:"usage_percent"`
 	Level        string  `json:"level"` // "ok", "warning", "critical"
+	// 上限到達後に切り替えるセルフホストのルーティングエンジン（osrm / valhalla、未設定の場合は省略）
+	FallbackEngine string `json:"fallback_engine,omitempty"`
+	// Geocoding APIの使用量統計（Routes APIとは別の上限でカウント）
+	Geocoding *UsageStats `json:"geocoding,omitempty"`
 }
 
-// GetStats 現在の使用量統計を取得
+// GetStats 現在の使用量統計を取得（Routes API と Geocoding API）
 func (s *ApiUsageService) GetStats() (*UsageStats, error) {
-	usage, err := s.store.GetOrCreateCurrent()
+	usage, err := s.store.GetOrCreateCurrent(model.ApiTypeRoutes)
+	if err != nil {
+		return nil, err
+	}
+	geocoding, err := s.store.GetOrCreateCurrent(model.ApiTypeGeocoding)
 	if err != nil {
 		return nil, err
 	}
 
+	stats := newUsageStats(usage)
+	stats.FallbackEngine = s.fallbackEngine
+	stats.Geocoding = newUsageStats(geocoding)
+
+	return stats, nil
+}
+
+// newUsageStats API使用量から統計を作成
+func newUsageStats(usage *model.ApiUsage) *UsageStats {
 	stats := &UsageStats{
 		YearMonth:    usage.YearMonth,
 		RequestCount: usage.RequestCount,
@@ -90,7 +123,7 @@ func (s *ApiUsageService) GetStats() (*UsageStats, error) {
 		stats.Level = "warning"
 	}
 
-	return stats, nil
+	return stats
 }
 
 // CacheStats キャッシュ統計
@@ -11,6 +11,7 @@ import (
 // モック用のApiUsageRepository
 type mockApiUsageRepository struct {
 	usage        *model.ApiUsage
+	geocoding    *model.ApiUsage // Geocoding APIの使用量（nilの場合は usage を返す）
 	getErr       error
 	incrementErr error
 }
@@ -26,18 +27,22 @@ func newMockApiUsageRepository(requestCount, limitCount int) *mockApiUsageReposi
 	}
 }
 
-func (m *mockApiUsageRepository) GetOrCreateCurrent() (*model.ApiUsage, error) {
+func (m *mockApiUsageRepository) GetOrCreateCurrent(apiType string) (*model.ApiUsage, error) {
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
+	if apiType == model.ApiTypeGeocoding && m.geocoding != nil {
+		return m.geocoding, nil
+	}
 	return m.usage, nil
 }
 
-func (m *mockApiUsageRepository) IncrementCount(yearMonth string) error {
+func (m *mockApiUsageRepository) IncrementCount(yearMonth, apiType string) error {
 	if m.incrementErr != nil {
 		return m.incrementErr
 	}
-	m.usage.RequestCount++
+	usage, _ := m.GetOrCreateCurrent(apiType)
+	usage.RequestCount++
 	return nil
 }
 
@@ -145,6 +150,50 @@ func TestApiUsageService_GetStats_Warning(t *testing.T) {
 	}
 }
 
+// TestApiUsageService_GetStats_FallbackEngine 上限到達後の切り替え先を返す
+func TestApiUsageService_GetStats_FallbackEngine(t *testing.T) {
+	repo := newMockApiUsageRepository(9000, 9000)
+	service := NewApiUsageService(repo)
+	service.SetFallbackEngine(RouteSourceOSRM)
+
+	stats, err := service.GetStats()
+	if err != nil {
+		t.Fatalf("GetStats() エラーが発生: %v", err)
+	}
+
+	if stats.Remaining != 0 || stats.FallbackEngine != RouteSourceOSRM {
+		t.Errorf("GetStats() Remaining = %d, FallbackEngine = %s, want 0, osrm", stats.Remaining, stats.FallbackEngine)
+	}
+}
+
+// TestApiUsageService_Geocoding Geocoding APIの使用量を Routes API とは別の上限でカウントする
+func TestApiUsageService_Geocoding(t *testing.T) {
+	repo := newMockApiUsageRepository(100, 9000)
+	repo.geocoding = &model.ApiUsage{YearMonth: repo.usage.YearMonth, ApiType: model.ApiTypeGeocoding, RequestCount: 4999, LimitCount: 5000}
+	service := NewApiUsageService(repo)
+
+	if err := service.IncrementAndCheckFor(model.ApiTypeGeocoding); err != nil {
+		t.Fatalf("IncrementAndCheckFor(geocoding) エラーが発生: %v", err)
+	}
+	if err := service.CheckLimitFor(model.ApiTypeGeocoding); !errors.Is(err, ErrApiLimitExceeded) {
+		t.Errorf("CheckLimitFor(geocoding) = %v, want ErrApiLimitExceeded", err)
+	}
+	if err := service.CheckLimit(); err != nil {
+		t.Errorf("CheckLimit() Geocoding APIの上限で Routes API が止まった: %v", err)
+	}
+
+	stats, err := service.GetStats()
+	if err != nil {
+		t.Fatalf("GetStats() エラーが発生: %v", err)
+	}
+	if stats.RequestCount != 100 || stats.Geocoding == nil {
+		t.Fatalf("GetStats() = %+v", stats)
+	}
+	if stats.Geocoding.RequestCount != 5000 || stats.Geocoding.Remaining != 0 || stats.Geocoding.Level != "critical" {
+		t.Errorf("GetStats() Geocoding = %+v", stats.Geocoding)
+	}
+}
+
 // TestApiUsageService_GetStats_Critical 危険レベル
 func TestApiUsageService_GetStats_Critical(t *testing.T) {
 	repo := newMockApiUsageRepository(8600, 9000) // 約96%
@@ -0,0 +1,188 @@
+package service
+
+import "fmt"
+
+// 改善基準告示（トラック運転者の労働時間等の改善のための基準、2024年4月適用）の基準値
+const (
+	ComplianceMaxContinuousDrivingMinutes = 4 * 60  // 連続運転時間の上限（分、430休憩）
+	ComplianceBreakMinutes                = 30      // 連続運転4時間ごとに必要な休憩（分）
+	ComplianceDailyRestraintMinutes       = 13 * 60 // 1日の拘束時間（分、原則）
+	ComplianceMaxDailyRestraintMinutes    = 15 * 60 // 1日の拘束時間（分、延長時の上限、14時間超は週2回までが目安）
+	ComplianceDailyDrivingMinutes         = 9 * 60  // 1日の運転時間（分、2日平均）
+	ComplianceRestPeriodMinutes           = 11 * 60 // 休息期間（分、継続11時間以上を基本）
+	ComplianceTwoDriverRestraintMinutes   = 20 * 60 // 2人乗務の拘束時間の上限（分）
+)
+
+// CompliancePlan 改善基準告示を守れる運行方法
+type CompliancePlan string
+
+const (
+	CompliancePlanSingleDay CompliancePlan = "single_day" // 1人乗務・日帰り
+	CompliancePlanExtended  CompliancePlan = "extended"   // 1人乗務・拘束時間を延長して日帰り
+	CompliancePlanOvernight CompliancePlan = "overnight"  // 1人乗務・宿泊を伴う運行（2人乗務でも可）
+)
+
+// Label 表示用ラベル
+func (p CompliancePlan) Label() string {
+	switch p {
+	case CompliancePlanSingleDay:
+		return "1人乗務・日帰り"
+	case CompliancePlanExtended:
+		return "1人乗務・日帰り（拘束時間の延長）"
+	case CompliancePlanOvernight:
+		return "宿泊を伴う運行または2人乗務"
+	}
+	return string(p)
+}
+
+// ComplianceDay 1人乗務の1日分の運転・荷役・休憩
+type ComplianceDay struct {
+	Day              int // 日目（1始まり）
+	DrivingMinutes   int // 運転時間（分）
+	LoadingMinutes   int // 荷役時間（分）
+	BreakCount       int // 430休憩の回数
+	BreakMinutes     int // 休憩時間（分）
+	RestraintMinutes int // 拘束時間（運転＋荷役＋休憩、分）
+}
+
+// ComplianceResult 改善基準告示の確認結果
+type ComplianceResult struct {
+	DrivingMinutes int // 走行時間（分、ルート解決の値）
+	LoadingMinutes int // 荷役時間（分）
+
+	Plan CompliancePlan  // 改善基準告示を守れる運行方法
+	Days []ComplianceDay // 1人乗務の日ごとの運転・休憩（日帰りは1日）
+
+	BreakMinutes      int // 430休憩の合計（分）
+	RestPeriodMinutes int // 宿泊を伴う運行の休息期間の合計（分）
+	ExtraMinutes      int // 休憩・休息期間で増える時間（分、走行＋荷役に対する増分）
+
+	TwoDriverRestraintMinutes int  // 2人乗務の拘束時間（分、交代で運転するため休憩で止まらない）
+	TwoDriverFeasible         bool // 2人乗務なら日帰りできるか
+
+	Warnings []string // 違反・注意事項
+}
+
+// NeedsSecondDriverOrOvernight 1人乗務の日帰りでは基準を守れず、2人乗務または宿泊が必要か
+func (r *ComplianceResult) NeedsSecondDriverOrOvernight() bool {
+	return r.Plan == CompliancePlanOvernight
+}
+
+// DayCount 1人乗務の運行日数
+func (r *ComplianceResult) DayCount() int {
+	return len(r.Days)
+}
+
+// BreakCount 430休憩の合計回数
+func (r *ComplianceResult) BreakCount() int {
+	count := 0
+	for _, d := range r.Days {
+		count += d.BreakCount
+	}
+	return count
+}
+
+// RestraintMinutes 1人乗務の拘束時間の合計（分、休息期間を除く）
+func (r *ComplianceResult) RestraintMinutes() int {
+	total := 0
+	for _, d := range r.Days {
+		total += d.RestraintMinutes
+	}
+	return total
+}
+
+// ElapsedMinutes 1人乗務の出発から到着までの所要時間（分、休息期間を含む）
+func (r *ComplianceResult) ElapsedMinutes() int {
+	return r.RestraintMinutes() + r.RestPeriodMinutes
+}
+
+// ComplianceService 改善基準告示（2024年4月）に基づき、1人の運転者で運行できるかを確認するサービス
+type ComplianceService struct{}
+
+// NewComplianceService 新しいComplianceServiceを作成
+func NewComplianceService() *ComplianceService {
+	return &ComplianceService{}
+}
+
+// Check 走行時間・荷役時間から必要な休憩・運行方法・増える時間を求める
+// 休憩は連続運転4時間ごとに30分（荷役は休憩に含めない）、1日の拘束時間は原則13時間（延長15時間）、
+// 運転時間は1日9時間までとし、日帰りできない場合は日数で均等に分けた宿泊を伴う運行（休息期間11時間）を求める
+func (s *ComplianceService) Check(drivingMinutes, loadingMinutes int) *ComplianceResult {
+	r := &ComplianceResult{
+		DrivingMinutes:            drivingMinutes,
+		LoadingMinutes:            loadingMinutes,
+		TwoDriverRestraintMinutes: drivingMinutes + loadingMinutes,
+	}
+	r.TwoDriverFeasible = r.TwoDriverRestraintMinutes <= ComplianceTwoDriverRestraintMinutes
+
+	day := complianceDay(1, drivingMinutes, loadingMinutes)
+	switch {
+	case day.DrivingMinutes <= ComplianceDailyDrivingMinutes && day.RestraintMinutes <= ComplianceDailyRestraintMinutes:
+		r.Plan = CompliancePlanSingleDay
+		r.Days = []ComplianceDay{day}
+	case day.DrivingMinutes <= ComplianceDailyDrivingMinutes && day.RestraintMinutes <= ComplianceMaxDailyRestraintMinutes:
+		r.Plan = CompliancePlanExtended
+		r.Days = []ComplianceDay{day}
+		r.Warnings = append(r.Warnings, fmt.Sprintf("拘束時間%sが原則の13時間を超えます（延長は15時間まで、14時間超は週2回までが目安）",
+			formatHoursMinutes(day.RestraintMinutes)))
+	default:
+		r.Plan = CompliancePlanOvernight
+		r.Days = splitComplianceDays(drivingMinutes, loadingMinutes)
+		r.RestPeriodMinutes = (len(r.Days) - 1) * ComplianceRestPeriodMinutes
+		r.Warnings = append(r.Warnings, fmt.Sprintf("1人乗務の日帰りでは拘束時間%s（原則13時間・延長15時間まで）・運転時間%s（1日9時間まで）の基準を守れません。"+
+			"宿泊を伴う運行（%d日、休息期間11時間×%d回）または2人乗務が必要です",
+			formatHoursMinutes(day.RestraintMinutes), formatHoursMinutes(day.DrivingMinutes), len(r.Days), len(r.Days)-1))
+		if !r.TwoDriverFeasible {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("2人乗務でも拘束時間%sが20時間を超えるため、宿泊を伴う運行が必要です",
+				formatHoursMinutes(r.TwoDriverRestraintMinutes)))
+		}
+	}
+
+	for _, d := range r.Days {
+		r.BreakMinutes += d.BreakMinutes
+	}
+	r.ExtraMinutes = r.BreakMinutes + r.RestPeriodMinutes
+	return r
+}
+
+// complianceDay 1日分の運転・荷役から430休憩と拘束時間を求める
+func complianceDay(day, drivingMinutes, loadingMinutes int) ComplianceDay {
+	breaks := 0
+	if drivingMinutes > 0 {
+		// 運転4時間ごとに休憩（最後の運転の後は到着のため不要）
+		breaks = (drivingMinutes - 1) / ComplianceMaxContinuousDrivingMinutes
+	}
+	return ComplianceDay{
+		Day:              day,
+		DrivingMinutes:   drivingMinutes,
+		LoadingMinutes:   loadingMinutes,
+		BreakCount:       breaks,
+		BreakMinutes:     breaks * ComplianceBreakMinutes,
+		RestraintMinutes: drivingMinutes + loadingMinutes + breaks*ComplianceBreakMinutes,
+	}
+}
+
+// maxComplianceDays 宿泊を伴う運行の日数の上限（荷役時間が極端に長い場合の打ち切り）
+const maxComplianceDays = 31
+
+// splitComplianceDays 運転・荷役を日数で均等に分け、すべての日が拘束13時間・運転9時間に収まる最小の日数を求める
+func splitComplianceDays(drivingMinutes, loadingMinutes int) []ComplianceDay {
+	var days []ComplianceDay
+	for n := 2; n <= maxComplianceDays; n++ {
+		driving := splitEvenly(drivingMinutes, n)
+		loading := splitEvenly(loadingMinutes, n)
+		days = days[:0]
+		fits := true
+		for i := 0; i < n; i++ {
+			d := complianceDay(i+1, driving[i], loading[i])
+			if d.DrivingMinutes > ComplianceDailyDrivingMinutes || d.RestraintMinutes > ComplianceDailyRestraintMinutes {
+				fits = false
+			}
+			days = append(days, d)
+		}
+		if fits {
+			break
+		}
+	}
+	return days
+}
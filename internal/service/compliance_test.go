@@ -0,0 +1,216 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComplianceService_Check(t *testing.T) {
+	svc := NewComplianceService()
+
+	tests := []struct {
+		name             string
+		drivingMinutes   int
+		loadingMinutes   int
+		wantPlan         CompliancePlan
+		wantDays         []ComplianceDay
+		wantBreak        int
+		wantRest         int
+		wantExtra        int
+		wantTwoDriver    bool
+		wantWarnings     int
+		wantNeedsSupport bool
+	}{
+		{
+			// 運転4時間以内は休憩不要
+			name:           "日帰り・休憩なし",
+			drivingMinutes: 240,
+			loadingMinutes: 60,
+			wantPlan:       CompliancePlanSingleDay,
+			wantDays:       []ComplianceDay{{Day: 1, DrivingMinutes: 240, LoadingMinutes: 60, RestraintMinutes: 300}},
+			wantTwoDriver:  true,
+		},
+		{
+			// 運転8時間 → 4時間後に30分休憩、拘束9時間30分
+			name:           "日帰り・430休憩",
+			drivingMinutes: 480,
+			loadingMinutes: 60,
+			wantPlan:       CompliancePlanSingleDay,
+			wantDays:       []ComplianceDay{{Day: 1, DrivingMinutes: 480, LoadingMinutes: 60, BreakCount: 1, BreakMinutes: 30, RestraintMinutes: 570}},
+			wantBreak:      30,
+			wantExtra:      30,
+			wantTwoDriver:  true,
+		},
+		{
+			// 運転9時間・荷役4時間 → 休憩2回で拘束14時間、延長で日帰り（注意あり）
+			name:           "拘束時間の延長",
+			drivingMinutes: 540,
+			loadingMinutes: 240,
+			wantPlan:       CompliancePlanExtended,
+			wantDays:       []ComplianceDay{{Day: 1, DrivingMinutes: 540, LoadingMinutes: 240, BreakCount: 2, BreakMinutes: 60, RestraintMinutes: 840}},
+			wantBreak:      60,
+			wantExtra:      60,
+			wantTwoDriver:  true,
+			wantWarnings:   1,
+		},
+		{
+			// 運転10時間は1日9時間を超える → 2日（1日あたり運転5時間・休憩1回）、休息期間11時間
+			name:           "運転時間超過で宿泊",
+			drivingMinutes: 600,
+			loadingMinutes: 60,
+			wantPlan:       CompliancePlanOvernight,
+			wantDays: []ComplianceDay{
+				{Day: 1, DrivingMinutes: 300, LoadingMinutes: 30, BreakCount: 1, BreakMinutes: 30, RestraintMinutes: 360},
+				{Day: 2, DrivingMinutes: 300, LoadingMinutes: 30, BreakCount: 1, BreakMinutes: 30, RestraintMinutes: 360},
+			},
+			wantBreak:        60,
+			wantRest:         660,
+			wantExtra:        720,
+			wantTwoDriver:    true,
+			wantWarnings:     1,
+			wantNeedsSupport: true,
+		},
+		{
+			// 運転20時間・荷役2時間 → 2人乗務でも20時間超、2日では運転10時間/日のため3日
+			name:           "2人乗務でも日帰り不可",
+			drivingMinutes: 1200,
+			loadingMinutes: 120,
+			wantPlan:       CompliancePlanOvernight,
+			wantDays: []ComplianceDay{
+				{Day: 1, DrivingMinutes: 400, LoadingMinutes: 40, BreakCount: 1, BreakMinutes: 30, RestraintMinutes: 470},
+				{Day: 2, DrivingMinutes: 400, LoadingMinutes: 40, BreakCount: 1, BreakMinutes: 30, RestraintMinutes: 470},
+				{Day: 3, DrivingMinutes: 400, LoadingMinutes: 40, BreakCount: 1, BreakMinutes: 30, RestraintMinutes: 470},
+			},
+			wantBreak:        90,
+			wantRest:         1320,
+			wantExtra:        1410,
+			wantWarnings:     2,
+			wantNeedsSupport: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := svc.Check(tt.drivingMinutes, tt.loadingMinutes)
+			if r.Plan != tt.wantPlan {
+				t.Errorf("Plan = %q, want %q", r.Plan, tt.wantPlan)
+			}
+			if !reflect.DeepEqual(r.Days, tt.wantDays) {
+				t.Errorf("Days = %+v, want %+v", r.Days, tt.wantDays)
+			}
+			if r.BreakMinutes != tt.wantBreak {
+				t.Errorf("BreakMinutes = %d, want %d", r.BreakMinutes, tt.wantBreak)
+			}
+			if r.RestPeriodMinutes != tt.wantRest {
+				t.Errorf("RestPeriodMinutes = %d, want %d", r.RestPeriodMinutes, tt.wantRest)
+			}
+			if r.ExtraMinutes != tt.wantExtra {
+				t.Errorf("ExtraMinutes = %d, want %d", r.ExtraMinutes, tt.wantExtra)
+			}
+			if r.TwoDriverFeasible != tt.wantTwoDriver {
+				t.Errorf("TwoDriverFeasible = %v, want %v", r.TwoDriverFeasible, tt.wantTwoDriver)
+			}
+			if len(r.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d件", r.Warnings, tt.wantWarnings)
+			}
+			if r.NeedsSecondDriverOrOvernight() != tt.wantNeedsSupport {
+				t.Errorf("NeedsSecondDriverOrOvernight() = %v, want %v", r.NeedsSecondDriverOrOvernight(), tt.wantNeedsSupport)
+			}
+			if got := r.ElapsedMinutes(); got != tt.drivingMinutes+tt.loadingMinutes+tt.wantExtra {
+				t.Errorf("ElapsedMinutes() = %d, want %d", got, tt.drivingMinutes+tt.loadingMinutes+tt.wantExtra)
+			}
+		})
+	}
+}
+
+func TestTimeFareService_Calculate_WithCompliance(t *testing.T) {
+	// 関東・大型車（8時間制: 基礎額60,090円・基礎走行キロ130km、距離加算630円/10km、時間加算4,180円/時間）
+	svc := NewTimeFareService(&MockTimeFareGetter{})
+	compliance := NewComplianceService()
+
+	t.Run("休憩を作業時間に含める", func(t *testing.T) {
+		// 320km・走行8時間・荷役1時間 + 休憩30分 → 時間超過1時間30分（2時間）
+		result, err := svc.Calculate(3, 3, 320, 480, 60, false, false, false, WithCompliance(compliance.Check(480, 60)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.BreakMinutes != 30 || result.TotalMinutes != 570 {
+			t.Errorf("BreakMinutes = %d, TotalMinutes = %d, want 30, 570", result.BreakMinutes, result.TotalMinutes)
+		}
+		if want := 60090 + 19*630 + 2*4180; result.TotalFare != want {
+			t.Errorf("TotalFare = %d, want %d", result.TotalFare, want)
+		}
+		if !strings.Contains(result.Breakdown(), "休憩時間: 0時間30分") {
+			t.Errorf("Breakdown() に休憩時間がありません:\n%s", result.Breakdown())
+		}
+	})
+
+	t.Run("宿泊が必要な場合は運行日数を合わせる", func(t *testing.T) {
+		// 総作業11時間は拘束13時間以内だが運転10時間のため2日（1日あたり200km・走行5時間・荷役30分・休憩30分）
+		result, err := svc.Calculate(3, 3, 400, 600, 60, false, false, false, WithCompliance(compliance.Check(600, 60)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wantDay := TimeFareDay{DistanceKm: 200, DrivingMinutes: 300, LoadingMinutes: 30, BreakMinutes: 30, TotalMinutes: 360, AppliedHours: 8, BaseKm: 130, ExcessKm: 70, BaseFare: 60090, DistanceSurcharge: 7 * 630, SubTotal: 60090 + 7*630}
+		if len(result.Days) != 2 {
+			t.Fatalf("Days = %+v, want 2日", result.Days)
+		}
+		for i, d := range result.Days {
+			wantDay.Day = i + 1
+			if d != wantDay {
+				t.Errorf("Days[%d] = %+v, want %+v", i, d, wantDay)
+			}
+		}
+		if want := 2 * (60090 + 7*630); result.TotalFare != want {
+			t.Errorf("TotalFare = %d, want %d", result.TotalFare, want)
+		}
+	})
+}
+
+func TestFareCalculatorService_CalculateAll_Compliance(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+	req := &FareCalculationRequest{
+		RegionCode:            3,
+		VehicleCode:           3,
+		DistanceKm:            320,
+		DrivingMinutes:        480,
+		LoadingMinutes:        60,
+		IncludeComplianceTime: true,
+	}
+
+	// 確認サービス未設定で休憩を含める指定はエラー
+	if _, err := calculator.CalculateAll(req); err == nil {
+		t.Fatal("確認サービス未設定でエラーになりません")
+	}
+
+	calculator.SetComplianceService(NewComplianceService())
+	result, err := calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Compliance == nil || result.Compliance.BreakMinutes != 30 {
+		t.Fatalf("Compliance = %+v, want 休憩30分", result.Compliance)
+	}
+	if result.TimeFareResult.BreakMinutes != 30 {
+		t.Errorf("TimeFareResult.BreakMinutes = %d, want 30", result.TimeFareResult.BreakMinutes)
+	}
+
+	// 含めない場合も確認結果は返すが、時間制運賃には休憩を加えない
+	req.IncludeComplianceTime = false
+	result, err = calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Compliance == nil {
+		t.Error("Compliance = nil, want 確認結果")
+	}
+	if result.TimeFareResult.BreakMinutes != 0 || result.TimeFareResult.TotalMinutes != 540 {
+		t.Errorf("TimeFareResult.BreakMinutes = %d, TotalMinutes = %d, want 0, 540",
+			result.TimeFareResult.BreakMinutes, result.TimeFareResult.TotalMinutes)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// CustomerPricingGetter 荷主・契約条件取得インターフェース（テスト用にモック可能）
+// 該当する荷主がない場合は sql.ErrNoRows を返す
+type CustomerPricingGetter interface {
+	GetCustomer(id int64) (*model.Customer, error)
+	// GetPricingRules 荷主の契約条件を適用開始日順（同日は登録順）に返す
+	GetPricingRules(customerID int64) ([]*model.CustomerPricingRule, error)
+}
+
+// ContractFare 運賃タイプごとの標準運賃と契約運賃
+type ContractFare struct {
+	Type           string     // 運賃タイプ
+	Standard       *TaxAmount // 標準運賃
+	Contract       *TaxAmount // 契約運賃
+	Difference     int        // 契約運賃 − 標準運賃（円、税抜）
+	MinimumApplied bool       // 最低運賃を適用したか
+}
+
+// ContractFareResult 荷主の契約条件を適用した運賃
+type ContractFareResult struct {
+	Customer     *model.Customer
+	Rule         *model.CustomerPricingRule // 適用した契約条件（nilは該当なしで標準運賃のまま）
+	Fares        []ContractFare             // 契約運賃の安い順（同額は標準運賃のランキング順）
+	CheapestType string                     // 契約運賃の最安運賃タイプ
+	CheapestFare int                        // 契約運賃の最安額（円、税込）
+}
+
+// selectPricingRule 車格・運輸局・見積日に該当する契約条件を1つ選ぶ
+// 車格・運輸局を指定した条件を優先し、同じ絞り込み度合いの場合は後の条件（適用開始日が新しい条件）を使う
+func selectPricingRule(rules []*model.CustomerPricingRule, vehicleCode, regionCode int, date time.Time) *model.CustomerPricingRule {
+	var selected *model.CustomerPricingRule
+	for _, rule := range rules {
+		if !rule.Matches(vehicleCode, regionCode, date) {
+			continue
+		}
+		if selected == nil || rule.Specificity() >= selected.Specificity() {
+			selected = rule
+		}
+	}
+	return selected
+}
+
+// newContractFareResult 標準運賃のランキングに契約条件を適用する
+// 契約条件は税抜額に適用し、増減がない運賃は標準運賃の税額をそのまま使う
+func newContractFareResult(customer *model.Customer, rule *model.CustomerPricingRule, rankings []FareRanking, tax *TaxCalculator) *ContractFareResult {
+	result := &ContractFareResult{Customer: customer, Rule: rule}
+	for _, ranking := range rankings {
+		standard := &TaxAmount{
+			Exclusive: ranking.FareExclTax,
+			Tax:       ranking.Fare - ranking.FareExclTax,
+			Inclusive: ranking.Fare,
+		}
+		fare := ContractFare{Type: ranking.Type, Standard: standard, Contract: standard}
+		if rule != nil {
+			contract, minimumApplied := rule.Apply(standard.Exclusive)
+			if contract != standard.Exclusive {
+				fare.Contract = taxAmountPtr(tax.FromExclusive(contract))
+			}
+			fare.Difference = contract - standard.Exclusive
+			fare.MinimumApplied = minimumApplied
+		}
+		result.Fares = append(result.Fares, fare)
+	}
+
+	sort.SliceStable(result.Fares, func(i, j int) bool {
+		return result.Fares[i].Contract.Inclusive < result.Fares[j].Contract.Inclusive
+	})
+	if len(result.Fares) > 0 {
+		result.CheapestType = result.Fares[0].Type
+		result.CheapestFare = result.Fares[0].Contract.Inclusive
+	}
+	return result
+}
+
+// RuleLabel 適用した契約条件の表示用ラベル（例: 2025年度契約（10%引・最低運賃30000円））
+func (r *ContractFareResult) RuleLabel() string {
+	if r.Rule == nil {
+		return "該当する契約条件なし（標準運賃）"
+	}
+	if r.Rule.Name == "" {
+		return r.Rule.AdjustmentLabel()
+	}
+	return fmt.Sprintf("%s（%s）", r.Rule.Name, r.Rule.AdjustmentLabel())
+}
+
+// Breakdown 計算根拠を文字列で返す
+func (r *ContractFareResult) Breakdown() string {
+	result := fmt.Sprintf("【契約運賃】%s（%s）\n", r.Customer.Name, r.Customer.Code)
+	result += fmt.Sprintf("  契約条件: %s\n", r.RuleLabel())
+	for _, f := range r.Fares {
+		note := ""
+		if f.MinimumApplied {
+			note = "（最低運賃）"
+		}
+		result += fmt.Sprintf("  %s: 標準 %d円 → 契約 %d円%s（税込、税抜 %d円）\n",
+			f.Type, f.Standard.Inclusive, f.Contract.Inclusive, note, f.Contract.Exclusive)
+	}
+	return result
+}
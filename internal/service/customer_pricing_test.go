@@ -0,0 +1,169 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// mockCustomerPricingGetter テスト用の荷主・契約条件取得モック
+type mockCustomerPricingGetter struct {
+	customers map[int64]*model.Customer
+	rules     map[int64][]*model.CustomerPricingRule
+}
+
+func (m *mockCustomerPricingGetter) GetCustomer(id int64) (*model.Customer, error) {
+	if c, ok := m.customers[id]; ok {
+		return c, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockCustomerPricingGetter) GetPricingRules(customerID int64) ([]*model.CustomerPricingRule, error) {
+	return m.rules[customerID], nil
+}
+
+// TestSelectPricingRule 車格・運輸局・見積日による契約条件の選択テスト
+func TestSelectPricingRule(t *testing.T) {
+	from, to := "2025-04-01", "2026-03-31"
+	rules := []*model.CustomerPricingRule{
+		{ID: 1, AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: -5},
+		{ID: 2, AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: -10, ValidFrom: &from, ValidTo: &to},
+		{ID: 3, AdjustmentType: model.CustomerAdjustmentFixed, MinimumFareYen: 30000, VehicleCodes: "3,4"},
+		{ID: 4, AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: 5, RegionCodes: "6"},
+	}
+	date := func(s string) time.Time {
+		d, _ := time.Parse(model.TariffDateFormat, s)
+		return d
+	}
+
+	tests := []struct {
+		name        string
+		vehicleCode int
+		regionCode  int
+		date        time.Time
+		wantID      int64
+	}{
+		{"適用期間外は全体の条件", 2, 3, date("2024-12-01"), 1},
+		{"適用期間内は新しい条件", 2, 3, date("2025-10-01"), 2},
+		{"車格指定を優先", 3, 6, date("2025-10-01"), 3},
+		{"運輸局指定を優先", 2, 6, date("2025-10-01"), 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectPricingRule(rules, tt.vehicleCode, tt.regionCode, tt.date)
+			if got == nil || got.ID != tt.wantID {
+				t.Errorf("selectPricingRule() = %+v, want ID %d", got, tt.wantID)
+			}
+		})
+	}
+
+	if got := selectPricingRule(rules[2:3], 1, 3, date("2025-10-01")); got != nil {
+		t.Errorf("対象外の車格で契約条件が選ばれた: %+v", got)
+	}
+}
+
+// TestCustomerPricingRule_Apply 契約条件の適用テスト
+func TestCustomerPricingRule_Apply(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        model.CustomerPricingRule
+		fare        int
+		want        int
+		wantMinimum bool
+	}{
+		{"1割引", model.CustomerPricingRule{AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: -10}, 50000, 45000, false},
+		{"5%増", model.CustomerPricingRule{AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: 5}, 50000, 52500, false},
+		{"定額値引き", model.CustomerPricingRule{AdjustmentType: model.CustomerAdjustmentFixed, AdjustmentYen: -2000}, 50000, 48000, false},
+		{"最低運賃", model.CustomerPricingRule{AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: -10, MinimumFareYen: 30000}, 20000, 30000, true},
+		{"マイナスにはしない", model.CustomerPricingRule{AdjustmentType: model.CustomerAdjustmentFixed, AdjustmentYen: -5000}, 3000, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, minimum := tt.rule.Apply(tt.fare)
+			if got != tt.want || minimum != tt.wantMinimum {
+				t.Errorf("Apply(%d) = %d, %v, want %d, %v", tt.fare, got, minimum, tt.want, tt.wantMinimum)
+			}
+		})
+	}
+}
+
+// TestFareCalculatorService_CustomerPricing 荷主指定時の契約運賃テスト
+func TestFareCalculatorService_CustomerPricing(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+	req := &FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    3,
+		DistanceKm:     100,
+		DrivingMinutes: 120,
+		LoadingMinutes: 60,
+		CustomerID:     1,
+	}
+
+	// 取得元が未設定の場合はエラー
+	if _, err := calculator.CalculateAll(req); err == nil {
+		t.Error("取得元未設定で荷主を指定してもエラーが発生しなかった")
+	}
+
+	calculator.SetCustomerPricingGetter(&mockCustomerPricingGetter{
+		customers: map[int64]*model.Customer{
+			1: {ID: 1, Code: "C001", Name: "山田商事"},
+			2: {ID: 2, Code: "C002", Name: "東信物産"},
+		},
+		rules: map[int64][]*model.CustomerPricingRule{
+			1: {{ID: 1, Name: "2025年度契約", AdjustmentType: model.CustomerAdjustmentRate, AdjustmentPercent: -10}},
+		},
+	})
+
+	result, err := calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+
+	// 標準運賃のランキングは変わらない
+	if result.DistanceFareResult.TotalFare != 35000 {
+		t.Errorf("DistanceFareResult.TotalFare = %d, want 35000", result.DistanceFareResult.TotalFare)
+	}
+
+	contract := result.Contract
+	if contract == nil || contract.Rule == nil || len(contract.Fares) != 2 {
+		t.Fatalf("Contract = %+v", contract)
+	}
+	for _, f := range contract.Fares {
+		want := f.Standard.Exclusive * 90 / 100
+		if f.Contract.Exclusive != want || f.Difference != want-f.Standard.Exclusive {
+			t.Errorf("%s: Contract.Exclusive = %d, Difference = %d, want %d", f.Type, f.Contract.Exclusive, f.Difference, want)
+		}
+	}
+	if contract.CheapestFare != contract.Fares[0].Contract.Inclusive {
+		t.Errorf("CheapestFare = %d, want %d", contract.CheapestFare, contract.Fares[0].Contract.Inclusive)
+	}
+	breakdown := result.Breakdown()
+	if !containsString(breakdown, "【契約運賃】山田商事（C001）") || !containsString(breakdown, "2025年度契約（10%引）") {
+		t.Errorf("Breakdown に契約運賃が含まれていない:\n%s", breakdown)
+	}
+
+	// 契約条件がない荷主は標準運賃のまま
+	noRule := *req
+	noRule.CustomerID = 2
+	result, err = calculator.CalculateAll(&noRule)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if result.Contract.Rule != nil || result.Contract.Fares[0].Contract != result.Contract.Fares[0].Standard {
+		t.Errorf("契約条件なしの荷主に標準運賃以外が設定された: %+v", result.Contract)
+	}
+
+	// 未登録の荷主はエラー
+	unknown := *req
+	unknown.CustomerID = 99
+	if _, err := calculator.CalculateAll(&unknown); err == nil {
+		t.Error("未登録の荷主でエラーが発生しなかった")
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// 深夜割増の時間帯（22:00-05:00）
+const (
+	NightStartHour = 22 // 深夜開始（時）
+	NightEndHour   = 5  // 深夜終了（時）
+)
+
+// DepartureDateTimeFormat 出発日時の入力形式（datetime-local）
+const DepartureDateTimeFormat = "2006-01-02T15:04"
+
+// HolidayChecker 祝日判定インターフェース（日曜日以外の休日を判定する）
+// 休日であれば休日を、休日でなければnilを返す
+type HolidayChecker interface {
+	LookupHoliday(date time.Time) (*Holiday, error)
+}
+
+// DepartureAnalysis 出発日時からの運行時間帯の分析結果
+type DepartureAnalysis struct {
+	DepartureAt  time.Time // 出発日時
+	ArrivalAt    time.Time // 到着日時（出発日時 + 走行時間 + 荷役時間）
+	TotalMinutes int       // 運行時間（分）
+	NightMinutes int       // 深夜時間帯（22:00-05:00）の時間（分）
+	Holidays     []Holiday // 運行にかかる日曜日・祝日
+}
+
+// AnalyzeDeparture 出発日時と運行時間から深夜時間・休日を判定する
+// holidaysがnilの場合は日曜日のみを休日とする
+func AnalyzeDeparture(departureAt time.Time, totalMinutes int, holidays HolidayChecker) (*DepartureAnalysis, error) {
+	if totalMinutes < 0 {
+		totalMinutes = 0
+	}
+	arrivalAt := departureAt.Add(time.Duration(totalMinutes) * time.Minute)
+
+	result := &DepartureAnalysis{
+		DepartureAt:  departureAt,
+		ArrivalAt:    arrivalAt,
+		TotalMinutes: totalMinutes,
+	}
+
+	// 運行にかかる日ごとに深夜時間帯との重なりと休日を判定
+	day := time.Date(departureAt.Year(), departureAt.Month(), departureAt.Day(), 0, 0, 0, 0, departureAt.Location())
+	for day.Before(arrivalAt) || day.Equal(departureAt) {
+		nextDay := day.AddDate(0, 0, 1)
+
+		// 当日の深夜時間帯: 0:00-5:00 と 22:00-24:00
+		result.NightMinutes += overlapMinutes(departureAt, arrivalAt, day, day.Add(NightEndHour*time.Hour))
+		result.NightMinutes += overlapMinutes(departureAt, arrivalAt, day.Add(NightStartHour*time.Hour), nextDay)
+
+		// 祝日判定を優先し、祝日でない日曜日は「日曜日」とする
+		var holiday *Holiday
+		if holidays != nil {
+			h, err := holidays.LookupHoliday(day)
+			if err != nil {
+				return nil, fmt.Errorf("休日判定エラー: %w", err)
+			}
+			holiday = h
+		}
+		if holiday == nil && day.Weekday() == time.Sunday {
+			holiday = &Holiday{Date: day, Name: HolidayTypeSunday.Label(), Type: HolidayTypeSunday}
+		}
+		if holiday != nil {
+			result.Holidays = append(result.Holidays, *holiday)
+		}
+
+		day = nextDay
+	}
+
+	return result, nil
+}
+
+// overlapMinutes 2つの期間の重なり（分）を返す
+func overlapMinutes(start, end, rangeStart, rangeEnd time.Time) int {
+	if rangeStart.After(start) {
+		start = rangeStart
+	}
+	if rangeEnd.Before(end) {
+		end = rangeEnd
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return int(end.Sub(start) / time.Minute)
+}
+
+// IsNight 深夜時間帯にかかるか
+func (a *DepartureAnalysis) IsNight() bool {
+	return a.NightMinutes > 0
+}
+
+// IsHoliday 日曜日・祝日にかかるか
+func (a *DepartureAnalysis) IsHoliday() bool {
+	return len(a.Holidays) > 0
+}
+
+// DayMinutes 深夜時間帯以外の時間（分）
+func (a *DepartureAnalysis) DayMinutes() int {
+	return a.TotalMinutes - a.NightMinutes
+}
+
+// Breakdown 計算根拠を文字列で返す
+func (a *DepartureAnalysis) Breakdown() string {
+	result := fmt.Sprintf("運行: %s 出発 → %s 到着（%d分）\n",
+		a.DepartureAt.Format("2006-01-02 15:04"), a.ArrivalAt.Format("2006-01-02 15:04"), a.TotalMinutes)
+	result += fmt.Sprintf("  深夜（22:00-05:00）: %d分 / 日中: %d分\n", a.NightMinutes, a.DayMinutes())
+	for _, h := range a.Holidays {
+		result += fmt.Sprintf("  休日: %s（%s・%s）\n", h.Date.Format(HolidayDateFormat), WeekdayLabel(h.Date.Weekday()), h.Name)
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockHolidayChecker 祝日判定のモック
+type mockHolidayChecker struct {
+	names map[string]string // YYYY-MM-DD → 休日名
+	err   error
+}
+
+func (m *mockHolidayChecker) LookupHoliday(date time.Time) (*Holiday, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	name, ok := m.names[date.Format(HolidayDateFormat)]
+	if !ok {
+		return nil, nil
+	}
+	return &Holiday{Date: date, Name: name, Type: HolidayTypeNational}, nil
+}
+
+func TestAnalyzeDeparture_NightMinutes(t *testing.T) {
+	tests := []struct {
+		name         string
+		departure    string
+		totalMinutes int
+		wantNight    int
+		wantArrival  string
+	}{
+		{"日中のみ", "2026-10-16T09:00", 180, 0, "2026-10-16T12:00"},
+		{"22時をまたぐ", "2026-10-16T21:00", 180, 120, "2026-10-17T00:00"},
+		{"5時をまたぐ", "2026-10-16T04:00", 120, 60, "2026-10-16T06:00"},
+		{"深夜帯のみ", "2026-10-16T23:00", 240, 240, "2026-10-17T03:00"},
+		{"深夜帯を通過", "2026-10-16T20:00", 600, 420, "2026-10-17T06:00"},
+		{"翌日の22時まで", "2026-10-16T21:00", 1500, 420, "2026-10-17T22:00"},
+		{"2晩にまたがる", "2026-10-16T21:00", 1560, 480, "2026-10-17T23:00"},
+		{"22時ちょうどに到着", "2026-10-16T19:00", 180, 0, "2026-10-16T22:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			departure, _ := time.ParseInLocation(DepartureDateTimeFormat, tt.departure, time.Local)
+			got, err := AnalyzeDeparture(departure, tt.totalMinutes, nil)
+			if err != nil {
+				t.Fatalf("AnalyzeDeparture() error = %v", err)
+			}
+			if got.NightMinutes != tt.wantNight {
+				t.Errorf("NightMinutes = %d, want %d", got.NightMinutes, tt.wantNight)
+			}
+			if got.DayMinutes() != tt.totalMinutes-tt.wantNight {
+				t.Errorf("DayMinutes() = %d, want %d", got.DayMinutes(), tt.totalMinutes-tt.wantNight)
+			}
+			if got.IsNight() != (tt.wantNight > 0) {
+				t.Errorf("IsNight() = %v", got.IsNight())
+			}
+			if a := got.ArrivalAt.Format(DepartureDateTimeFormat); a != tt.wantArrival {
+				t.Errorf("ArrivalAt = %s, want %s", a, tt.wantArrival)
+			}
+		})
+	}
+}
+
+func TestAnalyzeDeparture_Holiday(t *testing.T) {
+	// 2026-10-12 は月曜（スポーツの日）、2026-10-18 は日曜
+	holidays := &mockHolidayChecker{names: map[string]string{"2026-10-12": "スポーツの日"}}
+
+	tests := []struct {
+		name         string
+		departure    string
+		totalMinutes int
+		holidays     HolidayChecker
+		wantDates    []string
+	}{
+		{"平日", "2026-10-16T09:00", 180, holidays, nil},
+		{"日曜日", "2026-10-18T09:00", 180, holidays, []string{"2026-10-18"}},
+		{"土曜深夜から日曜にかかる", "2026-10-17T22:00", 180, holidays, []string{"2026-10-18"}},
+		{"日曜0時ちょうどに到着", "2026-10-17T21:00", 180, holidays, nil},
+		{"祝日", "2026-10-12T09:00", 180, holidays, []string{"2026-10-12"}},
+		{"祝日判定なしは日曜日のみ", "2026-10-12T09:00", 180, nil, nil},
+		{"日曜から祝日にかかる", "2026-10-11T20:00", 600, holidays, []string{"2026-10-11", "2026-10-12"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			departure, _ := time.ParseInLocation(DepartureDateTimeFormat, tt.departure, time.Local)
+			got, err := AnalyzeDeparture(departure, tt.totalMinutes, tt.holidays)
+			if err != nil {
+				t.Fatalf("AnalyzeDeparture() error = %v", err)
+			}
+
+			var dates []string
+			for _, h := range got.Holidays {
+				dates = append(dates, h.Date.Format(HolidayDateFormat))
+			}
+			if strings.Join(dates, ",") != strings.Join(tt.wantDates, ",") {
+				t.Errorf("HolidayDates = %v, want %v", dates, tt.wantDates)
+			}
+			if got.IsHoliday() != (len(tt.wantDates) > 0) {
+				t.Errorf("IsHoliday() = %v", got.IsHoliday())
+			}
+		})
+	}
+}
+
+func TestAnalyzeDeparture_HolidayCheckerError(t *testing.T) {
+	departure := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	if _, err := AnalyzeDeparture(departure, 180, &mockHolidayChecker{err: errors.New("DB接続エラー")}); err == nil {
+		t.Error("エラーが期待されたが、発生しなかった")
+	}
+}
+
+func TestDepartureAnalysis_Breakdown(t *testing.T) {
+	departure := time.Date(2026, 10, 11, 21, 0, 0, 0, time.Local)
+	analysis, err := AnalyzeDeparture(departure, 240, &mockHolidayChecker{names: map[string]string{"2026-10-12": "スポーツの日"}})
+	if err != nil {
+		t.Fatalf("AnalyzeDeparture() error = %v", err)
+	}
+	breakdown := analysis.Breakdown()
+	for _, want := range []string{
+		"2026-10-11 21:00 出発 → 2026-10-12 01:00 到着（240分）",
+		"深夜（22:00-05:00）: 180分 / 日中: 60分",
+		"休日: 2026-10-11（日・日曜日）",
+		"休日: 2026-10-12（月・スポーツの日）",
+	} {
+		if !strings.Contains(breakdown, want) {
+			t.Errorf("Breakdown に %q が含まれていない:\n%s", want, breakdown)
+		}
+	}
+}
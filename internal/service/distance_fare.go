@@ -2,6 +2,8 @@ package service
 
 import (
 	"fmt"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
 )
 
 // 割増率の定数
@@ -11,8 +13,9 @@ const (
 )
 
 // FareGetter 運賃取得インターフェース（テスト用にモック可能）
+// version が nil の場合は版指定なし（現行データ）
 type FareGetter interface {
-	GetDistanceFareYen(regionCode, vehicleCode, distanceKm int) (int, error)
+	GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error)
 }
 
 // JtaSupabaseClientAdapter JtaSupabaseClientをFareGetterに適合させるアダプター
@@ -26,7 +29,11 @@ func NewJtaSupabaseClientAdapter(client *JtaSupabaseClient) *JtaSupabaseClientAd
 }
 
 // GetDistanceFareYen 運賃を取得して金額のみ返す
-func (a *JtaSupabaseClientAdapter) GetDistanceFareYen(regionCode, vehicleCode, distanceKm int) (int, error) {
+// Supabaseの運賃データは現行版のみのため、適用終了した運賃版はエラーとする
+func (a *JtaSupabaseClientAdapter) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
+	if version != nil && !version.IsCurrent() {
+		return 0, fmt.Errorf("運賃版 %s の距離制運賃データがありません（現行版のみ対応）", version.Label())
+	}
 	fare, err := a.client.GetDistanceFare(regionCode, vehicleCode, distanceKm)
 	if err != nil {
 		return 0, err
@@ -55,25 +62,55 @@ type DistanceFareResult struct {
 	RoundedKm   int // 丸め後距離 (km)
 
 	// 運賃計算結果
-	BaseFare          int // 基本運賃（円）
-	NightSurcharge    int // 深夜割増額（円）
-	HolidaySurcharge  int // 休日割増額（円）
-	TotalFare         int // 合計運賃（円）
+	BaseFare         int // 基本運賃（円）
+	NightSurcharge   int // 深夜割増額（円）
+	HolidaySurcharge int // 休日割増額（円）
+	TotalFare        int // 合計運賃（円、税抜）
+
+	// 付帯料金（FareCalculatorServiceが設定、TotalFareに含む）
+	HandlingCharge int // 積込・取卸料（円）
+	WaitingCharge  int // 待機時間料（円）
+
+	// 燃料サーチャージ（FareCalculatorServiceが設定、TotalFareに含む）
+	FuelSurcharge int // 燃料サーチャージ（円）
 
 	// 割増率
 	NightRate   float64 // 深夜割増率（1.0 or 1.3）
 	HolidayRate float64 // 休日割増率（1.0 or 1.2）
 
+	// 深夜時間の内訳（nilは全体に深夜割増）
+	NightSplit *NightSplit
+
+	// 特殊車両割増（BodyTypeがnilの場合は割増なし）
+	BodyType          *model.BodyTypeSurcharge // 車体種別
+	BodyTypeSurcharge int                      // 特殊車両割増額（円）
+
+	// 割増項目（速達割増・付帯作業など、TotalFareに含む）
+	SurchargeItems      []AppliedSurchargeItem // 項目ごとの割増額
+	SurchargeItemsTotal int                    // 割増項目の合計（円）
+
 	// フラグ
 	IsNight   bool // 深夜適用
 	IsHoliday bool // 休日適用
+
+	// 適用運賃版（nilは版指定なし）
+	TariffVersion *model.TariffVersion
+
+	// 運賃データの取得元・鮮度（nilは取得元を返さないFareGetter）
+	FareSource *FareSource
+
+	// 消費税（FareCalculatorServiceが設定、nilは未計算）
+	Tax *TaxAmount
 }
 
 // Calculate 距離制運賃を計算する
 func (s *DistanceFareService) Calculate(
 	regionCode, vehicleCode, distanceKm int,
 	isNight, isHoliday bool,
+	opts ...FareOption,
 ) (*DistanceFareResult, error) {
+	o := newFareOptions(opts)
+
 	// 入力値検証
 	if err := s.validateInput(regionCode, vehicleCode, distanceKm); err != nil {
 		return nil, err
@@ -82,8 +119,15 @@ func (s *DistanceFareService) Calculate(
 	// 距離を丸める
 	roundedKm := RoundDistance(distanceKm, regionCode)
 
-	// 基本運賃を取得
-	baseFare, err := s.fareGetter.GetDistanceFareYen(regionCode, vehicleCode, roundedKm)
+	// 基本運賃を取得（取得元を返すFareGetterの場合は取得元・鮮度も記録）
+	var baseFare int
+	var source *FareSource
+	var err error
+	if g, ok := s.fareGetter.(SourcedFareGetter); ok {
+		baseFare, source, err = g.GetDistanceFareYenWithSource(o.tariffVersion, regionCode, vehicleCode, roundedKm)
+	} else {
+		baseFare, err = s.fareGetter.GetDistanceFareYen(o.tariffVersion, regionCode, vehicleCode, roundedKm)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("運賃取得エラー: %w", err)
 	}
@@ -95,10 +139,14 @@ func (s *DistanceFareService) Calculate(
 	holidaySurcharge := 0
 	totalFare := baseFare
 
-	// 深夜割増（3割増）
+	// 特殊車両割増（冷蔵車・冷凍車など）- 基本運賃に適用
+	bodyTypeSurcharge := o.bodyTypeSurcharge(baseFare)
+	totalFare += bodyTypeSurcharge
+
+	// 深夜割増（3割増）- 深夜時間の内訳がある場合は深夜時間の割合で按分
 	if isNight {
 		nightRate = NightSurchargeRate
-		nightSurcharge = int(float64(baseFare) * (NightSurchargeRate - 1.0))
+		nightSurcharge = int(float64(baseFare) * (NightSurchargeRate - 1.0) * o.nightSplit.Ratio())
 		totalFare += nightSurcharge
 	}
 
@@ -109,19 +157,30 @@ func (s *DistanceFareService) Calculate(
 		totalFare += holidaySurcharge
 	}
 
+	// 割増項目（速達割増・付帯作業など）- 基本運賃に対して計算し、深夜・休日割増の対象外
+	surchargeItems, surchargeItemsTotal := o.surchargeItems(baseFare)
+	totalFare += surchargeItemsTotal
+
 	return &DistanceFareResult{
-		RegionCode:       regionCode,
-		VehicleCode:      vehicleCode,
-		DistanceKm:       distanceKm,
-		RoundedKm:        roundedKm,
-		BaseFare:         baseFare,
-		NightSurcharge:   nightSurcharge,
-		HolidaySurcharge: holidaySurcharge,
-		TotalFare:        totalFare,
-		NightRate:        nightRate,
-		HolidayRate:      holidayRate,
-		IsNight:          isNight,
-		IsHoliday:        isHoliday,
+		RegionCode:          regionCode,
+		VehicleCode:         vehicleCode,
+		DistanceKm:          distanceKm,
+		RoundedKm:           roundedKm,
+		BaseFare:            baseFare,
+		NightSurcharge:      nightSurcharge,
+		HolidaySurcharge:    holidaySurcharge,
+		TotalFare:           totalFare,
+		NightRate:           nightRate,
+		HolidayRate:         holidayRate,
+		IsNight:             isNight,
+		IsHoliday:           isHoliday,
+		NightSplit:          o.nightSplit,
+		BodyType:            o.bodyType,
+		BodyTypeSurcharge:   bodyTypeSurcharge,
+		SurchargeItems:      surchargeItems,
+		SurchargeItemsTotal: surchargeItemsTotal,
+		TariffVersion:       o.tariffVersion,
+		FareSource:          source,
 	}, nil
 }
 
@@ -158,17 +217,45 @@ func (r *DistanceFareResult) Breakdown() string {
 	result := fmt.Sprintf("【計算根拠】\n")
 	result += fmt.Sprintf("  運輸局: %s\n", regionNames[r.RegionCode])
 	result += fmt.Sprintf("  車格: %s\n", vehicleNames[r.VehicleCode])
+	if r.TariffVersion != nil {
+		result += fmt.Sprintf("  適用運賃版: %s\n", r.TariffVersion.Label())
+	}
 	result += fmt.Sprintf("  経路距離: %dkm → 運賃計算距離: %dkm\n", r.DistanceKm, r.RoundedKm)
 	result += fmt.Sprintf("  基本運賃: %d円\n", r.BaseFare)
+	if r.FareSource != nil {
+		result += fmt.Sprintf("  運賃データ: %s\n", r.FareSource.Label())
+	}
+
+	if r.BodyType != nil && r.BodyType.SurchargePercent > 0 {
+		result += fmt.Sprintf("  特殊車両割増: +%d円（%s %d%%増）\n", r.BodyTypeSurcharge, r.BodyType.Name, r.BodyType.SurchargePercent)
+	}
 
 	if r.IsNight {
-		result += fmt.Sprintf("  深夜割増: +%d円（%.0f%%増）\n", r.NightSurcharge, (r.NightRate-1.0)*100)
+		if r.NightSplit != nil {
+			result += fmt.Sprintf("  深夜割増: +%d円（%.0f%%増 × 深夜%d分/運行%d分）\n",
+				r.NightSurcharge, (r.NightRate-1.0)*100, r.NightSplit.NightMinutes, r.NightSplit.TotalMinutes)
+		} else {
+			result += fmt.Sprintf("  深夜割増: +%d円（%.0f%%増）\n", r.NightSurcharge, (r.NightRate-1.0)*100)
+		}
 	}
 	if r.IsHoliday {
 		result += fmt.Sprintf("  休日割増: +%d円（%.0f%%増）\n", r.HolidaySurcharge, (r.HolidayRate-1.0)*100)
 	}
+	result += surchargeItemsBreakdown(r.SurchargeItems)
+	if r.HandlingCharge > 0 {
+		result += fmt.Sprintf("  積込・取卸料: +%d円\n", r.HandlingCharge)
+	}
+	if r.WaitingCharge > 0 {
+		result += fmt.Sprintf("  待機時間料: +%d円\n", r.WaitingCharge)
+	}
+	if r.FuelSurcharge > 0 {
+		result += fmt.Sprintf("  燃料サーチャージ: +%d円\n", r.FuelSurcharge)
+	}
 
 	result += fmt.Sprintf("  合計運賃: %d円\n", r.TotalFare)
+	if r.Tax != nil {
+		result += r.Tax.Breakdown()
+	}
 
 	return result
 }
@@ -1,7 +1,11 @@
 package service
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
 )
 
 // モック用のSupabaseクライアント
@@ -10,7 +14,7 @@ type mockSupabaseClient struct {
 	err     error
 }
 
-func (m *mockSupabaseClient) GetDistanceFareYen(regionCode, vehicleCode, distanceKm int) (int, error) {
+func (m *mockSupabaseClient) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
 	if m.err != nil {
 		return 0, m.err
 	}
@@ -208,3 +212,42 @@ func TestDistanceFareResult_Breakdown(t *testing.T) {
 		t.Errorf("HolidaySurcharge = %d, want %d", result.HolidaySurcharge, expectedHolidaySurcharge)
 	}
 }
+
+func TestDistanceFareService_Calculate_TariffVersion(t *testing.T) {
+	mock := &mockSupabaseClient{fareYen: 50000}
+	service := NewDistanceFareService(mock)
+
+	version := &model.TariffVersion{
+		ID:         1,
+		TariffType: model.TariffTypeJTA,
+		Name:       "令和6年3月告示",
+	}
+	result, err := service.Calculate(3, 3, 100, false, false, WithTariffVersion(version))
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	if result.TariffVersion != version {
+		t.Errorf("TariffVersion = %v, want %v", result.TariffVersion, version)
+	}
+	if !strings.Contains(result.Breakdown(), "適用運賃版: 令和6年3月告示") {
+		t.Errorf("Breakdown に適用運賃版が含まれていない:\n%s", result.Breakdown())
+	}
+}
+
+func TestJtaSupabaseClientAdapter_SupersededVersion(t *testing.T) {
+	// 適用終了した運賃版はSupabaseに問い合わせずエラーとする
+	adapter := NewJtaSupabaseClientAdapter(nil)
+
+	to := time.Date(2024, 3, 21, 0, 0, 0, 0, time.Local)
+	version := &model.TariffVersion{
+		ID:            1,
+		TariffType:    model.TariffTypeJTA,
+		Name:          "令和2年4月告示",
+		EffectiveFrom: time.Date(2020, 4, 24, 0, 0, 0, 0, time.Local),
+		EffectiveTo:   &to,
+	}
+	if _, err := adapter.GetDistanceFareYen(version, 3, 3, 100); err == nil {
+		t.Error("エラーが期待されたが、発生しなかった")
+	}
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 予算からの逆算の探索範囲
+const (
+	FareBudgetMaxDistanceKm      = 1000 // 距離制の探索上限（km）
+	FareBudgetMaxWorkingDays     = 3    // 時間制の探索上限（運行日数）
+	fareBudgetHoursSystemMinutes = 240  // 4時間制の上限（分、DetermineHoursSystemと同じ）
+
+	// 時間制の探索上限（拘束時間、分）
+	FareBudgetMaxWorkingMinutes = FareBudgetMaxWorkingDays * DefaultDailyWorkingLimitMinutes
+)
+
+// FareBudgetRequest 予算から距離・時間を逆算する条件
+type FareBudgetRequest struct {
+	Budget      int  // 予算（円）
+	TaxIncluded bool // 予算が税込か（falseは税抜）
+	SpeedKmh    int  // 走行時間・距離を換算する平均速度（km/h、0は既定値）
+
+	// 見積条件（運輸局・車格・割増・荷役時間など）。距離・走行時間は逆算で変えるため指定不要
+	// 経由地・運行形態・出発日時・荷主は使わない（深夜・休日はIsNight/IsHolidayで指定）
+	Conditions FareCalculationRequest
+}
+
+// FareBudgetLimit 予算内に収まる上限（運賃タイプごと）
+type FareBudgetLimit struct {
+	Name           string // 運賃タイプ
+	Feasible       bool   // 予算内に収まる条件があるか
+	DistanceKm     int    // 最大距離（km、時間制は拘束時間の走行距離）
+	RoundedKm      int    // 運賃計算距離（km、距離制のみ）
+	WorkingMinutes int    // 最大拘束時間（分、時間制のみ）
+	DrivingMinutes int    // 走行時間（分）
+	AppliedHours   int    // 適用時間制（時間制のみ、複数日運行は1日目）
+	WorkingDays    int    // 運行日数（時間制のみ）
+	Fare           int    // 上限での運賃（円、予算と同じ税区分）
+	NextFare       int    // 上限を1km（時間制は1分）超えた場合の運賃（円、0は探索上限）
+	ReachedLimit   bool   // 探索上限まで予算内（実際の上限は探索範囲より大きい）
+}
+
+// Label 表示用ラベル（例: 最大200km（運賃計算距離200km、60000円））
+func (l *FareBudgetLimit) Label() string {
+	if !l.Feasible {
+		return "予算内に収まる条件なし"
+	}
+	limit := ""
+	if l.ReachedLimit {
+		limit = "以上"
+	}
+	if l.WorkingMinutes > 0 {
+		system := fmt.Sprintf("%d時間制", l.AppliedHours)
+		if l.WorkingDays > 1 {
+			system = fmt.Sprintf("%d日運行", l.WorkingDays)
+		}
+		return fmt.Sprintf("最大%s%s・%dkm（%s、%d円）",
+			formatHoursMinutes(l.WorkingMinutes), limit, l.DistanceKm, system, l.Fare)
+	}
+	return fmt.Sprintf("最大%dkm%s（運賃計算距離%dkm、%d円）", l.DistanceKm, limit, l.RoundedKm, l.Fare)
+}
+
+// FareBudgetResult 予算からの逆算結果
+type FareBudgetResult struct {
+	Budget         int
+	TaxIncluded    bool
+	RegionCode     int
+	VehicleCode    int
+	SpeedKmh       int
+	LoadingMinutes int
+
+	Distance *FareBudgetLimit // 距離制（予算内の最大距離）
+	Time     *FareBudgetLimit // 時間制（予算内の最大拘束時間）
+}
+
+// Summary 要約（例: 予算80000円（税抜）: 距離制は最大200km…、時間制は最大9時間…）
+func (r *FareBudgetResult) Summary() string {
+	tax := "税抜"
+	if r.TaxIncluded {
+		tax = "税込"
+	}
+	var parts []string
+	for _, l := range []*FareBudgetLimit{r.Distance, r.Time} {
+		parts = append(parts, l.Name+"は"+l.Label())
+	}
+	return fmt.Sprintf("予算%d円（%s）: %s", r.Budget, tax, strings.Join(parts, "、"))
+}
+
+// FareBudgetService 予算から距離・時間を逆算するサービス
+// 見積と同じFareCalculatorServiceで運賃を計算し、予算内に収まる最大の距離・拘束時間を二分探索する
+// （距離制はRoundDistanceの丸め、時間制は10km・1時間単位の加算額のため、上限は丸め単位の境目になる）
+type FareBudgetService struct {
+	calculator *FareCalculatorService
+}
+
+// NewFareBudgetService 新しいFareBudgetServiceを作成
+func NewFareBudgetService(calculator *FareCalculatorService) *FareBudgetService {
+	return &FareBudgetService{calculator: calculator}
+}
+
+// Solve 予算内に収まる距離制の最大距離と時間制の最大拘束時間を求める
+func (s *FareBudgetService) Solve(req *FareBudgetRequest) (*FareBudgetResult, error) {
+	if req.Budget <= 0 {
+		return nil, fmt.Errorf("無効な予算: %d（1円以上を指定）", req.Budget)
+	}
+	cond := req.Conditions
+	if cond.VehicleCode < 1 || cond.VehicleCode > 4 {
+		return nil, fmt.Errorf("無効な車格コード: %d（逆算はトラックの1-4のみ対応）", cond.VehicleCode)
+	}
+	cond.Route = nil
+	cond.TripMode = ""
+	cond.DepartureAt = time.Time{}
+	cond.CustomerID = 0
+
+	result := &FareBudgetResult{
+		Budget:         req.Budget,
+		TaxIncluded:    req.TaxIncluded,
+		RegionCode:     cond.RegionCode,
+		VehicleCode:    cond.VehicleCode,
+		SpeedKmh:       req.SpeedKmh,
+		LoadingMinutes: cond.LoadingMinutes,
+	}
+	if result.SpeedKmh <= 0 {
+		result.SpeedKmh = DefaultFareMatrixSpeedKmh
+	}
+
+	e := &fareBudgetEvaluator{calculator: s.calculator, cond: cond, taxIncluded: req.TaxIncluded, budget: req.Budget, speedKmh: result.SpeedKmh}
+
+	var err error
+	if result.Distance, err = e.solveDistance(); err != nil {
+		return nil, err
+	}
+	if result.Time, err = e.solveTime(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// fareBudgetEvaluator 逆算の探索で条件ごとの運賃を計算する
+type fareBudgetEvaluator struct {
+	calculator  *FareCalculatorService
+	cond        FareCalculationRequest
+	taxIncluded bool
+	budget      int
+	speedKmh    int
+}
+
+// fare 距離・走行時間を指定して運賃タイプの運賃を計算する（予算と同じ税区分）
+func (e *fareBudgetEvaluator) fare(name string, distanceKm, drivingMinutes int) (int, *FareComparisonResult, error) {
+	req := e.cond
+	req.DistanceKm = distanceKm
+	req.DistanceKmRaw = float64(distanceKm)
+	req.DrivingMinutes = drivingMinutes
+
+	result, err := e.calculator.CalculateAll(&req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%dkm・走行%d分の運賃計算エラー: %w", distanceKm, drivingMinutes, err)
+	}
+	for _, r := range result.Results {
+		if r.Name != name {
+			continue
+		}
+		if e.taxIncluded {
+			return r.Tax.Inclusive, result, nil
+		}
+		return r.Tax.Exclusive, result, nil
+	}
+	return 0, nil, fmt.Errorf("運賃計算方式がありません: %s", name)
+}
+
+// solveDistance 距離制の運賃が予算内に収まる最大距離を求める
+// 距離制運賃は距離に対して単調増加（丸め単位の中は同額）のため、1km単位で二分探索する
+func (e *fareBudgetEvaluator) solveDistance() (*FareBudgetLimit, error) {
+	const name = "距離制"
+	at := func(km int) (int, *FareComparisonResult, error) {
+		return e.fare(name, km, drivingMinutesAt(km, e.speedKmh))
+	}
+
+	km, err := searchMaxWithinBudget(1, FareBudgetMaxDistanceKm, func(km int) (bool, error) {
+		fare, _, err := at(km)
+		return fare <= e.budget, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	limit := &FareBudgetLimit{Name: name}
+	if km == 0 {
+		return limit, nil
+	}
+
+	fare, result, err := at(km)
+	if err != nil {
+		return nil, err
+	}
+	limit.Feasible = true
+	limit.DistanceKm = km
+	limit.RoundedKm = result.DistanceFareResult.RoundedKm
+	limit.DrivingMinutes = result.DrivingMinutes
+	limit.Fare = fare
+	if km == FareBudgetMaxDistanceKm {
+		limit.ReachedLimit = true
+	} else if limit.NextFare, _, err = at(km + 1); err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
+// solveTime 時間制の運賃が予算内に収まる最大拘束時間を求める
+// 走行距離は「拘束時間 − 荷役時間」を平均速度で換算する。4時間制と8時間制の境目と、複数日運行の日数の境目
+// （1日分の基礎額が加わる）で運賃が段差になるため、区間ごとに二分探索し（区間の中では拘束時間に対して単調増加）、
+// 最も長い区間の上限を採用する
+func (e *fareBudgetEvaluator) solveTime() (*FareBudgetLimit, error) {
+	const name = "時間制"
+	loading := e.cond.LoadingMinutes
+	at := func(minutes int) (int, *FareComparisonResult, error) {
+		driving := minutes - loading
+		km := max(1, driving*e.speedKmh/60)
+		return e.fare(name, km, driving)
+	}
+	fits := func(minutes int) (bool, error) {
+		fare, _, err := at(minutes)
+		return fare <= e.budget, err
+	}
+
+	// 走行時間は1分以上
+	minMinutes := loading + 1
+	best := 0
+	var ranges [][2]int
+	for days := FareBudgetMaxWorkingDays; days >= 2; days-- {
+		ranges = append(ranges, [2]int{(days-1)*DefaultDailyWorkingLimitMinutes + 1, days * DefaultDailyWorkingLimitMinutes}) // 複数日運行
+	}
+	ranges = append(ranges,
+		[2]int{max(minMinutes, fareBudgetHoursSystemMinutes+1), DefaultDailyWorkingLimitMinutes}, // 8時間制
+		[2]int{minMinutes, fareBudgetHoursSystemMinutes},                                         // 4時間制
+	)
+	for _, r := range ranges {
+		if r[0] > r[1] {
+			continue
+		}
+		found, err := searchMaxWithinBudget(r[0], r[1], fits)
+		if err != nil {
+			return nil, err
+		}
+		if found > 0 {
+			best = found
+			break
+		}
+	}
+
+	limit := &FareBudgetLimit{Name: name}
+	if best == 0 {
+		return limit, nil
+	}
+	fare, result, err := at(best)
+	if err != nil {
+		return nil, err
+	}
+	limit.Feasible = true
+	limit.WorkingMinutes = best
+	limit.DistanceKm = result.TimeFareResult.DistanceKm
+	limit.DrivingMinutes = result.TimeFareResult.DrivingMinutes
+	limit.AppliedHours = result.TimeFareResult.AppliedHours
+	limit.WorkingDays = max(1, len(result.TimeFareResult.Days))
+	limit.Fare = fare
+	if best == FareBudgetMaxWorkingMinutes {
+		limit.ReachedLimit = true
+	} else if limit.NextFare, _, err = at(best + 1); err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
+// searchMaxWithinBudget lo〜hiの範囲で fits が真となる最大値を二分探索する（fitsは単調、該当なしは0）
+func searchMaxWithinBudget(lo, hi int, fits func(int) (bool, error)) (int, error) {
+	ok, err := fits(lo)
+	if err != nil || !ok {
+		return 0, err
+	}
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		ok, err := fits(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
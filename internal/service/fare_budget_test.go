@@ -0,0 +1,159 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+// newFareBudgetTestService 距離制は運賃計算距離×300円、時間制は関東・大型車のモックで逆算するサービス
+func newFareBudgetTestService() *FareBudgetService {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&perKmFareGetter{ratePerKm: 300}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+	return NewFareBudgetService(calculator)
+}
+
+func TestFareBudgetService_Solve(t *testing.T) {
+	svc := newFareBudgetTestService()
+
+	tests := []struct {
+		name         string
+		budget       int
+		taxIncluded  bool
+		wantKm       int // 距離制の最大距離
+		wantDistFare int
+		wantNextFare int
+		wantMinutes  int // 時間制の最大拘束時間
+		wantTimeKm   int
+		wantHours    int
+		wantTimeFare int
+	}{
+		{
+			// 距離制: 260km=78000円、261km以上は280km（20km単位の丸め）=84000円
+			// 時間制: 9時間（走行8時間・320km）=60090+19×630+1×4180=76240円、9時間1分で2時間超過=80420円
+			name:   "税抜8万円",
+			budget: 80000,
+			wantKm: 260, wantDistFare: 78000, wantNextFare: 84000,
+			wantMinutes: 540, wantTimeKm: 320, wantHours: 8, wantTimeFare: 76240,
+		},
+		{
+			// 距離制: 500km=150000円、501km以上は550km（50km単位の丸め）=165000円
+			// 時間制は2日運行（16時間28分・618km、1日あたり8時間14分・309km）=2×(60090+17×630+1×4180)=149960円
+			name:   "税抜15万円（複数日運行）",
+			budget: 150000,
+			wantKm: 500, wantDistFare: 150000, wantNextFare: 165000,
+			wantMinutes: 988, wantTimeKm: 618, wantHours: 8, wantTimeFare: 149960,
+		},
+		{
+			// 距離制の丸め単位の途中の予算でも、上限は丸め単位の境目（200km）になる
+			// 時間制は10km単位の距離超過加算の境目（209km=60090+7×630、210kmで8回目の加算）
+			name:   "丸め単位の途中",
+			budget: 65000,
+			wantKm: 200, wantDistFare: 60000, wantNextFare: 66000,
+			wantMinutes: 374, wantTimeKm: 209, wantHours: 8, wantTimeFare: 64500,
+		},
+		{
+			// 8時間制の基礎額に届かない予算は4時間制（4時間・走行3時間・120km）=36050+6×630=39830円
+			name:   "4時間制のみ",
+			budget: 40000,
+			wantKm: 130, wantDistFare: 39000, wantNextFare: 42000,
+			wantMinutes: 240, wantTimeKm: 120, wantHours: 4, wantTimeFare: 39830,
+		},
+		{
+			// 税込66000円は税抜60000円（200km）まで。8時間制の基礎額は税込66099円のため4時間制
+			name:        "税込予算",
+			budget:      66000,
+			taxIncluded: true,
+			wantKm:      200, wantDistFare: 66000, wantNextFare: 72600,
+			wantMinutes: 240, wantTimeKm: 120, wantHours: 4, wantTimeFare: 43813,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.Solve(&FareBudgetRequest{
+				Budget:      tt.budget,
+				TaxIncluded: tt.taxIncluded,
+				SpeedKmh:    40,
+				Conditions:  FareCalculationRequest{RegionCode: 3, VehicleCode: 3, LoadingMinutes: 60},
+			})
+			if err != nil {
+				t.Fatalf("Solve() error = %v", err)
+			}
+
+			d := result.Distance
+			if !d.Feasible || d.DistanceKm != tt.wantKm || d.Fare != tt.wantDistFare || d.NextFare != tt.wantNextFare {
+				t.Errorf("距離制 = %+v, want %dkm / %d円 / 次%d円", d, tt.wantKm, tt.wantDistFare, tt.wantNextFare)
+			}
+			if d.RoundedKm != RoundDistance(tt.wantKm, 3) {
+				t.Errorf("運賃計算距離 = %d, want %d", d.RoundedKm, RoundDistance(tt.wantKm, 3))
+			}
+			if d.Fare > tt.budget || d.NextFare <= tt.budget {
+				t.Errorf("距離制の上限が予算の境目ではない: %d円 / 次%d円", d.Fare, d.NextFare)
+			}
+
+			tm := result.Time
+			if !tm.Feasible || tm.WorkingMinutes != tt.wantMinutes || tm.DistanceKm != tt.wantTimeKm || tm.AppliedHours != tt.wantHours || tm.Fare != tt.wantTimeFare {
+				t.Errorf("時間制 = %+v, want %d分 / %dkm / %d時間制 / %d円", tm, tt.wantMinutes, tt.wantTimeKm, tt.wantHours, tt.wantTimeFare)
+			}
+			if tm.Fare > tt.budget || tm.NextFare <= tt.budget {
+				t.Errorf("時間制の上限が予算の境目ではない: %d円 / 次%d円", tm.Fare, tm.NextFare)
+			}
+		})
+	}
+}
+
+func TestFareBudgetService_Solve_Infeasible(t *testing.T) {
+	result, err := newFareBudgetTestService().Solve(&FareBudgetRequest{
+		Budget:     1000,
+		Conditions: FareCalculationRequest{RegionCode: 3, VehicleCode: 3, LoadingMinutes: 60},
+	})
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if result.Distance.Feasible || result.Time.Feasible {
+		t.Errorf("予算内に収まらないはず: %+v / %+v", result.Distance, result.Time)
+	}
+	if want := "予算1000円（税抜）: 距離制は予算内に収まる条件なし、時間制は予算内に収まる条件なし"; result.Summary() != want {
+		t.Errorf("Summary() = %q, want %q", result.Summary(), want)
+	}
+}
+
+func TestFareBudgetService_Solve_Invalid(t *testing.T) {
+	svc := newFareBudgetTestService()
+	tests := []struct {
+		name string
+		req  *FareBudgetRequest
+		want string
+	}{
+		{"予算なし", &FareBudgetRequest{Conditions: FareCalculationRequest{RegionCode: 3, VehicleCode: 3}}, "無効な予算"},
+		{"軽貨物", &FareBudgetRequest{Budget: 10000, Conditions: FareCalculationRequest{VehicleCode: VehicleCodeLight}}, "無効な車格コード"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Solve(tt.req)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Solve() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFareBudgetLimit_Label(t *testing.T) {
+	tests := []struct {
+		limit *FareBudgetLimit
+		want  string
+	}{
+		{&FareBudgetLimit{Feasible: true, DistanceKm: 200, RoundedKm: 200, Fare: 60000}, "最大200km（運賃計算距離200km、60000円）"},
+		{&FareBudgetLimit{Feasible: true, WorkingMinutes: 510, DistanceKm: 300, AppliedHours: 8, Fare: 70000}, "最大8時間30分・300km（8時間制、70000円）"},
+		{&FareBudgetLimit{Feasible: true, WorkingMinutes: 988, DistanceKm: 618, AppliedHours: 8, WorkingDays: 2, Fare: 149960}, "最大16時間28分・618km（2日運行、149960円）"},
+		{&FareBudgetLimit{Feasible: true, DistanceKm: 1000, RoundedKm: 1000, Fare: 300000, ReachedLimit: true}, "最大1000km以上（運賃計算距離1000km、300000円）"},
+	}
+	for _, tt := range tests {
+		if got := tt.limit.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -1,16 +1,42 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
 )
 
+// TariffVersionResolver 見積日に適用される運賃版を取得するインターフェース（テスト用にモック可能）
+// 該当する版がない場合は sql.ErrNoRows を返す
+type TariffVersionResolver interface {
+	GetEffective(tariffType string, date time.Time) (*model.TariffVersion, error)
+}
+
+// BodyTypeSurchargeGetter 特殊車両割増取得インターフェース（テスト用にモック可能）
+// 該当する車体種別がない場合は sql.ErrNoRows を返す
+type BodyTypeSurchargeGetter interface {
+	GetBodyTypeSurcharge(version *model.TariffVersion, bodyType string) (*model.BodyTypeSurcharge, error)
+}
+
 // FareCalculatorService 統合運賃計算サービス
-// 距離制・時間制・赤帽の3運賃を一括計算する
+// 登録された運賃計算方式（距離制・時間制・赤帽など）のうち車格に対応するものを一括計算する
 type FareCalculatorService struct {
-	distanceFare *DistanceFareService
-	timeFare     *TimeFareService
-	akabouFare   *AkabouFareService
+	strategies *FareStrategyRegistry // 運賃計算方式（登録順に計算・ランキング）
+	akabouFare *AkabouFareService    // 赤帽付帯料金の計算
+
+	tariffVersionResolver TariffVersionResolver   // 運賃版の解決（nilの場合は版指定なし）
+	jtaCharge             *JtaChargeService       // トラ協付帯料金（nilの場合は計算しない）
+	fuelSurcharge         *FuelSurchargeService   // 燃料サーチャージ（nilの場合は計算できない）
+	tax                   *TaxCalculator          // 消費税計算（nilの場合は10%・切り捨て）
+	holidays              HolidayChecker          // 祝日判定（nilの場合は日曜日のみ休日）
+	bodyTypes             BodyTypeSurchargeGetter // 特殊車両割増（nilの場合は車体種別を指定できない）
+	surchargeItems        SurchargeItemGetter     // 割増項目（nilの場合は割増項目を指定できない）
+	customers             CustomerPricingGetter   // 荷主別の契約条件（nilの場合は荷主を指定できない）
+	compliance            *ComplianceService      // 改善基準告示の確認（nilの場合は確認しない）
 }
 
 // NewFareCalculatorService 新しいFareCalculatorServiceを作成
@@ -20,20 +46,82 @@ func NewFareCalculatorService(
 	akabouFare *AkabouFareService,
 ) *FareCalculatorService {
 	return &FareCalculatorService{
-		distanceFare: distanceFare,
-		timeFare:     timeFare,
-		akabouFare:   akabouFare,
+		akabouFare: akabouFare,
+		strategies: &FareStrategyRegistry{strategies: []FareStrategy{
+			&distanceFareStrategy{service: distanceFare},
+			&timeFareStrategy{service: timeFare},
+			&akabouDistanceStrategy{service: akabouFare},
+			&akabouTimeStrategy{service: akabouFare},
+		}},
 	}
 }
 
+// RegisterStrategy 運賃計算方式を追加する（自社運賃・協力会社の料金表など）
+// 追加した方式は対応する車格の見積で標準の運賃と同じランキングに並ぶ
+func (s *FareCalculatorService) RegisterStrategy(strategy FareStrategy) error {
+	return s.strategies.Register(strategy)
+}
+
+// SetTariffVersionResolver 運賃版の解決に使うリゾルバーを設定する
+func (s *FareCalculatorService) SetTariffVersionResolver(resolver TariffVersionResolver) {
+	s.tariffVersionResolver = resolver
+}
+
+// SetJtaChargeService トラ協付帯料金（待機時間料・積込取卸料）の計算サービスを設定する
+func (s *FareCalculatorService) SetJtaChargeService(jtaCharge *JtaChargeService) {
+	s.jtaCharge = jtaCharge
+}
+
+// SetFuelSurchargeService 燃料サーチャージの計算サービスを設定する
+func (s *FareCalculatorService) SetFuelSurchargeService(fuelSurcharge *FuelSurchargeService) {
+	s.fuelSurcharge = fuelSurcharge
+}
+
+// SetTaxCalculator 消費税の税率・端数処理を設定する
+func (s *FareCalculatorService) SetTaxCalculator(tax *TaxCalculator) {
+	s.tax = tax
+}
+
+// SetHolidayChecker 出発日時からの休日判定に使う祝日判定を設定する
+func (s *FareCalculatorService) SetHolidayChecker(holidays HolidayChecker) {
+	s.holidays = holidays
+}
+
+// SetBodyTypeSurchargeGetter 特殊車両割増（車体種別）の取得元を設定する
+func (s *FareCalculatorService) SetBodyTypeSurchargeGetter(bodyTypes BodyTypeSurchargeGetter) {
+	s.bodyTypes = bodyTypes
+}
+
+// SetSurchargeItemGetter 割増項目（速達割増・付帯作業など）の取得元を設定する
+func (s *FareCalculatorService) SetSurchargeItemGetter(items SurchargeItemGetter) {
+	s.surchargeItems = items
+}
+
+// SetCustomerPricingGetter 荷主別の契約条件の取得元を設定
+func (s *FareCalculatorService) SetCustomerPricingGetter(customers CustomerPricingGetter) {
+	s.customers = customers
+}
+
+// SetComplianceService 改善基準告示（運転者の拘束時間・休憩）の確認サービスを設定する
+func (s *FareCalculatorService) SetComplianceService(compliance *ComplianceService) {
+	s.compliance = compliance
+}
+
 // FareCalculationRequest 運賃計算リクエスト
 type FareCalculationRequest struct {
 	// 共通パラメータ
-	RegionCode  int  // 運輸局コード（1-10）
-	VehicleCode int  // 車格コード（1-4）
-	DistanceKm  int  // 距離（km）
-	IsNight     bool // 深夜割増
-	IsHoliday   bool // 休日割増
+	RegionCode  int    // 運輸局コード（1-10）
+	VehicleCode int    // 車格コード（1-4）
+	BodyType    string // 車体種別（トラ協運賃の特殊車両割増、空文字・standardは割増なし）
+	DistanceKm  int    // 距離（km）
+	IsNight     bool   // 深夜割増
+	IsHoliday   bool   // 休日割増
+
+	// 見積日（適用運賃版の判定用、ゼロ値の場合は当日）
+	QuoteDate time.Time
+
+	// 出発日時（指定時は走行時間・荷役時間から深夜時間・休日を判定し、IsNight/IsHolidayより優先）
+	DepartureAt time.Time
 
 	// 距離（表示用）
 	DistanceKmRaw float64 // 元距離（km、小数点付き）- Google Maps API取得値
@@ -43,17 +131,93 @@ type FareCalculationRequest struct {
 	LoadingMinutes  int  // 荷役時間（分）- デフォルト60分
 	UseSimpleBaseKm bool // シンプル版基礎走行キロ使用（false=トラ協PDF版）
 
+	// 複数日運行の1泊あたりの宿泊費（円、時間制で総作業時間が1日の拘束時間の上限を超える場合に泊数分を加算）
+	OvernightCostYen int
+
+	// 改善基準告示の休憩時間・運行日数を時間制運賃の作業時間に含めるか（トラック用）
+	IncludeComplianceTime bool
+
+	// 燃料サーチャージを加算するか（トラック用）
+	UseFuelSurcharge bool
+
 	// 赤帽用パラメータ
-	Area           string // 地区（東京23区、大阪市内など）
-	WorkMinutes    int    // 作業時間（分）- 赤帽付帯料金用
-	WaitingMinutes int    // 待機時間（分）- 赤帽付帯料金用
+	Area string // 地区（東京23区、大阪市内など）
+
+	// 割増項目コード（速達割増・手積み手降ろしなど、距離制・時間制・赤帽の全運賃に適用）
+	SurchargeItems []string
+
+	// 荷主ID（指定時は荷主の契約条件を標準運賃に適用した契約運賃も計算する。0は指定なし）
+	CustomerID int64
+
+	// 付帯料金用パラメータ（赤帽: 作業料金・待機時間料、トラ協: 積込・取卸料・待機時間料）
+	WorkMinutes    int // 作業時間（分）
+	WaitingMinutes int // 待機時間（分）
+
+	// 経由地（指定時は区間の合計距離・走行時間を使い、経由地ごとの荷役時間を荷役時間に加算）
+	Route *MultiStopRoute
+
+	// 運行形態（空文字は片道）。往復・空車回送の場合は距離・走行時間を計上分に置き換える
+	TripMode               TripMode
+	EmptyReturnRatePercent int // 空車回送の計上率（%）
+
+	// 高速道路利用で短縮する走行時間（分、CompareHighwayが設定。経由地・運行形態を反映した後に差し引く）
+	highwaySavedMinutes int
+}
+
+// applyRoute 経由地を反映したリクエストを返す（元のリクエストは変更しない）
+func (req *FareCalculationRequest) applyRoute() *FareCalculationRequest {
+	if req.Route == nil {
+		return req
+	}
+	applied := *req
+	if req.Route.HasLegs() {
+		applied.DistanceKmRaw = req.Route.TotalDistanceKm()
+		applied.DistanceKm = int(applied.DistanceKmRaw)
+		applied.DrivingMinutes = req.Route.TotalDurationMin()
+	}
+	applied.LoadingMinutes += req.Route.TotalStopLoadingMinutes()
+	return &applied
+}
+
+// applyTripMode 運行形態を反映したリクエストを返す（片道の場合はTripがnil）
+func (req *FareCalculationRequest) applyTripMode() (*FareCalculationRequest, *Trip, error) {
+	if req.TripMode == "" || req.TripMode == TripModeOneWay {
+		return req, nil, nil
+	}
+
+	oneWayKm := req.DistanceKmRaw
+	if oneWayKm == 0 {
+		oneWayKm = float64(req.DistanceKm)
+	}
+	trip, err := NewTrip(req.TripMode, req.EmptyReturnRatePercent, oneWayKm, req.DrivingMinutes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied := *req
+	applied.DistanceKmRaw = trip.DistanceKm()
+	applied.DistanceKm = int(trip.DistanceKm())
+	applied.DrivingMinutes = trip.DrivingMinutes()
+	return &applied, trip, nil
+}
+
+// applyHighway 高速道路利用で短縮する走行時間を反映したリクエストを返す（走行時間は1分以上）
+func (req *FareCalculationRequest) applyHighway() *FareCalculationRequest {
+	if req.highwaySavedMinutes <= 0 {
+		return req
+	}
+	applied := *req
+	applied.DrivingMinutes = max(1, req.DrivingMinutes-req.highwaySavedMinutes)
+	return &applied
 }
 
 // FareRanking 運賃ランキング
+// トラ協運賃（税抜）と赤帽運賃・高速料金（税込）を同じ基準で比較するため、税込額で順位付けする
 type FareRanking struct {
-	Rank int    // 順位（1が最安）
-	Type string // 運賃タイプ
-	Fare int    // 運賃額（円）
+	Rank        int    // 順位（1が最安）
+	Type        string // 運賃タイプ
+	Fare        int    // 運賃額（円、税込）
+	FareExclTax int    // 運賃額（円、税抜）
 }
 
 // FareComparisonResult 運賃比較結果
@@ -62,21 +226,43 @@ type FareComparisonResult struct {
 	VehicleCode int
 
 	// 共通情報（計算根拠表示用）
-	DistanceKmRaw  float64 // 元距離（km、小数点付き）
-	DrivingMinutes int     // 走行時間（分）
-	LoadingMinutes int     // 荷役時間（分）
+	DistanceKmRaw  float64   // 元距離（km、小数点付き）
+	DrivingMinutes int       // 走行時間（分）
+	LoadingMinutes int       // 荷役時間（分、経由地の荷役時間を含む）
+	QuoteDate      time.Time // 見積日
+
+	// 経由地を含むルート（経由地指定時のみ）
+	Route *MultiStopRoute
+
+	// 運行形態（往復・空車回送の場合のみ）。距離・走行時間は計上分
+	Trip *Trip
+
+	// 出発日時からの深夜時間・休日の判定結果（出発日時指定時のみ）
+	Departure *DepartureAnalysis
 
 	// 各運賃の計算結果
-	DistanceFareResult   *DistanceFareResult        // 距離制運賃（トラック用）
-	TimeFareResult       *TimeFareResult            // 時間制運賃（トラック用）
-	AkabouDistanceResult *AkabouDistanceFareResult  // 赤帽運賃（距離制、軽貨物用）
-	AkabouTimeResult     *AkabouTimeFareResult      // 赤帽運賃（時間制、軽貨物用）
+	DistanceFareResult   *DistanceFareResult         // 距離制運賃（トラック用）
+	TimeFareResult       *TimeFareResult             // 時間制運賃（トラック用）
+	AkabouDistanceResult *AkabouDistanceFareResult   // 赤帽運賃（距離制、軽貨物用）
+	AkabouTimeResult     *AkabouTimeFareResult       // 赤帽運賃（時間制、軽貨物用）
 	AdditionalFees       *AkabouAdditionalFeesResult // 赤帽付帯料金（軽貨物用）
+	JtaCharges           *JtaChargeResult            // トラ協付帯料金（トラック用）
+	FuelSurcharge        *FuelSurchargeResult        // 燃料サーチャージ（トラック用、指定時のみ）
+	Compliance           *ComplianceResult           // 改善基準告示の確認（トラック用、確認サービス設定時のみ）
+
+	// 運賃計算方式ごとの計算結果（登録順）
+	Results []*FareStrategyResult
 
 	// 比較・ランキング
-	Rankings     []FareRanking // 金額順ランキング
+	Rankings     []FareRanking // 金額順ランキング（税込）
 	CheapestType string        // 最安運賃タイプ
-	CheapestFare int           // 最安運賃額（円）
+	CheapestFare int           // 最安運賃額（円、税込）
+
+	// 荷主の契約運賃（荷主指定時のみ）
+	Contract *ContractFareResult
+
+	// 適用した消費税設定
+	TaxCalculator *TaxCalculator
 }
 
 // VehicleCodeLight 軽貨物/赤帽の車格コード
@@ -85,125 +271,303 @@ const VehicleCodeLight = 0
 // CalculateAll 運賃を一括計算する
 // 軽貨物（VehicleCode=0）の場合は赤帽のみ、2t以上（VehicleCode=1-4）の場合はトラ協のみを計算
 func (s *FareCalculatorService) CalculateAll(req *FareCalculationRequest) (*FareComparisonResult, error) {
+	req = req.applyRoute()
+	req, trip, err := req.applyTripMode()
+	if err != nil {
+		return nil, err
+	}
+	req = req.applyHighway()
+
+	quoteDate := req.QuoteDate
+	if quoteDate.IsZero() {
+		quoteDate = time.Now()
+	}
+
+	tax := s.tax
+	if tax == nil {
+		tax = DefaultTaxCalculator()
+	}
+
 	result := &FareComparisonResult{
 		VehicleCode:    req.VehicleCode,
 		DistanceKmRaw:  req.DistanceKmRaw,
 		DrivingMinutes: req.DrivingMinutes,
 		LoadingMinutes: req.LoadingMinutes,
+		QuoteDate:      quoteDate,
+		Route:          req.Route,
+		Trip:           trip,
+		TaxCalculator:  tax,
 	}
 
-	// 軽貨物（赤帽）の場合
-	if req.VehicleCode == VehicleCodeLight {
-		// 赤帽運賃（距離制）を計算
-		akabouDistanceResult, err := s.akabouFare.CalculateDistanceFare(
-			req.DistanceKm,
-			req.IsNight,
-			req.IsHoliday,
-			req.Area,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("赤帽距離制運賃計算エラー: %w", err)
-		}
-		result.AkabouDistanceResult = akabouDistanceResult
-
-		// 赤帽運賃（時間制）を計算
-		totalMinutes := req.DrivingMinutes + req.LoadingMinutes
-		akabouTimeResult, err := s.akabouFare.CalculateTimeFare(
-			totalMinutes,
-			req.IsNight,
-			req.IsHoliday,
-			req.Area,
-		)
+	// 深夜・休日割増の判定（出発日時指定時は運行時間帯から判定）
+	ctx := &FareContext{
+		Request:   req,
+		QuoteDate: quoteDate,
+		IsNight:   req.IsNight,
+		IsHoliday: req.IsHoliday,
+		Tax:       tax,
+	}
+	if !req.DepartureAt.IsZero() {
+		departure, err := AnalyzeDeparture(req.DepartureAt, req.DrivingMinutes+req.LoadingMinutes, s.holidays)
 		if err != nil {
-			return nil, fmt.Errorf("赤帽時間制運賃計算エラー: %w", err)
+			return nil, err
 		}
-		result.AkabouTimeResult = akabouTimeResult
-
-		// 付帯料金を計算
-		additionalFees := s.akabouFare.CalculateAdditionalFees(req.WorkMinutes, req.WaitingMinutes)
-		result.AdditionalFees = additionalFees
+		result.Departure = departure
+		ctx.Departure = departure
+		ctx.IsNight, ctx.IsHoliday = departure.IsNight(), departure.IsHoliday()
+	}
 
-		// 付帯料金をTotalFareに加算
-		if additionalFees.TotalFee > 0 {
-			result.AkabouDistanceResult.TotalFare += additionalFees.TotalFee
-			result.AkabouTimeResult.TotalFare += additionalFees.TotalFee
-		}
+	// 割増項目（車格ごとの適用可否を確認）
+	items, err := s.resolveSurchargeItems(req.SurchargeItems, req.VehicleCode)
+	if err != nil {
+		return nil, err
+	}
+	ctx.SurchargeItems = items
 
-		// ランキングを生成（赤帽のみ）
-		result.Rankings = s.createRankingsForLight(result)
+	// 車格ごとの共通項目（軽貨物は赤帽、2t以上はトラ協）
+	if req.VehicleCode == VehicleCodeLight {
+		err = s.prepareLight(ctx)
 	} else {
-		// 2t以上（トラ協）の場合
-		// 距離制運賃を計算
-		distanceResult, err := s.distanceFare.Calculate(
-			req.RegionCode,
-			req.VehicleCode,
-			req.DistanceKm,
-			req.IsNight,
-			req.IsHoliday,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("距離制運賃計算エラー: %w", err)
-		}
-		result.DistanceFareResult = distanceResult
-
-		// 時間制運賃を計算
-		timeResult, err := s.timeFare.Calculate(
-			req.RegionCode,
-			req.VehicleCode,
-			req.DistanceKm,
-			req.DrivingMinutes,
-			req.LoadingMinutes,
-			req.IsNight,
-			req.IsHoliday,
-			req.UseSimpleBaseKm,
-		)
+		err = s.prepareTruck(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.AdditionalFees = ctx.AdditionalFees
+	result.JtaCharges = ctx.JtaCharges
+	result.FuelSurcharge = ctx.FuelSurcharge
+	result.Compliance = ctx.Compliance
+
+	// 車格に対応する運賃計算方式をすべて計算
+	strategies := s.strategies.ForVehicle(req.VehicleCode)
+	if len(strategies) == 0 {
+		return nil, fmt.Errorf("車格コード%dに対応する運賃計算方式がありません", req.VehicleCode)
+	}
+	for _, strategy := range strategies {
+		r, err := strategy.Calculate(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("時間制運賃計算エラー: %w", err)
+			return nil, err
 		}
-		result.TimeFareResult = timeResult
-
-		// ランキングを生成（トラ協のみ）
-		result.Rankings = s.createRankingsForTruck(result)
+		result.addStrategyResult(r)
 	}
 
+	// ランキングを生成
+	result.Rankings = createRankings(result.Results)
+
 	// 最安値を設定
 	if len(result.Rankings) > 0 {
 		result.CheapestType = result.Rankings[0].Type
 		result.CheapestFare = result.Rankings[0].Fare
 	}
 
+	// 荷主の契約条件を適用
+	if req.CustomerID != 0 {
+		contract, err := s.applyCustomerPricing(req, quoteDate, result.Rankings, tax)
+		if err != nil {
+			return nil, err
+		}
+		result.Contract = contract
+	}
+
 	return result, nil
 }
 
-// createRankingsForLight 軽貨物用ランキングを生成（赤帽のみ）
-func (s *FareCalculatorService) createRankingsForLight(result *FareComparisonResult) []FareRanking {
-	rankings := []FareRanking{
-		{Type: "赤帽（距離制）", Fare: result.AkabouDistanceResult.TotalFare},
-		{Type: "赤帽（時間制）", Fare: result.AkabouTimeResult.TotalFare},
+// applyCustomerPricing 荷主の契約条件を標準運賃のランキングに適用する
+func (s *FareCalculatorService) applyCustomerPricing(req *FareCalculationRequest, quoteDate time.Time, rankings []FareRanking, tax *TaxCalculator) (*ContractFareResult, error) {
+	if s.customers == nil {
+		return nil, fmt.Errorf("荷主別の契約条件の取得元が設定されていません")
+	}
+	customer, err := s.customers.GetCustomer(req.CustomerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("荷主が登録されていません: %d", req.CustomerID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("荷主取得エラー: %w", err)
+	}
+	rules, err := s.customers.GetPricingRules(customer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("契約条件取得エラー: %w", err)
 	}
 
-	// 金額昇順でソート
-	sort.Slice(rankings, func(i, j int) bool {
-		return rankings[i].Fare < rankings[j].Fare
-	})
+	rule := selectPricingRule(rules, req.VehicleCode, req.RegionCode, quoteDate)
+	return newContractFareResult(customer, rule, rankings, tax), nil
+}
 
-	// 順位を設定
-	for i := range rankings {
-		rankings[i].Rank = i + 1
+// resolveTariffVersion 見積日に適用される運賃版を取得する
+// リゾルバー未設定・該当版なしの場合は nil（版指定なし）を返す
+func (s *FareCalculatorService) resolveTariffVersion(tariffType string, quoteDate time.Time) (*model.TariffVersion, error) {
+	return resolveTariffVersion(s.tariffVersionResolver, tariffType, quoteDate)
+}
+
+// resolveTariffVersion リゾルバーから見積日に適用される運賃版を取得する（運賃表と共通）
+func resolveTariffVersion(resolver TariffVersionResolver, tariffType string, quoteDate time.Time) (*model.TariffVersion, error) {
+	if resolver == nil {
+		return nil, nil
+	}
+	version, err := resolver.GetEffective(tariffType, quoteDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("運賃版取得エラー: %w", err)
 	}
+	return version, nil
+}
 
-	return rankings
+// resolveBodyType 車体種別の特殊車両割増を取得する
+// 車体種別が未指定・標準の場合は nil（割増なし）を返す
+func (s *FareCalculatorService) resolveBodyType(version *model.TariffVersion, bodyType string) (*model.BodyTypeSurcharge, error) {
+	if bodyType == "" || bodyType == model.BodyTypeStandard {
+		return nil, nil
+	}
+	if s.bodyTypes == nil {
+		return nil, fmt.Errorf("特殊車両割増の取得元が設定されていません")
+	}
+	surcharge, err := s.bodyTypes.GetBodyTypeSurcharge(version, bodyType)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("車体種別が登録されていません: %s", bodyType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("特殊車両割増取得エラー: %w", err)
+	}
+	return surcharge, nil
 }
 
-// createRankingsForTruck 2t以上用ランキングを生成（トラ協のみ）
-func (s *FareCalculatorService) createRankingsForTruck(result *FareComparisonResult) []FareRanking {
-	rankings := []FareRanking{
-		{Type: "距離制", Fare: result.DistanceFareResult.TotalFare},
-		{Type: "時間制", Fare: result.TimeFareResult.TotalFare},
+// resolveSurchargeItems 割増項目コードから割増項目を取得する
+// 同じ項目の重複指定は1つにまとめ、車格に適用できない項目はエラーとする
+func (s *FareCalculatorService) resolveSurchargeItems(codes []string, vehicleCode int) ([]*model.SurchargeItem, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	if s.surchargeItems == nil {
+		return nil, fmt.Errorf("割増項目の取得元が設定されていません")
+	}
+
+	var items []*model.SurchargeItem
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		item, err := s.surchargeItems.GetSurchargeItem(code)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("割増項目が登録されていません: %s", code)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("割増項目取得エラー: %w", err)
+		}
+		if !item.AppliesTo(vehicleCode) {
+			return nil, fmt.Errorf("割増項目「%s」はこの車格（車格コード%d）には適用できません", item.Name, vehicleCode)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// prepareLight 軽貨物（赤帽）の共通項目を計算する（適用運賃版・付帯料金）
+func (s *FareCalculatorService) prepareLight(ctx *FareContext) error {
+	version, err := s.resolveTariffVersion(model.TariffTypeAkabou, ctx.QuoteDate)
+	if err != nil {
+		return err
+	}
+	ctx.TariffVersion = version
+
+	// 付帯料金（距離制・時間制の両方に加算）
+	req := ctx.Request
+	fees, err := s.akabouFare.CalculateAdditionalFees(req.WorkMinutes, req.WaitingMinutes, WithTariffVersion(version))
+	if err != nil {
+		return fmt.Errorf("赤帽付帯料金計算エラー: %w", err)
+	}
+	ctx.AdditionalFees = fees
+	return nil
+}
+
+// prepareTruck トラック（トラ協）の共通項目を計算する（適用運賃版・特殊車両割増・付帯料金・燃料サーチャージ）
+func (s *FareCalculatorService) prepareTruck(ctx *FareContext) error {
+	version, err := s.resolveTariffVersion(model.TariffTypeJTA, ctx.QuoteDate)
+	if err != nil {
+		return err
+	}
+	ctx.TariffVersion = version
+
+	// 特殊車両割増（車体種別指定時）
+	req := ctx.Request
+	bodyType, err := s.resolveBodyType(version, req.BodyType)
+	if err != nil {
+		return err
+	}
+	ctx.BodyType = bodyType
+
+	// 付帯料金（距離制・時間制の両方に加算）
+	if s.jtaCharge != nil {
+		charges, err := s.jtaCharge.Calculate(req.VehicleCode, req.WorkMinutes, req.WaitingMinutes)
+		if err != nil {
+			return fmt.Errorf("付帯料金計算エラー: %w", err)
+		}
+		ctx.JtaCharges = charges
+	}
+
+	// 燃料サーチャージ（距離制・時間制の両方に加算）
+	if req.UseFuelSurcharge {
+		if s.fuelSurcharge == nil {
+			return fmt.Errorf("燃料サーチャージの計算サービスが設定されていません")
+		}
+		fuel, err := s.fuelSurcharge.Calculate(req.VehicleCode, req.DistanceKm, ctx.QuoteDate, WithTariffVersion(version))
+		if err != nil {
+			return fmt.Errorf("燃料サーチャージ計算エラー: %w", err)
+		}
+		ctx.FuelSurcharge = fuel
+	}
+
+	// 改善基準告示の確認（経由地・運行形態を反映した走行時間・荷役時間で確認）
+	if req.IncludeComplianceTime && s.compliance == nil {
+		return fmt.Errorf("改善基準告示の確認サービスが設定されていません")
+	}
+	if s.compliance != nil {
+		ctx.Compliance = s.compliance.Check(req.DrivingMinutes, req.LoadingMinutes)
+	}
+	return nil
+}
+
+// addStrategyResult 運賃計算方式の計算結果を追加する（標準の運賃は個別の結果にも設定）
+func (r *FareComparisonResult) addStrategyResult(sr *FareStrategyResult) {
+	r.Results = append(r.Results, sr)
+	switch d := sr.Detail.(type) {
+	case *DistanceFareResult:
+		r.DistanceFareResult = d
+	case *TimeFareResult:
+		r.TimeFareResult = d
+	case *AkabouDistanceFareResult:
+		r.AkabouDistanceResult = d
+	case *AkabouTimeFareResult:
+		r.AkabouTimeResult = d
+	}
+}
+
+// ExtraResults 標準の運賃（トラ協・赤帽）以外に追加した運賃計算方式の計算結果
+func (r *FareComparisonResult) ExtraResults() []*FareStrategyResult {
+	var extra []*FareStrategyResult
+	for _, sr := range r.Results {
+		switch sr.Detail.(type) {
+		case *DistanceFareResult, *TimeFareResult, *AkabouDistanceFareResult, *AkabouTimeFareResult:
+			continue
+		}
+		extra = append(extra, sr)
+	}
+	return extra
+}
+
+// createRankings 運賃計算方式ごとの結果から金額順ランキングを生成（同額は登録順）
+func createRankings(results []*FareStrategyResult) []FareRanking {
+	rankings := make([]FareRanking, len(results))
+	for i, sr := range results {
+		rankings[i] = newFareRanking(sr.Name, sr.Tax)
 	}
 
 	// 金額昇順でソート
-	sort.Slice(rankings, func(i, j int) bool {
+	sort.SliceStable(rankings, func(i, j int) bool {
 		return rankings[i].Fare < rankings[j].Fare
 	})
 
@@ -215,36 +579,67 @@ func (s *FareCalculatorService) createRankingsForTruck(result *FareComparisonRes
 	return rankings
 }
 
+// newFareRanking 税込・税抜額からランキング要素を作成する
+func newFareRanking(fareType string, tax *TaxAmount) FareRanking {
+	return FareRanking{Type: fareType, Fare: tax.Inclusive, FareExclTax: tax.Exclusive}
+}
+
+// taxAmountPtr TaxAmountのポインタを返す
+func taxAmountPtr(a TaxAmount) *TaxAmount {
+	return &a
+}
+
 // Breakdown 計算根拠を文字列で返す
 func (r *FareComparisonResult) Breakdown() string {
 	result := "========================================\n"
 	result += "【運賃比較結果】\n"
 	result += "========================================\n\n"
 
+	if !r.QuoteDate.IsZero() {
+		result += fmt.Sprintf("見積日: %s\n\n", r.QuoteDate.Format(model.TariffDateFormat))
+	}
+
+	if r.Route != nil {
+		result += r.Route.Breakdown() + "\n"
+	}
+
+	if r.Trip != nil {
+		result += r.Trip.Breakdown() + "\n"
+	}
+
+	if r.Departure != nil {
+		result += r.Departure.Breakdown() + "\n"
+	}
+
+	if r.FuelSurcharge != nil {
+		result += r.FuelSurcharge.Breakdown() + "\n"
+	}
+
 	// ランキング表示
 	result += "【ランキング】\n"
+	if r.TaxCalculator != nil {
+		result += fmt.Sprintf("  消費税: %s（税込で比較）\n", r.TaxCalculator.Label())
+	}
 	for _, ranking := range r.Rankings {
 		marker := ""
 		if ranking.Rank == 1 {
 			marker = " ← 最安"
 		}
-		result += fmt.Sprintf("  %d位: %s %d円%s\n", ranking.Rank, ranking.Type, ranking.Fare, marker)
+		result += fmt.Sprintf("  %d位: %s %d円（税抜 %d円）%s\n", ranking.Rank, ranking.Type, ranking.Fare, ranking.FareExclTax, marker)
 	}
 	result += "\n"
 
-	// 各運賃の詳細（車格に応じて表示）
-	if r.VehicleCode == VehicleCodeLight {
-		// 軽貨物: 赤帽のみ
-		result += "----------------------------------------\n"
-		result += r.AkabouDistanceResult.Breakdown()
-		result += "\n----------------------------------------\n"
-		result += r.AkabouTimeResult.Breakdown()
-	} else {
-		// トラック: トラ協のみ
+	if r.Contract != nil {
+		result += r.Contract.Breakdown() + "\n"
+	}
+
+	// 各運賃の詳細（計算した運賃計算方式の順）
+	for i, sr := range r.Results {
+		if i > 0 {
+			result += "\n"
+		}
 		result += "----------------------------------------\n"
-		result += r.DistanceFareResult.Breakdown()
-		result += "\n----------------------------------------\n"
-		result += r.TimeFareResult.Breakdown()
+		result += sr.Breakdown()
 	}
 
 	return result
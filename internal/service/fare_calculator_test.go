@@ -1,7 +1,9 @@
 package service
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/y-suzuki/standard-truck-rate/internal/model"
 )
@@ -9,7 +11,7 @@ import (
 // MockTimeFareGetter テスト用モック
 type MockTimeFareGetter struct{}
 
-func (m *MockTimeFareGetter) GetBaseFare(regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
+func (m *MockTimeFareGetter) GetBaseFare(version *model.TariffVersion, regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
 	// 関東・大型車・8時間制の場合
 	if regionCode == 3 && vehicleCode == 3 && hours == 8 {
 		return &model.JtaTimeBaseFare{
@@ -39,7 +41,7 @@ func (m *MockTimeFareGetter) GetBaseFare(regionCode, vehicleCode, hours int) (*m
 	}, nil
 }
 
-func (m *MockTimeFareGetter) GetSurcharge(regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
+func (m *MockTimeFareGetter) GetSurcharge(version *model.TariffVersion, regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
 	if surchargeType == "distance" {
 		return &model.JtaTimeSurcharge{
 			RegionCode:    regionCode,
@@ -59,7 +61,7 @@ func (m *MockTimeFareGetter) GetSurcharge(regionCode, vehicleCode int, surcharge
 // MockFareGetter 距離制運賃用モック
 type MockFareGetter struct{}
 
-func (m *MockFareGetter) GetDistanceFareYen(regionCode, vehicleCode, distanceKm int) (int, error) {
+func (m *MockFareGetter) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
 	// 関東・大型車・100kmの場合
 	if regionCode == 3 && vehicleCode == 3 && distanceKm == 100 {
 		return 35000, nil
@@ -76,7 +78,7 @@ func TestFareCalculatorService_CalculateAll_Truck(t *testing.T) {
 	// サービスを作成
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -143,7 +145,7 @@ func TestFareCalculatorService_CalculateAll_Light(t *testing.T) {
 	// サービスを作成
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -209,7 +211,7 @@ func TestFareCalculatorService_CalculateAll_Light(t *testing.T) {
 func TestFareCalculatorService_Rankings(t *testing.T) {
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -255,7 +257,7 @@ func TestFareCalculatorService_Rankings(t *testing.T) {
 func TestFareCalculatorService_WithSurcharges_Truck(t *testing.T) {
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -308,7 +310,7 @@ func TestFareCalculatorService_WithSurcharges_Truck(t *testing.T) {
 func TestFareCalculatorService_WithSurcharges_Light(t *testing.T) {
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -361,7 +363,7 @@ func TestFareCalculatorService_WithSurcharges_Light(t *testing.T) {
 func TestFareCalculatorService_Breakdown_Truck(t *testing.T) {
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -405,7 +407,7 @@ func TestFareCalculatorService_Breakdown_Truck(t *testing.T) {
 func TestFareCalculatorService_Breakdown_Light(t *testing.T) {
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -442,7 +444,7 @@ func TestFareCalculatorService_Breakdown_Light(t *testing.T) {
 func TestFareCalculatorService_AreaSurcharge(t *testing.T) {
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -495,7 +497,7 @@ func TestFareCalculatorService_AreaSurcharge(t *testing.T) {
 func TestFareCalculatorService_DistanceKmRaw(t *testing.T) {
 	distanceFareService := NewDistanceFareService(&MockFareGetter{})
 	timeFareService := NewTimeFareService(&MockTimeFareGetter{})
-	akabouFareService := NewAkabouFareService()
+	akabouFareService := NewAkabouFareService(&mockAkabouFareGetter{})
 
 	calculator := NewFareCalculatorService(distanceFareService, timeFareService, akabouFareService)
 
@@ -548,6 +550,220 @@ func TestFareCalculatorService_DistanceKmRaw(t *testing.T) {
 	}
 }
 
+// mockTariffVersionResolver 運賃版リゾルバーのモック
+type mockTariffVersionResolver struct {
+	versions []*model.TariffVersion
+}
+
+func (m *mockTariffVersionResolver) GetEffective(tariffType string, date time.Time) (*model.TariffVersion, error) {
+	for _, v := range m.versions {
+		if v.TariffType == tariffType && v.Covers(date) {
+			return v, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// TestFareCalculatorService_TariffVersion 見積日による運賃版の適用テスト
+func TestFareCalculatorService_TariffVersion(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	oldTo := time.Date(2024, 3, 21, 0, 0, 0, 0, time.Local)
+	oldVersion := &model.TariffVersion{ID: 1, TariffType: model.TariffTypeJTA, Name: "令和2年4月告示", EffectiveFrom: time.Date(2020, 4, 24, 0, 0, 0, 0, time.Local), EffectiveTo: &oldTo}
+	newVersion := &model.TariffVersion{ID: 2, TariffType: model.TariffTypeJTA, Name: "令和6年3月告示", EffectiveFrom: time.Date(2024, 3, 22, 0, 0, 0, 0, time.Local)}
+	calculator.SetTariffVersionResolver(&mockTariffVersionResolver{
+		versions: []*model.TariffVersion{oldVersion, newVersion},
+	})
+
+	tests := []struct {
+		name        string
+		vehicleCode int
+		quoteDate   time.Time
+		want        *model.TariffVersion
+	}{
+		{name: "旧版の期間", vehicleCode: 3, quoteDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local), want: oldVersion},
+		{name: "新版の開始日", vehicleCode: 3, quoteDate: time.Date(2024, 3, 22, 0, 0, 0, 0, time.Local), want: newVersion},
+		{name: "該当版なし", vehicleCode: 3, quoteDate: time.Date(2019, 1, 1, 0, 0, 0, 0, time.Local), want: nil},
+		{name: "赤帽（版未登録）", vehicleCode: 0, quoteDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calculator.CalculateAll(&FareCalculationRequest{
+				RegionCode:     3,
+				VehicleCode:    tt.vehicleCode,
+				DistanceKm:     100,
+				DrivingMinutes: 120,
+				LoadingMinutes: 60,
+				QuoteDate:      tt.quoteDate,
+			})
+			if err != nil {
+				t.Fatalf("CalculateAll failed: %v", err)
+			}
+
+			if tt.vehicleCode == VehicleCodeLight {
+				if result.AkabouDistanceResult.TariffVersion != tt.want {
+					t.Errorf("AkabouDistanceResult.TariffVersion = %v, want %v", result.AkabouDistanceResult.TariffVersion, tt.want)
+				}
+				return
+			}
+			if result.DistanceFareResult.TariffVersion != tt.want {
+				t.Errorf("DistanceFareResult.TariffVersion = %v, want %v", result.DistanceFareResult.TariffVersion, tt.want)
+			}
+			if result.TimeFareResult.TariffVersion != tt.want {
+				t.Errorf("TimeFareResult.TariffVersion = %v, want %v", result.TimeFareResult.TariffVersion, tt.want)
+			}
+			if !result.QuoteDate.Equal(tt.quoteDate) {
+				t.Errorf("QuoteDate = %v, want %v", result.QuoteDate, tt.quoteDate)
+			}
+		})
+	}
+}
+
+// TestFareCalculatorService_JtaCharges トラ協付帯料金が距離制・時間制の両方に加算されること
+func TestFareCalculatorService_JtaCharges(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+	calculator.SetJtaChargeService(NewJtaChargeService(&mockChargeDataGetter{}))
+
+	// 関東・大型車・100km・積込60分・待機90分
+	result, err := calculator.CalculateAll(&FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    3,
+		DistanceKm:     100,
+		DrivingMinutes: 120,
+		LoadingMinutes: 60,
+		WorkMinutes:    60,
+		WaitingMinutes: 90,
+	})
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+
+	if result.JtaCharges == nil {
+		t.Fatal("JtaCharges should not be nil")
+	}
+	// 積込・取卸料: 60分 → 4,400円、待機時間料: 超過60分 → 4,400円
+	wantHandling, wantWaiting := 4400, 4400
+	if result.JtaCharges.HandlingCharge != wantHandling || result.JtaCharges.WaitingCharge != wantWaiting {
+		t.Errorf("JtaCharges = %+v, want handling=%d waiting=%d", result.JtaCharges, wantHandling, wantWaiting)
+	}
+
+	// 距離制: 35,000 + 8,800
+	if result.DistanceFareResult.TotalFare != 35000+8800 {
+		t.Errorf("DistanceFare TotalFare = %d, want %d", result.DistanceFareResult.TotalFare, 35000+8800)
+	}
+	if result.DistanceFareResult.HandlingCharge != wantHandling || result.DistanceFareResult.WaitingCharge != wantWaiting {
+		t.Errorf("DistanceFareResult charges = %d/%d, want %d/%d",
+			result.DistanceFareResult.HandlingCharge, result.DistanceFareResult.WaitingCharge, wantHandling, wantWaiting)
+	}
+
+	// 時間制: 付帯料金なしの合計 + 8,800
+	without, err := NewTimeFareService(&MockTimeFareGetter{}).Calculate(3, 3, 100, 120, 60, false, false, false)
+	if err != nil {
+		t.Fatalf("TimeFare Calculate failed: %v", err)
+	}
+	if result.TimeFareResult.TotalFare != without.TotalFare+8800 {
+		t.Errorf("TimeFare TotalFare = %d, want %d", result.TimeFareResult.TotalFare, without.TotalFare+8800)
+	}
+
+	// ランキングに付帯料金込みの金額（税抜）が使われること
+	for _, r := range result.Rankings {
+		if r.Type == "距離制" && r.FareExclTax != result.DistanceFareResult.TotalFare {
+			t.Errorf("Ranking 距離制 = %d, want %d", r.FareExclTax, result.DistanceFareResult.TotalFare)
+		}
+		if r.Type == "時間制" && r.FareExclTax != result.TimeFareResult.TotalFare {
+			t.Errorf("Ranking 時間制 = %d, want %d", r.FareExclTax, result.TimeFareResult.TotalFare)
+		}
+	}
+
+	breakdown := result.Breakdown()
+	if !containsString(breakdown, "積込・取卸料: +4400円") || !containsString(breakdown, "待機時間料: +4400円") {
+		t.Errorf("Breakdown に付帯料金が含まれていない:\n%s", breakdown)
+	}
+}
+
+// TestFareCalculatorService_FuelSurcharge 燃料サーチャージが指定時のみ加算されること
+func TestFareCalculatorService_FuelSurcharge(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	req := &FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    3,
+		DistanceKm:     100,
+		DrivingMinutes: 120,
+		LoadingMinutes: 60,
+	}
+
+	// サービス未設定で指定した場合はエラー
+	req.UseFuelSurcharge = true
+	if _, err := calculator.CalculateAll(req); err == nil {
+		t.Error("エラーが期待されたが、発生しなかった")
+	}
+
+	calculator.SetFuelSurchargeService(NewFuelSurchargeService(&mockFuelSurchargeGetter{
+		price: &model.FuelPrice{YearMonth: "2026-10", PriceYen: 150.5, ReferencePriceYen: 120},
+	}))
+
+	// 指定なしの場合は加算しない
+	req.UseFuelSurcharge = false
+	without, err := calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if without.FuelSurcharge != nil {
+		t.Errorf("FuelSurcharge = %+v, want nil", without.FuelSurcharge)
+	}
+
+	// 指定ありの場合は距離制・時間制の両方に加算（100km迄 130円 × 7刻み）
+	req.UseFuelSurcharge = true
+	result, err := calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if result.FuelSurcharge == nil || result.FuelSurcharge.Surcharge != 910 {
+		t.Fatalf("FuelSurcharge = %+v, want 910円", result.FuelSurcharge)
+	}
+	if result.DistanceFareResult.FuelSurcharge != 910 || result.DistanceFareResult.TotalFare != without.DistanceFareResult.TotalFare+910 {
+		t.Errorf("DistanceFareResult: FuelSurcharge=%d TotalFare=%d", result.DistanceFareResult.FuelSurcharge, result.DistanceFareResult.TotalFare)
+	}
+	if result.TimeFareResult.FuelSurcharge != 910 || result.TimeFareResult.TotalFare != without.TimeFareResult.TotalFare+910 {
+		t.Errorf("TimeFareResult: FuelSurcharge=%d TotalFare=%d", result.TimeFareResult.FuelSurcharge, result.TimeFareResult.TotalFare)
+	}
+	// 最安運賃は税込（サーチャージ910円 + 消費税91円）
+	if result.CheapestFare != without.CheapestFare+1001 {
+		t.Errorf("CheapestFare = %d, want %d", result.CheapestFare, without.CheapestFare+1001)
+	}
+
+	breakdown := result.Breakdown()
+	for _, want := range []string{"燃料価格: 2026-10 軽油 150.5円/L", "燃料サーチャージ: +910円"} {
+		if !containsString(breakdown, want) {
+			t.Errorf("Breakdown に %q が含まれていない:\n%s", want, breakdown)
+		}
+	}
+
+	// 軽貨物は対象外
+	req.VehicleCode = VehicleCodeLight
+	light, err := calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if light.FuelSurcharge != nil {
+		t.Errorf("軽貨物の FuelSurcharge = %+v, want nil", light.FuelSurcharge)
+	}
+}
+
 // containsString 文字列に部分文字列が含まれるか
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsStringHelper(s, substr))
@@ -561,3 +777,460 @@ func containsStringHelper(s, substr string) bool {
 	}
 	return false
 }
+
+// TestFareCalculatorService_Tax トラ協（税抜）と赤帽（税込）が税込で比較されること
+func TestFareCalculatorService_Tax(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	// トラ協運賃: 税抜 → 税込
+	result, err := calculator.CalculateAll(&FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    3,
+		DistanceKm:     100,
+		DrivingMinutes: 120,
+		LoadingMinutes: 60,
+	})
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	distance := result.DistanceFareResult
+	if distance.Tax == nil || distance.Tax.Exclusive != distance.TotalFare || distance.Tax.Inclusive != distance.TotalFare+distance.TotalFare/10 {
+		t.Errorf("DistanceFareResult.Tax = %+v, TotalFare = %d", distance.Tax, distance.TotalFare)
+	}
+	for _, r := range result.Rankings {
+		if r.Type == "距離制" && (r.Fare != distance.Tax.Inclusive || r.FareExclTax != distance.Tax.Exclusive) {
+			t.Errorf("Ranking 距離制 = %+v, want %+v", r, distance.Tax)
+		}
+	}
+	if !containsString(result.Breakdown(), "消費税: 10%・切り捨て") {
+		t.Errorf("Breakdown に消費税設定が含まれていない:\n%s", result.Breakdown())
+	}
+
+	// 赤帽運賃: 税込 → 税抜、四捨五入
+	tax, err := NewTaxCalculator(10, TaxRoundingRound)
+	if err != nil {
+		t.Fatalf("NewTaxCalculator failed: %v", err)
+	}
+	calculator.SetTaxCalculator(tax)
+	result, err = calculator.CalculateAll(&FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    VehicleCodeLight,
+		DistanceKm:     30,
+		DrivingMinutes: 60,
+		LoadingMinutes: 30,
+	})
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	akabou := result.AkabouDistanceResult
+	if akabou.Tax == nil || akabou.Tax.Inclusive != akabou.TotalFare || akabou.Tax.Exclusive+akabou.Tax.Tax != akabou.TotalFare {
+		t.Errorf("AkabouDistanceResult.Tax = %+v, TotalFare = %d", akabou.Tax, akabou.TotalFare)
+	}
+	if result.TaxCalculator != tax {
+		t.Errorf("TaxCalculator = %+v, want %+v", result.TaxCalculator, tax)
+	}
+	if result.CheapestFare != result.Rankings[0].Fare {
+		t.Errorf("CheapestFare = %d, want %d", result.CheapestFare, result.Rankings[0].Fare)
+	}
+}
+
+// TestFareCalculatorService_DepartureAt 出発日時から深夜割増を按分し、休日を判定すること
+func TestFareCalculatorService_DepartureAt(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	// 金曜 21:00 出発、運行3時間（深夜 2時間 / 日中 1時間）
+	req := &FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    3,
+		DistanceKm:     100,
+		DrivingMinutes: 120,
+		LoadingMinutes: 60,
+		IsHoliday:      true, // 出発日時指定時は無視される
+		DepartureAt:    time.Date(2026, 10, 16, 21, 0, 0, 0, time.Local),
+	}
+	result, err := calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+
+	if result.Departure == nil || result.Departure.NightMinutes != 120 {
+		t.Fatalf("Departure = %+v, want NightMinutes=120", result.Departure)
+	}
+	distance := result.DistanceFareResult
+	if !distance.IsNight || distance.IsHoliday {
+		t.Errorf("IsNight=%v IsHoliday=%v, want true/false", distance.IsNight, distance.IsHoliday)
+	}
+	// 深夜割増は 基本運賃 × 30% × 120/180
+	wantNight := int(float64(distance.BaseFare) * (NightSurchargeRate - 1.0) * 120 / 180)
+	if distance.NightSurcharge != wantNight {
+		t.Errorf("NightSurcharge = %d, want %d", distance.NightSurcharge, wantNight)
+	}
+	timeResult := result.TimeFareResult
+	wantTimeNight := int(float64(timeResult.SubTotal) * (NightSurchargeRate - 1.0) * 120 / 180)
+	if timeResult.NightSurcharge != wantTimeNight {
+		t.Errorf("TimeFare NightSurcharge = %d, want %d", timeResult.NightSurcharge, wantTimeNight)
+	}
+
+	breakdown := result.Breakdown()
+	for _, want := range []string{"深夜（22:00-05:00）: 120分 / 日中: 60分", "深夜120分/運行180分"} {
+		if !containsString(breakdown, want) {
+			t.Errorf("Breakdown に %q が含まれていない:\n%s", want, breakdown)
+		}
+	}
+
+	// 日曜日の出発は休日割増、祝日判定を設定すると祝日も休日割増
+	req.DepartureAt = time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	result, err = calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if result.DistanceFareResult.IsNight || !result.DistanceFareResult.IsHoliday {
+		t.Errorf("日曜日: IsNight=%v IsHoliday=%v, want false/true", result.DistanceFareResult.IsNight, result.DistanceFareResult.IsHoliday)
+	}
+
+	req.DepartureAt = time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local)
+	calculator.SetHolidayChecker(&mockHolidayChecker{names: map[string]string{"2026-10-12": "スポーツの日"}})
+	result, err = calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if !result.DistanceFareResult.IsHoliday {
+		t.Error("祝日: IsHoliday = false, want true")
+	}
+}
+
+func TestFareCalculatorService_MultiStopRoute(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	// 区間の合計 50.5 + 49.8 = 100.3km / 90 + 60 = 150分、経由地2か所 × 30分
+	result, err := calculator.CalculateAll(&FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    3,
+		LoadingMinutes: 60,
+		Route: &MultiStopRoute{
+			Waypoints: []string{"神奈川県横浜市", "神奈川県川崎市"},
+			Legs: []RouteLeg{
+				{Origin: "東京都千代田区", Dest: "神奈川県横浜市", DistanceKm: 30.2, DurationMin: 50},
+				{Origin: "神奈川県横浜市", Dest: "神奈川県川崎市", DistanceKm: 20.3, DurationMin: 40},
+				{Origin: "神奈川県川崎市", Dest: "東京都江東区", DistanceKm: 49.8, DurationMin: 60},
+			},
+			StopLoadingMinutes: 30,
+		},
+	})
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+
+	if result.DistanceKmRaw != 100.3 || result.DrivingMinutes != 150 {
+		t.Errorf("DistanceKmRaw = %.1f, DrivingMinutes = %d, want 100.3/150", result.DistanceKmRaw, result.DrivingMinutes)
+	}
+	if result.LoadingMinutes != 120 {
+		t.Errorf("LoadingMinutes = %d, want 120（60 + 30 × 2）", result.LoadingMinutes)
+	}
+	if result.DistanceFareResult.DistanceKm != 100 || result.DistanceFareResult.BaseFare != 35000 {
+		t.Errorf("DistanceFareResult = %dkm / %d円, want 100km / 35000円", result.DistanceFareResult.DistanceKm, result.DistanceFareResult.BaseFare)
+	}
+	if result.TimeFareResult.LoadingMinutes != 120 {
+		t.Errorf("TimeFareResult.LoadingMinutes = %d, want 120", result.TimeFareResult.LoadingMinutes)
+	}
+
+	breakdown := result.Breakdown()
+	for _, want := range []string{"【経由ルート】（経由地2か所）", "区間2: 神奈川県横浜市 → 神奈川県川崎市  20.3km / 40分", "合計: 100.3km / 150分", "30分 × 2か所 = 60分"} {
+		if !containsString(breakdown, want) {
+			t.Errorf("Breakdown に %q が含まれていない:\n%s", want, breakdown)
+		}
+	}
+
+	// 手入力（区間なし）の場合は入力した距離・走行時間に経由地の荷役時間のみ加算
+	result, err = calculator.CalculateAll(&FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    3,
+		DistanceKm:     550,
+		DrivingMinutes: 360,
+		LoadingMinutes: 60,
+		Route:          &MultiStopRoute{Waypoints: []string{"静岡県静岡市"}, StopLoadingMinutes: 45},
+	})
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if result.DistanceFareResult.DistanceKm != 550 || result.LoadingMinutes != 105 {
+		t.Errorf("DistanceKm = %d, LoadingMinutes = %d, want 550/105", result.DistanceFareResult.DistanceKm, result.LoadingMinutes)
+	}
+}
+
+func TestFareCalculatorService_TripMode(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	// 片道 50km / 走行120分 + 荷役60分 = 3時間 → 4時間制
+	newRequest := func(mode TripMode, ratePercent int) *FareCalculationRequest {
+		return &FareCalculationRequest{
+			RegionCode:             3,
+			VehicleCode:            3,
+			DistanceKm:             50,
+			DistanceKmRaw:          50.0,
+			DrivingMinutes:         120,
+			LoadingMinutes:         60,
+			TripMode:               mode,
+			EmptyReturnRatePercent: ratePercent,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		req           *FareCalculationRequest
+		wantKm        int
+		wantMinutes   int
+		wantHours     int
+		wantBreakdown string
+	}{
+		{"片道", newRequest(TripModeOneWay, 0), 50, 120, 4, ""},
+		{"往復は距離・時間が2倍で8時間制", newRequest(TripModeRoundTrip, 0), 100, 240, 8, "運行形態: 往復"},
+		{"空車回送50%は4時間制のまま", newRequest(TripModeEmptyReturn, 50), 75, 180, 4, "運行形態: 片道＋空車回送（回送50%）"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calculator.CalculateAll(tt.req)
+			if err != nil {
+				t.Fatalf("CalculateAll failed: %v", err)
+			}
+			if result.DistanceFareResult.DistanceKm != tt.wantKm {
+				t.Errorf("距離制 DistanceKm = %d, want %d", result.DistanceFareResult.DistanceKm, tt.wantKm)
+			}
+			if result.TimeFareResult.DistanceKm != tt.wantKm || result.TimeFareResult.DrivingMinutes != tt.wantMinutes {
+				t.Errorf("時間制 DistanceKm = %d, DrivingMinutes = %d, want %d/%d",
+					result.TimeFareResult.DistanceKm, result.TimeFareResult.DrivingMinutes, tt.wantKm, tt.wantMinutes)
+			}
+			if result.TimeFareResult.AppliedHours != tt.wantHours {
+				t.Errorf("AppliedHours = %d, want %d", result.TimeFareResult.AppliedHours, tt.wantHours)
+			}
+			if tt.wantBreakdown == "" {
+				if result.Trip != nil {
+					t.Errorf("片道で Trip = %+v, want nil", result.Trip)
+				}
+			} else if !containsString(result.Breakdown(), tt.wantBreakdown) {
+				t.Errorf("Breakdown に %q が含まれていない:\n%s", tt.wantBreakdown, result.Breakdown())
+			}
+		})
+	}
+
+	// 元のリクエストは変更しない
+	req := newRequest(TripModeRoundTrip, 0)
+	if _, err := calculator.CalculateAll(req); err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if req.DistanceKm != 50 || req.DrivingMinutes != 120 {
+		t.Errorf("リクエストが変更された: DistanceKm = %d, DrivingMinutes = %d", req.DistanceKm, req.DrivingMinutes)
+	}
+
+	if _, err := calculator.CalculateAll(newRequest(TripModeEmptyReturn, 120)); err == nil {
+		t.Error("回送計上率120%でエラーが発生しなかった")
+	}
+}
+
+// mockBodyTypeSurchargeGetter テスト用の特殊車両割増取得モック
+type mockBodyTypeSurchargeGetter struct{}
+
+func (m *mockBodyTypeSurchargeGetter) GetBodyTypeSurcharge(version *model.TariffVersion, bodyType string) (*model.BodyTypeSurcharge, error) {
+	if bodyType == "refrigerated" {
+		return &model.BodyTypeSurcharge{BodyType: bodyType, Name: "冷蔵車・冷凍車", SurchargePercent: 20}, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestFareCalculatorService_BodyType(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	newRequest := func(bodyType string) *FareCalculationRequest {
+		return &FareCalculationRequest{
+			RegionCode:     3,
+			VehicleCode:    3,
+			DistanceKm:     100,
+			DistanceKmRaw:  100.0,
+			DrivingMinutes: 120,
+			LoadingMinutes: 60,
+			BodyType:       bodyType,
+		}
+	}
+
+	// 取得元が未設定の場合、標準以外はエラー
+	if _, err := calculator.CalculateAll(newRequest("refrigerated")); err == nil {
+		t.Error("取得元未設定で特殊車両を指定してもエラーが発生しなかった")
+	}
+
+	calculator.SetBodyTypeSurchargeGetter(&mockBodyTypeSurchargeGetter{})
+
+	standard, err := calculator.CalculateAll(newRequest(""))
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if standard.DistanceFareResult.BodyType != nil || standard.DistanceFareResult.BodyTypeSurcharge != 0 {
+		t.Errorf("標準で特殊車両割増が適用された: %+v", standard.DistanceFareResult)
+	}
+
+	result, err := calculator.CalculateAll(newRequest("refrigerated"))
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+
+	// 距離制: 基本運賃の2割増
+	d := result.DistanceFareResult
+	if want := d.BaseFare * 20 / 100; d.BodyTypeSurcharge != want {
+		t.Errorf("距離制 BodyTypeSurcharge = %d, want %d", d.BodyTypeSurcharge, want)
+	}
+	if d.TotalFare != standard.DistanceFareResult.TotalFare+d.BodyTypeSurcharge {
+		t.Errorf("距離制 TotalFare = %d, want %d", d.TotalFare, standard.DistanceFareResult.TotalFare+d.BodyTypeSurcharge)
+	}
+
+	// 時間制: 割増前の小計の2割増
+	tf := result.TimeFareResult
+	if want := tf.SubTotal * 20 / 100; tf.BodyTypeSurcharge != want {
+		t.Errorf("時間制 BodyTypeSurcharge = %d, want %d", tf.BodyTypeSurcharge, want)
+	}
+	if tf.TotalFare != standard.TimeFareResult.TotalFare+tf.BodyTypeSurcharge {
+		t.Errorf("時間制 TotalFare = %d, want %d", tf.TotalFare, standard.TimeFareResult.TotalFare+tf.BodyTypeSurcharge)
+	}
+
+	if !containsString(result.Breakdown(), "特殊車両割増: +") || !containsString(result.Breakdown(), "冷蔵車・冷凍車 20%増") {
+		t.Errorf("Breakdown に特殊車両割増が含まれていない:\n%s", result.Breakdown())
+	}
+
+	// 未登録の車体種別はエラー
+	if _, err := calculator.CalculateAll(newRequest("unknown")); err == nil {
+		t.Error("未登録の車体種別でエラーが発生しなかった")
+	}
+}
+
+// mockSurchargeItemGetter テスト用の割増項目取得モック
+type mockSurchargeItemGetter map[string]*model.SurchargeItem
+
+func (m mockSurchargeItemGetter) GetSurchargeItem(code string) (*model.SurchargeItem, error) {
+	if item, ok := m[code]; ok {
+		return item, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestFareCalculatorService_SurchargeItems(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	newRequest := func(vehicleCode int, codes ...string) *FareCalculationRequest {
+		return &FareCalculationRequest{
+			RegionCode:     3,
+			VehicleCode:    vehicleCode,
+			DistanceKm:     100,
+			DistanceKmRaw:  100.0,
+			DrivingMinutes: 120,
+			LoadingMinutes: 60,
+			IsNight:        true,
+			SurchargeItems: codes,
+		}
+	}
+
+	// 取得元が未設定の場合はエラー
+	if _, err := calculator.CalculateAll(newRequest(3, "express")); err == nil {
+		t.Error("取得元未設定で割増項目を指定してもエラーが発生しなかった")
+	}
+
+	calculator.SetSurchargeItemGetter(mockSurchargeItemGetter{
+		"express":      {Code: "express", Name: "速達割増", CalcType: model.SurchargeCalcTypeRate, RatePercent: 20},
+		"hand_loading": {Code: "hand_loading", Name: "手積み・手降ろし", CalcType: model.SurchargeCalcTypeFixed, AmountYen: 3000, VehicleCodes: "1,2,3,4"},
+	})
+
+	t.Run("トラック", func(t *testing.T) {
+		standard, err := calculator.CalculateAll(newRequest(3))
+		if err != nil {
+			t.Fatalf("CalculateAll failed: %v", err)
+		}
+		result, err := calculator.CalculateAll(newRequest(3, "express", "hand_loading", "express"))
+		if err != nil {
+			t.Fatalf("CalculateAll failed: %v", err)
+		}
+
+		// 距離制: 基本運賃の20% + 3000円（深夜割増の対象外）
+		d := result.DistanceFareResult
+		if len(d.SurchargeItems) != 2 {
+			t.Fatalf("距離制 SurchargeItems = %d件, want 2（重複指定は1つにまとめる）", len(d.SurchargeItems))
+		}
+		if want := d.BaseFare*20/100 + 3000; d.SurchargeItemsTotal != want {
+			t.Errorf("距離制 SurchargeItemsTotal = %d, want %d", d.SurchargeItemsTotal, want)
+		}
+		if d.NightSurcharge != standard.DistanceFareResult.NightSurcharge {
+			t.Errorf("距離制 NightSurcharge = %d, want %d", d.NightSurcharge, standard.DistanceFareResult.NightSurcharge)
+		}
+		if d.TotalFare != standard.DistanceFareResult.TotalFare+d.SurchargeItemsTotal {
+			t.Errorf("距離制 TotalFare = %d, want %d", d.TotalFare, standard.DistanceFareResult.TotalFare+d.SurchargeItemsTotal)
+		}
+
+		// 時間制: 割増前の小計の20% + 3000円
+		tf := result.TimeFareResult
+		if want := tf.SubTotal*20/100 + 3000; tf.SurchargeItemsTotal != want {
+			t.Errorf("時間制 SurchargeItemsTotal = %d, want %d", tf.SurchargeItemsTotal, want)
+		}
+		if tf.TotalFare != standard.TimeFareResult.TotalFare+tf.SurchargeItemsTotal {
+			t.Errorf("時間制 TotalFare = %d, want %d", tf.TotalFare, standard.TimeFareResult.TotalFare+tf.SurchargeItemsTotal)
+		}
+
+		breakdown := result.Breakdown()
+		if !containsString(breakdown, "速達割増: +") || !containsString(breakdown, "20%増）") || !containsString(breakdown, "手積み・手降ろし: +3000円（定額）") {
+			t.Errorf("Breakdown に割増項目が含まれていない:\n%s", breakdown)
+		}
+	})
+
+	t.Run("軽貨物", func(t *testing.T) {
+		standard, err := calculator.CalculateAll(newRequest(VehicleCodeLight))
+		if err != nil {
+			t.Fatalf("CalculateAll failed: %v", err)
+		}
+		result, err := calculator.CalculateAll(newRequest(VehicleCodeLight, "express"))
+		if err != nil {
+			t.Fatalf("CalculateAll failed: %v", err)
+		}
+
+		d := result.AkabouDistanceResult
+		subtotal := d.BaseFare + d.DistanceCharge + d.AreaSurcharge
+		if want := subtotal * 20 / 100; d.SurchargeItemsTotal != want {
+			t.Errorf("赤帽距離制 SurchargeItemsTotal = %d, want %d", d.SurchargeItemsTotal, want)
+		}
+		if d.TotalFare != standard.AkabouDistanceResult.TotalFare+d.SurchargeItemsTotal {
+			t.Errorf("赤帽距離制 TotalFare = %d, want %d", d.TotalFare, standard.AkabouDistanceResult.TotalFare+d.SurchargeItemsTotal)
+		}
+		if result.AkabouTimeResult.SurchargeItemsTotal == 0 {
+			t.Error("赤帽時間制に割増項目が適用されていない")
+		}
+
+		// 軽貨物に適用できない項目はエラー
+		if _, err := calculator.CalculateAll(newRequest(VehicleCodeLight, "hand_loading")); err == nil {
+			t.Error("適用車格外の割増項目でエラーが発生しなかった")
+		}
+	})
+
+	// 未登録の項目はエラー
+	if _, err := calculator.CalculateAll(newRequest(3, "unknown")); err == nil {
+		t.Error("未登録の割増項目でエラーが発生しなかった")
+	}
+}
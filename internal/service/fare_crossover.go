@@ -0,0 +1,314 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// FareCrossoverRequest 運賃の損益分岐（安い運賃が入れ替わる距離）の分析条件
+type FareCrossoverRequest struct {
+	RegionCode      int       // 運輸局コード（トラックのみ）
+	VehicleCode     int       // 車格コード（0=軽貨物/赤帽, 1-4=トラック）
+	FromKm          int       // 開始距離（km）
+	ToKm            int       // 終了距離（km）
+	StepKm          int       // 刻み幅（km）
+	SpeedKmh        int       // 走行時間を求める平均速度（km/h、0は既定値）
+	LoadingMinutes  int       // 荷役時間（分、0は既定値）
+	IsNight         bool      // 深夜割増
+	IsHoliday       bool      // 休日割増
+	Area            string    // 赤帽の地区（軽貨物のみ）
+	QuoteDate       time.Time // 見積日（適用運賃版の判定用、ゼロ値の場合は当日）
+	UseSimpleBaseKm bool      // シンプル版基礎走行キロ使用（トラックのみ）
+}
+
+// FareCrossoverSeries 運賃タイプごとの距離別運賃（グラフの系列）
+type FareCrossoverSeries struct {
+	Name  string // 運賃タイプ（ランキングと同じ名称）
+	Fares []int  // 距離ごとの運賃（円、DistancesKmの順）
+}
+
+// FareCrossoverPoint 安い運賃が入れ替わる距離
+// FromKm までは Before、ToKm からは After が安い（刻み幅の間で逆転する）
+type FareCrossoverPoint struct {
+	FromKm int
+	ToKm   int
+	Before string
+	After  string
+}
+
+// Label 表示用ラベル（例: 100〜110kmで時間制→距離制）
+func (p FareCrossoverPoint) Label() string {
+	return fmt.Sprintf("%d〜%dkmで%s→%s", p.FromKm, p.ToKm, p.Before, p.After)
+}
+
+// FareCrossoverSegment 同じ運賃タイプが最安となる距離の範囲
+type FareCrossoverSegment struct {
+	FromKm   int
+	ToKm     int
+	Cheapest string
+}
+
+// FareCrossoverAnalysis 運賃の損益分岐の分析結果
+type FareCrossoverAnalysis struct {
+	RegionCode     int
+	VehicleCode    int
+	SpeedKmh       int
+	LoadingMinutes int
+	IsNight        bool
+	IsHoliday      bool
+	QuoteDate      time.Time
+	TariffVersion  *model.TariffVersion // 適用運賃版（nilは版指定なし）
+	TaxIncluded    bool                 // 運賃が税込か（赤帽は税込、トラ協は税抜）
+
+	DistancesKm    []int                 // 距離（km）
+	DrivingMinutes []int                 // 距離ごとの走行時間（分）
+	Series         []FareCrossoverSeries // 運賃タイプごとの運賃
+	Cheapest       []string              // 距離ごとの最安運賃タイプ
+	Crossovers     []FareCrossoverPoint  // 安い運賃が入れ替わる距離
+}
+
+// Segments 最安の運賃タイプごとの距離の範囲
+func (a *FareCrossoverAnalysis) Segments() []FareCrossoverSegment {
+	var segments []FareCrossoverSegment
+	for i, name := range a.Cheapest {
+		if n := len(segments); n > 0 && segments[n-1].Cheapest == name {
+			segments[n-1].ToKm = a.DistancesKm[i]
+			continue
+		}
+		segments = append(segments, FareCrossoverSegment{FromKm: a.DistancesKm[i], ToKm: a.DistancesKm[i], Cheapest: name})
+	}
+	return segments
+}
+
+// Summary 分析結果の要約（例: 10〜100kmは時間制、110〜500kmは距離制が安い）
+func (a *FareCrossoverAnalysis) Summary() string {
+	segments := a.Segments()
+	if len(segments) == 0 {
+		return ""
+	}
+	if len(segments) == 1 {
+		s := segments[0]
+		return fmt.Sprintf("%d〜%dkmの範囲では常に%sが安い", s.FromKm, s.ToKm, s.Cheapest)
+	}
+	parts := make([]string, 0, len(segments))
+	for _, s := range segments {
+		parts = append(parts, fmt.Sprintf("%d〜%dkmは%s", s.FromKm, s.ToKm, s.Cheapest))
+	}
+	return strings.Join(parts, "、") + "が安い"
+}
+
+// Note 分析の前提条件
+func (a *FareCrossoverAnalysis) Note() string {
+	target := fareMatrixVehicleName(a.VehicleCode)
+	if a.VehicleCode == VehicleCodeLight {
+		target = "軽貨物/赤帽"
+	} else {
+		target = fareMatrixRegionName(a.RegionCode) + "・" + target
+	}
+	surcharge := FareMatrixNormal
+	switch {
+	case a.IsNight && a.IsHoliday:
+		surcharge = FareMatrixNightAndHoliday
+	case a.IsNight:
+		surcharge = FareMatrixNight
+	case a.IsHoliday:
+		surcharge = FareMatrixHoliday
+	}
+	tax := "税抜"
+	if a.TaxIncluded {
+		tax = "税込"
+	}
+	return fmt.Sprintf("%s／%s／平均%dkm/h・荷役%d分／金額は%s", target, surcharge.Label(), a.SpeedKmh, a.LoadingMinutes, tax)
+}
+
+// 見積結果から損益分岐を分析する際の距離範囲
+const (
+	fareCrossoverChartPoints = 50  // グラフの点数の目安
+	fareCrossoverMinToKm     = 100 // 分析する最大距離の下限（km）
+)
+
+// CrossoverQuery 見積と同じ条件で損益分岐を分析するクエリ文字列（/api/fare/crossover 用）
+// 平均速度は見積の距離・走行時間から求め、見積距離の2倍（最低100km）までを分析する
+// 運賃を計算していない場合は空文字を返す
+func (r *FareComparisonResult) CrossoverQuery() string {
+	q := url.Values{}
+	q.Set("vehicle", strconv.Itoa(r.VehicleCode))
+	if r.VehicleCode == VehicleCodeLight {
+		if r.AkabouDistanceResult == nil {
+			return ""
+		}
+		q.Set("night", strconv.FormatBool(r.AkabouDistanceResult.IsNight))
+		q.Set("holiday", strconv.FormatBool(r.AkabouDistanceResult.IsHoliday))
+		if r.AkabouDistanceResult.Area != "" {
+			q.Set("area", r.AkabouDistanceResult.Area)
+		}
+	} else {
+		if r.DistanceFareResult == nil {
+			return ""
+		}
+		q.Set("region", strconv.Itoa(r.DistanceFareResult.RegionCode))
+		q.Set("night", strconv.FormatBool(r.DistanceFareResult.IsNight))
+		q.Set("holiday", strconv.FormatBool(r.DistanceFareResult.IsHoliday))
+	}
+
+	// 見積距離の2倍まで（10km単位）を約50点で分析
+	toKm := max(fareCrossoverMinToKm, int(math.Ceil(r.DistanceKmRaw*2/10))*10)
+	stepKm := max(10, int(math.Ceil(float64(toKm)/fareCrossoverChartPoints/10))*10)
+	q.Set("from", strconv.Itoa(stepKm))
+	q.Set("to", strconv.Itoa(toKm))
+	q.Set("step", strconv.Itoa(stepKm))
+
+	if r.DistanceKmRaw > 0 && r.DrivingMinutes > 0 {
+		speed := int(math.Round(r.DistanceKmRaw * 60 / float64(r.DrivingMinutes)))
+		q.Set("speed", strconv.Itoa(max(1, speed)))
+	}
+	if r.LoadingMinutes > 0 {
+		q.Set("loading_minutes", strconv.Itoa(r.LoadingMinutes))
+	}
+	if !r.QuoteDate.IsZero() {
+		q.Set("quote_date", r.QuoteDate.Format(model.TariffDateFormat))
+	}
+	return q.Encode()
+}
+
+// FareCrossoverService 運賃の損益分岐分析サービス
+// 見積と同じ計算サービスで距離ごとの運賃を計算し、安い運賃が入れ替わる距離を求める
+type FareCrossoverService struct {
+	distanceFare          *DistanceFareService
+	timeFare              *TimeFareService
+	akabouFare            *AkabouFareService
+	tariffVersionResolver TariffVersionResolver // 運賃版の解決（nilの場合は版指定なし）
+}
+
+// NewFareCrossoverService 新しいFareCrossoverServiceを作成
+func NewFareCrossoverService(distanceFare *DistanceFareService, timeFare *TimeFareService, akabouFare *AkabouFareService) *FareCrossoverService {
+	return &FareCrossoverService{distanceFare: distanceFare, timeFare: timeFare, akabouFare: akabouFare}
+}
+
+// SetTariffVersionResolver 運賃版リゾルバーを設定
+func (s *FareCrossoverService) SetTariffVersionResolver(resolver TariffVersionResolver) {
+	s.tariffVersionResolver = resolver
+}
+
+// Analyze 距離ごとの運賃を計算し、安い運賃が入れ替わる距離を求める
+// トラックは距離制・時間制、軽貨物は赤帽の距離制・時間制を比較する（同じ車格の運賃は税抜・税込の基準が同じ）
+func (s *FareCrossoverService) Analyze(req *FareCrossoverRequest) (*FareCrossoverAnalysis, error) {
+	if req.VehicleCode < VehicleCodeLight || req.VehicleCode > 4 {
+		return nil, fmt.Errorf("無効な車格コード: %d（0-4の範囲で指定）", req.VehicleCode)
+	}
+	distances, err := FareMatrixDistances(req.FromKm, req.ToKm, req.StepKm)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &FareCrossoverAnalysis{
+		RegionCode:     req.RegionCode,
+		VehicleCode:    req.VehicleCode,
+		SpeedKmh:       req.SpeedKmh,
+		LoadingMinutes: req.LoadingMinutes,
+		IsNight:        req.IsNight,
+		IsHoliday:      req.IsHoliday,
+		QuoteDate:      req.QuoteDate,
+		TaxIncluded:    req.VehicleCode == VehicleCodeLight,
+		DistancesKm:    distances,
+	}
+	if a.QuoteDate.IsZero() {
+		a.QuoteDate = time.Now()
+	}
+	if a.SpeedKmh <= 0 {
+		a.SpeedKmh = DefaultFareMatrixSpeedKmh
+	}
+	if a.LoadingMinutes <= 0 {
+		a.LoadingMinutes = DefaultFareMatrixLoadingMinutes
+	}
+
+	tariffType := model.TariffTypeJTA
+	if req.VehicleCode == VehicleCodeLight {
+		tariffType = model.TariffTypeAkabou
+	}
+	a.TariffVersion, err = resolveTariffVersion(s.tariffVersionResolver, tariffType, a.QuoteDate)
+	if err != nil {
+		return nil, err
+	}
+	opts := []FareOption{WithTariffVersion(a.TariffVersion)}
+
+	calculate := s.truckFares
+	a.Series = []FareCrossoverSeries{{Name: "距離制"}, {Name: "時間制"}}
+	if req.VehicleCode == VehicleCodeLight {
+		calculate = s.akabouFares
+		a.Series = []FareCrossoverSeries{{Name: "赤帽（距離制）"}, {Name: "赤帽（時間制）"}}
+	}
+
+	for _, km := range distances {
+		minutes := drivingMinutesAt(km, a.SpeedKmh)
+		distanceFare, timeFare, err := calculate(req, km, minutes, a.LoadingMinutes, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%dkmの運賃計算エラー: %w", km, err)
+		}
+		a.DrivingMinutes = append(a.DrivingMinutes, minutes)
+		a.Series[0].Fares = append(a.Series[0].Fares, distanceFare)
+		a.Series[1].Fares = append(a.Series[1].Fares, timeFare)
+	}
+
+	a.findCrossovers()
+	return a, nil
+}
+
+// truckFares トラ協の距離制・時間制運賃（税抜）
+func (s *FareCrossoverService) truckFares(req *FareCrossoverRequest, km, drivingMinutes, loadingMinutes int, opts []FareOption) (int, int, error) {
+	distance, err := s.distanceFare.Calculate(req.RegionCode, req.VehicleCode, km, req.IsNight, req.IsHoliday, opts...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("距離制運賃計算エラー: %w", err)
+	}
+	timeFare, err := s.timeFare.Calculate(req.RegionCode, req.VehicleCode, km, drivingMinutes, loadingMinutes,
+		req.IsNight, req.IsHoliday, req.UseSimpleBaseKm, opts...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("時間制運賃計算エラー: %w", err)
+	}
+	return distance.TotalFare, timeFare.TotalFare, nil
+}
+
+// akabouFares 赤帽の距離制・時間制運賃（税込）
+func (s *FareCrossoverService) akabouFares(req *FareCrossoverRequest, km, drivingMinutes, loadingMinutes int, opts []FareOption) (int, int, error) {
+	distance, err := s.akabouFare.CalculateDistanceFare(km, req.IsNight, req.IsHoliday, req.Area, opts...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("赤帽距離制運賃計算エラー: %w", err)
+	}
+	timeFare, err := s.akabouFare.CalculateTimeFare(drivingMinutes+loadingMinutes, req.IsNight, req.IsHoliday, req.Area, opts...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("赤帽時間制運賃計算エラー: %w", err)
+	}
+	return distance.TotalFare, timeFare.TotalFare, nil
+}
+
+// findCrossovers 距離ごとの最安運賃タイプと、入れ替わる距離を求める
+// 同額の場合は直前の最安運賃タイプのまま（先頭は系列の順）とし、同額での入れ替わりは数えない
+func (a *FareCrossoverAnalysis) findCrossovers() {
+	a.Cheapest = make([]string, len(a.DistancesKm))
+	a.Crossovers = nil
+	current := -1
+	for i := range a.DistancesKm {
+		cheapest := current
+		for j, series := range a.Series {
+			if cheapest < 0 || series.Fares[i] < a.Series[cheapest].Fares[i] {
+				cheapest = j
+			}
+		}
+		if current >= 0 && cheapest != current {
+			a.Crossovers = append(a.Crossovers, FareCrossoverPoint{
+				FromKm: a.DistancesKm[i-1],
+				ToKm:   a.DistancesKm[i],
+				Before: a.Series[current].Name,
+				After:  a.Series[cheapest].Name,
+			})
+		}
+		current = cheapest
+		a.Cheapest[i] = a.Series[cheapest].Name
+	}
+}
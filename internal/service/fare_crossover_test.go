@@ -0,0 +1,162 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// perKmFareGetter 距離に比例する距離制運賃のモック（損益分岐の確認用）
+type perKmFareGetter struct {
+	ratePerKm int
+}
+
+func (g *perKmFareGetter) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
+	return distanceKm * g.ratePerKm, nil
+}
+
+// TestFareCrossoverService_Analyze_Truck 近距離は距離制、遠距離は時間制が安くなる場合
+func TestFareCrossoverService_Analyze_Truck(t *testing.T) {
+	service := NewFareCrossoverService(
+		NewDistanceFareService(&perKmFareGetter{ratePerKm: 400}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+	analysis, err := service.Analyze(&FareCrossoverRequest{RegionCode: 3, VehicleCode: 3, FromKm: 10, ToKm: 300, StepKm: 10})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if len(analysis.Series) != 2 || analysis.Series[0].Name != "距離制" || len(analysis.Series[1].Fares) != 30 {
+		t.Fatalf("Series = %+v", analysis.Series)
+	}
+	if analysis.TaxIncluded {
+		t.Error("トラ協運賃が税込になっている")
+	}
+	if len(analysis.Crossovers) == 0 {
+		t.Fatal("損益分岐が見つからない")
+	}
+
+	// 入れ替わる距離の前後で運賃の大小が逆転している
+	p := analysis.Crossovers[0]
+	if p.Before != "距離制" || p.After != "時間制" || p.ToKm-p.FromKm != 10 {
+		t.Errorf("Crossovers[0] = %+v", p)
+	}
+	i := (p.ToKm - 10) / 10
+	distance, timeFare := analysis.Series[0].Fares, analysis.Series[1].Fares
+	if distance[i-1] > timeFare[i-1] || distance[i] <= timeFare[i] {
+		t.Errorf("%dkm: 距離制%d/時間制%d、%dkm: 距離制%d/時間制%d", p.FromKm, distance[i-1], timeFare[i-1], p.ToKm, distance[i], timeFare[i])
+	}
+
+	// 見積と同じ計算サービスの結果
+	want, _ := NewTimeFareService(&MockTimeFareGetter{}).Calculate(3, 3, 100, 150, DefaultFareMatrixLoadingMinutes, false, false, false)
+	if got := timeFare[9]; got != want.TotalFare {
+		t.Errorf("100kmの時間制 = %d, want %d", got, want.TotalFare)
+	}
+}
+
+// TestFareCrossoverService_Analyze_Light 軽貨物は赤帽の距離制・時間制を比較
+func TestFareCrossoverService_Analyze_Light(t *testing.T) {
+	service := NewFareCrossoverService(nil, nil, NewAkabouFareService(&mockAkabouFareGetter{}))
+	analysis, err := service.Analyze(&FareCrossoverRequest{VehicleCode: VehicleCodeLight, FromKm: 10, ToKm: 100, StepKm: 10, SpeedKmh: 30, LoadingMinutes: 30})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if analysis.Series[0].Name != "赤帽（距離制）" || analysis.Series[1].Name != "赤帽（時間制）" {
+		t.Errorf("Series = %+v", analysis.Series)
+	}
+	if !analysis.TaxIncluded || analysis.DrivingMinutes[0] != 20 {
+		t.Errorf("TaxIncluded = %v, DrivingMinutes[0] = %d", analysis.TaxIncluded, analysis.DrivingMinutes[0])
+	}
+	if len(analysis.Cheapest) != 10 {
+		t.Errorf("len(Cheapest) = %d, want 10", len(analysis.Cheapest))
+	}
+
+	if _, err := service.Analyze(&FareCrossoverRequest{VehicleCode: 5, FromKm: 10, ToKm: 100, StepKm: 10}); err == nil {
+		t.Error("無効な車格でエラーが発生しなかった")
+	}
+}
+
+// TestFareCrossoverAnalysis_Summary 同額は入れ替わりとしない
+func TestFareCrossoverAnalysis_Summary(t *testing.T) {
+	a := &FareCrossoverAnalysis{
+		DistancesKm: []int{10, 20, 30, 40, 50},
+		Series: []FareCrossoverSeries{
+			{Name: "距離制", Fares: []int{100, 200, 300, 400, 500}},
+			{Name: "時間制", Fares: []int{150, 200, 250, 350, 450}},
+		},
+	}
+	a.findCrossovers()
+
+	if len(a.Crossovers) != 1 {
+		t.Fatalf("Crossovers = %+v", a.Crossovers)
+	}
+	if got := a.Crossovers[0].Label(); got != "20〜30kmで距離制→時間制" {
+		t.Errorf("Label() = %s", got)
+	}
+	if got := a.Summary(); got != "10〜20kmは距離制、30〜50kmは時間制が安い" {
+		t.Errorf("Summary() = %s", got)
+	}
+
+	a.Series[1].Fares = []int{150, 250, 350, 450, 550}
+	a.findCrossovers()
+	if got := a.Summary(); got != "10〜50kmの範囲では常に距離制が安い" {
+		t.Errorf("Summary() = %s", got)
+	}
+}
+
+func TestFareComparisonResult_CrossoverQuery(t *testing.T) {
+	quoteDate := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		result *FareComparisonResult
+		want   string
+	}{
+		{
+			name: "トラック（見積距離の2倍まで）",
+			result: &FareComparisonResult{
+				VehicleCode:        3,
+				DistanceKmRaw:      123.4,
+				DrivingMinutes:     185,
+				LoadingMinutes:     90,
+				QuoteDate:          quoteDate,
+				DistanceFareResult: &DistanceFareResult{RegionCode: 3, IsNight: true},
+			},
+			want: "from=10&holiday=false&loading_minutes=90&night=true&quote_date=2026-10-01&region=3&speed=40&step=10&to=250&vehicle=3",
+		},
+		{
+			name: "長距離は刻み幅を広げる",
+			result: &FareComparisonResult{
+				VehicleCode:        4,
+				DistanceKmRaw:      480,
+				DrivingMinutes:     480,
+				DistanceFareResult: &DistanceFareResult{RegionCode: 5},
+			},
+			want: "from=20&holiday=false&night=false&region=5&speed=60&step=20&to=960&vehicle=4",
+		},
+		{
+			name: "軽貨物（最低100km）",
+			result: &FareComparisonResult{
+				VehicleCode:          VehicleCodeLight,
+				DistanceKmRaw:        12,
+				DrivingMinutes:       30,
+				AkabouDistanceResult: &AkabouDistanceFareResult{Area: "東京23区", IsHoliday: true},
+			},
+			want: "area=%E6%9D%B1%E4%BA%AC23%E5%8C%BA&from=10&holiday=true&night=false&speed=24&step=10&to=100&vehicle=0",
+		},
+		{
+			name:   "運賃未計算",
+			result: &FareComparisonResult{VehicleCode: 3},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.CrossoverQuery(); got != tt.want {
+				t.Errorf("CrossoverQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
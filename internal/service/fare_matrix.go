@@ -0,0 +1,264 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// 運賃表の既定値
+const (
+	DefaultFareMatrixSpeedKmh       = 40   // 時間制運賃の走行時間を求める平均速度（km/h）
+	DefaultFareMatrixLoadingMinutes = 60   // 時間制運賃の荷役時間（分）
+	MaxFareMatrixRows               = 500  // 1つの運賃表の距離の行数の上限
+	MaxFareMatrixCalculations       = 4000 // 運輸局 × 車格 × 距離 × 割増条件の計算件数の上限
+)
+
+// FareMatrixVariant 運賃表の割増条件
+type FareMatrixVariant string
+
+// 運賃表の割増条件
+const (
+	FareMatrixNormal          FareMatrixVariant = "normal"        // 割増なし
+	FareMatrixNight           FareMatrixVariant = "night"         // 深夜割増
+	FareMatrixHoliday         FareMatrixVariant = "holiday"       // 休日割増
+	FareMatrixNightAndHoliday FareMatrixVariant = "night_holiday" // 深夜・休日割増
+)
+
+// ParseFareMatrixVariant 割増条件の文字列を解析する
+func ParseFareMatrixVariant(s string) (FareMatrixVariant, error) {
+	switch v := FareMatrixVariant(strings.TrimSpace(s)); v {
+	case FareMatrixNormal, FareMatrixNight, FareMatrixHoliday, FareMatrixNightAndHoliday:
+		return v, nil
+	}
+	return "", fmt.Errorf("無効な割増条件: %s（normal / night / holiday / night_holiday で指定）", s)
+}
+
+// IsNight 深夜割増を適用するか
+func (v FareMatrixVariant) IsNight() bool {
+	return v == FareMatrixNight || v == FareMatrixNightAndHoliday
+}
+
+// IsHoliday 休日割増を適用するか
+func (v FareMatrixVariant) IsHoliday() bool {
+	return v == FareMatrixHoliday || v == FareMatrixNightAndHoliday
+}
+
+// Label 表示用ラベル
+func (v FareMatrixVariant) Label() string {
+	switch v {
+	case FareMatrixNight:
+		return "深夜割増"
+	case FareMatrixHoliday:
+		return "休日割増"
+	case FareMatrixNightAndHoliday:
+		return "深夜・休日割増"
+	}
+	return "割増なし"
+}
+
+// ParseFareMatrixCodes カンマ区切りの整数一覧を解析する（例: "1,2,3,4"）
+func ParseFareMatrixCodes(s string) ([]int, error) {
+	var codes []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		code, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("整数で指定してください: %s", part)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// FareMatrixDistances 開始距離から終了距離まで刻み幅ごとの距離一覧を返す
+func FareMatrixDistances(fromKm, toKm, stepKm int) ([]int, error) {
+	if fromKm < 1 || toKm < fromKm || stepKm < 1 {
+		return nil, fmt.Errorf("無効な距離の範囲: %d〜%dkm（刻み%dkm）", fromKm, toKm, stepKm)
+	}
+	if (toKm-fromKm)/stepKm+1 > MaxFareMatrixRows {
+		return nil, fmt.Errorf("距離の行数が多すぎます（上限%d行）", MaxFareMatrixRows)
+	}
+	var distances []int
+	for km := fromKm; km <= toKm; km += stepKm {
+		distances = append(distances, km)
+	}
+	return distances, nil
+}
+
+// FareMatrixRequest 運賃表の作成条件
+type FareMatrixRequest struct {
+	RegionCodes     []int               // 運輸局コード
+	VehicleCodes    []int               // 車格コード（1-4）
+	DistancesKm     []int               // 距離（km）
+	Variants        []FareMatrixVariant // 割増条件（空は割増なしのみ）
+	QuoteDate       time.Time           // 見積日（適用運賃版の判定用、ゼロ値の場合は当日）
+	SpeedKmh        int                 // 時間制運賃の平均速度（km/h、0は既定値）
+	LoadingMinutes  int                 // 時間制運賃の荷役時間（分、0は既定値）
+	UseSimpleBaseKm bool                // シンプル版基礎走行キロ使用
+}
+
+// FareMatrixCell 車格ごとの運賃（税抜）
+type FareMatrixCell struct {
+	DistanceFare int // 距離制運賃（円）
+	TimeFare     int // 時間制運賃（円）
+	TimeHours    int // 時間制運賃の適用時間制（4 or 8）
+}
+
+// FareMatrixRow 距離ごとの運賃
+type FareMatrixRow struct {
+	DistanceKm     int              // 距離（km）
+	DrivingMinutes int              // 時間制運賃の走行時間（分）
+	Cells          []FareMatrixCell // 車格ごとの運賃（VehicleCodesの順）
+}
+
+// FareMatrixTable 運輸局・割増条件ごとの運賃表
+type FareMatrixTable struct {
+	RegionCode   int
+	Variant      FareMatrixVariant
+	VehicleCodes []int
+	Rows         []FareMatrixRow
+}
+
+// Title 運賃表の表題（例: 関東・割増なし）
+func (t *FareMatrixTable) Title() string {
+	return fmt.Sprintf("%s・%s", fareMatrixRegionName(t.RegionCode), t.Variant.Label())
+}
+
+// FareMatrix 運賃表（トラ協距離制・時間制）
+type FareMatrix struct {
+	QuoteDate      time.Time
+	TariffVersion  *model.TariffVersion // 適用運賃版（nilは版指定なし）
+	SpeedKmh       int
+	LoadingMinutes int
+	Tables         []*FareMatrixTable
+}
+
+// Note 運賃表の前提条件
+func (m *FareMatrix) Note() string {
+	version := "版指定なし"
+	if m.TariffVersion != nil {
+		version = m.TariffVersion.Label()
+	}
+	return fmt.Sprintf("見積日 %s／適用運賃版 %s／金額は税抜（円）／時間制は平均%dkm/h・荷役%d分で計算",
+		m.QuoteDate.Format(model.TariffDateFormat), version, m.SpeedKmh, m.LoadingMinutes)
+}
+
+// FareMatrixService 運賃表作成サービス
+// 見積と同じ距離制・時間制の計算サービスで各条件の運賃を計算する
+type FareMatrixService struct {
+	distanceFare          *DistanceFareService
+	timeFare              *TimeFareService
+	tariffVersionResolver TariffVersionResolver // 運賃版の解決（nilの場合は版指定なし）
+}
+
+// NewFareMatrixService 新しいFareMatrixServiceを作成
+func NewFareMatrixService(distanceFare *DistanceFareService, timeFare *TimeFareService) *FareMatrixService {
+	return &FareMatrixService{distanceFare: distanceFare, timeFare: timeFare}
+}
+
+// SetTariffVersionResolver 運賃版リゾルバーを設定
+func (s *FareMatrixService) SetTariffVersionResolver(resolver TariffVersionResolver) {
+	s.tariffVersionResolver = resolver
+}
+
+// Build 運賃表を作成する
+func (s *FareMatrixService) Build(req *FareMatrixRequest) (*FareMatrix, error) {
+	if len(req.RegionCodes) == 0 || len(req.VehicleCodes) == 0 || len(req.DistancesKm) == 0 {
+		return nil, fmt.Errorf("運輸局・車格・距離をそれぞれ1つ以上指定してください")
+	}
+	variants := req.Variants
+	if len(variants) == 0 {
+		variants = []FareMatrixVariant{FareMatrixNormal}
+	}
+	if n := len(req.RegionCodes) * len(req.VehicleCodes) * len(req.DistancesKm) * len(variants); n > MaxFareMatrixCalculations {
+		return nil, fmt.Errorf("計算件数が多すぎます: %d件（上限%d件）", n, MaxFareMatrixCalculations)
+	}
+
+	matrix := &FareMatrix{
+		QuoteDate:      req.QuoteDate,
+		SpeedKmh:       req.SpeedKmh,
+		LoadingMinutes: req.LoadingMinutes,
+	}
+	if matrix.QuoteDate.IsZero() {
+		matrix.QuoteDate = time.Now()
+	}
+	if matrix.SpeedKmh <= 0 {
+		matrix.SpeedKmh = DefaultFareMatrixSpeedKmh
+	}
+	if matrix.LoadingMinutes <= 0 {
+		matrix.LoadingMinutes = DefaultFareMatrixLoadingMinutes
+	}
+
+	// 適用運賃版（見積と同じ判定）
+	version, err := resolveTariffVersion(s.tariffVersionResolver, model.TariffTypeJTA, matrix.QuoteDate)
+	if err != nil {
+		return nil, err
+	}
+	matrix.TariffVersion = version
+	opts := []FareOption{WithTariffVersion(matrix.TariffVersion)}
+
+	for _, regionCode := range req.RegionCodes {
+		for _, variant := range variants {
+			table := &FareMatrixTable{RegionCode: regionCode, Variant: variant, VehicleCodes: req.VehicleCodes}
+			for _, km := range req.DistancesKm {
+				row := FareMatrixRow{DistanceKm: km, DrivingMinutes: drivingMinutesAt(km, matrix.SpeedKmh)}
+				for _, vehicleCode := range req.VehicleCodes {
+					distance, err := s.distanceFare.Calculate(regionCode, vehicleCode, km, variant.IsNight(), variant.IsHoliday(), opts...)
+					if err != nil {
+						return nil, fmt.Errorf("距離制運賃計算エラー（%s %dkm）: %w", table.Title(), km, err)
+					}
+					timeFare, err := s.timeFare.Calculate(
+						regionCode, vehicleCode, km, row.DrivingMinutes, matrix.LoadingMinutes,
+						variant.IsNight(), variant.IsHoliday(), req.UseSimpleBaseKm, opts...,
+					)
+					if err != nil {
+						return nil, fmt.Errorf("時間制運賃計算エラー（%s %dkm）: %w", table.Title(), km, err)
+					}
+					row.Cells = append(row.Cells, FareMatrixCell{
+						DistanceFare: distance.TotalFare,
+						TimeFare:     timeFare.TotalFare,
+						TimeHours:    timeFare.AppliedHours,
+					})
+				}
+				table.Rows = append(table.Rows, row)
+			}
+			matrix.Tables = append(matrix.Tables, table)
+		}
+	}
+	return matrix, nil
+}
+
+// drivingMinutesAt 平均速度で距離を走行する時間（分、切り上げ）
+func drivingMinutesAt(distanceKm, speedKmh int) int {
+	return (distanceKm*60 + speedKmh - 1) / speedKmh
+}
+
+// fareMatrixRegionName 運輸局コードの名称
+func fareMatrixRegionName(code int) string {
+	names := map[int]string{
+		1: "北海道", 2: "東北", 3: "関東", 4: "北陸信越", 5: "中部",
+		6: "近畿", 7: "中国", 8: "四国", 9: "九州", 10: "沖縄",
+	}
+	if name, ok := names[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("運輸局%d", code)
+}
+
+// fareMatrixVehicleName 車格コードの名称
+func fareMatrixVehicleName(code int) string {
+	names := map[int]string{
+		1: "小型車(2t)", 2: "中型車(4t)", 3: "大型車(10t)", 4: "トレーラー(20t)",
+	}
+	if name, ok := names[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("車格%d", code)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// 運賃表の出力形式
+const (
+	FareMatrixFormatCSV  = "csv"
+	FareMatrixFormatXLSX = "xlsx"
+)
+
+// fareMatrixTitle 運賃表の見出し
+const fareMatrixTitle = "運賃表（標準的な運賃 距離制・時間制）"
+
+// header 運賃表の列見出し（距離・走行時間・時間制の適用、車格ごとの距離制・時間制）
+func (t *FareMatrixTable) header() []string {
+	header := []string{"距離(km)", "走行時間(分)", "時間制の適用"}
+	for _, code := range t.VehicleCodes {
+		name := fareMatrixVehicleName(code)
+		header = append(header, name+" 距離制", name+" 時間制")
+	}
+	return header
+}
+
+// hoursLabel 時間制の適用（例: 8時間制）
+func (r *FareMatrixRow) hoursLabel() string {
+	if len(r.Cells) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d時間制", r.Cells[0].TimeHours)
+}
+
+// WriteCSV 運賃表をCSVで書き出す（Excelで開けるようBOM付きUTF-8、運賃表は空行で区切る）
+func (m *FareMatrix) WriteCSV(w io.Writer) error {
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	records := [][]string{{fareMatrixTitle}, {m.Note()}}
+	for _, table := range m.Tables {
+		records = append(records, []string{}, []string{table.Title()}, table.header())
+		for _, row := range table.Rows {
+			record := []string{strconv.Itoa(row.DistanceKm), strconv.Itoa(row.DrivingMinutes), row.hoursLabel()}
+			for _, cell := range row.Cells {
+				record = append(record, strconv.Itoa(cell.DistanceFare), strconv.Itoa(cell.TimeFare))
+			}
+			records = append(records, record)
+		}
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("CSV書き込みエラー: %w", err)
+	}
+	return nil
+}
+
+// WriteXLSX 運賃表をXLSXで書き出す（運輸局・割増条件ごとに1シート）
+func (m *FareMatrix) WriteXLSX(w io.Writer) error {
+	sheets := make([]xlsxSheet, 0, len(m.Tables))
+	for _, table := range m.Tables {
+		sheet := xlsxSheet{
+			Name: table.Title(),
+			Rows: [][]xlsxCell{
+				{{Value: fareMatrixTitle + " " + table.Title(), Bold: true}},
+				{{Value: m.Note()}},
+				{},
+			},
+			ColWidths: []float64{10, 12, 12},
+		}
+
+		var header []xlsxCell
+		for _, h := range table.header() {
+			header = append(header, xlsxCell{Value: h, Bold: true})
+		}
+		sheet.Rows = append(sheet.Rows, header)
+		for range table.VehicleCodes {
+			sheet.ColWidths = append(sheet.ColWidths, 20, 20)
+		}
+
+		for _, row := range table.Rows {
+			cells := []xlsxCell{{Value: row.DistanceKm}, {Value: row.DrivingMinutes}, {Value: row.hoursLabel()}}
+			for _, cell := range row.Cells {
+				cells = append(cells, xlsxCell{Value: cell.DistanceFare}, xlsxCell{Value: cell.TimeFare})
+			}
+			sheet.Rows = append(sheet.Rows, cells)
+		}
+		sheets = append(sheets, sheet)
+	}
+	if err := writeXLSX(w, sheets); err != nil {
+		return fmt.Errorf("XLSX書き込みエラー: %w", err)
+	}
+	return nil
+}
+
+// Write 指定した形式（csv / xlsx）で運賃表を書き出す
+func (m *FareMatrix) Write(w io.Writer, format string) error {
+	switch format {
+	case FareMatrixFormatCSV:
+		return m.WriteCSV(w)
+	case FareMatrixFormatXLSX:
+		return m.WriteXLSX(w)
+	}
+	return fmt.Errorf("無効な出力形式: %s（csv / xlsx で指定）", format)
+}
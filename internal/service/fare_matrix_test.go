@@ -0,0 +1,181 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func newTestFareMatrixService() *FareMatrixService {
+	return NewFareMatrixService(NewDistanceFareService(&MockFareGetter{}), NewTimeFareService(&MockTimeFareGetter{}))
+}
+
+// TestFareMatrixService_Build 運賃表が見積と同じ計算サービスの結果になること
+func TestFareMatrixService_Build(t *testing.T) {
+	distances, err := FareMatrixDistances(50, 150, 50)
+	if err != nil {
+		t.Fatalf("FareMatrixDistances failed: %v", err)
+	}
+	matrix, err := newTestFareMatrixService().Build(&FareMatrixRequest{
+		RegionCodes:  []int{3},
+		VehicleCodes: []int{2, 3},
+		DistancesKm:  distances,
+		Variants:     []FareMatrixVariant{FareMatrixNormal, FareMatrixNightAndHoliday},
+	})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(matrix.Tables) != 2 {
+		t.Fatalf("Tables = %d件, want 2", len(matrix.Tables))
+	}
+	if got := matrix.Tables[1].Title(); got != "関東・深夜・休日割増" {
+		t.Errorf("Title() = %s", got)
+	}
+
+	// 関東・大型車・100km（2行目・2列目）を見積と同じサービスで計算した結果と比較
+	distanceFare, _ := NewDistanceFareService(&MockFareGetter{}).Calculate(3, 3, 100, true, true)
+	timeFare, _ := NewTimeFareService(&MockTimeFareGetter{}).Calculate(3, 3, 100, 150, DefaultFareMatrixLoadingMinutes, true, true, false)
+	row := matrix.Tables[1].Rows[1]
+	if row.DistanceKm != 100 || row.DrivingMinutes != 150 {
+		t.Errorf("row = %dkm / %d分, want 100km / 150分", row.DistanceKm, row.DrivingMinutes)
+	}
+	if cell := row.Cells[1]; cell.DistanceFare != distanceFare.TotalFare || cell.TimeFare != timeFare.TotalFare || cell.TimeHours != timeFare.AppliedHours {
+		t.Errorf("cell = %+v, want 距離制 %d / 時間制 %d（%d時間制）", cell, distanceFare.TotalFare, timeFare.TotalFare, timeFare.AppliedHours)
+	}
+
+	// 割増なしの運賃は割増ありより安い
+	if normal := matrix.Tables[0].Rows[1].Cells[1]; normal.DistanceFare >= row.Cells[1].DistanceFare {
+		t.Errorf("割増なし %d円 >= 深夜・休日 %d円", normal.DistanceFare, row.Cells[1].DistanceFare)
+	}
+}
+
+// TestFareMatrixService_Build_Invalid 運賃表の作成条件のエラー
+func TestFareMatrixService_Build_Invalid(t *testing.T) {
+	service := newTestFareMatrixService()
+	tests := []struct {
+		name string
+		req  *FareMatrixRequest
+	}{
+		{"運輸局なし", &FareMatrixRequest{VehicleCodes: []int{1}, DistancesKm: []int{10}}},
+		{"無効な車格", &FareMatrixRequest{RegionCodes: []int{3}, VehicleCodes: []int{0}, DistancesKm: []int{10}}},
+		{"計算件数の上限", &FareMatrixRequest{RegionCodes: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, VehicleCodes: []int{1, 2, 3, 4}, DistancesKm: make([]int, 101)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Build(tt.req); err == nil {
+				t.Error("エラーが発生しなかった")
+			}
+		})
+	}
+
+	if _, err := FareMatrixDistances(10, 5, 10); err == nil {
+		t.Error("FareMatrixDistances: 終了距離が開始距離より短くてもエラーが発生しなかった")
+	}
+	if _, err := FareMatrixDistances(1, 10000, 1); err == nil {
+		t.Error("FareMatrixDistances: 行数の上限を超えてもエラーが発生しなかった")
+	}
+	if _, err := ParseFareMatrixVariant("midnight"); err == nil {
+		t.Error("ParseFareMatrixVariant: 無効な割増条件でエラーが発生しなかった")
+	}
+	if codes, err := ParseFareMatrixCodes(" 1, 3 ,"); err != nil || len(codes) != 2 || codes[1] != 3 {
+		t.Errorf("ParseFareMatrixCodes() = %v, %v", codes, err)
+	}
+}
+
+// TestFareMatrix_Write 運賃表のCSV・XLSX出力テスト
+func TestFareMatrix_Write(t *testing.T) {
+	matrix, err := newTestFareMatrixService().Build(&FareMatrixRequest{
+		RegionCodes:  []int{3, 6},
+		VehicleCodes: []int{3},
+		DistancesKm:  []int{100},
+	})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := matrix.Write(&buf, FareMatrixFormatCSV); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		out := buf.String()
+		if !strings.HasPrefix(out, "\uFEFF") {
+			t.Error("BOMがない")
+		}
+		for _, want := range []string{"関東・割増なし", "近畿・割増なし", "大型車(10t) 距離制", "100,150,4時間制,35000,"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("CSVに %q が含まれていない:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := matrix.Write(&buf, FareMatrixFormatXLSX); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("zip読み込みエラー: %v", err)
+		}
+
+		files := make(map[string]string)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatalf("%s: %v", f.Name, err)
+			}
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			files[f.Name] = string(data)
+
+			// XMLとして読めること
+			dec := xml.NewDecoder(bytes.NewReader(data))
+			for {
+				if _, err := dec.Token(); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("%s: XMLパースエラー: %v", f.Name, err)
+				}
+			}
+		}
+
+		for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+			if _, ok := files[name]; !ok {
+				t.Errorf("%s がない", name)
+			}
+		}
+		if !strings.Contains(files["xl/workbook.xml"], `<sheet name="近畿・割増なし"`) {
+			t.Errorf("workbook.xml にシート名がない: %s", files["xl/workbook.xml"])
+		}
+		if !strings.Contains(files["xl/worksheets/sheet1.xml"], `<c r="D5" s="1"><v>35000</v></c>`) {
+			t.Errorf("sheet1.xml に距離制運賃がない: %s", files["xl/worksheets/sheet1.xml"])
+		}
+	})
+
+	if err := matrix.Write(io.Discard, "pdf"); err == nil {
+		t.Error("無効な出力形式でエラーが発生しなかった")
+	}
+}
+
+func TestXlsxCellRef(t *testing.T) {
+	tests := []struct {
+		col, row int
+		want     string
+	}{
+		{0, 0, "A1"},
+		{25, 9, "Z10"},
+		{26, 0, "AA1"},
+		{701, 1, "ZZ2"},
+		{702, 2, "AAA3"},
+	}
+	for _, tt := range tests {
+		if got := xlsxCellRef(tt.col, tt.row); got != tt.want {
+			t.Errorf("xlsxCellRef(%d, %d) = %s, want %s", tt.col, tt.row, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// FareOption 運賃計算の追加オプション
+type FareOption func(*fareOptions)
+
+// fareOptions 運賃計算の追加オプション値
+type fareOptions struct {
+	tariffVersion *model.TariffVersion     // 適用運賃版（nilは版指定なし）
+	nightSplit    *NightSplit              // 深夜時間の内訳（nilは全体に深夜割増）
+	bodyType      *model.BodyTypeSurcharge // 特殊車両割増（nilは割増なし）
+	items         []*model.SurchargeItem   // 割増項目（速達割増・付帯作業など）
+
+	// 複数日運行（時間制のみ）
+	dailyLimitMinutes int // 1日の拘束時間の上限（分、0は既定値）
+	overnightCostYen  int // 1泊あたりの宿泊費（円、0は加算なし）
+
+	// 改善基準告示の休憩・運行日数を作業時間に含める（時間制のみ、nilは含めない）
+	compliance *ComplianceResult
+}
+
+// NightSplit 運行時間のうち深夜時間帯にかかる時間
+type NightSplit struct {
+	NightMinutes int // 深夜時間（分）
+	TotalMinutes int // 運行時間（分）
+}
+
+// Ratio 深夜時間の割合（0.0-1.0）
+func (n *NightSplit) Ratio() float64 {
+	if n == nil {
+		return 1.0
+	}
+	if n.TotalMinutes <= 0 {
+		return 0
+	}
+	ratio := float64(n.NightMinutes) / float64(n.TotalMinutes)
+	if ratio > 1.0 {
+		return 1.0
+	}
+	return ratio
+}
+
+// WithTariffVersion 適用する運賃版を指定する
+func WithTariffVersion(v *model.TariffVersion) FareOption {
+	return func(o *fareOptions) {
+		o.tariffVersion = v
+	}
+}
+
+// WithNightMinutes 深夜割増を運行時間に占める深夜時間の割合で按分する
+func WithNightMinutes(nightMinutes, totalMinutes int) FareOption {
+	return func(o *fareOptions) {
+		o.nightSplit = &NightSplit{NightMinutes: nightMinutes, TotalMinutes: totalMinutes}
+	}
+}
+
+// WithBodyTypeSurcharge 車体種別の特殊車両割増を適用する
+func WithBodyTypeSurcharge(s *model.BodyTypeSurcharge) FareOption {
+	return func(o *fareOptions) {
+		o.bodyType = s
+	}
+}
+
+// bodyTypeSurcharge 割増前運賃に対する特殊車両割増額を返す
+func (o *fareOptions) bodyTypeSurcharge(fare int) int {
+	if o.bodyType == nil {
+		return 0
+	}
+	return fare * o.bodyType.SurchargePercent / 100
+}
+
+// WithSurchargeItems 選択した割増項目（速達割増・付帯作業など）を適用する
+func WithSurchargeItems(items []*model.SurchargeItem) FareOption {
+	return func(o *fareOptions) {
+		o.items = items
+	}
+}
+
+// surchargeItems 割増前運賃に対する割増項目ごとの割増額と合計を返す
+func (o *fareOptions) surchargeItems(fare int) ([]AppliedSurchargeItem, int) {
+	return applySurchargeItems(o.items, fare)
+}
+
+// WithDailyWorkingLimit 複数日運行に分ける1日の拘束時間の上限を指定する（時間制のみ）
+func WithDailyWorkingLimit(minutes int) FareOption {
+	return func(o *fareOptions) {
+		o.dailyLimitMinutes = minutes
+	}
+}
+
+// WithOvernightCost 複数日運行の1泊あたりの宿泊費を加算する（時間制のみ）
+func WithOvernightCost(yen int) FareOption {
+	return func(o *fareOptions) {
+		o.overnightCostYen = yen
+	}
+}
+
+// WithCompliance 改善基準告示の確認結果の休憩時間を作業時間に加え、運行日数を確認結果以上にする（時間制のみ）
+func WithCompliance(c *ComplianceResult) FareOption {
+	return func(o *fareOptions) {
+		o.compliance = c
+	}
+}
+
+// complianceBreakMinutes 作業時間に加える休憩時間（分）と最低運行日数
+func (o *fareOptions) complianceBreakMinutes() (int, int) {
+	if o.compliance == nil {
+		return 0, 1
+	}
+	return o.compliance.BreakMinutes, o.compliance.DayCount()
+}
+
+// dailyLimitOrDefault 1日の拘束時間の上限（未指定は既定値）
+func (o *fareOptions) dailyLimitOrDefault() int {
+	if o.dailyLimitMinutes <= 0 {
+		return DefaultDailyWorkingLimitMinutes
+	}
+	return o.dailyLimitMinutes
+}
+
+// newFareOptions オプションを適用した値を返す
+func newFareOptions(opts []FareOption) *fareOptions {
+	o := &fareOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
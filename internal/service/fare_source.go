@@ -0,0 +1,197 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// 距離制運賃データの取得元
+const (
+	FareSourceSupabase    = "Supabase（トラ協）"
+	FareSourceLocalMirror = "ローカルミラー"
+)
+
+// FareSource 距離制運賃データの取得元と鮮度
+type FareSource struct {
+	Name           string    // 取得元
+	SyncedAt       time.Time // ローカルミラーの同期日時（ゼロ値はSupabaseから直接取得）
+	CheckedAt      time.Time // 取得日時
+	FallbackFrom   string    // 優先の取得元（代替した場合のみ）
+	FallbackReason string    // 代替した理由（優先の取得元のエラー）
+}
+
+// Age 同期からの経過時間（直接取得は0）
+func (s *FareSource) Age() time.Duration {
+	if s.SyncedAt.IsZero() {
+		return 0
+	}
+	return s.CheckedAt.Sub(s.SyncedAt)
+}
+
+// IsStale 同期から再同期を促す期間が経過しているか
+func (s *FareSource) IsStale() bool {
+	return s.Age() > JtaMirrorStaleAfter
+}
+
+// IsFallback 優先の取得元が使えず代替したか
+func (s *FareSource) IsFallback() bool {
+	return s.FallbackFrom != ""
+}
+
+// Label 表示用ラベル（例: ローカルミラー（2026-10-01 09:00同期・15日前））
+func (s *FareSource) Label() string {
+	label := s.Name
+	if s.SyncedAt.IsZero() {
+		label += "（直接取得）"
+	} else {
+		freshness := "本日"
+		if days := int(s.Age().Hours() / 24); days > 0 {
+			freshness = fmt.Sprintf("%d日前", days)
+		}
+		if s.IsStale() {
+			freshness += "・要再同期"
+		}
+		label += fmt.Sprintf("（%s同期・%s）", s.SyncedAt.Format("2006-01-02 15:04"), freshness)
+	}
+	if s.IsFallback() {
+		label += fmt.Sprintf(" ※%sが利用できないため代替", s.FallbackFrom)
+	}
+	return label
+}
+
+// SourcedFareGetter 取得元も返すFareGetter（DistanceFareServiceが計算根拠に表示する）
+type SourcedFareGetter interface {
+	FareGetter
+	GetDistanceFareYenWithSource(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, *FareSource, error)
+}
+
+// GetDistanceFareYenWithSource 運賃と取得元を返す
+func (a *JtaSupabaseClientAdapter) GetDistanceFareYenWithSource(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, *FareSource, error) {
+	fare, err := a.GetDistanceFareYen(version, regionCode, vehicleCode, distanceKm)
+	if err != nil {
+		return 0, nil, err
+	}
+	return fare, &FareSource{Name: FareSourceSupabase, CheckedAt: time.Now()}, nil
+}
+
+// FallbackFareGetter 優先の取得元で運賃を取得できない場合に代替の取得元から取得するFareGetter
+type FallbackFareGetter struct {
+	primary      SourcedFareGetter
+	fallback     SourcedFareGetter
+	primaryName  string
+	fallbackName string
+}
+
+// NewFallbackFareGetter 新しいFallbackFareGetterを作成
+func NewFallbackFareGetter(primary SourcedFareGetter, primaryName string, fallback SourcedFareGetter, fallbackName string) *FallbackFareGetter {
+	return &FallbackFareGetter{primary: primary, fallback: fallback, primaryName: primaryName, fallbackName: fallbackName}
+}
+
+// GetDistanceFareYen 運賃を取得して金額のみ返す
+func (g *FallbackFareGetter) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
+	fare, _, err := g.GetDistanceFareYenWithSource(version, regionCode, vehicleCode, distanceKm)
+	return fare, err
+}
+
+// GetDistanceFareYenWithSource 運賃と取得元を返す（代替した場合は優先の取得元のエラーを記録する）
+func (g *FallbackFareGetter) GetDistanceFareYenWithSource(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, *FareSource, error) {
+	fare, source, err := g.primary.GetDistanceFareYenWithSource(version, regionCode, vehicleCode, distanceKm)
+	if err == nil {
+		return fare, source, nil
+	}
+
+	fare, source, fallbackErr := g.fallback.GetDistanceFareYenWithSource(version, regionCode, vehicleCode, distanceKm)
+	if fallbackErr != nil {
+		return 0, nil, fmt.Errorf("%s: %v／%s: %w", g.primaryName, err, g.fallbackName, fallbackErr)
+	}
+	source.FallbackFrom = g.primaryName
+	source.FallbackReason = err.Error()
+	return fare, source, nil
+}
+
+// FallbackChargeDataGetter 優先の取得元で付帯料金を取得できない場合に代替の取得元から取得するChargeDataGetter
+type FallbackChargeDataGetter struct {
+	primary  ChargeDataGetter
+	fallback ChargeDataGetter
+}
+
+// NewFallbackChargeDataGetter 新しいFallbackChargeDataGetterを作成
+func NewFallbackChargeDataGetter(primary, fallback ChargeDataGetter) *FallbackChargeDataGetter {
+	return &FallbackChargeDataGetter{primary: primary, fallback: fallback}
+}
+
+// GetChargeData 付帯料金データを全件取得（優先の取得元が0件の場合も代替する）
+func (g *FallbackChargeDataGetter) GetChargeData() ([]model.JtaChargeData, error) {
+	charges, err := g.primary.GetChargeData()
+	if err == nil && len(charges) > 0 {
+		return charges, nil
+	}
+
+	charges, fallbackErr := g.fallback.GetChargeData()
+	if fallbackErr != nil {
+		if err == nil {
+			return nil, fallbackErr
+		}
+		return nil, fmt.Errorf("%v／%w", err, fallbackErr)
+	}
+	return charges, nil
+}
+
+// JtaFareSourceMode トラ協データ（距離制運賃・付帯料金）の取得元の設定
+type JtaFareSourceMode string
+
+// トラ協データの取得元の設定
+const (
+	JtaFareSourceSupabase      JtaFareSourceMode = "supabase"       // Supabaseのみ
+	JtaFareSourceSupabaseFirst JtaFareSourceMode = "supabase_first" // Supabase優先、取得できない場合はローカルミラー
+	JtaFareSourceLocalFirst    JtaFareSourceMode = "local_first"    // ローカルミラー優先、取得できない場合はSupabase
+	JtaFareSourceLocal         JtaFareSourceMode = "local"          // ローカルミラーのみ
+)
+
+// ParseJtaFareSourceMode 取得元の設定を解析する
+func ParseJtaFareSourceMode(s string) (JtaFareSourceMode, error) {
+	switch m := JtaFareSourceMode(strings.TrimSpace(s)); m {
+	case JtaFareSourceSupabase, JtaFareSourceSupabaseFirst, JtaFareSourceLocalFirst, JtaFareSourceLocal:
+		return m, nil
+	}
+	return "", fmt.Errorf("無効な取得元: %s（supabase / supabase_first / local_first / local で指定）", s)
+}
+
+// UsesSupabase Supabaseを使うか
+func (m JtaFareSourceMode) UsesSupabase() bool {
+	return m != JtaFareSourceLocal
+}
+
+// UsesLocalMirror ローカルミラーを使うか
+func (m JtaFareSourceMode) UsesLocalMirror() bool {
+	return m != JtaFareSourceSupabase
+}
+
+// FareGetter 取得元の設定に応じた距離制運賃のFareGetterを返す（使わない取得元はnil可）
+func (m JtaFareSourceMode) FareGetter(supabase, mirror SourcedFareGetter) SourcedFareGetter {
+	switch m {
+	case JtaFareSourceSupabaseFirst:
+		return NewFallbackFareGetter(supabase, FareSourceSupabase, mirror, FareSourceLocalMirror)
+	case JtaFareSourceLocalFirst:
+		return NewFallbackFareGetter(mirror, FareSourceLocalMirror, supabase, FareSourceSupabase)
+	case JtaFareSourceLocal:
+		return mirror
+	}
+	return supabase
+}
+
+// ChargeDataGetter 取得元の設定に応じた付帯料金のChargeDataGetterを返す（使わない取得元はnil可）
+func (m JtaFareSourceMode) ChargeDataGetter(supabase, mirror ChargeDataGetter) ChargeDataGetter {
+	switch m {
+	case JtaFareSourceSupabaseFirst:
+		return NewFallbackChargeDataGetter(supabase, mirror)
+	case JtaFareSourceLocalFirst:
+		return NewFallbackChargeDataGetter(mirror, supabase)
+	case JtaFareSourceLocal:
+		return mirror
+	}
+	return supabase
+}
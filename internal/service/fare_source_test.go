@@ -0,0 +1,129 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// stubSourcedFareGetter テスト用の取得元付きFareGetter
+type stubSourcedFareGetter struct {
+	name string
+	fare int
+	err  error
+}
+
+func (g *stubSourcedFareGetter) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
+	fare, _, err := g.GetDistanceFareYenWithSource(version, regionCode, vehicleCode, distanceKm)
+	return fare, err
+}
+
+func (g *stubSourcedFareGetter) GetDistanceFareYenWithSource(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, *FareSource, error) {
+	if g.err != nil {
+		return 0, nil, g.err
+	}
+	return g.fare, &FareSource{Name: g.name, CheckedAt: time.Now()}, nil
+}
+
+// stubChargeDataGetter テスト用の付帯料金取得元
+type stubChargeDataGetter struct {
+	charges []model.JtaChargeData
+	err     error
+}
+
+func (g *stubChargeDataGetter) GetChargeData() ([]model.JtaChargeData, error) {
+	return g.charges, g.err
+}
+
+func TestFareSource_Label(t *testing.T) {
+	checkedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		source *FareSource
+		want   string
+	}{
+		{"直接取得", &FareSource{Name: FareSourceSupabase, CheckedAt: checkedAt}, "Supabase（トラ協）（直接取得）"},
+		{"本日同期", &FareSource{Name: FareSourceLocalMirror, SyncedAt: checkedAt.Add(-time.Hour), CheckedAt: checkedAt}, "ローカルミラー（2026-10-16 08:00同期・本日）"},
+		{"要再同期", &FareSource{Name: FareSourceLocalMirror, SyncedAt: checkedAt.AddDate(0, -2, 0), CheckedAt: checkedAt}, "ローカルミラー（2026-08-16 09:00同期・61日前・要再同期）"},
+		{"代替", &FareSource{Name: FareSourceLocalMirror, SyncedAt: checkedAt.AddDate(0, 0, -3), CheckedAt: checkedAt, FallbackFrom: FareSourceSupabase}, "ローカルミラー（2026-10-13 09:00同期・3日前） ※Supabase（トラ協）が利用できないため代替"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.source.Label(); got != tt.want {
+				t.Errorf("Label() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFallbackFareGetter(t *testing.T) {
+	supabase := &stubSourcedFareGetter{name: FareSourceSupabase, fare: 35000}
+	mirror := &stubSourcedFareGetter{name: FareSourceLocalMirror, fare: 34000}
+
+	// 優先の取得元で取得できる場合はそのまま
+	getter := JtaFareSourceSupabaseFirst.FareGetter(supabase, mirror)
+	fare, source, err := getter.GetDistanceFareYenWithSource(nil, 3, 3, 100)
+	if err != nil || fare != 35000 || source.IsFallback() {
+		t.Errorf("優先の取得元: fare=%d, source=%+v, err=%v", fare, source, err)
+	}
+
+	// 優先の取得元のエラーは代替の取得元で取得し、理由を記録
+	supabase.err = errors.New("タイムアウト")
+	fare, source, err = getter.GetDistanceFareYenWithSource(nil, 3, 3, 100)
+	if err != nil || fare != 34000 || source.FallbackFrom != FareSourceSupabase || source.FallbackReason != "タイムアウト" {
+		t.Errorf("代替: fare=%d, source=%+v, err=%v", fare, source, err)
+	}
+
+	// 両方失敗した場合は両方のエラー
+	mirror.err = errors.New("未同期")
+	if _, err := getter.GetDistanceFareYen(nil, 3, 3, 100); err == nil || !strings.Contains(err.Error(), "タイムアウト") || !strings.Contains(err.Error(), "未同期") {
+		t.Errorf("両方失敗のエラー = %v", err)
+	}
+
+	// DistanceFareServiceの計算根拠に取得元を表示
+	mirror.err = nil
+	result, err := NewDistanceFareService(JtaFareSourceLocalFirst.FareGetter(supabase, mirror)).Calculate(3, 3, 100, false, false)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if result.FareSource == nil || result.FareSource.Name != FareSourceLocalMirror {
+		t.Fatalf("FareSource = %+v", result.FareSource)
+	}
+	if !strings.Contains(result.Breakdown(), "運賃データ: ローカルミラー") {
+		t.Errorf("計算根拠に取得元がない:\n%s", result.Breakdown())
+	}
+}
+
+func TestFallbackChargeDataGetter(t *testing.T) {
+	charges := []model.JtaChargeData{{IDCode: 1, VehicleCode: 3, TimeCode: 30, ChargeYen: 1500}}
+	supabase := &stubChargeDataGetter{err: errors.New("接続エラー")}
+	mirror := &stubChargeDataGetter{charges: charges}
+
+	got, err := JtaFareSourceSupabaseFirst.ChargeDataGetter(supabase, mirror).GetChargeData()
+	if err != nil || len(got) != 1 {
+		t.Errorf("代替: %v, %v", got, err)
+	}
+
+	// 優先の取得元が0件の場合も代替
+	supabase.err = nil
+	if got, err := JtaFareSourceSupabaseFirst.ChargeDataGetter(supabase, mirror).GetChargeData(); err != nil || len(got) != 1 {
+		t.Errorf("0件の代替: %v, %v", got, err)
+	}
+}
+
+func TestParseJtaFareSourceMode(t *testing.T) {
+	for _, s := range []string{"supabase", "supabase_first", "local_first", "local"} {
+		if _, err := ParseJtaFareSourceMode(s); err != nil {
+			t.Errorf("ParseJtaFareSourceMode(%s) error = %v", s, err)
+		}
+	}
+	if _, err := ParseJtaFareSourceMode("sqlite"); err == nil {
+		t.Error("無効な取得元でエラーが発生しなかった")
+	}
+	if JtaFareSourceSupabase.UsesLocalMirror() || JtaFareSourceLocal.UsesSupabase() {
+		t.Error("UsesLocalMirror / UsesSupabase の判定が不正")
+	}
+}
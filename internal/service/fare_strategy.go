@@ -0,0 +1,260 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// FareStrategy 運賃計算方式（ランキングで比較する運賃の1つ）
+// 自社運賃・協力会社の料金表・キロ単価契約などを登録して比較に加えられる
+type FareStrategy interface {
+	Name() string                  // 運賃タイプ（ランキングの表示名、登録済みの方式と重複不可）
+	Supports(vehicleCode int) bool // 車格コードに対応しているか
+	Calculate(ctx *FareContext) (*FareStrategyResult, error)
+}
+
+// FareDetail 運賃計算方式ごとの計算結果（計算根拠を返す）
+type FareDetail interface {
+	Breakdown() string
+}
+
+// FareStrategyResult 運賃計算方式の共通の計算結果
+type FareStrategyResult struct {
+	Name   string     // 運賃タイプ
+	Tax    *TaxAmount // 運賃額（税込・税抜）
+	Detail FareDetail // 方式ごとの計算結果
+}
+
+// Breakdown 計算根拠を文字列で返す
+func (r *FareStrategyResult) Breakdown() string {
+	return r.Detail.Breakdown()
+}
+
+// FareContext 運賃計算方式に渡す計算条件
+// CalculateAllが経由地・運行形態・出発日時を反映し、車格ごとの共通項目を計算して設定する
+type FareContext struct {
+	Request        *FareCalculationRequest // 経由地・運行形態を反映したリクエスト
+	QuoteDate      time.Time               // 見積日
+	IsNight        bool                    // 深夜割増（出発日時指定時は判定結果）
+	IsHoliday      bool                    // 休日割増（出発日時指定時は判定結果）
+	Departure      *DepartureAnalysis      // 出発日時からの判定結果（出発日時指定時のみ）
+	SurchargeItems []*model.SurchargeItem  // 割増項目
+	Tax            *TaxCalculator          // 消費税計算
+
+	// 車格ごとの共通項目（軽貨物は赤帽、トラックはトラ協）
+	TariffVersion  *model.TariffVersion        // 適用運賃版（nilは版指定なし）
+	BodyType       *model.BodyTypeSurcharge    // 特殊車両割増（トラック、指定時のみ）
+	JtaCharges     *JtaChargeResult            // トラ協付帯料金（トラック、計算サービス設定時のみ）
+	FuelSurcharge  *FuelSurchargeResult        // 燃料サーチャージ（トラック、指定時のみ）
+	AdditionalFees *AkabouAdditionalFeesResult // 赤帽付帯料金（軽貨物のみ）
+	Compliance     *ComplianceResult           // 改善基準告示の確認（トラック、確認サービス設定時のみ）
+}
+
+// jtaOptions トラ協運賃の計算オプション（深夜割増は深夜時間の割合で按分）
+func (c *FareContext) jtaOptions() []FareOption {
+	opts := []FareOption{WithTariffVersion(c.TariffVersion), WithSurchargeItems(c.SurchargeItems)}
+	if c.Departure != nil {
+		opts = append(opts, WithNightMinutes(c.Departure.NightMinutes, c.Departure.TotalMinutes))
+	}
+	if c.BodyType != nil {
+		opts = append(opts, WithBodyTypeSurcharge(c.BodyType))
+	}
+	return opts
+}
+
+// akabouOptions 赤帽運賃の計算オプション
+func (c *FareContext) akabouOptions() []FareOption {
+	return []FareOption{WithTariffVersion(c.TariffVersion), WithSurchargeItems(c.SurchargeItems)}
+}
+
+// FareStrategyRegistry 運賃計算方式の登録簿（登録順にランキングへ追加する）
+type FareStrategyRegistry struct {
+	strategies []FareStrategy
+}
+
+// NewFareStrategyRegistry 新しいFareStrategyRegistryを作成
+func NewFareStrategyRegistry(strategies ...FareStrategy) (*FareStrategyRegistry, error) {
+	r := &FareStrategyRegistry{}
+	for _, s := range strategies {
+		if err := r.Register(s); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register 運賃計算方式を登録する（運賃タイプが重複する場合はエラー）
+func (r *FareStrategyRegistry) Register(strategy FareStrategy) error {
+	for _, s := range r.strategies {
+		if s.Name() == strategy.Name() {
+			return fmt.Errorf("運賃計算方式が登録済みです: %s", strategy.Name())
+		}
+	}
+	r.strategies = append(r.strategies, strategy)
+	return nil
+}
+
+// ForVehicle 車格コードに対応する運賃計算方式を登録順に返す
+func (r *FareStrategyRegistry) ForVehicle(vehicleCode int) []FareStrategy {
+	var strategies []FareStrategy
+	for _, s := range r.strategies {
+		if s.Supports(vehicleCode) {
+			strategies = append(strategies, s)
+		}
+	}
+	return strategies
+}
+
+// distanceFareStrategy トラ協距離制運賃
+type distanceFareStrategy struct {
+	service *DistanceFareService
+}
+
+// Name 運賃タイプ
+func (s *distanceFareStrategy) Name() string {
+	return "距離制"
+}
+
+// Supports 車格コードに対応しているか
+func (s *distanceFareStrategy) Supports(vehicleCode int) bool {
+	return vehicleCode != VehicleCodeLight
+}
+
+// Calculate 運賃を計算する
+func (s *distanceFareStrategy) Calculate(ctx *FareContext) (*FareStrategyResult, error) {
+	req := ctx.Request
+	result, err := s.service.Calculate(req.RegionCode, req.VehicleCode, req.DistanceKm, ctx.IsNight, ctx.IsHoliday, ctx.jtaOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("距離制運賃計算エラー: %w", err)
+	}
+
+	// 付帯料金・燃料サーチャージを加算
+	if c := ctx.JtaCharges; c != nil {
+		result.HandlingCharge = c.HandlingCharge
+		result.WaitingCharge = c.WaitingCharge
+		result.TotalFare += c.TotalCharge
+	}
+	if f := ctx.FuelSurcharge; f != nil {
+		result.FuelSurcharge = f.Surcharge
+		result.TotalFare += f.Surcharge
+	}
+
+	// 消費税（トラ協運賃は税抜）
+	result.Tax = taxAmountPtr(ctx.Tax.FromExclusive(result.TotalFare))
+	return &FareStrategyResult{Name: s.Name(), Tax: result.Tax, Detail: result}, nil
+}
+
+// timeFareStrategy トラ協時間制運賃
+type timeFareStrategy struct {
+	service *TimeFareService
+}
+
+// Name 運賃タイプ
+func (s *timeFareStrategy) Name() string {
+	return "時間制"
+}
+
+// Supports 車格コードに対応しているか
+func (s *timeFareStrategy) Supports(vehicleCode int) bool {
+	return vehicleCode != VehicleCodeLight
+}
+
+// Calculate 運賃を計算する
+func (s *timeFareStrategy) Calculate(ctx *FareContext) (*FareStrategyResult, error) {
+	req := ctx.Request
+	opts := append(ctx.jtaOptions(), WithOvernightCost(req.OvernightCostYen))
+	if req.IncludeComplianceTime && ctx.Compliance != nil {
+		opts = append(opts, WithCompliance(ctx.Compliance))
+	}
+	result, err := s.service.Calculate(
+		req.RegionCode, req.VehicleCode, req.DistanceKm, req.DrivingMinutes, req.LoadingMinutes,
+		ctx.IsNight, ctx.IsHoliday, req.UseSimpleBaseKm, opts...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("時間制運賃計算エラー: %w", err)
+	}
+
+	// 付帯料金・燃料サーチャージを加算
+	if c := ctx.JtaCharges; c != nil {
+		result.HandlingCharge = c.HandlingCharge
+		result.WaitingCharge = c.WaitingCharge
+		result.TotalFare += c.TotalCharge
+	}
+	if f := ctx.FuelSurcharge; f != nil {
+		result.FuelSurcharge = f.Surcharge
+		result.TotalFare += f.Surcharge
+	}
+
+	// 消費税（トラ協運賃は税抜）
+	result.Tax = taxAmountPtr(ctx.Tax.FromExclusive(result.TotalFare))
+	return &FareStrategyResult{Name: s.Name(), Tax: result.Tax, Detail: result}, nil
+}
+
+// akabouDistanceStrategy 赤帽運賃（距離制）
+type akabouDistanceStrategy struct {
+	service *AkabouFareService
+}
+
+// Name 運賃タイプ
+func (s *akabouDistanceStrategy) Name() string {
+	return "赤帽（距離制）"
+}
+
+// Supports 車格コードに対応しているか
+func (s *akabouDistanceStrategy) Supports(vehicleCode int) bool {
+	return vehicleCode == VehicleCodeLight
+}
+
+// Calculate 運賃を計算する
+func (s *akabouDistanceStrategy) Calculate(ctx *FareContext) (*FareStrategyResult, error) {
+	req := ctx.Request
+	result, err := s.service.CalculateDistanceFare(req.DistanceKm, ctx.IsNight, ctx.IsHoliday, req.Area, ctx.akabouOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("赤帽距離制運賃計算エラー: %w", err)
+	}
+
+	// 付帯料金を加算
+	if ctx.AdditionalFees != nil {
+		result.TotalFare += ctx.AdditionalFees.TotalFee
+	}
+
+	// 消費税（赤帽運賃は税込）
+	result.Tax = taxAmountPtr(ctx.Tax.FromInclusive(result.TotalFare))
+	return &FareStrategyResult{Name: s.Name(), Tax: result.Tax, Detail: result}, nil
+}
+
+// akabouTimeStrategy 赤帽運賃（時間制）
+type akabouTimeStrategy struct {
+	service *AkabouFareService
+}
+
+// Name 運賃タイプ
+func (s *akabouTimeStrategy) Name() string {
+	return "赤帽（時間制）"
+}
+
+// Supports 車格コードに対応しているか
+func (s *akabouTimeStrategy) Supports(vehicleCode int) bool {
+	return vehicleCode == VehicleCodeLight
+}
+
+// Calculate 運賃を計算する
+func (s *akabouTimeStrategy) Calculate(ctx *FareContext) (*FareStrategyResult, error) {
+	req := ctx.Request
+	totalMinutes := req.DrivingMinutes + req.LoadingMinutes
+	result, err := s.service.CalculateTimeFare(totalMinutes, ctx.IsNight, ctx.IsHoliday, req.Area, ctx.akabouOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("赤帽時間制運賃計算エラー: %w", err)
+	}
+
+	// 付帯料金を加算
+	if ctx.AdditionalFees != nil {
+		result.TotalFare += ctx.AdditionalFees.TotalFee
+	}
+
+	// 消費税（赤帽運賃は税込）
+	result.Tax = taxAmountPtr(ctx.Tax.FromInclusive(result.TotalFare))
+	return &FareStrategyResult{Name: s.Name(), Tax: result.Tax, Detail: result}, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+)
+
+// mockFareStrategy テスト用の運賃計算方式
+type mockFareStrategy struct {
+	name string
+	fare int
+	err  error
+}
+
+func (m *mockFareStrategy) Name() string                  { return m.name }
+func (m *mockFareStrategy) Supports(vehicleCode int) bool { return vehicleCode != VehicleCodeLight }
+func (m *mockFareStrategy) Calculate(ctx *FareContext) (*FareStrategyResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := &PerKmRateResult{Name: m.name, TotalFare: m.fare}
+	result.Tax = taxAmountPtr(ctx.Tax.FromExclusive(m.fare))
+	return &FareStrategyResult{Name: m.name, Tax: result.Tax, Detail: result}, nil
+}
+
+// TestFareStrategyRegistry 運賃計算方式の登録・車格ごとの絞り込みテスト
+func TestFareStrategyRegistry(t *testing.T) {
+	registry, err := NewFareStrategyRegistry(
+		&mockFareStrategy{name: "自社運賃"},
+		&akabouDistanceStrategy{},
+	)
+	if err != nil {
+		t.Fatalf("NewFareStrategyRegistry failed: %v", err)
+	}
+
+	// 運賃タイプの重複はエラー
+	if err := registry.Register(&mockFareStrategy{name: "自社運賃"}); err == nil {
+		t.Error("運賃タイプが重複してもエラーが発生しなかった")
+	}
+	if _, err := NewFareStrategyRegistry(&mockFareStrategy{name: "A"}, &mockFareStrategy{name: "A"}); err == nil {
+		t.Error("NewFareStrategyRegistry: 運賃タイプが重複してもエラーが発生しなかった")
+	}
+
+	tests := []struct {
+		vehicleCode int
+		want        []string
+	}{
+		{VehicleCodeLight, []string{"赤帽（距離制）"}},
+		{3, []string{"自社運賃"}},
+	}
+	for _, tt := range tests {
+		strategies := registry.ForVehicle(tt.vehicleCode)
+		if len(strategies) != len(tt.want) {
+			t.Fatalf("ForVehicle(%d) = %d件, want %d", tt.vehicleCode, len(strategies), len(tt.want))
+		}
+		for i, s := range strategies {
+			if s.Name() != tt.want[i] {
+				t.Errorf("ForVehicle(%d)[%d] = %s, want %s", tt.vehicleCode, i, s.Name(), tt.want[i])
+			}
+		}
+	}
+}
+
+// TestFareCalculatorService_RegisterStrategy 登録した運賃計算方式がランキングに加わるテスト
+func TestFareCalculatorService_RegisterStrategy(t *testing.T) {
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&MockFareGetter{}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+
+	// 組み込みの運賃タイプとの重複はエラー
+	if err := calculator.RegisterStrategy(&mockFareStrategy{name: "距離制"}); err == nil {
+		t.Error("組み込みの運賃タイプと重複してもエラーが発生しなかった")
+	}
+
+	perKm, err := NewPerKmRateStrategy("キロ単価契約", 300, 20000, 3)
+	if err != nil {
+		t.Fatalf("NewPerKmRateStrategy failed: %v", err)
+	}
+	if err := calculator.RegisterStrategy(perKm); err != nil {
+		t.Fatalf("RegisterStrategy failed: %v", err)
+	}
+
+	req := &FareCalculationRequest{
+		RegionCode:     3,
+		VehicleCode:    3,
+		DistanceKm:     100,
+		DrivingMinutes: 120,
+		LoadingMinutes: 60,
+	}
+	result, err := calculator.CalculateAll(req)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+
+	// 組み込みの計算結果はこれまでどおり設定される
+	if result.DistanceFareResult == nil || result.TimeFareResult == nil {
+		t.Fatal("DistanceFareResult / TimeFareResult should not be nil")
+	}
+	if len(result.Results) != 3 || len(result.Rankings) != 3 {
+		t.Fatalf("Results = %d件, Rankings = %d件, want 3", len(result.Results), len(result.Rankings))
+	}
+
+	// キロ単価契約: 100km × 300円 = 30000円（税抜）が最安
+	if result.CheapestType != "キロ単価契約" {
+		t.Errorf("CheapestType = %s, want キロ単価契約", result.CheapestType)
+	}
+	if result.Rankings[0].FareExclTax != 30000 {
+		t.Errorf("Rankings[0].FareExclTax = %d, want 30000", result.Rankings[0].FareExclTax)
+	}
+
+	extra := result.ExtraResults()
+	if len(extra) != 1 || extra[0].Name != "キロ単価契約" {
+		t.Fatalf("ExtraResults = %+v, want [キロ単価契約]", extra)
+	}
+	breakdown := result.Breakdown()
+	if !containsString(breakdown, "【キロ単価契約】") || !containsString(breakdown, "100km × キロ単価 300円 = 30000円") {
+		t.Errorf("Breakdown にキロ単価契約が含まれていない:\n%s", breakdown)
+	}
+
+	// 対応しない車格（軽貨物）では計算しない
+	light := *req
+	light.VehicleCode = VehicleCodeLight
+	result, err = calculator.CalculateAll(&light)
+	if err != nil {
+		t.Fatalf("CalculateAll failed: %v", err)
+	}
+	if len(result.Rankings) != 2 || len(result.ExtraResults()) != 0 {
+		t.Errorf("軽貨物 Rankings = %d件, ExtraResults = %d件, want 2, 0", len(result.Rankings), len(result.ExtraResults()))
+	}
+
+	// 運賃計算方式のエラーは呼び出し元に返す
+	if err := calculator.RegisterStrategy(&mockFareStrategy{name: "協力会社", err: fmt.Errorf("料金表なし")}); err != nil {
+		t.Fatalf("RegisterStrategy failed: %v", err)
+	}
+	if _, err := calculator.CalculateAll(req); err == nil {
+		t.Error("運賃計算方式のエラーが返されなかった")
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// FuelSurchargeGetter 燃料価格・燃料サーチャージ取得インターフェース（テスト用にモック可能）
+// 該当データがない場合は sql.ErrNoRows を返す
+type FuelSurchargeGetter interface {
+	GetFuelPrice(date time.Time) (*model.FuelPrice, error)
+	GetSurcharge(version *model.TariffVersion, vehicleCode, distanceKm int) (*model.FuelSurcharge, error)
+}
+
+// FuelSurchargeService 燃料サーチャージ計算サービス
+type FuelSurchargeService struct {
+	getter FuelSurchargeGetter
+}
+
+// NewFuelSurchargeService 新しいFuelSurchargeServiceを作成
+func NewFuelSurchargeService(getter FuelSurchargeGetter) *FuelSurchargeService {
+	return &FuelSurchargeService{
+		getter: getter,
+	}
+}
+
+// FuelSurchargeResult 燃料サーチャージ計算結果
+type FuelSurchargeResult struct {
+	YearMonth         string  // 燃料価格の対象月（YYYY-MM）
+	FuelPriceYen      float64 // 軽油価格（円/L）
+	ReferencePriceYen float64 // 基準価格（円/L）
+	StepYen           int     // 価格差の刻み（円/L）
+	Steps             int     // 基準価格からの刻み数
+	AmountPerStep     int     // 1刻みあたりの加算額（円）
+	Surcharge         int     // 燃料サーチャージ額（円）
+}
+
+// Calculate 燃料サーチャージを計算する
+// 軽油価格が基準価格を超えた場合に、超過額を刻み単位で切り上げた刻み数 × 距離帯・車格別の加算額を返す
+func (s *FuelSurchargeService) Calculate(vehicleCode, distanceKm int, date time.Time, opts ...FareOption) (*FuelSurchargeResult, error) {
+	o := newFareOptions(opts)
+
+	price, err := s.getter.GetFuelPrice(date)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s以前の燃料価格が登録されていません", date.Format(model.FuelYearMonthFormat))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("燃料価格取得エラー: %w", err)
+	}
+
+	result := &FuelSurchargeResult{
+		YearMonth:         price.YearMonth,
+		FuelPriceYen:      price.PriceYen,
+		ReferencePriceYen: price.ReferencePriceYen,
+	}
+
+	// 基準価格以下はサーチャージなし
+	if price.PriceYen <= price.ReferencePriceYen {
+		return result, nil
+	}
+
+	band, err := s.getter.GetSurcharge(o.tariffVersion, vehicleCode, distanceKm)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("燃料サーチャージが見つかりません: 車格=%d, 距離=%dkm", vehicleCode, distanceKm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("燃料サーチャージ取得エラー: %w", err)
+	}
+	if band.StepYen <= 0 {
+		return nil, fmt.Errorf("燃料サーチャージの価格刻みが不正です: %d円", band.StepYen)
+	}
+
+	result.StepYen = band.StepYen
+	result.AmountPerStep = band.AmountYen
+	result.Steps = int(math.Ceil((price.PriceYen - price.ReferencePriceYen) / float64(band.StepYen)))
+	result.Surcharge = result.Steps * band.AmountYen
+
+	return result, nil
+}
+
+// Breakdown 計算根拠を文字列で返す
+func (r *FuelSurchargeResult) Breakdown() string {
+	result := fmt.Sprintf("燃料価格: %s 軽油 %.1f円/L（基準 %.1f円/L）\n", r.YearMonth, r.FuelPriceYen, r.ReferencePriceYen)
+	if r.Steps > 0 {
+		result += fmt.Sprintf("燃料サーチャージ: %d円 × %d（%d円刻み）= %d円\n", r.AmountPerStep, r.Steps, r.StepYen, r.Surcharge)
+	} else {
+		result += "燃料サーチャージ: 基準価格以下のため加算なし\n"
+	}
+	return result
+}
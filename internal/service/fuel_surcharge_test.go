@@ -0,0 +1,135 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// mockFuelSurchargeGetter 燃料価格・燃料サーチャージのモック
+// 大型車: 100km迄 130円、100km超 260円（5円刻み）
+type mockFuelSurchargeGetter struct {
+	price   *model.FuelPrice // nilの場合は未登録
+	version *model.TariffVersion
+	err     error
+}
+
+func (m *mockFuelSurchargeGetter) GetFuelPrice(date time.Time) (*model.FuelPrice, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.price == nil {
+		return nil, sql.ErrNoRows
+	}
+	return m.price, nil
+}
+
+func (m *mockFuelSurchargeGetter) GetSurcharge(version *model.TariffVersion, vehicleCode, distanceKm int) (*model.FuelSurcharge, error) {
+	m.version = version
+	if vehicleCode != 3 {
+		return nil, sql.ErrNoRows
+	}
+	if distanceKm <= 100 {
+		return &model.FuelSurcharge{VehicleCode: 3, MinKm: 0, StepYen: 5, AmountYen: 130}, nil
+	}
+	return &model.FuelSurcharge{VehicleCode: 3, MinKm: 101, StepYen: 5, AmountYen: 260}, nil
+}
+
+func TestFuelSurchargeService_Calculate(t *testing.T) {
+	tests := []struct {
+		name          string
+		priceYen      float64
+		distanceKm    int
+		wantSteps     int
+		wantSurcharge int
+	}{
+		{"基準価格と同額", 120, 100, 0, 0},
+		{"基準価格未満", 110, 100, 0, 0},
+		{"0.1円超過は1刻み", 120.1, 100, 1, 130},
+		{"5円超過は1刻み", 125, 100, 1, 130},
+		{"30.5円超過は7刻み", 150.5, 100, 7, 910},
+		{"長距離帯", 150.5, 300, 7, 1820},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewFuelSurchargeService(&mockFuelSurchargeGetter{
+				price: &model.FuelPrice{YearMonth: "2026-10", PriceYen: tt.priceYen, ReferencePriceYen: 120},
+			})
+
+			result, err := service.Calculate(3, tt.distanceKm, time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local))
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if result.Steps != tt.wantSteps {
+				t.Errorf("Steps = %d, want %d", result.Steps, tt.wantSteps)
+			}
+			if result.Surcharge != tt.wantSurcharge {
+				t.Errorf("Surcharge = %d, want %d", result.Surcharge, tt.wantSurcharge)
+			}
+			if result.YearMonth != "2026-10" {
+				t.Errorf("YearMonth = %s, want 2026-10", result.YearMonth)
+			}
+		})
+	}
+}
+
+func TestFuelSurchargeService_Calculate_TariffVersion(t *testing.T) {
+	getter := &mockFuelSurchargeGetter{
+		price: &model.FuelPrice{YearMonth: "2026-10", PriceYen: 150, ReferencePriceYen: 120},
+	}
+	service := NewFuelSurchargeService(getter)
+
+	version := &model.TariffVersion{ID: 1, TariffType: model.TariffTypeJTA, Name: "令和6年3月告示"}
+	if _, err := service.Calculate(3, 100, time.Now(), WithTariffVersion(version)); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if getter.version != version {
+		t.Errorf("GetSurcharge に渡された運賃版 = %v, want %v", getter.version, version)
+	}
+}
+
+func TestFuelSurchargeService_Calculate_Error(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local)
+
+	t.Run("燃料価格未登録", func(t *testing.T) {
+		service := NewFuelSurchargeService(&mockFuelSurchargeGetter{})
+		_, err := service.Calculate(3, 100, date)
+		if err == nil || !strings.Contains(err.Error(), "2026-10以前の燃料価格が登録されていません") {
+			t.Errorf("error = %v", err)
+		}
+	})
+
+	t.Run("距離帯なし", func(t *testing.T) {
+		service := NewFuelSurchargeService(&mockFuelSurchargeGetter{
+			price: &model.FuelPrice{YearMonth: "2026-10", PriceYen: 150, ReferencePriceYen: 120},
+		})
+		if _, err := service.Calculate(1, 100, date); err == nil {
+			t.Error("エラーが期待されたが、発生しなかった")
+		}
+	})
+
+	t.Run("取得エラー", func(t *testing.T) {
+		service := NewFuelSurchargeService(&mockFuelSurchargeGetter{err: errors.New("DB接続エラー")})
+		if _, err := service.Calculate(3, 100, date); err == nil {
+			t.Error("エラーが期待されたが、発生しなかった")
+		}
+	})
+}
+
+func TestFuelSurchargeResult_Breakdown(t *testing.T) {
+	result := &FuelSurchargeResult{
+		YearMonth: "2026-10", FuelPriceYen: 150.5, ReferencePriceYen: 120,
+		StepYen: 5, Steps: 7, AmountPerStep: 130, Surcharge: 910,
+	}
+	breakdown := result.Breakdown()
+	for _, want := range []string{"2026-10 軽油 150.5円/L（基準 120.0円/L）", "130円 × 7（5円刻み）= 910円"} {
+		if !strings.Contains(breakdown, want) {
+			t.Errorf("Breakdown に %q が含まれていない:\n%s", want, breakdown)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+	"golang.org/x/text/width"
+)
+
+// KEN_ALL（日本郵便の郵便番号データ、全国一括）の列
+const (
+	kenAllColJisCode    = 0 // 全国地方公共団体コード
+	kenAllColPostalCode = 2 // 郵便番号（7桁）
+	kenAllColPrefecture = 6 // 都道府県名
+	kenAllColCity       = 7 // 市区町村名
+	kenAllColTown       = 8 // 町域名
+	kenAllColumns       = 9 // 取り込みに必要な列数
+)
+
+// LoadKenAll KEN_ALL 形式のCSV（UTF-8に変換済み）を読み込む
+// 町域名の括弧書き（「（次のビルを除く）」など、複数行にわたるものを含む）は除き、
+// 「以下に掲載がない場合」「〜の次に番地がくる場合」は町域なし（空文字）とする
+func LoadKenAll(r io.Reader) ([]*model.PostalCode, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var codes []*model.PostalCode
+	inParen := false // 括弧書きが次の行に続いている
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("郵便番号データ読み込みエラー: %w", err)
+		}
+		if len(record) < kenAllColumns {
+			return nil, fmt.Errorf("郵便番号データの%d行目の列数が不足しています", line)
+		}
+
+		town := record[kenAllColTown]
+		if inParen {
+			// 括弧書きの続きの行は前の行と同じ町域なので読み飛ばす
+			inParen = !strings.Contains(town, "）")
+			continue
+		}
+		if i := strings.Index(town, "（"); i >= 0 {
+			inParen = !strings.Contains(town, "）")
+			town = town[:i]
+		}
+		if town == "以下に掲載がない場合" || strings.HasSuffix(town, "の次に番地がくる場合") {
+			town = ""
+		}
+
+		codes = append(codes, &model.PostalCode{
+			PostalCode: record[kenAllColPostalCode],
+			JisCode:    record[kenAllColJisCode],
+			Prefecture: record[kenAllColPrefecture],
+			City:       record[kenAllColCity],
+			Town:       width.Fold.String(town),
+		})
+	}
+
+	if len(codes) == 0 {
+		return nil, errors.New("郵便番号データが空です")
+	}
+	return codes, nil
+}
+
+// GazetteerStore 郵便番号データストアインターフェース
+type GazetteerStore interface {
+	FindByPostalCode(postalCode string) ([]*model.PostalCode, error)
+	GetMunicipalities() ([]*model.Municipality, error)
+}
+
+// gazetteerEntry 市区町村名（別名を含む）に対応する市区町村
+type gazetteerEntry struct {
+	prefecture string
+	city       string // 市区町村（政令指定都市は市まで）
+	ward       string // 政令指定都市の区
+}
+
+// postalCodePattern 住所の先頭の郵便番号（〒100-0005、1000005）
+var postalCodePattern = regexp.MustCompile(`^〒?(\d{3})-?(\d{4})(.*)$`)
+
+// designatedCityPattern 政令指定都市の区（大阪市北区 → 大阪市 + 北区）
+var designatedCityPattern = regexp.MustCompile(`^(.+?市)(.+区)$`)
+
+// countyTownPattern 郡部の町村（西多摩郡奥多摩町 → 奥多摩町、住所では郡を省略することが多い）
+var countyTownPattern = regexp.MustCompile(`^.+?郡(.+[町村])$`)
+
+// Gazetteer 郵便番号データによる住所の判定（郵便番号・住所の一部から都道府県・市区町村・区をAPIなしで判定する）
+type Gazetteer struct {
+	store          GazetteerStore
+	names          map[string][]gazetteerEntry // 市区町村名（別名を含む）→ 市区町村
+	maxNameLen     int                         // 市区町村名の最大文字数
+	municipalities int                         // 読み込んだ市区町村の数
+}
+
+// NewGazetteer 郵便番号データの市区町村を読み込んで住所の判定を作成
+func NewGazetteer(store GazetteerStore) (*Gazetteer, error) {
+	municipalities, err := store.GetMunicipalities()
+	if err != nil {
+		return nil, fmt.Errorf("市区町村の読み込みエラー: %w", err)
+	}
+
+	g := &Gazetteer{
+		store:          store,
+		names:          make(map[string][]gazetteerEntry),
+		municipalities: len(municipalities),
+	}
+	for _, m := range municipalities {
+		city, ward := splitDesignatedCity(m.City)
+		g.addName(m.City, gazetteerEntry{m.Prefecture, city, ward})
+		if ward != "" {
+			// 区を省略した住所（大阪市梅田）は市として判定する
+			g.addName(city, gazetteerEntry{m.Prefecture, city, ""})
+			// 市を省略した住所（北区梅田）は区名で判定する（東京23区・他の市の同名の区と重なる場合は判定しない）
+			g.addName(ward, gazetteerEntry{m.Prefecture, city, ward})
+		}
+		if match := countyTownPattern.FindStringSubmatch(m.City); match != nil {
+			g.addName(match[1], gazetteerEntry{m.Prefecture, m.City, ""})
+		}
+	}
+	return g, nil
+}
+
+// addName 市区町村名を登録する（同じ市区町村は1件にまとめる）
+func (g *Gazetteer) addName(name string, entry gazetteerEntry) {
+	for _, e := range g.names[name] {
+		if e == entry {
+			return
+		}
+	}
+	g.names[name] = append(g.names[name], entry)
+	g.maxNameLen = max(g.maxNameLen, len([]rune(name)))
+}
+
+// Size 読み込んだ市区町村の数（郵便番号データ未取込の場合は0）
+func (g *Gazetteer) Size() int {
+	if g == nil {
+		return 0
+	}
+	return g.municipalities
+}
+
+// Resolve 郵便番号または住所（都道府県の省略を含む）から都道府県・市区町村・区を判定する
+// 判定できない場合（該当なし・同名の市区町村が複数の都道府県にある場合など）は false を返す。nil の場合も false
+func (g *Gazetteer) Resolve(address string) (*AddressComponents, bool) {
+	if g == nil {
+		return nil, false
+	}
+	s := NormalizeAddress(address)
+
+	if match := postalCodePattern.FindStringSubmatch(s); match != nil {
+		if components, ok := g.resolvePostalCode(match[1]+match[2], match[3]); ok {
+			return components, true
+		}
+		s = match[3]
+	}
+
+	prefecture, rest := "", s
+	for pref := range prefectureToRegion {
+		if strings.HasPrefix(s, pref) {
+			prefecture, rest = pref, strings.TrimPrefix(s, pref)
+			break
+		}
+	}
+
+	entry, ok := g.matchMunicipality(prefecture, rest)
+	if !ok {
+		return nil, false
+	}
+	if entry.ward != "" && !strings.HasPrefix(rest, entry.city) {
+		// 市を省略した区（淀川区西中島）は市を補う
+		rest = entry.city + rest
+	}
+	return &AddressComponents{
+		Prefecture: entry.prefecture,
+		City:       entry.city,
+		Ward:       entry.ward,
+		Address:    entry.prefecture + rest,
+	}, true
+}
+
+// matchMunicipality 住所の先頭に最も長く一致する市区町村を探す（都道府県が指定されていればその都道府県のみ）
+func (g *Gazetteer) matchMunicipality(prefecture, rest string) (gazetteerEntry, bool) {
+	runes := []rune(rest)
+	for n := min(len(runes), g.maxNameLen); n > 0; n-- {
+		var found []gazetteerEntry
+		for _, e := range g.names[string(runes[:n])] {
+			if prefecture == "" || e.prefecture == prefecture {
+				found = append(found, e)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], true
+		default:
+			// 同名の市区町村が複数ある（府中市など）
+			return gazetteerEntry{}, false
+		}
+	}
+	return gazetteerEntry{}, false
+}
+
+// resolvePostalCode 郵便番号から判定する（rest は郵便番号に続く住所）
+func (g *Gazetteer) resolvePostalCode(postalCode, rest string) (*AddressComponents, bool) {
+	codes, err := g.store.FindByPostalCode(postalCode)
+	if err != nil || len(codes) == 0 {
+		return nil, false
+	}
+	first := codes[0]
+	for _, c := range codes[1:] {
+		if c.Prefecture != first.Prefecture || c.City != first.City {
+			return nil, false
+		}
+	}
+
+	city, ward := splitDesignatedCity(first.City)
+	// 郵便番号に続く住所から都道府県・市区町村を除き、郵便番号データの表記で組み立てる
+	rest = strings.TrimPrefix(rest, first.Prefecture)
+	switch {
+	case strings.HasPrefix(rest, first.City):
+		rest = strings.TrimPrefix(rest, first.City)
+	case ward != "" && strings.HasPrefix(rest, city):
+		rest = strings.TrimPrefix(rest, city)
+	}
+	if rest == "" && len(codes) == 1 {
+		rest = first.Town
+	}
+
+	return &AddressComponents{
+		Prefecture: first.Prefecture,
+		City:       city,
+		Ward:       ward,
+		Address:    first.Prefecture + first.City + rest,
+	}, true
+}
+
+// splitDesignatedCity 政令指定都市の区を市と区に分ける（それ以外は区を空文字で返す）
+func splitDesignatedCity(city string) (string, string) {
+	if match := designatedCityPattern.FindStringSubmatch(city); match != nil {
+		return match[1], match[2]
+	}
+	return city, ""
+}
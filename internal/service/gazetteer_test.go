@@ -0,0 +1,186 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// testKenAll KEN_ALL 形式のテストデータ（UTF-8に変換済み）
+const testKenAll = `13101,"100  ","1000000","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ｲｶﾆｹｲｻｲｶﾞﾅｲﾊﾞｱｲ","東京都","千代田区","以下に掲載がない場合",0,0,0,0,0,0
+13101,"100  ","1000005","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ﾏﾙﾉｳﾁ(ﾂｷﾞﾉﾋﾞﾙｦﾉｿﾞｸ)","東京都","千代田区","丸の内（次のビルを除く）",0,0,1,0,0,0
+13206,"183  ","1830000","ﾄｳｷｮｳﾄ","ﾌﾁｭｳｼ","ｲｶﾆｹｲｻｲｶﾞﾅｲﾊﾞｱｲ","東京都","府中市","以下に掲載がない場合",0,0,0,0,0,0
+13308,"19802","1980200","ﾄｳｷｮｳﾄ","ﾆｼﾀﾏｸﾞﾝｵｸﾀﾏﾏﾁ","ｲｶﾆｹｲｻｲｶﾞﾅｲﾊﾞｱｲ","東京都","西多摩郡奥多摩町","以下に掲載がない場合",0,0,0,0,0,0
+27127,"530  ","5300001","ｵｵｻｶﾌ","ｵｵｻｶｼｷﾀｸ","ｳﾒﾀﾞ","大阪府","大阪市北区","梅田",0,0,1,0,0,0
+27128,"540  ","5400002","ｵｵｻｶﾌ","ｵｵｻｶｼﾁｭｳｵｳｸ","ｵｵｻｶｼﾞｮｳ","大阪府","大阪市中央区","大阪城",0,0,0,0,0,0
+27146,"591  ","5918025","ｵｵｻｶﾌ","ｻｶｲｼｷﾀｸ","ﾅｶﾞｿﾈﾁｮｳ","大阪府","堺市北区","長曽根町",0,0,0,0,0,0
+34208,"726  ","7260001","ﾋﾛｼﾏｹﾝ","ﾌﾁｭｳｼ","ｲｶﾆｹｲｻｲｶﾞﾅｲﾊﾞｱｲ","広島県","府中市","以下に掲載がない場合",0,0,0,0,0,0
+01101,"060  ","0600042","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ(1-19ﾁｮｳﾒ)","北海道","札幌市中央区","大通西（１～１９丁目）",1,0,1,0,0,0
+01101,"064  ","0640941","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｱｻﾋｶﾞｵｶ","北海道","札幌市中央区","旭ケ丘（１～５丁目、",0,0,1,0,0,0
+01101,"064  ","0640941","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｱｻﾋｶﾞｵｶ","北海道","札幌市中央区","６丁目１番～３番）",0,0,1,0,0,0
+13117,"114  ","1140000","ﾄｳｷｮｳﾄ","ｷﾀｸ","ｲｶﾆｹｲｻｲｶﾞﾅｲﾊﾞｱｲ","東京都","北区","以下に掲載がない場合",0,0,0,0,0,0
+27118,"532  ","5320011","ｵｵｻｶﾌ","ｵｵｻｶｼﾖﾄﾞｶﾞﾜｸ","ﾆｼﾅｶｼﾞﾏ","大阪府","大阪市淀川区","西中島",0,0,1,0,0,0
+`
+
+// mockGazetteerStore テスト用の郵便番号データストア
+type mockGazetteerStore struct {
+	codes []*model.PostalCode
+}
+
+func (m *mockGazetteerStore) FindByPostalCode(postalCode string) ([]*model.PostalCode, error) {
+	var found []*model.PostalCode
+	for _, c := range m.codes {
+		if c.PostalCode == postalCode {
+			found = append(found, c)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockGazetteerStore) GetMunicipalities() ([]*model.Municipality, error) {
+	seen := make(map[string]bool)
+	var municipalities []*model.Municipality
+	for _, c := range m.codes {
+		if !seen[c.JisCode] {
+			seen[c.JisCode] = true
+			municipalities = append(municipalities, &model.Municipality{JisCode: c.JisCode, Prefecture: c.Prefecture, City: c.City})
+		}
+	}
+	return municipalities, nil
+}
+
+func newTestGazetteer(t *testing.T) *Gazetteer {
+	t.Helper()
+	codes, err := LoadKenAll(strings.NewReader(testKenAll))
+	if err != nil {
+		t.Fatalf("LoadKenAll() error = %v", err)
+	}
+	g, err := NewGazetteer(&mockGazetteerStore{codes: codes})
+	if err != nil {
+		t.Fatalf("NewGazetteer() error = %v", err)
+	}
+	return g
+}
+
+func TestLoadKenAll(t *testing.T) {
+	codes, err := LoadKenAll(strings.NewReader(testKenAll))
+	if err != nil {
+		t.Fatalf("LoadKenAll() error = %v", err)
+	}
+	// 括弧書きの続きの行（６丁目１番～３番））は読み飛ばす
+	if len(codes) != 12 {
+		t.Fatalf("LoadKenAll() = %d件, want 12", len(codes))
+	}
+
+	tests := []struct {
+		index    int
+		wantCode string
+		wantTown string
+	}{
+		{0, "1000000", ""},    // 以下に掲載がない場合
+		{1, "1000005", "丸の内"}, // 括弧書きを除く
+		{8, "0600042", "大通西"}, // 全角数字の括弧書き
+		{9, "0640941", "旭ケ丘"}, // 複数行にわたる括弧書き
+	}
+	for _, tt := range tests {
+		got := codes[tt.index]
+		if got.PostalCode != tt.wantCode || got.Town != tt.wantTown {
+			t.Errorf("codes[%d] = %+v, want %s %s", tt.index, got, tt.wantCode, tt.wantTown)
+		}
+	}
+
+	if _, err := LoadKenAll(strings.NewReader("")); err == nil {
+		t.Error("LoadKenAll() 空データでエラーが返らない")
+	}
+	if _, err := LoadKenAll(strings.NewReader("13101,100,1000000\n")); err == nil {
+		t.Error("LoadKenAll() 列数不足でエラーが返らない")
+	}
+}
+
+func TestGazetteer_Resolve(t *testing.T) {
+	g := newTestGazetteer(t)
+	if g.Size() != 10 {
+		t.Errorf("Size() = %d, want 10", g.Size())
+	}
+
+	tests := []struct {
+		name           string
+		address        string
+		wantPrefecture string
+		wantCity       string
+		wantWard       string
+		wantAddress    string
+	}{
+		{"郵便番号のみ", "〒100-0005", "東京都", "千代田区", "", "東京都千代田区丸の内"},
+		{"全角の郵便番号と住所", "〒５３０－０００１ 梅田3-1-1", "大阪府", "大阪市", "北区", "大阪府大阪市北区梅田3-1-1"},
+		{"郵便番号ハイフンなし", "5300001大阪府大阪市北区梅田", "大阪府", "大阪市", "北区", "大阪府大阪市北区梅田"},
+		{"大阪府を省略", "大阪市北区梅田3-1-1", "大阪府", "大阪市", "北区", "大阪府大阪市北区梅田3-1-1"},
+		{"大阪府と区を省略", "大阪市中央区大阪城1-1", "大阪府", "大阪市", "中央区", "大阪府大阪市中央区大阪城1-1"},
+		{"区を省略", "大阪市梅田", "大阪府", "大阪市", "", "大阪府大阪市梅田"},
+		{"堺市の北区", "大阪府堺市北区長曽根町", "大阪府", "堺市", "北区", "大阪府堺市北区長曽根町"},
+		{"東京都を省略", "千代田区丸の内1丁目1", "東京都", "千代田区", "", "東京都千代田区丸の内1-1"},
+		{"郡を省略", "奥多摩町氷川", "東京都", "西多摩郡奥多摩町", "", "東京都奥多摩町氷川"},
+		{"同名の市は都道府県で判定", "広島県府中市府川町", "広島県", "府中市", "", "広島県府中市府川町"},
+		{"市を省略した区", "淀川区西中島5-1", "大阪府", "大阪市", "淀川区", "大阪府大阪市淀川区西中島5-1"},
+		{"東京23区と同名の区は都道府県で判定", "東京都北区赤羽", "東京都", "北区", "", "東京都北区赤羽"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := g.Resolve(tt.address)
+			if !ok {
+				t.Fatalf("Resolve(%q) 判定できない", tt.address)
+			}
+			if got.Prefecture != tt.wantPrefecture || got.City != tt.wantCity || got.Ward != tt.wantWard || got.Address != tt.wantAddress {
+				t.Errorf("Resolve(%q) = %+v, want %s %s %s %s", tt.address, got, tt.wantPrefecture, tt.wantCity, tt.wantWard, tt.wantAddress)
+			}
+		})
+	}
+
+	// 同名の市・区（府中市、東京都北区・大阪市北区・堺市北区）は都道府県がなければ判定しない
+	for _, address := range []string{"府中市府中町", "北区梅田1-1", "大阪府北区梅田", "〒999-9999", "横浜市西区", ""} {
+		if got, ok := g.Resolve(address); ok {
+			t.Errorf("Resolve(%q) = %+v, want 判定できない", address, got)
+		}
+	}
+
+	var empty *Gazetteer
+	if _, ok := empty.Resolve("大阪市北区"); ok || empty.Size() != 0 {
+		t.Error("nil の Gazetteer で判定できた")
+	}
+}
+
+func TestResolveAkabouAreaFromComponents(t *testing.T) {
+	g := newTestGazetteer(t)
+
+	tests := []struct {
+		name     string
+		address  string
+		wantArea string
+	}{
+		{"大阪府を省略した大阪市", "大阪市北区梅田", "大阪市内"},
+		{"郵便番号の大阪市", "〒540-0002", "大阪市内"},
+		{"堺市は大阪市外", "大阪府堺市北区長曽根町", ""},
+		{"東京都を省略した23区", "千代田区丸の内1-1", "東京23区"},
+		{"23区外", "奥多摩町氷川", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components, ok := g.Resolve(tt.address)
+			if !ok {
+				t.Fatalf("Resolve(%q) 判定できない", tt.address)
+			}
+			if got := ResolveAkabouAreaFromComponents(components); got != tt.wantArea {
+				t.Errorf("ResolveAkabouAreaFromComponents(%+v) = %q, want %q", components, got, tt.wantArea)
+			}
+		})
+	}
+
+	// 都道府県・市区町村が分からない場合は住所の文字列で判定する
+	if got := ResolveAkabouAreaFromComponents(&AddressComponents{Address: "東京都新宿区西新宿"}); got != "東京23区" {
+		t.Errorf("住所のみ = %q, want 東京23区", got)
+	}
+	if got := ResolveAkabouAreaFromComponents(nil); got != "" {
+		t.Errorf("nil = %q, want 空文字", got)
+	}
+}
@@ -5,17 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
 )
 
 // AddressComponents 住所の構成要素
 type AddressComponents struct {
 	Prefecture string // 都道府県
 	City       string // 市区町村
+	Ward       string // 政令指定都市の区（郵便番号データで判定した場合のみ）
 	Address    string // 詳細住所
 }
 
@@ -66,8 +70,9 @@ func (c *GoogleGeocodingClient) GetPrefecture(address string) (string, error) {
 	return components.Prefecture, nil
 }
 
-// GetAddressComponents 住所から構成要素を取得
+// GetAddressComponents 住所から構成要素を取得（住所は NormalizeAddress で表記を統一してから問い合わせる）
 func (c *GoogleGeocodingClient) GetAddressComponents(address string) (*AddressComponents, error) {
+	address = NormalizeAddress(address)
 	if address == "" {
 		return nil, errors.New("住所が指定されていません")
 	}
@@ -151,6 +156,7 @@ func NewMockGeocodingClient() *MockGeocodingClient {
 
 // GetPrefecture モック都道府県を返す
 func (c *MockGeocodingClient) GetPrefecture(address string) (string, error) {
+	address = NormalizeAddress(address)
 	if address == "" {
 		return "", errors.New("住所が指定されていません")
 	}
@@ -171,6 +177,7 @@ func (c *MockGeocodingClient) GetPrefecture(address string) (string, error) {
 
 // GetAddressComponents モック住所構成要素を返す
 func (c *MockGeocodingClient) GetAddressComponents(address string) (*AddressComponents, error) {
+	address = NormalizeAddress(address)
 	if address == "" {
 		return nil, errors.New("住所が指定されていません")
 	}
@@ -189,93 +196,136 @@ func (c *MockGeocodingClient) GetAddressComponents(address string) (*AddressComp
 	return components, nil
 }
 
-// SetMockData モックデータを設定
+// SetMockData モックデータを設定（住所は表記を統一したものをキーにする）
 func (c *MockGeocodingClient) SetMockData(address string, prefecture, city string) {
-	c.mockData[address] = &AddressComponents{
+	c.mockData[NormalizeAddress(address)] = &AddressComponents{
 		Prefecture: prefecture,
 		City:       city,
 		Address:    address,
 	}
 }
 
-// ExtractPrefectureFromAddress 住所文字列から都道府県を抽出
-func ExtractPrefectureFromAddress(address string) (string, bool) {
-	if address == "" {
-		return "", false
+// GeocodeCacheStore ジオコーディングキャッシュストアインターフェース
+type GeocodeCacheStore interface {
+	Get(address string) (*model.GeocodeCache, error)
+	Upsert(cache *model.GeocodeCache) error
+}
+
+// CachedGeocodingClient キャッシュ付きGeocodingクライアント（GeocodingClient をラップする）
+// キャッシュにない住所のみAPIを呼び出し、Geocoding APIの使用量として Routes API とは別にカウントする
+type CachedGeocodingClient struct {
+	client GeocodingClient
+	store  GeocodeCacheStore
+	usage  *ApiUsageService // nilの場合は使用量をカウントしない
+}
+
+// NewCachedGeocodingClient 新しいキャッシュ付きGeocodingクライアントを作成
+func NewCachedGeocodingClient(client GeocodingClient, store GeocodeCacheStore, usage *ApiUsageService) *CachedGeocodingClient {
+	return &CachedGeocodingClient{
+		client: client,
+		store:  store,
+		usage:  usage,
 	}
+}
 
-	// 省略形から正式名称へのマッピング（先にチェック）
-	shortNames := map[string]string{
-		"東京":  "東京都",
-		"大阪":  "大阪府",
-		"京都":  "京都府",
-		"北海道": "北海道",
+// GetPrefecture 住所から都道府県を取得（キャッシュ付き）
+func (c *CachedGeocodingClient) GetPrefecture(address string) (string, error) {
+	components, err := c.GetAddressComponents(address)
+	if err != nil {
+		return "", err
 	}
+	return components.Prefecture, nil
+}
 
-	// 省略形をチェック（住所に含まれていれば）
-	for short, full := range shortNames {
-		if strings.Contains(address, short) {
-			return full, true
-		}
+// GetAddressComponents キャッシュを確認し、なければAPIから住所の構成要素を取得
+// Geocoding APIの使用量が上限に達している場合はAPIを呼ばず、住所の文字列から推定する（キャッシュには保存しない）
+func (c *CachedGeocodingClient) GetAddressComponents(address string) (*AddressComponents, error) {
+	address = NormalizeAddress(address)
+	if address == "" {
+		return nil, errors.New("住所が指定されていません")
 	}
 
-	// 都道府県のパターン（正式名称）
-	prefecturePatterns := []string{
-		"北海道",
-		"東京都",
-		"大阪府",
-		"京都府",
+	// キャッシュを確認
+	if cached, err := c.store.Get(address); err == nil && cached != nil {
+		return &AddressComponents{
+			Prefecture: cached.Prefecture,
+			City:       cached.City,
+			Address:    cached.FormattedAddress,
+		}, nil
 	}
 
-	// 都道府県（〜県）のパターン
-	prefectures := []string{
-		"青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
-		"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "神奈川県",
-		"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県",
-		"岐阜県", "静岡県", "愛知県", "三重県",
-		"滋賀県", "兵庫県", "奈良県", "和歌山県",
-		"鳥取県", "島根県", "岡山県", "広島県", "山口県",
-		"徳島県", "香川県", "愛媛県", "高知県",
-		"福岡県", "佐賀県", "長崎県", "熊本県", "大分県", "宮崎県", "鹿児島県",
-		"沖縄県",
+	// 上限到達時は住所の文字列から推定
+	if c.usage != nil {
+		if err := c.usage.CheckLimitFor(model.ApiTypeGeocoding); errors.Is(err, ErrApiLimitExceeded) {
+			components := extractAddressComponents(address)
+			if components.Prefecture == "" {
+				return nil, fmt.Errorf("都道府県を特定できません: %s: %w", address, err)
+			}
+			return components, nil
+		}
 	}
 
-	// 特殊な都道府県を先にチェック（正式名称）
-	for _, pref := range prefecturePatterns {
-		if strings.Contains(address, pref) {
-			return pref, true
+	// APIから取得
+	components, err := c.client.GetAddressComponents(address)
+	if err != nil {
+		return nil, err
+	}
+	if c.usage != nil {
+		// 使用量のカウントエラーはログに残し、取得した住所情報はそのまま返す
+		if err := c.usage.IncrementAndCheckFor(model.ApiTypeGeocoding); err != nil {
+			log.Printf("Geocoding API使用量カウントエラー: %v", err)
 		}
 	}
 
-	// 一般的な県をチェック（正式名称）
-	for _, pref := range prefectures {
+	// キャッシュに保存（保存エラーは無視する）
+	_ = c.store.Upsert(&model.GeocodeCache{
+		Address:          address,
+		Prefecture:       components.Prefecture,
+		City:             components.City,
+		FormattedAddress: components.Address,
+	})
+
+	return components, nil
+}
+
+// ExtractPrefectureFromAddress 住所文字列から都道府県を抽出
+func ExtractPrefectureFromAddress(address string) (string, bool) {
+	if address == "" {
+		return "", false
+	}
+
+	// 正式名称を先にチェック（「東京都」が省略形の「京都」に一致しないように）
+	for _, pref := range prefectureNames {
 		if strings.Contains(address, pref) {
 			return pref, true
 		}
 	}
 
-	// 県名の省略形もチェック（「県」なしでも検索）
-	prefectureShortNames := map[string]string{
-		"青森": "青森県", "岩手": "岩手県", "宮城": "宮城県", "秋田": "秋田県", "山形": "山形県", "福島": "福島県",
-		"茨城": "茨城県", "栃木": "栃木県", "群馬": "群馬県", "埼玉": "埼玉県", "千葉": "千葉県", "神奈川": "神奈川県",
-		"新潟": "新潟県", "富山": "富山県", "石川": "石川県", "福井": "福井県", "山梨": "山梨県", "長野": "長野県",
-		"岐阜": "岐阜県", "静岡": "静岡県", "愛知": "愛知県", "三重": "三重県",
-		"滋賀": "滋賀県", "兵庫": "兵庫県", "奈良": "奈良県", "和歌山": "和歌山県",
-		"鳥取": "鳥取県", "島根": "島根県", "岡山": "岡山県", "広島": "広島県", "山口": "山口県",
-		"徳島": "徳島県", "香川": "香川県", "愛媛": "愛媛県", "高知": "高知県",
-		"福岡": "福岡県", "佐賀": "佐賀県", "長崎": "長崎県", "熊本": "熊本県", "大分": "大分県", "宮崎": "宮崎県", "鹿児島": "鹿児島県",
-		"沖縄": "沖縄県",
-	}
-
-	for short, full := range prefectureShortNames {
-		if strings.Contains(address, short) {
-			return full, true
+	// 省略形（「都」「府」「県」なし）をチェック
+	for _, pref := range prefectureNames[1:] {
+		runes := []rune(pref)
+		if strings.Contains(address, string(runes[:len(runes)-1])) {
+			return pref, true
 		}
 	}
 
 	return "", false
 }
 
+// prefectureNames 都道府県の正式名称（全国地方公共団体コード順。省略形の判定もこの順で行うため、北海道は先頭に置く）
+var prefectureNames = []string{
+	"北海道",
+	"青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
+	"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県",
+	"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県",
+	"岐阜県", "静岡県", "愛知県", "三重県",
+	"滋賀県", "京都府", "大阪府", "兵庫県", "奈良県", "和歌山県",
+	"鳥取県", "島根県", "岡山県", "広島県", "山口県",
+	"徳島県", "香川県", "愛媛県", "高知県",
+	"福岡県", "佐賀県", "長崎県", "熊本県", "大分県", "宮崎県", "鹿児島県",
+	"沖縄県",
+}
+
 // extractAddressComponents 住所文字列から構成要素を抽出
 func extractAddressComponents(address string) *AddressComponents {
 	components := &AddressComponents{
@@ -1,6 +1,11 @@
 package service
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
 
 func TestGeocodingClient_GetPrefecture(t *testing.T) {
 	// モッククライアントを使用
@@ -138,3 +143,77 @@ func TestExtractPrefectureFromAddress(t *testing.T) {
 		})
 	}
 }
+
+// mockGeocodeCacheStore テスト用のジオコーディングキャッシュ
+type mockGeocodeCacheStore struct {
+	caches map[string]*model.GeocodeCache
+}
+
+func (m *mockGeocodeCacheStore) Get(address string) (*model.GeocodeCache, error) {
+	if c, ok := m.caches[address]; ok {
+		return c, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (m *mockGeocodeCacheStore) Upsert(cache *model.GeocodeCache) error {
+	m.caches[cache.Address] = cache
+	return nil
+}
+
+// countingGeocodingClient 呼び出し回数を数えるGeocodingクライアント
+type countingGeocodingClient struct {
+	*MockGeocodingClient
+	calls int
+}
+
+func (c *countingGeocodingClient) GetAddressComponents(address string) (*AddressComponents, error) {
+	c.calls++
+	return c.MockGeocodingClient.GetAddressComponents(address)
+}
+
+// TestCachedGeocodingClient 同じ住所（表記ゆれを含む）はAPIを1回だけ呼び、Geocoding APIの使用量としてカウントする
+func TestCachedGeocodingClient(t *testing.T) {
+	api := &countingGeocodingClient{MockGeocodingClient: NewMockGeocodingClient()}
+	api.SetMockData("東京都千代田区丸の内1-1", "東京都", "千代田区")
+	store := &mockGeocodeCacheStore{caches: make(map[string]*model.GeocodeCache)}
+	usageRepo := newMockApiUsageRepository(0, 9000)
+	usageRepo.geocoding = &model.ApiUsage{YearMonth: usageRepo.usage.YearMonth, ApiType: model.ApiTypeGeocoding, LimitCount: 9000}
+	client := NewCachedGeocodingClient(api, store, NewApiUsageService(usageRepo))
+
+	pref, err := client.GetPrefecture("東京都千代田区丸の内1-1")
+	if err != nil || pref != "東京都" {
+		t.Fatalf("GetPrefecture() = %s, %v", pref, err)
+	}
+	components, err := client.GetAddressComponents("東京都千代田区丸の内１丁目１")
+	if err != nil || components.City != "千代田区" {
+		t.Fatalf("GetAddressComponents() = %+v, %v", components, err)
+	}
+
+	if api.calls != 1 {
+		t.Errorf("API呼び出し回数 = %d, want 1", api.calls)
+	}
+	if usageRepo.geocoding.RequestCount != 1 || usageRepo.usage.RequestCount != 0 {
+		t.Errorf("使用量 geocoding = %d, routes = %d, want 1, 0", usageRepo.geocoding.RequestCount, usageRepo.usage.RequestCount)
+	}
+}
+
+// TestCachedGeocodingClient_LimitExceeded 上限到達後はAPIを呼ばず住所の文字列から推定する
+func TestCachedGeocodingClient_LimitExceeded(t *testing.T) {
+	api := &countingGeocodingClient{MockGeocodingClient: NewMockGeocodingClient()}
+	store := &mockGeocodeCacheStore{caches: make(map[string]*model.GeocodeCache)}
+	usageRepo := newMockApiUsageRepository(0, 9000)
+	usageRepo.geocoding = &model.ApiUsage{YearMonth: usageRepo.usage.YearMonth, ApiType: model.ApiTypeGeocoding, RequestCount: 9000, LimitCount: 9000}
+	client := NewCachedGeocodingClient(api, store, NewApiUsageService(usageRepo))
+
+	pref, err := client.GetPrefecture("大阪府大阪市北区梅田3-1-1")
+	if err != nil || pref != "大阪府" {
+		t.Errorf("GetPrefecture() = %s, %v", pref, err)
+	}
+	if api.calls != 0 || len(store.caches) != 0 {
+		t.Errorf("API呼び出し回数 = %d, キャッシュ = %d件, want 0, 0", api.calls, len(store.caches))
+	}
+	if _, err := client.GetPrefecture("存在しない場所"); !errors.Is(err, ErrApiLimitExceeded) {
+		t.Errorf("GetPrefecture() 推定できない住所 = %v, want ErrApiLimitExceeded", err)
+	}
+}
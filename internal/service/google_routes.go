@@ -13,13 +13,19 @@ import (
 )
 
 // RouteClient ルート情報を取得するクライアントインターフェース
+// opts はクライアントが対応する条件のみ使用する（対応しない条件は無視する）
+// 取得したルートの OptionsKey には実際に使用した条件（AppliedOptions）のキーを設定する
 type RouteClient interface {
-	GetRoute(origin, dest string) (*model.RouteCache, error)
+	GetRoute(origin, dest string, opts RouteOptions) (*model.RouteCache, error)
+	// GetRouteLegs 出発地・経由地・目的地の順に並んだ地点を通るルートの区間ごとの情報を取得
+	GetRouteLegs(points []string, opts RouteOptions) ([]*model.RouteCache, error)
+	// AppliedOptions opts のうちクライアントが実際に使用する条件（キャッシュのキーに使う）
+	AppliedOptions(opts RouteOptions) RouteOptions
 }
 
-// RouteCacheStore キャッシュストアインターフェース
+// RouteCacheStore キャッシュストアインターフェース（optionsKey は RouteClient.AppliedOptions の RouteOptions.Key()）
 type RouteCacheStore interface {
-	Get(origin, dest string) (*model.RouteCache, error)
+	Get(origin, dest, optionsKey string) (*model.RouteCache, error)
 	Upsert(cache *model.RouteCache) error
 }
 
@@ -43,13 +49,21 @@ func NewGoogleRoutesClient(apiKey string) *GoogleRoutesClient {
 
 // routesAPIRequest Routes API リクエスト構造体
 type routesAPIRequest struct {
-	Origin                   routesWaypoint `json:"origin"`
-	Destination              routesWaypoint `json:"destination"`
-	TravelMode               string         `json:"travelMode"`
-	RoutingPreference        string         `json:"routingPreference"`
-	ComputeAlternativeRoutes bool           `json:"computeAlternativeRoutes"`
-	LanguageCode             string         `json:"languageCode"`
-	Units                    string         `json:"units"`
+	Origin                   routesWaypoint   `json:"origin"`
+	Destination              routesWaypoint   `json:"destination"`
+	Intermediates            []routesWaypoint `json:"intermediates,omitempty"`
+	TravelMode               string           `json:"travelMode"`
+	RoutingPreference        string           `json:"routingPreference"`
+	ComputeAlternativeRoutes bool             `json:"computeAlternativeRoutes"`
+	LanguageCode             string           `json:"languageCode"`
+	Units                    string           `json:"units"`
+	RouteModifiers           *routeModifiers  `json:"routeModifiers,omitempty"`
+}
+
+// routeModifiers 有料道路・高速道路の回避（Routes APIは車両の寸法・重量・危険物に対応していない）
+type routeModifiers struct {
+	AvoidTolls    bool `json:"avoidTolls,omitempty"`
+	AvoidHighways bool `json:"avoidHighways,omitempty"`
 }
 
 type routesWaypoint struct {
@@ -59,8 +73,9 @@ type routesWaypoint struct {
 // routesAPIResponse Routes API レスポンス構造体
 type routesAPIResponse struct {
 	Routes []struct {
-		DistanceMeters int    `json:"distanceMeters"`
-		Duration       string `json:"duration"` // "3600s" 形式
+		DistanceMeters int         `json:"distanceMeters"`
+		Duration       string      `json:"duration"` // "3600s" 形式
+		Legs           []routesLeg `json:"legs"`     // 区間（経由地指定時）
 	} `json:"routes"`
 	Error *struct {
 		Code    int    `json:"code"`
@@ -69,13 +84,88 @@ type routesAPIResponse struct {
 	} `json:"error"`
 }
 
+type routesLeg struct {
+	DistanceMeters int    `json:"distanceMeters"`
+	Duration       string `json:"duration"` // "3600s" 形式
+}
+
 // GetRoute Google Maps Routes APIを使用してルート情報を取得
-func (c *GoogleRoutesClient) GetRoute(origin, dest string) (*model.RouteCache, error) {
+func (c *GoogleRoutesClient) GetRoute(origin, dest string, opts RouteOptions) (*model.RouteCache, error) {
 	// バリデーション
 	if err := validateRouteInput(origin, dest); err != nil {
 		return nil, err
 	}
 
+	apiResp, err := c.computeRoutes(origin, dest, nil, opts, "routes.duration,routes.distanceMeters")
+	if err != nil {
+		return nil, err
+	}
+
+	route := apiResp.Routes[0]
+
+	// 距離をkmに変換
+	distanceKm := float64(route.DistanceMeters) / 1000.0
+
+	// 所要時間を分に変換（"3600s" -> 60分）
+	durationMin := parseDurationSeconds(route.Duration)
+
+	return &model.RouteCache{
+		Origin:      origin,
+		Dest:        dest,
+		DistanceKm:  distanceKm,
+		DurationMin: durationMin,
+		OptionsKey:  c.AppliedOptions(opts).Key(),
+		CreatedAt:   time.Now(),
+		Source:      RouteSourceGoogle,
+	}, nil
+}
+
+// GetRouteLegs Google Maps Routes APIを使用して経由地を含むルートの区間ごとの情報を取得
+// 経由地はintermediatesとして1回のリクエストで送信する
+func (c *GoogleRoutesClient) GetRouteLegs(points []string, opts RouteOptions) ([]*model.RouteCache, error) {
+	// バリデーション
+	if err := validateRoutePoints(points); err != nil {
+		return nil, err
+	}
+
+	var intermediates []routesWaypoint
+	for _, p := range points[1 : len(points)-1] {
+		intermediates = append(intermediates, routesWaypoint{Address: p})
+	}
+
+	apiResp, err := c.computeRoutes(points[0], points[len(points)-1], intermediates, opts, "routes.legs.duration,routes.legs.distanceMeters")
+	if err != nil {
+		return nil, err
+	}
+
+	legs := apiResp.Routes[0].Legs
+	if len(legs) != len(points)-1 {
+		return nil, fmt.Errorf("区間数が一致しません（地点%d件に対して区間%d件）", len(points), len(legs))
+	}
+
+	now := time.Now()
+	routes := make([]*model.RouteCache, len(legs))
+	for i, leg := range legs {
+		routes[i] = &model.RouteCache{
+			Origin:      points[i],
+			Dest:        points[i+1],
+			DistanceKm:  float64(leg.DistanceMeters) / 1000.0,
+			DurationMin: parseDurationSeconds(leg.Duration),
+			OptionsKey:  c.AppliedOptions(opts).Key(),
+			CreatedAt:   now,
+			Source:      RouteSourceGoogle,
+		}
+	}
+	return routes, nil
+}
+
+// AppliedOptions Routes API（TRAVEL_MODE DRIVE）は有料道路・高速道路の回避のみ使用する（寸法・重量・危険物は指定できない）
+func (c *GoogleRoutesClient) AppliedOptions(opts RouteOptions) RouteOptions {
+	return opts.avoidanceOnly()
+}
+
+// computeRoutes Routes APIを呼び出してレスポンスを返す（ルートが1件以上あることを保証）
+func (c *GoogleRoutesClient) computeRoutes(origin, dest string, intermediates []routesWaypoint, opts RouteOptions, fieldMask string) (*routesAPIResponse, error) {
 	// APIキーチェック
 	if c.apiKey == "" {
 		return nil, errors.New("Google Maps APIキーが設定されていません")
@@ -85,12 +175,16 @@ func (c *GoogleRoutesClient) GetRoute(origin, dest string) (*model.RouteCache, e
 	reqBody := routesAPIRequest{
 		Origin:                   routesWaypoint{Address: origin},
 		Destination:              routesWaypoint{Address: dest},
+		Intermediates:            intermediates,
 		TravelMode:               "DRIVE",
 		RoutingPreference:        "TRAFFIC_AWARE",
 		ComputeAlternativeRoutes: false,
 		LanguageCode:             "ja",
 		Units:                    "METRIC",
 	}
+	if opts.AvoidTolls || opts.AvoidHighways {
+		reqBody.RouteModifiers = &routeModifiers{AvoidTolls: opts.AvoidTolls, AvoidHighways: opts.AvoidHighways}
+	}
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
@@ -105,7 +199,7 @@ func (c *GoogleRoutesClient) GetRoute(origin, dest string) (*model.RouteCache, e
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Goog-Api-Key", c.apiKey)
-	req.Header.Set("X-Goog-FieldMask", "routes.duration,routes.distanceMeters")
+	req.Header.Set("X-Goog-FieldMask", fieldMask)
 
 	// リクエスト送信
 	resp, err := c.httpClient.Do(req)
@@ -126,8 +220,11 @@ func (c *GoogleRoutesClient) GetRoute(origin, dest string) (*model.RouteCache, e
 		return nil, fmt.Errorf("レスポンスJSONパースエラー: %w", err)
 	}
 
-	// エラーチェック
+	// エラーチェック（割り当て超過はErrApiLimitExceededとして返し、セルフホストのエンジンへの切り替えに使う）
 	if apiResp.Error != nil {
+		if resp.StatusCode == http.StatusTooManyRequests || apiResp.Error.Status == "RESOURCE_EXHAUSTED" {
+			return nil, fmt.Errorf("%w: API エラー [%s]: %s", ErrApiLimitExceeded, apiResp.Error.Status, apiResp.Error.Message)
+		}
 		return nil, fmt.Errorf("API エラー [%s]: %s", apiResp.Error.Status, apiResp.Error.Message)
 	}
 
@@ -136,21 +233,7 @@ func (c *GoogleRoutesClient) GetRoute(origin, dest string) (*model.RouteCache, e
 		return nil, errors.New("ルートが見つかりません")
 	}
 
-	route := apiResp.Routes[0]
-
-	// 距離をkmに変換
-	distanceKm := float64(route.DistanceMeters) / 1000.0
-
-	// 所要時間を分に変換（"3600s" -> 60分）
-	durationMin := parseDurationSeconds(route.Duration)
-
-	return &model.RouteCache{
-		Origin:      origin,
-		Dest:        dest,
-		DistanceKm:  distanceKm,
-		DurationMin: durationMin,
-		CreatedAt:   time.Now(),
-	}, nil
+	return &apiResp, nil
 }
 
 // parseDurationSeconds "3600s" 形式の文字列を分に変換
@@ -174,8 +257,8 @@ func NewMockRoutesClient() *MockRoutesClient {
 	}
 }
 
-// GetRoute モックルート情報を返す
-func (c *MockRoutesClient) GetRoute(origin, dest string) (*model.RouteCache, error) {
+// GetRoute モックルート情報を返す（ルート検索の条件は使用しない）
+func (c *MockRoutesClient) GetRoute(origin, dest string, opts RouteOptions) (*model.RouteCache, error) {
 	// バリデーション
 	if err := validateRouteInput(origin, dest); err != nil {
 		return nil, err
@@ -184,7 +267,9 @@ func (c *MockRoutesClient) GetRoute(origin, dest string) (*model.RouteCache, err
 	// カスタムモックデータがあればそれを返す
 	key := origin + "|" + dest
 	if data, ok := c.mockData[key]; ok {
-		return data, nil
+		route := *data
+		route.OptionsKey = c.AppliedOptions(opts).Key()
+		return &route, nil
 	}
 
 	// デフォルトのモックデータを生成
@@ -197,10 +282,34 @@ func (c *MockRoutesClient) GetRoute(origin, dest string) (*model.RouteCache, err
 		Dest:        dest,
 		DistanceKm:  distanceKm,
 		DurationMin: durationMin,
+		OptionsKey:  c.AppliedOptions(opts).Key(),
 		CreatedAt:   time.Now(),
 	}, nil
 }
 
+// GetRouteLegs 区間ごとのモックルート情報を返す
+func (c *MockRoutesClient) GetRouteLegs(points []string, opts RouteOptions) ([]*model.RouteCache, error) {
+	// バリデーション
+	if err := validateRoutePoints(points); err != nil {
+		return nil, err
+	}
+
+	routes := make([]*model.RouteCache, 0, len(points)-1)
+	for i := 0; i+1 < len(points); i++ {
+		route, err := c.GetRoute(points[i], points[i+1], opts)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// AppliedOptions モックは条件をそのままキャッシュのキーにする（トラック対応のエンジンの代わり）
+func (c *MockRoutesClient) AppliedOptions(opts RouteOptions) RouteOptions {
+	return opts
+}
+
 // SetMockRoute モックデータを設定
 func (c *MockRoutesClient) SetMockRoute(origin, dest string, distanceKm float64, durationMin int) {
 	key := origin + "|" + dest
@@ -275,12 +384,33 @@ func validateRouteInput(origin, dest string) error {
 	return nil
 }
 
+// validateRoutePoints 経由地を含む地点のバリデーション
+func validateRoutePoints(points []string) error {
+	if len(points) < 2 {
+		return errors.New("出発地と目的地を指定してください")
+	}
+	for i := 0; i+1 < len(points); i++ {
+		if err := validateRouteInput(points[i], points[i+1]); err != nil {
+			if len(points) == 2 {
+				return err
+			}
+			return fmt.Errorf("区間%d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
 // RouteResult ルート取得結果（キャッシュ情報付き）
 type RouteResult struct {
 	Route     *model.RouteCache
 	FromCache bool
 }
 
+// CountsApiUsage Google Maps APIの使用量に数えるか（キャッシュ・セルフホストのエンジンから取得した場合は数えない）
+func (r *RouteResult) CountsApiUsage() bool {
+	return !r.FromCache && !IsSelfHostedRouteSource(r.Route.Source)
+}
+
 // CachedRouteService キャッシュ付きルートサービス
 type CachedRouteService struct {
 	client   RouteClient
@@ -297,15 +427,19 @@ func NewCachedRouteService(client RouteClient, store RouteCacheStore, cacheTTL t
 	}
 }
 
-// GetRoute キャッシュを確認し、なければAPIから取得
-func (s *CachedRouteService) GetRoute(origin, dest string) (*RouteResult, error) {
+// GetRoute キャッシュを確認し、なければAPIから取得（キャッシュはルート検索の条件ごと）
+// キャッシュのキーはクライアントが実際に使用する条件のみとし、条件を使わないエンジンの乗用車のルートをトラックのルートとして保存しない
+// 住所は NormalizeAddress で表記を統一してからキャッシュのキー・APIの入力に使う
+func (s *CachedRouteService) GetRoute(origin, dest string, opts RouteOptions) (*RouteResult, error) {
+	origin, dest = NormalizeAddress(origin), NormalizeAddress(dest)
+
 	// バリデーション
 	if err := validateRouteInput(origin, dest); err != nil {
 		return nil, err
 	}
 
-	// キャッシュを確認
-	cached, err := s.store.Get(origin, dest)
+	// キャッシュを確認（キーはクライアントが実際に使用する条件）
+	cached, err := s.store.Get(origin, dest, s.client.AppliedOptions(opts).Key())
 	if err == nil && cached != nil {
 		// キャッシュの有効期限をチェック（TTL=0は無期限）
 		if s.cacheTTL == 0 || time.Since(cached.CreatedAt) < s.cacheTTL {
@@ -314,7 +448,7 @@ func (s *CachedRouteService) GetRoute(origin, dest string) (*RouteResult, error)
 	}
 
 	// APIから取得
-	route, err := s.client.GetRoute(origin, dest)
+	route, err := s.client.GetRoute(origin, dest, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -327,3 +461,50 @@ func (s *CachedRouteService) GetRoute(origin, dest string) (*RouteResult, error)
 
 	return &RouteResult{Route: route, FromCache: false}, nil
 }
+
+// GetRouteLegs 経由地を含むルートを区間ごとに取得する
+// 全区間がキャッシュにあればAPIを呼ばず、1区間でもなければAPIを1回呼び出して全区間をキャッシュに保存する
+func (s *CachedRouteService) GetRouteLegs(points []string, opts RouteOptions) (*RouteLegsResult, error) {
+	normalized := make([]string, len(points))
+	for i, p := range points {
+		normalized[i] = NormalizeAddress(p)
+	}
+	points = normalized
+
+	// バリデーション
+	if err := validateRoutePoints(points); err != nil {
+		return nil, err
+	}
+
+	// キャッシュを確認（キーはクライアントが実際に使用する条件）
+	optionsKey := s.client.AppliedOptions(opts).Key()
+	legs := make([]*RouteResult, 0, len(points)-1)
+	for i := 0; i+1 < len(points); i++ {
+		cached, err := s.store.Get(points[i], points[i+1], optionsKey)
+		if err != nil || cached == nil {
+			break
+		}
+		if s.cacheTTL != 0 && time.Since(cached.CreatedAt) >= s.cacheTTL {
+			break
+		}
+		legs = append(legs, &RouteResult{Route: cached, FromCache: true})
+	}
+	if len(legs) == len(points)-1 {
+		return &RouteLegsResult{Legs: legs, FromCache: true}, nil
+	}
+
+	// APIから取得
+	routes, err := s.client.GetRouteLegs(points, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	legs = legs[:0]
+	for _, route := range routes {
+		// キャッシュ保存エラーは無視してルート情報を返す
+		_ = s.store.Upsert(route)
+		legs = append(legs, &RouteResult{Route: route, FromCache: false})
+	}
+
+	return &RouteLegsResult{Legs: legs, FromCache: false}, nil
+}
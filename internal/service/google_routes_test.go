@@ -1,7 +1,10 @@
 package service
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -21,9 +24,9 @@ func newMockRouteCacheRepository() *mockRouteCacheRepository {
 	}
 }
 
-func (m *mockRouteCacheRepository) Get(origin, dest string) (*model.RouteCache, error) {
+func (m *mockRouteCacheRepository) Get(origin, dest, optionsKey string) (*model.RouteCache, error) {
 	m.getCalled = true
-	key := origin + "|" + dest
+	key := origin + "|" + dest + "|" + optionsKey
 	if c, ok := m.cache[key]; ok {
 		return c, nil
 	}
@@ -34,13 +37,13 @@ func (m *mockRouteCacheRepository) Upsert(cache *model.RouteCache) error {
 	if m.upsertErr != nil {
 		return m.upsertErr
 	}
-	key := cache.Origin + "|" + cache.Dest
+	key := cache.Origin + "|" + cache.Dest + "|" + cache.OptionsKey
 	m.cache[key] = cache
 	return nil
 }
 
 func (m *mockRouteCacheRepository) setCache(origin, dest string, distanceKm float64, durationMin int, createdAt time.Time) {
-	key := origin + "|" + dest
+	key := origin + "|" + dest + "|"
 	m.cache[key] = &model.RouteCache{
 		Origin:      origin,
 		Dest:        dest,
@@ -54,7 +57,7 @@ func (m *mockRouteCacheRepository) setCache(origin, dest string, distanceKm floa
 func TestMockRoutesClient_GetRoute(t *testing.T) {
 	client := NewMockRoutesClient()
 
-	route, err := client.GetRoute("東京都千代田区", "大阪府大阪市")
+	route, err := client.GetRoute("東京都千代田区", "大阪府大阪市", RouteOptions{})
 	if err != nil {
 		t.Fatalf("エラーが発生しました: %v", err)
 	}
@@ -77,12 +80,12 @@ func TestMockRoutesClient_GetRoute(t *testing.T) {
 func TestMockRoutesClient_GetRoute_EmptyAddress(t *testing.T) {
 	client := NewMockRoutesClient()
 
-	_, err := client.GetRoute("", "大阪府大阪市")
+	_, err := client.GetRoute("", "大阪府大阪市", RouteOptions{})
 	if err == nil {
 		t.Error("空の出発地でエラーが発生しませんでした")
 	}
 
-	_, err = client.GetRoute("東京都千代田区", "")
+	_, err = client.GetRoute("東京都千代田区", "", RouteOptions{})
 	if err == nil {
 		t.Error("空の目的地でエラーが発生しませんでした")
 	}
@@ -98,7 +101,7 @@ func TestCachedRouteService_GetRoute_CacheHit(t *testing.T) {
 
 	service := NewCachedRouteService(mockClient, mockRepo, 30*24*time.Hour)
 
-	result, err := service.GetRoute("東京都千代田区", "大阪府大阪市")
+	result, err := service.GetRoute("東京都千代田区", "大阪府大阪市", RouteOptions{})
 	if err != nil {
 		t.Fatalf("エラーが発生しました: %v", err)
 	}
@@ -122,7 +125,7 @@ func TestCachedRouteService_GetRoute_CacheMiss(t *testing.T) {
 
 	service := NewCachedRouteService(mockClient, mockRepo, 30*24*time.Hour)
 
-	result, err := service.GetRoute("東京都千代田区", "大阪府大阪市")
+	result, err := service.GetRoute("東京都千代田区", "大阪府大阪市", RouteOptions{})
 	if err != nil {
 		t.Fatalf("エラーが発生しました: %v", err)
 	}
@@ -136,7 +139,7 @@ func TestCachedRouteService_GetRoute_CacheMiss(t *testing.T) {
 	}
 
 	// キャッシュに保存されていることを確認
-	cached, err := mockRepo.Get("東京都千代田区", "大阪府大阪市")
+	cached, err := mockRepo.Get("東京都千代田区", "大阪府大阪市", "")
 	if err != nil {
 		t.Errorf("キャッシュに保存されていません: %v", err)
 	}
@@ -156,7 +159,7 @@ func TestCachedRouteService_GetRoute_CacheExpired(t *testing.T) {
 
 	service := NewCachedRouteService(mockClient, mockRepo, 30*24*time.Hour)
 
-	result, err := service.GetRoute("東京都千代田区", "大阪府大阪市")
+	result, err := service.GetRoute("東京都千代田区", "大阪府大阪市", RouteOptions{})
 	if err != nil {
 		t.Fatalf("エラーが発生しました: %v", err)
 	}
@@ -164,7 +167,7 @@ func TestCachedRouteService_GetRoute_CacheExpired(t *testing.T) {
 	// 期限切れのため、APIから新しい値が取得されることを確認
 	// モッククライアントは固定値を返すので、キャッシュの500.0とは異なるはず
 	// （モッククライアントの実装次第だが、少なくともキャッシュが更新されるべき）
-	cached, _ := mockRepo.Get("東京都千代田区", "大阪府大阪市")
+	cached, _ := mockRepo.Get("東京都千代田区", "大阪府大阪市", "")
 	if cached.CreatedAt.Before(expiredTime) {
 		t.Errorf("キャッシュが更新されていません")
 	}
@@ -181,12 +184,210 @@ func TestCachedRouteService_GetRoute_CacheExpired(t *testing.T) {
 func TestGoogleRoutesClient_GetRoute_NoAPIKey(t *testing.T) {
 	client := NewGoogleRoutesClient("")
 
-	_, err := client.GetRoute("東京都千代田区", "大阪府大阪市")
+	_, err := client.GetRoute("東京都千代田区", "大阪府大阪市", RouteOptions{})
 	if err == nil {
 		t.Error("APIキーなしでエラーが発生しませんでした")
 	}
 }
 
+// countingRoutesClient API呼び出し回数を数えるルートクライアント
+type countingRoutesClient struct {
+	*MockRoutesClient
+	legsCalls int
+}
+
+func (c *countingRoutesClient) GetRouteLegs(points []string, opts RouteOptions) ([]*model.RouteCache, error) {
+	c.legsCalls++
+	return c.MockRoutesClient.GetRouteLegs(points, opts)
+}
+
+// TestMockRoutesClient_GetRouteLegs 経由地を含むルートの区間ごとの取得テスト
+func TestMockRoutesClient_GetRouteLegs(t *testing.T) {
+	client := NewMockRoutesClient()
+	client.SetMockRoute("東京都千代田区", "神奈川県横浜市", 30.5, 50)
+	client.SetMockRoute("神奈川県横浜市", "静岡県静岡市", 150.2, 120)
+
+	legs, err := client.GetRouteLegs([]string{"東京都千代田区", "神奈川県横浜市", "静岡県静岡市"}, RouteOptions{})
+	if err != nil {
+		t.Fatalf("エラーが発生しました: %v", err)
+	}
+	if len(legs) != 2 {
+		t.Fatalf("区間数 = %d, want 2", len(legs))
+	}
+	if legs[0].Dest != "神奈川県横浜市" || legs[0].DistanceKm != 30.5 {
+		t.Errorf("区間1 = %+v", legs[0])
+	}
+	if legs[1].Origin != "神奈川県横浜市" || legs[1].DurationMin != 120 {
+		t.Errorf("区間2 = %+v", legs[1])
+	}
+}
+
+// TestMockRoutesClient_GetRouteLegs_Validation 経由地のバリデーションテスト
+func TestMockRoutesClient_GetRouteLegs_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []string
+	}{
+		{"地点が1つ", []string{"東京都千代田区"}},
+		{"経由地が空", []string{"東京都千代田区", "", "大阪府大阪市"}},
+		{"連続する同一地点", []string{"東京都千代田区", "神奈川県横浜市", "神奈川県横浜市", "大阪府大阪市"}},
+	}
+
+	client := NewMockRoutesClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.GetRouteLegs(tt.points, RouteOptions{}); err == nil {
+				t.Error("エラーが期待されましたが発生しませんでした")
+			}
+		})
+	}
+}
+
+// TestCachedRouteService_GetRouteLegs 区間ごとのキャッシュのテスト
+func TestCachedRouteService_GetRouteLegs(t *testing.T) {
+	mockRepo := newMockRouteCacheRepository()
+	mockClient := &countingRoutesClient{MockRoutesClient: NewMockRoutesClient()}
+	points := []string{"東京都千代田区", "神奈川県横浜市", "静岡県静岡市"}
+
+	// 1区間のみキャッシュ済みの場合はAPIを1回呼び出して全区間を保存
+	mockRepo.setCache("東京都千代田区", "神奈川県横浜市", 30.0, 45, time.Now())
+	service := NewCachedRouteService(mockClient, mockRepo, 0)
+
+	result, err := service.GetRouteLegs(points, RouteOptions{})
+	if err != nil {
+		t.Fatalf("エラーが発生しました: %v", err)
+	}
+	if result.FromCache || mockClient.legsCalls != 1 {
+		t.Errorf("FromCache = %v, API呼び出し = %d, want false/1", result.FromCache, mockClient.legsCalls)
+	}
+	if len(result.Legs) != 2 {
+		t.Fatalf("区間数 = %d, want 2", len(result.Legs))
+	}
+	if _, err := mockRepo.Get("神奈川県横浜市", "静岡県静岡市", ""); err != nil {
+		t.Errorf("区間2がキャッシュに保存されていません: %v", err)
+	}
+
+	// 全区間がキャッシュにあればAPIを呼ばない
+	result, err = service.GetRouteLegs(points, RouteOptions{})
+	if err != nil {
+		t.Fatalf("エラーが発生しました: %v", err)
+	}
+	if !result.FromCache || mockClient.legsCalls != 1 {
+		t.Errorf("FromCache = %v, API呼び出し = %d, want true/1", result.FromCache, mockClient.legsCalls)
+	}
+	legs := result.RouteLegs()
+	if legs[0].Origin != "東京都千代田区" || legs[1].Dest != "静岡県静岡市" || !legs[1].FromCache {
+		t.Errorf("RouteLegs = %+v", legs)
+	}
+}
+
+// TestCachedRouteService_GetRoute_OptionsKey ルート検索の条件ごとにキャッシュし、乗用車のルートとトラックのルートを上書きしないこと
+func TestCachedRouteService_GetRoute_OptionsKey(t *testing.T) {
+	mockRepo := newMockRouteCacheRepository()
+	mockClient := NewMockRoutesClient()
+
+	// 条件の指定なし（乗用車）のキャッシュのみ
+	mockRepo.setCache("東京都千代田区", "大阪府大阪市", 500.0, 360, time.Now())
+	service := NewCachedRouteService(mockClient, mockRepo, 0)
+
+	truck := RouteOptionsForVehicle(3)
+	result, err := service.GetRoute("東京都千代田区", "大阪府大阪市", truck)
+	if err != nil {
+		t.Fatalf("エラーが発生しました: %v", err)
+	}
+	if result.FromCache || result.Route.OptionsKey != truck.Key() {
+		t.Errorf("FromCache = %v, OptionsKey = %s, want false, %s", result.FromCache, result.Route.OptionsKey, truck.Key())
+	}
+
+	car, err := mockRepo.Get("東京都千代田区", "大阪府大阪市", "")
+	if err != nil || car.DistanceKm != 500.0 {
+		t.Errorf("乗用車のキャッシュが上書きされました: %+v, %v", car, err)
+	}
+	result, err = service.GetRoute("東京都千代田区", "大阪府大阪市", truck)
+	if err != nil || !result.FromCache {
+		t.Errorf("トラックのルートがキャッシュされていません: %+v, %v", result, err)
+	}
+}
+
+// TestCachedRouteService_GetRoute_NormalizedAddress 住所の表記ゆれを同じキャッシュとして扱うこと
+func TestCachedRouteService_GetRoute_NormalizedAddress(t *testing.T) {
+	mockRepo := newMockRouteCacheRepository()
+	mockRepo.setCache("東京都千代田区丸の内1-1", "大阪府大阪市", 500.0, 360, time.Now())
+	service := NewCachedRouteService(NewMockRoutesClient(), mockRepo, 0)
+
+	for _, origin := range []string{"東京都千代田区丸の内１－１", "東京都千代田区丸の内1丁目1", " 東京都 千代田区丸の内1番地1 "} {
+		result, err := service.GetRoute(origin, "大阪府大阪市", RouteOptions{})
+		if err != nil {
+			t.Fatalf("%s: エラーが発生しました: %v", origin, err)
+		}
+		if !result.FromCache || result.Route.DistanceKm != 500.0 {
+			t.Errorf("%s: FromCache = %v, DistanceKm = %v, want true, 500", origin, result.FromCache, result.Route.DistanceKm)
+		}
+	}
+}
+
+// TestGoogleRoutesClient_RouteModifiers 有料道路・高速道路の回避をrouteModifiersとして送信すること
+func TestGoogleRoutesClient_RouteModifiers(t *testing.T) {
+	var got routesAPIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = routesAPIRequest{}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"routes":[{"distanceMeters":30500,"duration":"3000s"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewGoogleRoutesClient("test-key")
+	client.baseURL = server.URL
+
+	opts := RouteOptionsForVehicle(3)
+	if _, err := client.GetRoute("東京都千代田区", "神奈川県横浜市", opts); err != nil {
+		t.Fatalf("エラーが発生しました: %v", err)
+	}
+	if got.RouteModifiers != nil {
+		t.Errorf("回避なしでrouteModifiersを送信しました: %+v", got.RouteModifiers)
+	}
+
+	opts.AvoidTolls = true
+	if _, err := client.GetRoute("東京都千代田区", "神奈川県横浜市", opts); err != nil {
+		t.Fatalf("エラーが発生しました: %v", err)
+	}
+	if got.RouteModifiers == nil || !got.RouteModifiers.AvoidTolls || got.RouteModifiers.AvoidHighways {
+		t.Errorf("routeModifiers = %+v", got.RouteModifiers)
+	}
+}
+
+// TestGoogleRoutesClient_GetRouteLegs 経由地をintermediatesとして送信し、区間ごとの結果を返すテスト
+func TestGoogleRoutesClient_GetRouteLegs(t *testing.T) {
+	var got routesAPIRequest
+	var fieldMask string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fieldMask = r.Header.Get("X-Goog-FieldMask")
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"routes":[{"legs":[{"distanceMeters":30500,"duration":"3000s"},{"distanceMeters":150200,"duration":"7200s"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewGoogleRoutesClient("test-key")
+	client.baseURL = server.URL
+
+	legs, err := client.GetRouteLegs([]string{"東京都千代田区", "神奈川県横浜市", "静岡県静岡市"}, RouteOptions{})
+	if err != nil {
+		t.Fatalf("エラーが発生しました: %v", err)
+	}
+	if len(got.Intermediates) != 1 || got.Intermediates[0].Address != "神奈川県横浜市" {
+		t.Errorf("intermediates = %+v", got.Intermediates)
+	}
+	if fieldMask != "routes.legs.duration,routes.legs.distanceMeters" {
+		t.Errorf("FieldMask = %s", fieldMask)
+	}
+	if len(legs) != 2 || legs[0].DistanceKm != 30.5 || legs[0].DurationMin != 50 || legs[1].DurationMin != 120 {
+		t.Errorf("legs = %+v, %+v", legs[0], legs[1])
+	}
+	if legs[1].Origin != "神奈川県横浜市" || legs[1].Dest != "静岡県静岡市" {
+		t.Errorf("区間2 = %s → %s", legs[1].Origin, legs[1].Dest)
+	}
+}
+
 // TestRouteResult_Validation ルート結果の検証テスト
 func TestRouteResult_Validation(t *testing.T) {
 	tests := []struct {
@@ -206,7 +407,7 @@ func TestRouteResult_Validation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := client.GetRoute(tt.origin, tt.dest)
+			_, err := client.GetRoute(tt.origin, tt.dest, RouteOptions{})
 			if tt.expectError && err == nil {
 				t.Errorf("エラーが期待されましたが発生しませんでした")
 			}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// 高速道路比較のルート名
+const (
+	HighwayRouteGeneral = "一般道"
+	HighwayRouteHighway = "高速道路"
+)
+
+// HighwaySection 高速道路区間（乗IC〜降IC）
+type HighwaySection struct {
+	DistanceKm  float64 // 区間距離（km）
+	DurationMin int     // 区間の所要時間（分）
+	TollYen     int     // 高速代（円、税込、ETC料金）
+}
+
+// HighwayRanking 一般道・高速道路ごとの運賃タイプの合計（高速代を含む）
+type HighwayRanking struct {
+	Rank         int    // 順位（1が最安）
+	Route        string // ルート（一般道 / 高速道路）
+	Type         string // 運賃タイプ
+	Fare         int    // 運賃額（円、税込）
+	Toll         int    // 高速代（円、税込、一般道は0）
+	Total        int    // 合計（円、税込）
+	TotalExclTax int    // 合計（円、税抜）
+}
+
+// Label 表示用ラベル（例: 高速道路・時間制）
+func (r HighwayRanking) Label() string {
+	return r.Route + "・" + r.Type
+}
+
+// HighwayComparison 一般道と高速道路利用の運賃比較
+// 高速道路は区間の一般道の走行時間（ルートの平均速度で換算）を高速道路の所要時間に置き換えて走行時間を短縮し、
+// 運賃を計算し直して高速代を加える。距離は一般道のルートと同じとする。
+// 往復は行き・帰りとも高速道路区間を通るため短縮時間・高速代を2回分とする（空車回送の復路は一般道とする）
+type HighwayComparison struct {
+	Section        HighwaySection
+	Passes         int       // 高速道路区間を通る回数（往復は2、片道・空車回送は1）
+	Toll           TaxAmount // 高速代（税込・税抜、Passes回分）
+	GeneralMinutes int       // 区間を一般道で走る場合の推定走行時間（分、1回分）
+	SavedMinutes   int       // 高速道路利用で短縮する走行時間（分、Passes回分）
+
+	General *FareComparisonResult // 一般道の運賃
+	Highway *FareComparisonResult // 高速道路の運賃（走行時間を短縮）
+
+	Rankings []HighwayRanking // 一般道・高速道路 × 運賃タイプの合計（税込）の金額順
+}
+
+// Cheapest 合計が最安のルート・運賃タイプ
+func (c *HighwayComparison) Cheapest() HighwayRanking {
+	return c.Rankings[0]
+}
+
+// TimeFareSaving 高速道路利用で下がる時間制運賃（円、税込、時間制がない車格は0）
+func (c *HighwayComparison) TimeFareSaving() int {
+	general, ok := c.General.fareByType(c.timeFareType())
+	if !ok {
+		return 0
+	}
+	highway, _ := c.Highway.fareByType(c.timeFareType())
+	return general - highway
+}
+
+// TollPaysOff 時間制運賃の下がり幅で高速代をまかなえるか
+func (c *HighwayComparison) TollPaysOff() bool {
+	return c.TimeFareSaving() >= c.Toll.Inclusive
+}
+
+// Summary 要約（例: 高速道路で走行時間が45分短縮し、時間制の運賃が9196円下がります（高速代5500円を差し引いて3696円安くなります））
+func (c *HighwayComparison) Summary() string {
+	if c.SavedMinutes <= 0 {
+		return fmt.Sprintf("高速道路を使っても走行時間は短縮しないため、高速代%d円の分だけ高くなります", c.Toll.Inclusive)
+	}
+	saving := c.TimeFareSaving()
+	net := saving - c.Toll.Inclusive
+	result := fmt.Sprintf("高速道路で走行時間が%s短縮し、%sの運賃が%d円下がります", formatHoursMinutes(c.SavedMinutes), c.timeFareType(), saving)
+	if net >= 0 {
+		return result + fmt.Sprintf("（高速代%d円を差し引いて%d円安くなります）", c.Toll.Inclusive, net)
+	}
+	return result + fmt.Sprintf("（高速代%d円の方が%d円高くなります）", c.Toll.Inclusive, -net)
+}
+
+// timeFareType 比較に使う時間制運賃の運賃タイプ（軽貨物は赤帽の時間制）
+func (c *HighwayComparison) timeFareType() string {
+	if c.General.VehicleCode == VehicleCodeLight {
+		return "赤帽（時間制）"
+	}
+	return "時間制"
+}
+
+// fareByType 運賃タイプの運賃額（円、税込）
+func (r *FareComparisonResult) fareByType(fareType string) (int, bool) {
+	for _, ranking := range r.Rankings {
+		if ranking.Type == fareType {
+			return ranking.Fare, true
+		}
+	}
+	return 0, false
+}
+
+// highwayPasses 運行形態ごとの高速道路区間を通る回数（往復は2回、片道・空車回送は1回）
+func highwayPasses(trip *Trip) int {
+	if trip != nil && trip.Mode == TripModeRoundTrip {
+		return 2
+	}
+	return 1
+}
+
+// CompareHighway 一般道の計算結果と高速道路区間から、高速道路利用の運賃を計算して高速代を含む合計で比較する
+// general は req で計算した CalculateAll の結果。区間の一般道の走行時間は一般道ルート（片道）の平均速度で換算する
+func (s *FareCalculatorService) CompareHighway(req *FareCalculationRequest, general *FareComparisonResult, section HighwaySection) (*HighwayComparison, error) {
+	if section.TollYen < 0 || section.DurationMin < 0 || section.DistanceKm < 0 {
+		return nil, fmt.Errorf("無効な高速道路区間: 距離%.1fkm・所要%d分・高速代%d円", section.DistanceKm, section.DurationMin, section.TollYen)
+	}
+
+	// 一般道ルート（片道）の距離・走行時間
+	distanceKm, drivingMinutes := general.DistanceKmRaw, general.DrivingMinutes
+	if general.Trip != nil {
+		distanceKm, drivingMinutes = general.Trip.OneWayDistanceKm, general.Trip.OneWayDrivingMinutes
+	}
+	if distanceKm <= 0 {
+		distanceKm = float64(req.DistanceKm)
+	}
+
+	c := &HighwayComparison{Section: section, Passes: highwayPasses(general.Trip), General: general}
+	if distanceKm > 0 {
+		c.GeneralMinutes = min(drivingMinutes, int(math.Round(section.DistanceKm*float64(drivingMinutes)/distanceKm)))
+	}
+	c.SavedMinutes = max(0, c.GeneralMinutes-section.DurationMin) * c.Passes
+
+	tax := general.TaxCalculator
+	if tax == nil {
+		tax = DefaultTaxCalculator()
+	}
+	c.Toll = tax.FromInclusive(section.TollYen * c.Passes)
+
+	highwayReq := *req
+	highwayReq.highwaySavedMinutes = c.SavedMinutes
+	highway, err := s.CalculateAll(&highwayReq)
+	if err != nil {
+		return nil, fmt.Errorf("高速道路利用の運賃計算エラー: %w", err)
+	}
+	c.Highway = highway
+
+	for _, r := range general.Rankings {
+		c.Rankings = append(c.Rankings, HighwayRanking{
+			Route: HighwayRouteGeneral, Type: r.Type, Fare: r.Fare,
+			Total: r.Fare, TotalExclTax: r.FareExclTax,
+		})
+	}
+	for _, r := range highway.Rankings {
+		c.Rankings = append(c.Rankings, HighwayRanking{
+			Route: HighwayRouteHighway, Type: r.Type, Fare: r.Fare, Toll: c.Toll.Inclusive,
+			Total: r.Fare + c.Toll.Inclusive, TotalExclTax: r.FareExclTax + c.Toll.Exclusive,
+		})
+	}
+	sort.SliceStable(c.Rankings, func(i, j int) bool {
+		return c.Rankings[i].Total < c.Rankings[j].Total
+	})
+	for i := range c.Rankings {
+		c.Rankings[i].Rank = i + 1
+	}
+	return c, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFareCalculatorService_CompareHighway(t *testing.T) {
+	// 距離制は運賃計算距離×300円、時間制は関東・大型車（8時間制60,090円・基礎走行キロ130km、630円/10km、4,180円/時間）
+	calculator := NewFareCalculatorService(
+		NewDistanceFareService(&perKmFareGetter{ratePerKm: 300}),
+		NewTimeFareService(&MockTimeFareGetter{}),
+		NewAkabouFareService(&mockAkabouFareGetter{}),
+	)
+	// 300km・走行8時間・荷役1時間
+	// 一般道: 時間制 60090+17×630+1×4180=74980円（税込82478円）、距離制 90000円（税込99000円）
+	newReq := func() *FareCalculationRequest {
+		return &FareCalculationRequest{
+			RegionCode: 3, VehicleCode: 3, DistanceKm: 300, DistanceKmRaw: 300,
+			DrivingMinutes: 480, LoadingMinutes: 60,
+		}
+	}
+	// 高速道路200km・2時間 → 一般道では200km÷(300km/8時間)=5時間20分のため3時間20分短縮
+	// 高速道路: 走行4時間40分・作業5時間40分で時間超過なし → 時間制 60090+17×630=70800円（税込77880円）
+	section := HighwaySection{DistanceKm: 200, DurationMin: 120}
+
+	tests := []struct {
+		name         string
+		toll         int
+		wantCheapest string
+		wantPaysOff  bool
+		wantSummary  string
+	}{
+		{
+			name:         "高速代が時間制の下がり幅より高い",
+			toll:         11000,
+			wantCheapest: "一般道・時間制",
+			wantSummary:  "高速道路で走行時間が3時間20分短縮し、時間制の運賃が4598円下がります（高速代11000円の方が6402円高くなります）",
+		},
+		{
+			name:         "高速代の元が取れる",
+			toll:         3000,
+			wantCheapest: "高速道路・時間制",
+			wantPaysOff:  true,
+			wantSummary:  "高速道路で走行時間が3時間20分短縮し、時間制の運賃が4598円下がります（高速代3000円を差し引いて1598円安くなります）",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newReq()
+			general, err := calculator.CalculateAll(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			s := section
+			s.TollYen = tt.toll
+			c, err := calculator.CompareHighway(req, general, s)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.GeneralMinutes != 320 || c.SavedMinutes != 200 {
+				t.Errorf("GeneralMinutes = %d, SavedMinutes = %d, want 320, 200", c.GeneralMinutes, c.SavedMinutes)
+			}
+			if c.Highway.TimeFareResult.DrivingMinutes != 280 || c.Highway.TimeFareResult.TotalFare != 70800 {
+				t.Errorf("高速道路の時間制 = 走行%d分・%d円, want 280分・70800円",
+					c.Highway.TimeFareResult.DrivingMinutes, c.Highway.TimeFareResult.TotalFare)
+			}
+			if c.Highway.DistanceFareResult.TotalFare != general.DistanceFareResult.TotalFare {
+				t.Errorf("高速道路の距離制 = %d円, want 一般道と同じ%d円", c.Highway.DistanceFareResult.TotalFare, general.DistanceFareResult.TotalFare)
+			}
+			if len(c.Rankings) != 4 {
+				t.Fatalf("Rankings = %+v, want 4件", c.Rankings)
+			}
+			if got := c.Cheapest().Label(); got != tt.wantCheapest {
+				t.Errorf("Cheapest() = %s, want %s", got, tt.wantCheapest)
+			}
+			for i := 1; i < len(c.Rankings); i++ {
+				if c.Rankings[i].Total < c.Rankings[i-1].Total || c.Rankings[i].Rank != i+1 {
+					t.Errorf("Rankings が金額順ではありません: %+v", c.Rankings)
+				}
+			}
+			if got := c.TimeFareSaving(); got != 4598 {
+				t.Errorf("TimeFareSaving() = %d, want 4598", got)
+			}
+			if c.TollPaysOff() != tt.wantPaysOff {
+				t.Errorf("TollPaysOff() = %v, want %v", c.TollPaysOff(), tt.wantPaysOff)
+			}
+			if got := c.Summary(); got != tt.wantSummary {
+				t.Errorf("Summary() = %s, want %s", got, tt.wantSummary)
+			}
+		})
+	}
+
+	t.Run("往復は行き・帰りの2回分を短縮して高速代も2回分", func(t *testing.T) {
+		req := newReq()
+		req.TripMode = TripModeRoundTrip
+		general, err := calculator.CalculateAll(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		c, err := calculator.CompareHighway(req, general, HighwaySection{DistanceKm: 200, DurationMin: 120, TollYen: 5500})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.Passes != 2 || c.GeneralMinutes != 320 || c.SavedMinutes != 400 {
+			t.Errorf("Passes = %d, GeneralMinutes = %d, SavedMinutes = %d, want 2, 320, 400", c.Passes, c.GeneralMinutes, c.SavedMinutes)
+		}
+		if c.Highway.DrivingMinutes != 960-400 {
+			t.Errorf("Highway.DrivingMinutes = %d, want %d", c.Highway.DrivingMinutes, 960-400)
+		}
+		if c.Toll.Inclusive != 11000 {
+			t.Errorf("Toll.Inclusive = %d, want 11000", c.Toll.Inclusive)
+		}
+		for _, r := range c.Rankings {
+			if r.Route == HighwayRouteHighway && r.Total != r.Fare+11000 {
+				t.Errorf("高速道路の合計 = %+v, want 運賃＋高速代11000円", r)
+			}
+		}
+	})
+
+	t.Run("空車回送の復路は一般道", func(t *testing.T) {
+		req := newReq()
+		req.TripMode = TripModeEmptyReturn
+		req.EmptyReturnRatePercent = 50
+		general, err := calculator.CalculateAll(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		c, err := calculator.CompareHighway(req, general, HighwaySection{DistanceKm: 200, DurationMin: 120, TollYen: 5500})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.Passes != 1 || c.SavedMinutes != 200 || c.Toll.Inclusive != 5500 {
+			t.Errorf("Passes = %d, SavedMinutes = %d, Toll = %d, want 1, 200, 5500", c.Passes, c.SavedMinutes, c.Toll.Inclusive)
+		}
+	})
+
+	t.Run("短縮しない場合は高速代の分だけ高い", func(t *testing.T) {
+		req := newReq()
+		general, err := calculator.CalculateAll(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		c, err := calculator.CompareHighway(req, general, HighwaySection{DistanceKm: 30, DurationMin: 60, TollYen: 1000})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.SavedMinutes != 0 || c.TollPaysOff() {
+			t.Errorf("SavedMinutes = %d, TollPaysOff() = %v, want 0, false", c.SavedMinutes, c.TollPaysOff())
+		}
+		if !strings.Contains(c.Summary(), "短縮しない") {
+			t.Errorf("Summary() = %s", c.Summary())
+		}
+	})
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// bundledNationalHolidaysCSV 同梱の国民の祝日データ（cmd/tools/update_holidays で更新）
+//
+//go:embed data/national_holidays.csv
+var bundledNationalHolidaysCSV []byte
+
+// HolidayDateFormat 休日の日付フォーマット
+const HolidayDateFormat = "2006-01-02"
+
+// HolidayType 休日の種別
+type HolidayType string
+
+const (
+	HolidayTypeNational   HolidayType = "national"   // 国民の祝日
+	HolidayTypeSubstitute HolidayType = "substitute" // 振替休日
+	HolidayTypeCitizens   HolidayType = "citizens"   // 国民の休日（祝日に挟まれた日）
+	HolidayTypeCompany    HolidayType = "company"    // 会社休日（年末年始など）
+	HolidayTypeSunday     HolidayType = "sunday"     // 日曜日
+)
+
+// Label 表示用ラベル
+func (t HolidayType) Label() string {
+	switch t {
+	case HolidayTypeNational:
+		return "国民の祝日"
+	case HolidayTypeSubstitute:
+		return "振替休日"
+	case HolidayTypeCitizens:
+		return "国民の休日"
+	case HolidayTypeCompany:
+		return "会社休日"
+	case HolidayTypeSunday:
+		return "日曜日"
+	default:
+		return string(t)
+	}
+}
+
+// WeekdayLabel 曜日の表示用ラベル（「日」〜「土」）
+func WeekdayLabel(w time.Weekday) string {
+	return []string{"日", "月", "火", "水", "木", "金", "土"}[w]
+}
+
+// Holiday 休日
+type Holiday struct {
+	Date time.Time   // 日付
+	Name string      // 休日名
+	Type HolidayType // 種別
+}
+
+// CompanyHolidayGetter 会社休日取得インターフェース（テスト用にモック可能）
+type CompanyHolidayGetter interface {
+	GetCompanyHolidays(year int) ([]*model.CompanyHoliday, error)
+}
+
+// HolidayCalendarService 休日カレンダーサービス
+// 国民の祝日データから振替休日・国民の休日を導出し、会社休日と合わせて判定する
+type HolidayCalendarService struct {
+	national          map[string]Holiday // 日付 → 祝日（振替休日・国民の休日を含む）
+	firstYear         int                // 祝日データの最初の年
+	lastYear          int                // 祝日データの最後の年
+	companyHolidayGet CompanyHolidayGetter
+}
+
+// NewHolidayCalendarService 国民の祝日データから新しいHolidayCalendarServiceを作成
+func NewHolidayCalendarService(nationalHolidays []Holiday) *HolidayCalendarService {
+	s := &HolidayCalendarService{
+		national: make(map[string]Holiday),
+	}
+	for _, h := range nationalHolidays {
+		s.national[h.Date.Format(HolidayDateFormat)] = h
+		if s.firstYear == 0 || h.Date.Year() < s.firstYear {
+			s.firstYear = h.Date.Year()
+		}
+		if h.Date.Year() > s.lastYear {
+			s.lastYear = h.Date.Year()
+		}
+	}
+	s.deriveHolidays(nationalHolidays)
+	return s
+}
+
+// NewBundledHolidayCalendarService 同梱の祝日データから新しいHolidayCalendarServiceを作成
+func NewBundledHolidayCalendarService() (*HolidayCalendarService, error) {
+	holidays, err := LoadNationalHolidays(bytes.NewReader(bundledNationalHolidaysCSV))
+	if err != nil {
+		return nil, fmt.Errorf("同梱の祝日データ読み込みエラー: %w", err)
+	}
+	return NewHolidayCalendarService(holidays), nil
+}
+
+// SetCompanyHolidayGetter 会社休日の取得元を設定する
+func (s *HolidayCalendarService) SetCompanyHolidayGetter(getter CompanyHolidayGetter) {
+	s.companyHolidayGet = getter
+}
+
+// deriveHolidays 振替休日・国民の休日を導出する
+func (s *HolidayCalendarService) deriveHolidays(nationalHolidays []Holiday) {
+	isNational := func(d time.Time) bool {
+		_, ok := s.national[d.Format(HolidayDateFormat)]
+		return ok
+	}
+
+	var derived []Holiday
+	for _, h := range nationalHolidays {
+		// 国民の休日: 前日と翌日が国民の祝日である日（日曜日を除く）
+		next := h.Date.AddDate(0, 0, 1)
+		if !isNational(next) && next.Weekday() != time.Sunday && isNational(next.AddDate(0, 0, 1)) {
+			derived = append(derived, Holiday{Date: next, Name: "国民の休日", Type: HolidayTypeCitizens})
+		}
+
+		// 振替休日: 祝日が日曜日の場合、その後の最初の祝日でない日
+		if h.Date.Weekday() == time.Sunday {
+			d := next
+			for isNational(d) {
+				d = d.AddDate(0, 0, 1)
+			}
+			derived = append(derived, Holiday{Date: d, Name: "振替休日", Type: HolidayTypeSubstitute})
+		}
+	}
+
+	for _, h := range derived {
+		key := h.Date.Format(HolidayDateFormat)
+		if _, ok := s.national[key]; !ok {
+			s.national[key] = h
+		}
+	}
+}
+
+// Covers 祝日データの収録範囲内の年か
+func (s *HolidayCalendarService) Covers(year int) bool {
+	return year >= s.firstYear && year <= s.lastYear
+}
+
+// YearRange 祝日データの収録範囲（最初の年・最後の年）
+func (s *HolidayCalendarService) YearRange() (int, int) {
+	return s.firstYear, s.lastYear
+}
+
+// LookupHoliday 日付が祝日・振替休日・国民の休日・会社休日であれば休日を返す（HolidayCheckerインターフェース実装）
+// 休日でなければnilを返す
+func (s *HolidayCalendarService) LookupHoliday(date time.Time) (*Holiday, error) {
+	if h, ok := s.national[date.Format(HolidayDateFormat)]; ok {
+		return &h, nil
+	}
+
+	company, err := s.companyHolidays(date.Year())
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range company {
+		if h.Date.Format(HolidayDateFormat) == date.Format(HolidayDateFormat) {
+			return &h, nil
+		}
+	}
+	return nil, nil
+}
+
+// Holidays 指定年の休日を日付順に返す（日曜日は含まない）
+func (s *HolidayCalendarService) Holidays(year int) ([]Holiday, error) {
+	var holidays []Holiday
+	seen := make(map[string]bool)
+	for key, h := range s.national {
+		if h.Date.Year() == year {
+			holidays = append(holidays, h)
+			seen[key] = true
+		}
+	}
+
+	company, err := s.companyHolidays(year)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range company {
+		key := h.Date.Format(HolidayDateFormat)
+		if !seen[key] {
+			holidays = append(holidays, h)
+			seen[key] = true
+		}
+	}
+
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays, nil
+}
+
+// companyHolidays 指定年の会社休日を返す
+func (s *HolidayCalendarService) companyHolidays(year int) ([]Holiday, error) {
+	if s.companyHolidayGet == nil {
+		return nil, nil
+	}
+	rows, err := s.companyHolidayGet.GetCompanyHolidays(year)
+	if err != nil {
+		return nil, fmt.Errorf("会社休日取得エラー: %w", err)
+	}
+
+	var holidays []Holiday
+	for _, row := range rows {
+		var date time.Time
+		switch {
+		case row.Date != nil:
+			date, err = time.ParseInLocation(HolidayDateFormat, *row.Date, time.Local)
+		case row.MonthDay != nil:
+			date, err = time.ParseInLocation(HolidayDateFormat, fmt.Sprintf("%04d-%s", year, *row.MonthDay), time.Local)
+		default:
+			err = errors.New("日付が指定されていません")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("会社休日の日付が不正です（ID=%d）: %w", row.ID, err)
+		}
+		if date.Year() != year {
+			continue
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: row.Name, Type: HolidayTypeCompany})
+	}
+	return holidays, nil
+}
+
+// LoadNationalHolidays 国民の祝日CSV（日付,名称）を読み込む
+// 日付は YYYY-MM-DD または内閣府CSVの YYYY/M/D 形式。見出し行と「休日」（振替休日・国民の休日）の行は読み飛ばす
+func LoadNationalHolidays(r io.Reader) ([]Holiday, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var holidays []Holiday
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("祝日CSV読み込みエラー: %w", err)
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("祝日CSVの%d行目の列数が不足しています", line)
+		}
+
+		date, err := parseHolidayDate(strings.TrimSpace(record[0]))
+		if err != nil {
+			if line == 1 {
+				continue // 見出し行
+			}
+			return nil, fmt.Errorf("祝日CSVの%d行目の日付が不正です: %s", line, record[0])
+		}
+		name := strings.TrimSpace(record[1])
+		if name == "休日" {
+			continue // 振替休日・国民の休日は祝日から導出する
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: name, Type: HolidayTypeNational})
+	}
+
+	if len(holidays) == 0 {
+		return nil, errors.New("祝日データが空です")
+	}
+	return holidays, nil
+}
+
+// WriteNationalHolidays 国民の祝日をCSV（日付,名称）で書き出す
+func WriteNationalHolidays(w io.Writer, holidays []Holiday) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"date", "name"}); err != nil {
+		return err
+	}
+	for _, h := range holidays {
+		if h.Type != HolidayTypeNational {
+			continue
+		}
+		if err := writer.Write([]string{h.Date.Format(HolidayDateFormat), h.Name}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// parseHolidayDate YYYY-MM-DD または YYYY/M/D 形式の日付を解析する
+func parseHolidayDate(s string) (time.Time, error) {
+	if d, err := time.ParseInLocation(HolidayDateFormat, s, time.Local); err == nil {
+		return d, nil
+	}
+	return time.ParseInLocation("2006/1/2", s, time.Local)
+}
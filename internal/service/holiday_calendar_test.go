@@ -0,0 +1,166 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// mockCompanyHolidayGetter 会社休日のモック
+type mockCompanyHolidayGetter struct {
+	holidays []*model.CompanyHoliday
+	err      error
+}
+
+func (m *mockCompanyHolidayGetter) GetCompanyHolidays(year int) ([]*model.CompanyHoliday, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.holidays, nil
+}
+
+func newTestHolidayCalendar(t *testing.T) *HolidayCalendarService {
+	t.Helper()
+	calendar, err := NewBundledHolidayCalendarService()
+	if err != nil {
+		t.Fatalf("NewBundledHolidayCalendarService() error = %v", err)
+	}
+	return calendar
+}
+
+func TestHolidayCalendarService_LookupHoliday(t *testing.T) {
+	calendar := newTestHolidayCalendar(t)
+
+	tests := []struct {
+		date     string
+		wantName string
+		wantType HolidayType
+	}{
+		{"2026-01-01", "元日", HolidayTypeNational},
+		{"2026-10-12", "スポーツの日", HolidayTypeNational},
+		{"2026-05-06", "振替休日", HolidayTypeSubstitute}, // 憲法記念日（日）の振替、5/4・5/5は祝日
+		{"2025-11-24", "振替休日", HolidayTypeSubstitute}, // 勤労感謝の日（日）の振替
+		{"2027-03-22", "振替休日", HolidayTypeSubstitute}, // 春分の日（日）の振替
+		{"2026-09-22", "国民の休日", HolidayTypeCitizens},  // 敬老の日と秋分の日に挟まれた日
+		{"2026-10-16", "", ""},
+		{"2026-05-03", "憲法記念日", HolidayTypeNational},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, _ := time.ParseInLocation(HolidayDateFormat, tt.date, time.Local)
+			got, err := calendar.LookupHoliday(date)
+			if err != nil {
+				t.Fatalf("LookupHoliday() error = %v", err)
+			}
+			if tt.wantName == "" {
+				if got != nil {
+					t.Errorf("LookupHoliday() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Name != tt.wantName || got.Type != tt.wantType {
+				t.Errorf("LookupHoliday() = %+v, want %s（%s）", got, tt.wantName, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestHolidayCalendarService_CompanyHolidays(t *testing.T) {
+	calendar := newTestHolidayCalendar(t)
+
+	newYearsEve := "12-31"
+	newYearsDay := "01-01"
+	summer := "2026-08-14"
+	calendar.SetCompanyHolidayGetter(&mockCompanyHolidayGetter{holidays: []*model.CompanyHoliday{
+		{ID: 1, Name: "年末年始", MonthDay: &newYearsEve},
+		{ID: 2, Name: "年末年始", MonthDay: &newYearsDay}, // 元日と重複
+		{ID: 3, Name: "夏季休業", Date: &summer},
+	}})
+
+	got, err := calendar.LookupHoliday(time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("LookupHoliday() error = %v", err)
+	}
+	if got == nil || got.Name != "年末年始" || got.Type != HolidayTypeCompany {
+		t.Errorf("LookupHoliday(12/31) = %+v, want 年末年始", got)
+	}
+
+	// 祝日と重なる会社休日は祝日を優先
+	got, err = calendar.LookupHoliday(time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("LookupHoliday() error = %v", err)
+	}
+	if got == nil || got.Name != "元日" {
+		t.Errorf("LookupHoliday(1/1) = %+v, want 元日", got)
+	}
+
+	holidays, err := calendar.Holidays(2026)
+	if err != nil {
+		t.Fatalf("Holidays() error = %v", err)
+	}
+	// 国民の祝日16 + 振替休日1 + 国民の休日1 + 会社休日2（元日重複を除く）
+	if len(holidays) != 20 {
+		t.Errorf("Holidays(2026) returned %d items, want 20", len(holidays))
+	}
+	for i := 1; i < len(holidays); i++ {
+		if holidays[i].Date.Before(holidays[i-1].Date) {
+			t.Errorf("Holidays() が日付順でない: %v, %v", holidays[i-1].Date, holidays[i].Date)
+		}
+	}
+
+	calendar.SetCompanyHolidayGetter(&mockCompanyHolidayGetter{err: errors.New("DB接続エラー")})
+	if _, err := calendar.Holidays(2026); err == nil {
+		t.Error("エラーが期待されたが、発生しなかった")
+	}
+}
+
+func TestHolidayCalendarService_Covers(t *testing.T) {
+	calendar := newTestHolidayCalendar(t)
+	if !calendar.Covers(2026) {
+		t.Error("Covers(2026) = false, want true")
+	}
+	if calendar.Covers(1999) {
+		t.Error("Covers(1999) = true, want false")
+	}
+}
+
+func TestLoadNationalHolidays(t *testing.T) {
+	// 内閣府CSV形式（休日の行は読み飛ばす）
+	input := "国民の祝日・休日月日,国民の祝日・休日名称\n2026/1/1,元日\n2026/5/6,休日\n2026/5/3,憲法記念日\n"
+	holidays, err := LoadNationalHolidays(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("LoadNationalHolidays() error = %v", err)
+	}
+	if len(holidays) != 2 {
+		t.Fatalf("LoadNationalHolidays() returned %d items, want 2", len(holidays))
+	}
+	if holidays[1].Date.Format(HolidayDateFormat) != "2026-05-03" || holidays[1].Name != "憲法記念日" {
+		t.Errorf("holidays[1] = %+v", holidays[1])
+	}
+
+	// 書き出した結果を再度読み込める
+	var buf bytes.Buffer
+	if err := WriteNationalHolidays(&buf, holidays); err != nil {
+		t.Fatalf("WriteNationalHolidays() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "date,name\n2026-01-01,元日\n") {
+		t.Errorf("WriteNationalHolidays() = %q", buf.String())
+	}
+	if _, err := LoadNationalHolidays(&buf); err != nil {
+		t.Errorf("LoadNationalHolidays() error = %v", err)
+	}
+
+	// 不正な日付
+	if _, err := LoadNationalHolidays(strings.NewReader("date,name\n2026-13-01,元日\n")); err == nil {
+		t.Error("エラーが期待されたが、発生しなかった")
+	}
+	// 空データ
+	if _, err := LoadNationalHolidays(strings.NewReader("date,name\n")); err == nil {
+		t.Error("エラーが期待されたが、発生しなかった")
+	}
+}
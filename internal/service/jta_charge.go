@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// ChargeDataGetter トラ協付帯料金データ取得インターフェース（テスト用にモック可能）
+type ChargeDataGetter interface {
+	GetChargeData() ([]model.JtaChargeData, error)
+}
+
+// JtaSyncStatusGetter ローカルミラーの同期状態取得インターフェース（JtaMirrorRepositoryが実装）
+type JtaSyncStatusGetter interface {
+	GetSyncStatus(tableName string) (*model.JtaSyncStatus, error)
+}
+
+// JtaWaitingFreeMinutes 待機時間料の対象外となる待機時間（分）
+// 待機時間が30分を超えた場合に、超過分に対して待機時間料を収受する
+const JtaWaitingFreeMinutes = 30
+
+// JtaChargeService トラ協付帯料金（待機時間料・積込取卸料）計算サービス
+type JtaChargeService struct {
+	getter ChargeDataGetter
+	status JtaSyncStatusGetter // ローカルミラーの同期状態（未設定の場合は料金表を取り直さない）
+
+	mu       sync.Mutex
+	charges  map[int][]model.JtaChargeData // 車格コード別の料金表（time_code昇順）、nilの場合は未取得
+	syncedAt time.Time                     // 料金表を取得した時点のローカルミラーの同期日時
+}
+
+// NewJtaChargeService 新しいJtaChargeServiceを作成
+func NewJtaChargeService(getter ChargeDataGetter) *JtaChargeService {
+	return &JtaChargeService{
+		getter: getter,
+	}
+}
+
+// SetSyncStatusGetter ローカルミラーの同期状態を設定（同期日時が変わった場合は料金表を取り直す）
+func (s *JtaChargeService) SetSyncStatusGetter(status JtaSyncStatusGetter) {
+	s.status = status
+}
+
+// JtaChargeResult トラ協付帯料金計算結果
+type JtaChargeResult struct {
+	VehicleCode    int // 車格コード
+	WorkMinutes    int // 積込・取卸時間（分）
+	WaitingMinutes int // 待機時間（分）
+
+	HandlingCharge int // 積込・取卸料（円）
+	WaitingCharge  int // 待機時間料（円）
+	TotalCharge    int // 付帯料金合計（円）
+}
+
+// Calculate 付帯料金を計算する
+// 積込・取卸料は作業時間全体、待機時間料は30分超過分を対象とする
+func (s *JtaChargeService) Calculate(vehicleCode, workMinutes, waitingMinutes int) (*JtaChargeResult, error) {
+	if vehicleCode < 1 || vehicleCode > 4 {
+		return nil, fmt.Errorf("無効な車格コード: %d（1-4の範囲で指定してください）", vehicleCode)
+	}
+	if workMinutes < 0 {
+		workMinutes = 0
+	}
+	if waitingMinutes < 0 {
+		waitingMinutes = 0
+	}
+
+	result := &JtaChargeResult{
+		VehicleCode:    vehicleCode,
+		WorkMinutes:    workMinutes,
+		WaitingMinutes: waitingMinutes,
+	}
+
+	if workMinutes == 0 && waitingMinutes <= JtaWaitingFreeMinutes {
+		return result, nil
+	}
+
+	rows, err := s.chargeRows(vehicleCode)
+	if err != nil {
+		return nil, err
+	}
+
+	result.HandlingCharge, err = chargeForMinutes(rows, workMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("積込・取卸料計算エラー: %w", err)
+	}
+	result.WaitingCharge, err = chargeForMinutes(rows, waitingMinutes-JtaWaitingFreeMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("待機時間料計算エラー: %w", err)
+	}
+	result.TotalCharge = result.HandlingCharge + result.WaitingCharge
+
+	return result, nil
+}
+
+// chargeRows 車格の料金表を取得する（全件取得してキャッシュし、ローカルミラーが再同期された場合は取り直す）
+func (s *JtaChargeService) chargeRows(vehicleCode int) ([]model.JtaChargeData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	syncedAt := s.chargeSyncedAt()
+	if s.charges == nil || !syncedAt.Equal(s.syncedAt) {
+		data, err := s.getter.GetChargeData()
+		if err != nil {
+			return nil, fmt.Errorf("付帯料金データ取得エラー: %w", err)
+		}
+
+		charges := make(map[int][]model.JtaChargeData)
+		for _, c := range data {
+			charges[c.VehicleCode] = append(charges[c.VehicleCode], c)
+		}
+		for _, rows := range charges {
+			sort.Slice(rows, func(i, j int) bool {
+				return rows[i].TimeCode < rows[j].TimeCode
+			})
+		}
+		s.charges = charges
+		s.syncedAt = syncedAt
+	}
+
+	rows := s.charges[vehicleCode]
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("付帯料金データが見つかりません: 車格=%d", vehicleCode)
+	}
+	return rows, nil
+}
+
+// chargeSyncedAt ローカルミラーの付帯料金の同期日時（同期状態が未設定・未同期の場合はゼロ値）
+func (s *JtaChargeService) chargeSyncedAt() time.Time {
+	if s.status == nil {
+		return time.Time{}
+	}
+	status, err := s.status.GetSyncStatus(model.JtaTableChargeData)
+	if err != nil {
+		return time.Time{}
+	}
+	return status.SyncedAt
+}
+
+// chargeForMinutes 料金表から指定時間の料金を求める
+// time_code（分）が指定時間以上となる最初の行の料金を適用し、
+// 最大の time_code を超える分は 1m_yen（1分あたり料金）で加算する
+func chargeForMinutes(rows []model.JtaChargeData, minutes int) (int, error) {
+	if minutes <= 0 {
+		return 0, nil
+	}
+
+	for _, row := range rows {
+		if minutes <= row.TimeCode {
+			return row.ChargeYen, nil
+		}
+	}
+
+	last := rows[len(rows)-1]
+	if last.Per1MinYen == nil {
+		return 0, fmt.Errorf("%d分を超える料金が設定されていません", last.TimeCode)
+	}
+	return last.ChargeYen + (minutes-last.TimeCode)*(*last.Per1MinYen), nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// mockChargeDataGetter 付帯料金データのモック
+// 車格ごとに 30/60/90分 の料金と、90分超過の1分あたり料金を持つ
+type mockChargeDataGetter struct {
+	err   error
+	calls int
+}
+
+func (m *mockChargeDataGetter) GetChargeData() ([]model.JtaChargeData, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	perMin := func(yen int) *int { return &yen }
+	var charges []model.JtaChargeData
+	for vehicleCode, unit := range map[int]int{1: 1760, 2: 1980, 3: 2200, 4: 2420} {
+		charges = append(charges,
+			// 順不同でも time_code 順に並べ替えられること
+			model.JtaChargeData{VehicleCode: vehicleCode, TimeCode: 90, ChargeYen: unit * 3, Per1MinYen: perMin(unit / 30)},
+			model.JtaChargeData{VehicleCode: vehicleCode, TimeCode: 30, ChargeYen: unit},
+			model.JtaChargeData{VehicleCode: vehicleCode, TimeCode: 60, ChargeYen: unit * 2},
+		)
+	}
+	return charges, nil
+}
+
+func TestJtaChargeService_Calculate(t *testing.T) {
+	tests := []struct {
+		name           string
+		vehicleCode    int
+		workMinutes    int
+		waitingMinutes int
+		wantHandling   int
+		wantWaiting    int
+	}{
+		{"付帯作業なし", 3, 0, 0, 0, 0},
+		{"待機30分以内は無料", 3, 0, 30, 0, 0},
+		{"待機31分（超過1分）", 3, 0, 31, 0, 2200},
+		{"待機90分（超過60分）", 3, 0, 90, 0, 4400},
+		{"積込30分", 3, 30, 0, 2200, 0},
+		{"積込45分", 3, 45, 0, 4400, 0},
+		{"積込100分（90分超過は1分単価）", 3, 100, 0, 6600 + 10*73, 0},
+		{"小型車・積込60分・待機60分", 1, 60, 60, 3520, 1760},
+		{"負の値は0扱い", 3, -10, -10, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewJtaChargeService(&mockChargeDataGetter{})
+			result, err := service.Calculate(tt.vehicleCode, tt.workMinutes, tt.waitingMinutes)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if result.HandlingCharge != tt.wantHandling {
+				t.Errorf("HandlingCharge = %d, want %d", result.HandlingCharge, tt.wantHandling)
+			}
+			if result.WaitingCharge != tt.wantWaiting {
+				t.Errorf("WaitingCharge = %d, want %d", result.WaitingCharge, tt.wantWaiting)
+			}
+			if result.TotalCharge != tt.wantHandling+tt.wantWaiting {
+				t.Errorf("TotalCharge = %d, want %d", result.TotalCharge, tt.wantHandling+tt.wantWaiting)
+			}
+		})
+	}
+}
+
+func TestJtaChargeService_CachesChargeData(t *testing.T) {
+	getter := &mockChargeDataGetter{}
+	service := NewJtaChargeService(getter)
+
+	for i := 0; i < 3; i++ {
+		if _, err := service.Calculate(3, 60, 60); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+	}
+	if getter.calls != 1 {
+		t.Errorf("GetChargeData の呼び出し回数 = %d, want 1", getter.calls)
+	}
+
+	// 付帯作業がない場合は取得しない
+	service = NewJtaChargeService(getter)
+	getter.calls = 0
+	if _, err := service.Calculate(3, 0, 0); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if getter.calls != 0 {
+		t.Errorf("GetChargeData の呼び出し回数 = %d, want 0", getter.calls)
+	}
+}
+
+func TestJtaChargeService_ReloadsAfterMirrorSync(t *testing.T) {
+	mirror := newMemoryJtaMirror()
+	replace := func(chargeYen int, syncedAt time.Time) {
+		charges := []model.JtaChargeData{{VehicleCode: 3, TimeCode: 60, ChargeYen: chargeYen}}
+		if err := mirror.ReplaceChargeData(charges, &model.JtaSyncStatus{TableName: model.JtaTableChargeData, SyncedAt: syncedAt}); err != nil {
+			t.Fatalf("ReplaceChargeData() error: %v", err)
+		}
+	}
+	replace(4400, time.Date(2026, 4, 1, 3, 0, 0, 0, time.Local))
+
+	service := NewJtaChargeService(mirror)
+	service.SetSyncStatusGetter(mirror)
+	if result, err := service.Calculate(3, 60, 0); err != nil || result.HandlingCharge != 4400 {
+		t.Fatalf("同期前の積込・取卸料 = %v, %v, want 4400", result, err)
+	}
+
+	// 再同期後は新しい料金表で計算する
+	replace(4800, time.Date(2026, 5, 1, 3, 0, 0, 0, time.Local))
+	if result, err := service.Calculate(3, 60, 0); err != nil || result.HandlingCharge != 4800 {
+		t.Errorf("再同期後の積込・取卸料 = %v, %v, want 4800", result, err)
+	}
+}
+
+func TestJtaChargeService_Calculate_Error(t *testing.T) {
+	t.Run("無効な車格コード", func(t *testing.T) {
+		service := NewJtaChargeService(&mockChargeDataGetter{})
+		if _, err := service.Calculate(0, 60, 0); err == nil {
+			t.Error("エラーが期待されたが、発生しなかった")
+		}
+	})
+
+	t.Run("取得エラー（キャッシュしない）", func(t *testing.T) {
+		getter := &mockChargeDataGetter{err: errors.New("API呼び出しエラー")}
+		service := NewJtaChargeService(getter)
+		if _, err := service.Calculate(3, 60, 0); err == nil {
+			t.Error("エラーが期待されたが、発生しなかった")
+		}
+
+		getter.err = nil
+		if _, err := service.Calculate(3, 60, 0); err != nil {
+			t.Errorf("再取得で成功するはずが、エラー: %v", err)
+		}
+	})
+
+	t.Run("超過単価なし", func(t *testing.T) {
+		rows := []model.JtaChargeData{{VehicleCode: 3, TimeCode: 30, ChargeYen: 2200}}
+		if _, err := chargeForMinutes(rows, 31); err == nil {
+			t.Error("エラーが期待されたが、発生しなかった")
+		}
+	})
+}
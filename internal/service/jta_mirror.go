@@ -0,0 +1,216 @@
+package service
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// JtaMirrorStaleAfter ローカルミラーの再同期を促す経過期間
+const JtaMirrorStaleAfter = 30 * 24 * time.Hour
+
+// JtaDataSource トラ協データの全件取得インターフェース（JtaSupabaseClientが実装）
+type JtaDataSource interface {
+	GetAllDistanceFares() ([]model.JtaDistanceFare, error)
+	GetChargeData() ([]model.JtaChargeData, error)
+}
+
+// JtaMirrorReader ローカルミラーの読み取りインターフェース（テスト用にモック可能）
+// 該当する運賃・同期状態がない場合は sql.ErrNoRows を返す
+type JtaMirrorReader interface {
+	GetFareRate(regionCode, vehicleCode, uptoKm int) (*model.JtaDistanceFare, error)
+	GetChargeData() ([]model.JtaChargeData, error)
+	GetSyncStatus(tableName string) (*model.JtaSyncStatus, error)
+}
+
+// JtaMirrorStore ローカルミラーの読み書きインターフェース
+type JtaMirrorStore interface {
+	JtaMirrorReader
+	ReplaceFareRates(fares []model.JtaDistanceFare, status *model.JtaSyncStatus) error
+	ReplaceChargeData(charges []model.JtaChargeData, status *model.JtaSyncStatus) error
+}
+
+// JtaFareRatesChecksum 距離制運賃のチェックサム（取得順によらないよう運輸局・車格・距離順に並べて計算）
+func JtaFareRatesChecksum(fares []model.JtaDistanceFare) string {
+	sorted := append([]model.JtaDistanceFare(nil), fares...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.RegionCode != b.RegionCode {
+			return a.RegionCode < b.RegionCode
+		}
+		if a.VehicleCode != b.VehicleCode {
+			return a.VehicleCode < b.VehicleCode
+		}
+		return a.UptoKm < b.UptoKm
+	})
+
+	h := sha256.New()
+	for _, f := range sorted {
+		fmt.Fprintf(h, "%d,%d,%d,%d\n", f.RegionCode, f.VehicleCode, f.UptoKm, f.FareYen)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// JtaChargeDataChecksum 付帯料金のチェックサム（ID順に並べて計算、1分あたり料金のnullは空欄）
+func JtaChargeDataChecksum(charges []model.JtaChargeData) string {
+	sorted := append([]model.JtaChargeData(nil), charges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].IDCode < sorted[j].IDCode })
+
+	h := sha256.New()
+	for _, c := range sorted {
+		fmt.Fprintf(h, "%d,%d,%d,%d,", c.IDCode, c.VehicleCode, c.TimeCode, c.ChargeYen)
+		if c.Per1MinYen != nil {
+			fmt.Fprintf(h, "%d", *c.Per1MinYen)
+		}
+		io.WriteString(h, "\n")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// JtaTableSyncResult 同期元テーブルごとの同期結果
+type JtaTableSyncResult struct {
+	Status   *model.JtaSyncStatus // 同期後の状態
+	Previous *model.JtaSyncStatus // 同期前の状態（初回はnil）
+}
+
+// Changed 前回の同期からデータが変わったか（初回は変更あり）
+func (r *JtaTableSyncResult) Changed() bool {
+	return r.Previous == nil || r.Previous.Checksum != r.Status.Checksum
+}
+
+// Summary 同期結果の表示用文字列
+func (r *JtaTableSyncResult) Summary() string {
+	change := "変更なし"
+	switch {
+	case r.Previous == nil:
+		change = "初回同期"
+	case r.Changed():
+		change = fmt.Sprintf("変更あり（前回 %d件、%s同期）", r.Previous.RowCount, r.Previous.SyncedAt.Format("2006-01-02 15:04"))
+	}
+	return fmt.Sprintf("%s: %d件 チェックサム %s… %s", r.Status.TableName, r.Status.RowCount, r.Status.Checksum[:12], change)
+}
+
+// JtaSyncResult ローカルミラーの同期結果
+type JtaSyncResult struct {
+	FareRates  *JtaTableSyncResult
+	ChargeData *JtaTableSyncResult
+}
+
+// JtaMirrorSyncService トラ協データ（Supabase fare_rates・charge_data）をローカルミラーへ同期するサービス
+type JtaMirrorSyncService struct {
+	source JtaDataSource
+	store  JtaMirrorStore
+	now    func() time.Time
+}
+
+// NewJtaMirrorSyncService 新しいJtaMirrorSyncServiceを作成
+func NewJtaMirrorSyncService(source JtaDataSource, store JtaMirrorStore) *JtaMirrorSyncService {
+	return &JtaMirrorSyncService{source: source, store: store, now: time.Now}
+}
+
+// Sync 距離制運賃・付帯料金を全件取得してローカルミラーを入れ替える
+// 取得に失敗した場合や0件の場合はミラーを更新しない（同期前のデータで計算を続けられる）
+func (s *JtaMirrorSyncService) Sync() (*JtaSyncResult, error) {
+	fares, err := s.source.GetAllDistanceFares()
+	if err != nil {
+		return nil, fmt.Errorf("距離制運賃の取得エラー: %w", err)
+	}
+	if len(fares) == 0 {
+		return nil, fmt.Errorf("距離制運賃が0件のため同期を中止しました")
+	}
+	charges, err := s.source.GetChargeData()
+	if err != nil {
+		return nil, fmt.Errorf("付帯料金の取得エラー: %w", err)
+	}
+	if len(charges) == 0 {
+		return nil, fmt.Errorf("付帯料金が0件のため同期を中止しました")
+	}
+
+	syncedAt := s.now()
+	result := &JtaSyncResult{}
+
+	result.FareRates, err = s.syncTable(model.JtaTableFareRates, len(fares), JtaFareRatesChecksum(fares), syncedAt,
+		func(status *model.JtaSyncStatus) error { return s.store.ReplaceFareRates(fares, status) })
+	if err != nil {
+		return nil, fmt.Errorf("距離制運賃の保存エラー: %w", err)
+	}
+	result.ChargeData, err = s.syncTable(model.JtaTableChargeData, len(charges), JtaChargeDataChecksum(charges), syncedAt,
+		func(status *model.JtaSyncStatus) error { return s.store.ReplaceChargeData(charges, status) })
+	if err != nil {
+		return nil, fmt.Errorf("付帯料金の保存エラー: %w", err)
+	}
+	return result, nil
+}
+
+// syncTable 同期前の状態を取得してからテーブルを入れ替える
+func (s *JtaMirrorSyncService) syncTable(tableName string, rowCount int, checksum string, syncedAt time.Time, replace func(*model.JtaSyncStatus) error) (*JtaTableSyncResult, error) {
+	previous, err := s.store.GetSyncStatus(tableName)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	status := &model.JtaSyncStatus{
+		TableName: tableName,
+		RowCount:  rowCount,
+		Checksum:  checksum,
+		SyncedAt:  syncedAt,
+	}
+	if err := replace(status); err != nil {
+		return nil, err
+	}
+	return &JtaTableSyncResult{Status: status, Previous: previous}, nil
+}
+
+// JtaMirrorFareGetter ローカルミラーから距離制運賃を取得するFareGetter
+type JtaMirrorFareGetter struct {
+	store JtaMirrorReader
+	now   func() time.Time
+}
+
+// NewJtaMirrorFareGetter 新しいJtaMirrorFareGetterを作成
+func NewJtaMirrorFareGetter(store JtaMirrorReader) *JtaMirrorFareGetter {
+	return &JtaMirrorFareGetter{store: store, now: time.Now}
+}
+
+// GetDistanceFareYen 運賃を取得して金額のみ返す
+func (g *JtaMirrorFareGetter) GetDistanceFareYen(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, error) {
+	fare, _, err := g.GetDistanceFareYenWithSource(version, regionCode, vehicleCode, distanceKm)
+	return fare, err
+}
+
+// GetDistanceFareYenWithSource 運賃と取得元（同期日時）を返す
+// ミラーはSupabaseと同じく現行版のみのため、適用終了した運賃版はエラーとする
+func (g *JtaMirrorFareGetter) GetDistanceFareYenWithSource(version *model.TariffVersion, regionCode, vehicleCode, distanceKm int) (int, *FareSource, error) {
+	if version != nil && !version.IsCurrent() {
+		return 0, nil, fmt.Errorf("運賃版 %s の距離制運賃データがありません（現行版のみ対応）", version.Label())
+	}
+
+	status, err := g.store.GetSyncStatus(model.JtaTableFareRates)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil, fmt.Errorf("距離制運賃のローカルミラーが未同期です（go run ./cmd/tools/sync_jta_fares で同期してください）")
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("ローカルミラーの同期状態の取得エラー: %w", err)
+	}
+
+	fare, err := g.store.GetFareRate(regionCode, vehicleCode, distanceKm)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil, fmt.Errorf("運賃データが見つかりません: region=%d, vehicle=%d, distance=%d", regionCode, vehicleCode, distanceKm)
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("ローカルミラーの取得エラー: %w", err)
+	}
+
+	return fare.FareYen, &FareSource{
+		Name:      FareSourceLocalMirror,
+		SyncedAt:  status.SyncedAt,
+		CheckedAt: g.now(),
+	}, nil
+}
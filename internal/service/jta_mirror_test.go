@@ -0,0 +1,213 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// memoryJtaMirror テスト用のローカルミラー（メモリ上）
+type memoryJtaMirror struct {
+	fares    map[[3]int]int
+	charges  []model.JtaChargeData
+	statuses map[string]*model.JtaSyncStatus
+}
+
+func newMemoryJtaMirror() *memoryJtaMirror {
+	return &memoryJtaMirror{fares: map[[3]int]int{}, statuses: map[string]*model.JtaSyncStatus{}}
+}
+
+func (m *memoryJtaMirror) GetFareRate(regionCode, vehicleCode, uptoKm int) (*model.JtaDistanceFare, error) {
+	fare, ok := m.fares[[3]int{regionCode, vehicleCode, uptoKm}]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &model.JtaDistanceFare{RegionCode: regionCode, VehicleCode: vehicleCode, UptoKm: uptoKm, FareYen: fare}, nil
+}
+
+func (m *memoryJtaMirror) GetChargeData() ([]model.JtaChargeData, error) {
+	return m.charges, nil
+}
+
+func (m *memoryJtaMirror) GetSyncStatus(tableName string) (*model.JtaSyncStatus, error) {
+	status, ok := m.statuses[tableName]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return status, nil
+}
+
+func (m *memoryJtaMirror) ReplaceFareRates(fares []model.JtaDistanceFare, status *model.JtaSyncStatus) error {
+	m.fares = map[[3]int]int{}
+	for _, f := range fares {
+		m.fares[[3]int{f.RegionCode, f.VehicleCode, f.UptoKm}] = f.FareYen
+	}
+	m.statuses[status.TableName] = status
+	return nil
+}
+
+func (m *memoryJtaMirror) ReplaceChargeData(charges []model.JtaChargeData, status *model.JtaSyncStatus) error {
+	m.charges = charges
+	m.statuses[status.TableName] = status
+	return nil
+}
+
+// stubJtaDataSource テスト用のトラ協データ取得元
+type stubJtaDataSource struct {
+	fares   []model.JtaDistanceFare
+	charges []model.JtaChargeData
+	err     error
+}
+
+func (s *stubJtaDataSource) GetAllDistanceFares() ([]model.JtaDistanceFare, error) {
+	return s.fares, s.err
+}
+
+func (s *stubJtaDataSource) GetChargeData() ([]model.JtaChargeData, error) {
+	return s.charges, s.err
+}
+
+func TestJtaFareRatesChecksum(t *testing.T) {
+	fares := []model.JtaDistanceFare{
+		{RegionCode: 3, VehicleCode: 3, UptoKm: 110, FareYen: 37000},
+		{RegionCode: 3, VehicleCode: 3, UptoKm: 100, FareYen: 35000},
+	}
+	reordered := []model.JtaDistanceFare{fares[1], fares[0]}
+	if JtaFareRatesChecksum(fares) != JtaFareRatesChecksum(reordered) {
+		t.Error("取得順でチェックサムが変わった")
+	}
+
+	changed := []model.JtaDistanceFare{fares[0], {RegionCode: 3, VehicleCode: 3, UptoKm: 100, FareYen: 35100}}
+	if JtaFareRatesChecksum(fares) == JtaFareRatesChecksum(changed) {
+		t.Error("運賃が変わってもチェックサムが同じ")
+	}
+
+	per1Min := 30
+	withPer1Min := []model.JtaChargeData{{IDCode: 1, VehicleCode: 1, TimeCode: 30, ChargeYen: 900, Per1MinYen: &per1Min}}
+	withoutPer1Min := []model.JtaChargeData{{IDCode: 1, VehicleCode: 1, TimeCode: 30, ChargeYen: 900}}
+	if JtaChargeDataChecksum(withPer1Min) == JtaChargeDataChecksum(withoutPer1Min) {
+		t.Error("1分あたり料金のnullが区別されていない")
+	}
+}
+
+func TestJtaMirrorSyncService_Sync(t *testing.T) {
+	source := &stubJtaDataSource{
+		fares:   []model.JtaDistanceFare{{RegionCode: 3, VehicleCode: 3, UptoKm: 100, FareYen: 35000}},
+		charges: []model.JtaChargeData{{IDCode: 1, VehicleCode: 3, TimeCode: 30, ChargeYen: 1500}},
+	}
+	store := newMemoryJtaMirror()
+	service := NewJtaMirrorSyncService(source, store)
+	service.now = func() time.Time { return time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local) }
+
+	// 初回
+	result, err := service.Sync()
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if !result.FareRates.Changed() || result.FareRates.Previous != nil {
+		t.Errorf("初回同期: %+v", result.FareRates)
+	}
+	if !strings.Contains(result.FareRates.Summary(), "fare_rates: 1件") || !strings.Contains(result.FareRates.Summary(), "初回同期") {
+		t.Errorf("Summary() = %s", result.FareRates.Summary())
+	}
+	if fare, err := store.GetFareRate(3, 3, 100); err != nil || fare.FareYen != 35000 {
+		t.Errorf("同期後の運賃 = %v, %v", fare, err)
+	}
+
+	// 同じデータの再同期は変更なし
+	result, err = service.Sync()
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.FareRates.Changed() || result.ChargeData.Changed() {
+		t.Error("同じデータで変更ありと判定された")
+	}
+
+	// 取得エラー・0件はミラーを更新しない
+	for _, broken := range []*stubJtaDataSource{{err: errors.New("接続エラー")}, {}} {
+		if _, err := NewJtaMirrorSyncService(broken, store).Sync(); err == nil {
+			t.Error("エラーが発生しなかった")
+		}
+	}
+	if _, err := store.GetFareRate(3, 3, 100); err != nil {
+		t.Errorf("同期失敗でミラーが消えた: %v", err)
+	}
+}
+
+func TestJtaMirrorFareGetter(t *testing.T) {
+	store := newMemoryJtaMirror()
+	getter := NewJtaMirrorFareGetter(store)
+	getter.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local) }
+
+	// 未同期
+	if _, err := getter.GetDistanceFareYen(nil, 3, 3, 100); err == nil || !strings.Contains(err.Error(), "未同期") {
+		t.Errorf("未同期のエラー = %v", err)
+	}
+
+	store.ReplaceFareRates([]model.JtaDistanceFare{{RegionCode: 3, VehicleCode: 3, UptoKm: 100, FareYen: 35000}},
+		&model.JtaSyncStatus{TableName: model.JtaTableFareRates, RowCount: 1, SyncedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)})
+
+	fare, source, err := getter.GetDistanceFareYenWithSource(nil, 3, 3, 100)
+	if err != nil {
+		t.Fatalf("GetDistanceFareYenWithSource failed: %v", err)
+	}
+	if fare != 35000 {
+		t.Errorf("fare = %d, want 35000", fare)
+	}
+	if got := source.Label(); got != "ローカルミラー（2026-10-01 09:00同期・15日前）" {
+		t.Errorf("Label() = %s", got)
+	}
+
+	if _, err := getter.GetDistanceFareYen(nil, 3, 3, 999); err == nil {
+		t.Error("該当なしでエラーが発生しなかった")
+	}
+	endedTo := time.Date(2024, 3, 21, 0, 0, 0, 0, time.Local)
+	ended := &model.TariffVersion{Name: "令和2年4月告示", EffectiveFrom: time.Date(2020, 4, 24, 0, 0, 0, 0, time.Local), EffectiveTo: &endedTo}
+	if _, err := getter.GetDistanceFareYen(ended, 3, 3, 100); err == nil {
+		t.Error("適用終了した運賃版でエラーが発生しなかった")
+	}
+}
+
+func TestJtaSupabaseClient_GetAllDistanceFares(t *testing.T) {
+	// 1ページ目は上限件数、2ページ目で終了
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/v1/fare_rates" || r.Header.Get("apikey") != "key" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+
+		n := 3
+		if offset == "0" {
+			n = jtaFareRatesPageSize
+		}
+		start, _ := strconv.Atoi(offset)
+		fares := make([]model.JtaDistanceFare, n)
+		for i := range fares {
+			fares[i] = model.JtaDistanceFare{RegionCode: 3, VehicleCode: 3, UptoKm: (start + i + 1) * 10, FareYen: 1000}
+		}
+		json.NewEncoder(w).Encode(fares)
+	}))
+	defer server.Close()
+
+	fares, err := NewJtaSupabaseClient(server.URL, "key").GetAllDistanceFares()
+	if err != nil {
+		t.Fatalf("GetAllDistanceFares failed: %v", err)
+	}
+	if len(fares) != jtaFareRatesPageSize+3 {
+		t.Errorf("len(fares) = %d, want %d", len(fares), jtaFareRatesPageSize+3)
+	}
+	if strings.Join(offsets, ",") != "0,1000" {
+		t.Errorf("offsets = %v", offsets)
+	}
+}
@@ -83,6 +83,52 @@ func (c *JtaSupabaseClient) GetDistanceFare(regionCode, vehicleCode, distanceKm
 	return &fares[0], nil
 }
 
+// jtaFareRatesPageSize 距離制運賃の全件取得時の1回あたりの取得件数
+const jtaFareRatesPageSize = 1000
+
+// GetAllDistanceFares 距離制運賃データを全件取得（ローカルミラーの同期用）
+// PostgRESTの取得件数上限があるため、運輸局・車格・距離の順に並べてページ単位で取得する
+func (c *JtaSupabaseClient) GetAllDistanceFares() ([]model.JtaDistanceFare, error) {
+	endpoint := fmt.Sprintf("%s/rest/v1/fare_rates", c.baseURL)
+
+	var all []model.JtaDistanceFare
+	for offset := 0; ; offset += jtaFareRatesPageSize {
+		params := url.Values{}
+		params.Set("select", "region_code,vehicle_code,upto_km,fare_yen")
+		params.Set("order", "region_code.asc,vehicle_code.asc,upto_km.asc")
+		params.Set("limit", fmt.Sprintf("%d", jtaFareRatesPageSize))
+		params.Set("offset", fmt.Sprintf("%d", offset))
+
+		req, err := http.NewRequest("GET", fmt.Sprintf("%s?%s", endpoint, params.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf("リクエスト作成エラー: %w", err)
+		}
+
+		c.setHeaders(req)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("API呼び出しエラー: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("APIエラー: ステータスコード %d", resp.StatusCode)
+		}
+		var fares []model.JtaDistanceFare
+		err = json.NewDecoder(resp.Body).Decode(&fares)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("JSONデコードエラー: %w", err)
+		}
+
+		all = append(all, fares...)
+		if len(fares) < jtaFareRatesPageSize {
+			return all, nil
+		}
+	}
+}
+
 // GetChargeData 付帯料金データを全件取得
 func (c *JtaSupabaseClient) GetChargeData() ([]model.JtaChargeData, error) {
 	endpoint := fmt.Sprintf("%s/rest/v1/charge_data", c.baseURL)
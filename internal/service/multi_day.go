@@ -0,0 +1,64 @@
+package service
+
+import "fmt"
+
+// DefaultDailyWorkingLimitMinutes 1日の拘束時間の上限（分、改善基準告示の原則13時間）
+// 総作業時間がこれを超える場合は複数日運行として日ごとに時間制運賃を計算する
+const DefaultDailyWorkingLimitMinutes = ComplianceDailyRestraintMinutes
+
+// TimeFareDay 複数日運行の1日分の時間制運賃
+type TimeFareDay struct {
+	Day            int // 日目（1始まり）
+	DistanceKm     int // 走行距離（km）
+	DrivingMinutes int // 走行時間（分）
+	LoadingMinutes int // 荷役時間（分）
+	BreakMinutes   int // 休憩時間（分、改善基準告示の休憩を含める場合）
+	TotalMinutes   int // 作業時間（分）
+
+	AppliedHours  int // 適用時間制（4 or 8）
+	BaseKm        int // 基礎走行キロ
+	ExcessKm      int // 超過距離（km）
+	ExcessMinutes int // 超過時間（分）
+	ExcessHours   int // 超過時間（時間、切り上げ）
+
+	BaseFare          int // 基礎額（円）
+	DistanceSurcharge int // 距離超過加算額（円）
+	TimeSurcharge     int // 時間超過加算額（円）
+	SubTotal          int // 小計（円）
+}
+
+// Label 表示用ラベル（例: 1日目: 350km・作業10時間30分（8時間制））
+func (d TimeFareDay) Label() string {
+	return fmt.Sprintf("%d日目: %dkm・作業%d時間%d分（%d時間制）", d.Day, d.DistanceKm, d.TotalMinutes/60, d.TotalMinutes%60, d.AppliedHours)
+}
+
+// formatHoursMinutes 分を「9時間」「8時間30分」の形式にする
+func formatHoursMinutes(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d時間", minutes/60)
+	}
+	return fmt.Sprintf("%d時間%d分", minutes/60, minutes%60)
+}
+
+// WorkingDays 総作業時間を1日の拘束時間の上限で分けた運行日数（上限0以下は既定値）
+func WorkingDays(totalMinutes, dailyLimitMinutes int) int {
+	if dailyLimitMinutes <= 0 {
+		dailyLimitMinutes = DefaultDailyWorkingLimitMinutes
+	}
+	if totalMinutes <= dailyLimitMinutes {
+		return 1
+	}
+	return (totalMinutes + dailyLimitMinutes - 1) / dailyLimitMinutes
+}
+
+// splitEvenly 値をn日に均等に分ける（余りは前の日から1ずつ配分）
+func splitEvenly(value, n int) []int {
+	parts := make([]int, n)
+	for i := range parts {
+		parts[i] = value / n
+		if i < value%n {
+			parts[i]++
+		}
+	}
+	return parts
+}
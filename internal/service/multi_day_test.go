@@ -0,0 +1,144 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTimeFareService_Calculate_MultiDay(t *testing.T) {
+	// 関東・大型車（8時間制: 基礎額60,090円・基礎走行キロ130km、距離加算630円/10km、時間加算4,180円/時間）
+	svc := NewTimeFareService(&MockTimeFareGetter{})
+
+	tests := []struct {
+		name           string
+		distanceKm     int
+		drivingMinutes int
+		loadingMinutes int
+		opts           []FareOption
+		wantDays       []TimeFareDay
+		wantSubTotal   int
+		wantOvernight  int
+		wantTotalFare  int
+	}{
+		{
+			// 総作業13時間ちょうどは日帰り（8時間制 + 時間超過5時間）
+			name:           "1日の上限ちょうど",
+			distanceKm:     480,
+			drivingMinutes: 720,
+			loadingMinutes: 60,
+			wantSubTotal:   60090 + 35*630 + 5*4180,
+			wantTotalFare:  60090 + 35*630 + 5*4180,
+		},
+		{
+			// 700km・総作業16時間 → 2日（1日あたり350km・走行7時間・荷役30分）
+			name:           "2日運行・宿泊費あり",
+			distanceKm:     700,
+			drivingMinutes: 840,
+			loadingMinutes: 120,
+			opts:           []FareOption{WithOvernightCost(8000)},
+			wantDays: []TimeFareDay{
+				{Day: 1, DistanceKm: 350, DrivingMinutes: 420, LoadingMinutes: 60, TotalMinutes: 480, AppliedHours: 8, BaseKm: 130, ExcessKm: 220, BaseFare: 60090, DistanceSurcharge: 22 * 630, SubTotal: 60090 + 22*630},
+				{Day: 2, DistanceKm: 350, DrivingMinutes: 420, LoadingMinutes: 60, TotalMinutes: 480, AppliedHours: 8, BaseKm: 130, ExcessKm: 220, BaseFare: 60090, DistanceSurcharge: 22 * 630, SubTotal: 60090 + 22*630},
+			},
+			wantSubTotal:  2 * (60090 + 22*630),
+			wantOvernight: 8000,
+			wantTotalFare: 2*(60090+22*630) + 8000,
+		},
+		{
+			// 余りは前の日から配分（1日目351km・走行7時間1分）
+			name:           "2日運行・端数",
+			distanceKm:     701,
+			drivingMinutes: 841,
+			loadingMinutes: 120,
+			wantDays: []TimeFareDay{
+				{Day: 1, DistanceKm: 351, DrivingMinutes: 421, LoadingMinutes: 60, TotalMinutes: 481, AppliedHours: 8, BaseKm: 130, ExcessKm: 221, ExcessMinutes: 1, ExcessHours: 1, BaseFare: 60090, DistanceSurcharge: 22 * 630, TimeSurcharge: 4180, SubTotal: 60090 + 22*630 + 4180},
+				{Day: 2, DistanceKm: 350, DrivingMinutes: 420, LoadingMinutes: 60, TotalMinutes: 480, AppliedHours: 8, BaseKm: 130, ExcessKm: 220, BaseFare: 60090, DistanceSurcharge: 22 * 630, SubTotal: 60090 + 22*630},
+			},
+			wantSubTotal:  2*(60090+22*630) + 4180,
+			wantTotalFare: 2*(60090+22*630) + 4180,
+		},
+		{
+			// 1日の拘束時間の上限を10時間にすると、総作業12時間でも2日
+			name:           "上限の指定",
+			distanceKm:     300,
+			drivingMinutes: 600,
+			loadingMinutes: 120,
+			opts:           []FareOption{WithDailyWorkingLimit(600), WithOvernightCost(5000)},
+			wantDays: []TimeFareDay{
+				{Day: 1, DistanceKm: 150, DrivingMinutes: 300, LoadingMinutes: 60, TotalMinutes: 360, AppliedHours: 8, BaseKm: 130, ExcessKm: 20, BaseFare: 60090, DistanceSurcharge: 2 * 630, SubTotal: 60090 + 2*630},
+				{Day: 2, DistanceKm: 150, DrivingMinutes: 300, LoadingMinutes: 60, TotalMinutes: 360, AppliedHours: 8, BaseKm: 130, ExcessKm: 20, BaseFare: 60090, DistanceSurcharge: 2 * 630, SubTotal: 60090 + 2*630},
+			},
+			wantSubTotal:  2 * (60090 + 2*630),
+			wantOvernight: 5000,
+			wantTotalFare: 2*(60090+2*630) + 5000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.Calculate(3, 3, tt.distanceKm, tt.drivingMinutes, tt.loadingMinutes, false, false, false, tt.opts...)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if len(result.Days) != len(tt.wantDays) {
+				t.Fatalf("Days = %+v, want %d日", result.Days, len(tt.wantDays))
+			}
+			for i, want := range tt.wantDays {
+				if result.Days[i] != want {
+					t.Errorf("Days[%d] = %+v, want %+v", i, result.Days[i], want)
+				}
+			}
+			if result.IsMultiDay() != (len(tt.wantDays) > 1) {
+				t.Errorf("IsMultiDay() = %v", result.IsMultiDay())
+			}
+			if result.SubTotal != tt.wantSubTotal {
+				t.Errorf("SubTotal = %d, want %d", result.SubTotal, tt.wantSubTotal)
+			}
+			if result.OvernightCharge != tt.wantOvernight || result.Nights != max(0, len(tt.wantDays)-1) {
+				t.Errorf("OvernightCharge = %d（%d泊）, want %d", result.OvernightCharge, result.Nights, tt.wantOvernight)
+			}
+			if result.TotalFare != tt.wantTotalFare {
+				t.Errorf("TotalFare = %d, want %d", result.TotalFare, tt.wantTotalFare)
+			}
+		})
+	}
+}
+
+func TestTimeFareResult_Breakdown_MultiDay(t *testing.T) {
+	svc := NewTimeFareService(&MockTimeFareGetter{})
+	result, err := svc.Calculate(3, 3, 700, 840, 120, false, false, false, WithOvernightCost(8000))
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	breakdown := result.Breakdown()
+	for _, want := range []string{
+		"適用制度: 2日運行（1日の拘束時間13時間0分で分割、日ごとに時間制を適用）",
+		"1日目: 350km・作業8時間0分（8時間制）: 基礎額60090円 + 距離超過13860円 + 時間超過0円 = 73950円",
+		"宿泊費: +8000円（8000円 × 1泊）",
+	} {
+		if !strings.Contains(breakdown, want) {
+			t.Errorf("Breakdown() に %q が含まれない:\n%s", want, breakdown)
+		}
+	}
+}
+
+func TestWorkingDays(t *testing.T) {
+	tests := []struct {
+		totalMinutes int
+		dailyLimit   int
+		want         int
+	}{
+		{600, 0, 1},
+		{780, 0, 1},
+		{781, 0, 2},
+		{1560, 0, 2},
+		{1561, 0, 3},
+		{601, 600, 2},
+	}
+	for _, tt := range tests {
+		if got := WorkingDays(tt.totalMinutes, tt.dailyLimit); got != tt.want {
+			t.Errorf("WorkingDays(%d, %d) = %d, want %d", tt.totalMinutes, tt.dailyLimit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import "fmt"
+
+// RouteLegsResult 経由地を含むルートの取得結果（区間ごと）
+type RouteLegsResult struct {
+	Legs      []*RouteResult // 区間ごとの取得結果（出発地から順）
+	FromCache bool           // 全区間をキャッシュから取得したか（falseの場合はAPIを1回呼び出した）
+}
+
+// CountsApiUsage Google Maps APIの使用量に数えるか（キャッシュ・セルフホストのエンジンから取得した場合は数えない）
+func (r *RouteLegsResult) CountsApiUsage() bool {
+	for _, leg := range r.Legs {
+		if leg.CountsApiUsage() {
+			return true
+		}
+	}
+	return false
+}
+
+// RouteLegs 区間ごとの取得結果を運賃計算用の区間に変換する
+func (r *RouteLegsResult) RouteLegs() []RouteLeg {
+	legs := make([]RouteLeg, len(r.Legs))
+	for i, leg := range r.Legs {
+		legs[i] = RouteLeg{
+			Origin:      leg.Route.Origin,
+			Dest:        leg.Route.Dest,
+			DistanceKm:  leg.Route.DistanceKm,
+			DurationMin: leg.Route.DurationMin,
+			FromCache:   leg.FromCache,
+		}
+	}
+	return legs
+}
+
+// RouteLeg 経由地を含むルートの区間
+type RouteLeg struct {
+	Origin      string  // 区間の出発地
+	Dest        string  // 区間の到着地
+	DistanceKm  float64 // 距離（km）
+	DurationMin int     // 走行時間（分）
+	FromCache   bool    // キャッシュから取得したか
+}
+
+// MultiStopRoute 経由地を含むルート（A→B→Cのような巡回配送）
+type MultiStopRoute struct {
+	Waypoints          []string   // 経由地（訪問順）
+	Legs               []RouteLeg // 区間ごとの距離・走行時間（手入力時は空）
+	StopLoadingMinutes int        // 経由地1か所あたりの荷役時間（分）
+}
+
+// StopCount 経由地の数
+func (r *MultiStopRoute) StopCount() int {
+	return len(r.Waypoints)
+}
+
+// HasLegs 区間ごとの距離・走行時間があるか
+func (r *MultiStopRoute) HasLegs() bool {
+	return len(r.Legs) > 0
+}
+
+// TotalDistanceKm 全区間の合計距離（km）
+func (r *MultiStopRoute) TotalDistanceKm() float64 {
+	total := 0.0
+	for _, leg := range r.Legs {
+		total += leg.DistanceKm
+	}
+	return total
+}
+
+// TotalDurationMin 全区間の合計走行時間（分）
+func (r *MultiStopRoute) TotalDurationMin() int {
+	total := 0
+	for _, leg := range r.Legs {
+		total += leg.DurationMin
+	}
+	return total
+}
+
+// TotalStopLoadingMinutes 経由地での荷役時間の合計（分）
+func (r *MultiStopRoute) TotalStopLoadingMinutes() int {
+	return r.StopCount() * r.StopLoadingMinutes
+}
+
+// Breakdown 計算根拠を文字列で返す
+func (r *MultiStopRoute) Breakdown() string {
+	result := fmt.Sprintf("【経由ルート】（経由地%dか所）\n", r.StopCount())
+	if r.HasLegs() {
+		for i, leg := range r.Legs {
+			result += fmt.Sprintf("  区間%d: %s → %s  %.1fkm / %d分\n", i+1, leg.Origin, leg.Dest, leg.DistanceKm, leg.DurationMin)
+		}
+		result += fmt.Sprintf("  合計: %.1fkm / %d分\n", r.TotalDistanceKm(), r.TotalDurationMin())
+	} else {
+		for i, w := range r.Waypoints {
+			result += fmt.Sprintf("  経由地%d: %s\n", i+1, w)
+		}
+	}
+	result += fmt.Sprintf("  経由地の荷役時間: %d分 × %dか所 = %d分\n", r.StopLoadingMinutes, r.StopCount(), r.TotalStopLoadingMinutes())
+	return result
+}
@@ -0,0 +1,96 @@
+package service
+
+import "fmt"
+
+// PerKmRateStrategy キロ単価による契約運賃（運賃計算方式）
+// 距離 × キロ単価（最低運賃あり、税抜）で計算し、標準的な運賃と同じランキングで比較する
+type PerKmRateStrategy struct {
+	name         string
+	ratePerKm    int   // キロ単価（円/km、税抜）
+	minimumFare  int   // 最低運賃（円、税抜）
+	vehicleCodes []int // 対応車格コード（空は全車格）
+}
+
+// NewPerKmRateStrategy 新しいPerKmRateStrategyを作成
+func NewPerKmRateStrategy(name string, ratePerKm, minimumFare int, vehicleCodes ...int) (*PerKmRateStrategy, error) {
+	if name == "" {
+		return nil, fmt.Errorf("運賃タイプを指定してください")
+	}
+	if ratePerKm <= 0 {
+		return nil, fmt.Errorf("無効なキロ単価: %d（1円以上を指定）", ratePerKm)
+	}
+	if minimumFare < 0 {
+		return nil, fmt.Errorf("無効な最低運賃: %d（0円以上を指定）", minimumFare)
+	}
+	return &PerKmRateStrategy{
+		name:         name,
+		ratePerKm:    ratePerKm,
+		minimumFare:  minimumFare,
+		vehicleCodes: vehicleCodes,
+	}, nil
+}
+
+// Name 運賃タイプ
+func (s *PerKmRateStrategy) Name() string {
+	return s.name
+}
+
+// Supports 車格コードに対応しているか
+func (s *PerKmRateStrategy) Supports(vehicleCode int) bool {
+	if len(s.vehicleCodes) == 0 {
+		return true
+	}
+	for _, code := range s.vehicleCodes {
+		if code == vehicleCode {
+			return true
+		}
+	}
+	return false
+}
+
+// PerKmRateResult キロ単価契約運賃の計算結果
+type PerKmRateResult struct {
+	Name        string // 運賃タイプ
+	DistanceKm  int    // 距離（km）
+	RatePerKm   int    // キロ単価（円/km）
+	MinimumFare int    // 最低運賃（円）
+	KmFare      int    // 距離 × キロ単価（円）
+	TotalFare   int    // 合計運賃（円、税抜）
+
+	// 消費税
+	Tax *TaxAmount
+}
+
+// Calculate キロ単価契約運賃を計算する
+func (s *PerKmRateStrategy) Calculate(ctx *FareContext) (*FareStrategyResult, error) {
+	distanceKm := ctx.Request.DistanceKm
+	if distanceKm < 1 {
+		return nil, fmt.Errorf("%s計算エラー: 無効な距離: %d（1km以上を指定）", s.name, distanceKm)
+	}
+
+	kmFare := distanceKm * s.ratePerKm
+	result := &PerKmRateResult{
+		Name:        s.name,
+		DistanceKm:  distanceKm,
+		RatePerKm:   s.ratePerKm,
+		MinimumFare: s.minimumFare,
+		KmFare:      kmFare,
+		TotalFare:   max(kmFare, s.minimumFare),
+	}
+	result.Tax = taxAmountPtr(ctx.Tax.FromExclusive(result.TotalFare))
+	return &FareStrategyResult{Name: s.name, Tax: result.Tax, Detail: result}, nil
+}
+
+// Breakdown 計算根拠を文字列で返す
+func (r *PerKmRateResult) Breakdown() string {
+	result := fmt.Sprintf("【%s】\n", r.Name)
+	result += fmt.Sprintf("  距離: %dkm × キロ単価 %d円 = %d円\n", r.DistanceKm, r.RatePerKm, r.KmFare)
+	if r.MinimumFare > r.KmFare {
+		result += fmt.Sprintf("  最低運賃: %d円を適用\n", r.MinimumFare)
+	}
+	result += fmt.Sprintf("  合計運賃: %d円\n", r.TotalFare)
+	if r.Tax != nil {
+		result += r.Tax.Breakdown()
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package service
+
+import "testing"
+
+// TestNewPerKmRateStrategy キロ単価契約の入力チェックテスト
+func TestNewPerKmRateStrategy(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    string
+		ratePerKm   int
+		minimumFare int
+		wantErr     bool
+	}{
+		{"正常", "キロ単価契約", 300, 20000, false},
+		{"最低運賃なし", "キロ単価契約", 300, 0, false},
+		{"運賃タイプ未指定", "", 300, 0, true},
+		{"キロ単価0円", "キロ単価契約", 0, 0, true},
+		{"最低運賃がマイナス", "キロ単価契約", 300, -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPerKmRateStrategy(tt.strategy, tt.ratePerKm, tt.minimumFare)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPerKmRateStrategy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPerKmRateStrategy_Supports 対応車格のテスト
+func TestPerKmRateStrategy_Supports(t *testing.T) {
+	all, _ := NewPerKmRateStrategy("全車格", 300, 0)
+	truck, _ := NewPerKmRateStrategy("トラック", 300, 0, 1, 2, 3, 4)
+
+	if !all.Supports(VehicleCodeLight) || !all.Supports(3) {
+		t.Error("車格未指定の場合は全車格に対応すること")
+	}
+	if truck.Supports(VehicleCodeLight) || !truck.Supports(4) {
+		t.Error("指定した車格のみに対応すること")
+	}
+}
+
+// TestPerKmRateStrategy_Calculate キロ単価契約運賃の計算テスト
+func TestPerKmRateStrategy_Calculate(t *testing.T) {
+	strategy, err := NewPerKmRateStrategy("キロ単価契約", 300, 20000)
+	if err != nil {
+		t.Fatalf("NewPerKmRateStrategy failed: %v", err)
+	}
+	tax, _ := NewTaxCalculator(10, DefaultTaxRounding)
+
+	tests := []struct {
+		name       string
+		distanceKm int
+		wantFare   int
+	}{
+		{"距離 × キロ単価", 100, 30000},
+		{"最低運賃を適用", 50, 20000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &FareContext{Request: &FareCalculationRequest{DistanceKm: tt.distanceKm}, Tax: tax}
+			r, err := strategy.Calculate(ctx)
+			if err != nil {
+				t.Fatalf("Calculate failed: %v", err)
+			}
+			if r.Tax.Exclusive != tt.wantFare {
+				t.Errorf("Tax.Exclusive = %d, want %d", r.Tax.Exclusive, tt.wantFare)
+			}
+			if r.Tax.Inclusive != tt.wantFare*110/100 {
+				t.Errorf("Tax.Inclusive = %d, want %d", r.Tax.Inclusive, tt.wantFare*110/100)
+			}
+		})
+	}
+
+	if _, err := strategy.Calculate(&FareContext{Request: &FareCalculationRequest{}, Tax: tax}); err == nil {
+		t.Error("距離0kmでエラーが発生しなかった")
+	}
+}
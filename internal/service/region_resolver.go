@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"slices"
 	"strings"
 )
 
@@ -138,3 +139,22 @@ func ResolveAkabouArea(address string) string {
 
 	return ""
 }
+
+// ResolveAkabouAreaFromComponents 住所の構成要素（都道府県・市区町村）から赤帽地区を判定
+// 郵便番号データやGeocoding APIで都道府県・市区町村が分かっている場合はそれを使い、分からない場合は住所の文字列で判定する
+func ResolveAkabouAreaFromComponents(components *AddressComponents) string {
+	if components == nil {
+		return ""
+	}
+	if components.Prefecture == "" || components.City == "" {
+		return ResolveAkabouArea(components.Address)
+	}
+
+	switch {
+	case components.Prefecture == "東京都" && slices.Contains(tokyo23Wards, components.City):
+		return "東京23区"
+	case components.Prefecture == "大阪府" && strings.HasPrefix(components.City, "大阪市"):
+		return "大阪市内"
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RouteOptions ルート検索の条件（有料道路・高速道路の回避、車両の寸法・重量、危険物積載）
+// 対応する条件はクライアントごとに異なる（Google: 回避のみ、OSRM: 回避のみ、Valhalla: すべて）
+type RouteOptions struct {
+	AvoidTolls    bool    // 有料道路を避ける
+	AvoidHighways bool    // 高速道路を避ける
+	HeightM       float64 // 車高（m、0は指定なし）
+	WidthM        float64 // 車幅（m、0は指定なし）
+	LengthM       float64 // 車長（m、0は指定なし）
+	WeightT       float64 // 車両総重量（t、0は指定なし）
+	Hazmat        bool    // 危険物積載
+}
+
+// vehicleRouteOptions 車格ごとの標準的な寸法・車両総重量
+// 大型車・トレーラーは車両制限令の一般的制限値（高さ3.8m・幅2.5m）を上限とする
+var vehicleRouteOptions = map[int]RouteOptions{
+	VehicleCodeLight: {HeightM: 2.0, WidthM: 1.48, LengthM: 3.4, WeightT: 1.35}, // 軽貨物
+	1:                {HeightM: 3.0, WidthM: 1.9, LengthM: 4.7, WeightT: 5},     // 小型車(2t)
+	2:                {HeightM: 3.3, WidthM: 2.2, LengthM: 8.5, WeightT: 8},     // 中型車(4t)
+	3:                {HeightM: 3.8, WidthM: 2.5, LengthM: 12, WeightT: 25},     // 大型車(10t)
+	4:                {HeightM: 3.8, WidthM: 2.5, LengthM: 16.5, WeightT: 36},   // トレーラー
+}
+
+// RouteOptionsForVehicle 車格コードからルート検索の条件（寸法・車両総重量）を作成（未登録の車格は指定なし）
+func RouteOptionsForVehicle(vehicleCode int) RouteOptions {
+	return vehicleRouteOptions[vehicleCode]
+}
+
+// IsZero 条件の指定がないか（乗用車と同じルート）
+func (o RouteOptions) IsZero() bool {
+	return o == RouteOptions{}
+}
+
+// avoidanceOnly 有料道路・高速道路の回避のみの条件（寸法・重量・危険物を指定できないエンジン用）
+func (o RouteOptions) avoidanceOnly() RouteOptions {
+	return RouteOptions{AvoidTolls: o.AvoidTolls, AvoidHighways: o.AvoidHighways}
+}
+
+// IsAvoidanceOnlyKey キャッシュのキーが回避の条件のみ（または条件なし）か
+// 寸法・重量・危険物を含むキーはそれらを使用するエンジン（Valhalla）のルートにのみ使う
+func IsAvoidanceOnlyKey(key string) bool {
+	if key == "" {
+		return true
+	}
+	for _, part := range strings.Split(key, ",") {
+		if part != "avoid_tolls" && part != "avoid_highways" {
+			return false
+		}
+	}
+	return true
+}
+
+// Key キャッシュのキー（条件の指定がない場合は空文字、例: h3.8,w2.5,l12,t25,hazmat,avoid_tolls）
+func (o RouteOptions) Key() string {
+	var parts []string
+	for _, d := range []struct {
+		prefix string
+		value  float64
+	}{{"h", o.HeightM}, {"w", o.WidthM}, {"l", o.LengthM}, {"t", o.WeightT}} {
+		if d.value > 0 {
+			parts = append(parts, d.prefix+strconv.FormatFloat(d.value, 'f', -1, 64))
+		}
+	}
+	if o.Hazmat {
+		parts = append(parts, "hazmat")
+	}
+	if o.AvoidTolls {
+		parts = append(parts, "avoid_tolls")
+	}
+	if o.AvoidHighways {
+		parts = append(parts, "avoid_highways")
+	}
+	return strings.Join(parts, ",")
+}
+
+// Label 表示用ラベル（例: 車高3.8m・車幅2.5m・車長12m・総重量25t・危険物・有料道路回避）
+func (o RouteOptions) Label() string {
+	var parts []string
+	for _, d := range []struct {
+		name  string
+		value float64
+		unit  string
+	}{{"車高", o.HeightM, "m"}, {"車幅", o.WidthM, "m"}, {"車長", o.LengthM, "m"}, {"総重量", o.WeightT, "t"}} {
+		if d.value > 0 {
+			parts = append(parts, d.name+strconv.FormatFloat(d.value, 'f', -1, 64)+d.unit)
+		}
+	}
+	if o.Hazmat {
+		parts = append(parts, "危険物")
+	}
+	if o.AvoidTolls {
+		parts = append(parts, "有料道路回避")
+	}
+	if o.AvoidHighways {
+		parts = append(parts, "高速道路回避")
+	}
+	if len(parts) == 0 {
+		return "指定なし"
+	}
+	return strings.Join(parts, "・")
+}
@@ -0,0 +1,50 @@
+package service
+
+import "testing"
+
+func TestRouteOptions_Key(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      RouteOptions
+		wantKey   string
+		wantLabel string
+	}{
+		{"指定なし", RouteOptions{}, "", "指定なし"},
+		{"大型車", RouteOptionsForVehicle(3), "h3.8,w2.5,l12,t25", "車高3.8m・車幅2.5m・車長12m・総重量25t"},
+		{
+			"トレーラー・危険物・有料道路回避",
+			RouteOptions{HeightM: 3.8, WidthM: 2.5, LengthM: 16.5, WeightT: 36, Hazmat: true, AvoidTolls: true},
+			"h3.8,w2.5,l16.5,t36,hazmat,avoid_tolls",
+			"車高3.8m・車幅2.5m・車長16.5m・総重量36t・危険物・有料道路回避",
+		},
+		{"高速道路回避のみ", RouteOptions{AvoidHighways: true}, "avoid_highways", "高速道路回避"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Key(); got != tt.wantKey {
+				t.Errorf("Key() = %s, want %s", got, tt.wantKey)
+			}
+			if got := tt.opts.Label(); got != tt.wantLabel {
+				t.Errorf("Label() = %s, want %s", got, tt.wantLabel)
+			}
+			if got, want := IsAvoidanceOnlyKey(tt.opts.Key()), tt.opts == tt.opts.avoidanceOnly(); got != want {
+				t.Errorf("IsAvoidanceOnlyKey(%s) = %v, want %v", tt.opts.Key(), got, want)
+			}
+		})
+	}
+}
+
+func TestRouteOptionsForVehicle(t *testing.T) {
+	for code := VehicleCodeLight; code <= 4; code++ {
+		opts := RouteOptionsForVehicle(code)
+		if opts.IsZero() || opts.HeightM > 3.8 || opts.WidthM > 2.5 {
+			t.Errorf("車格%d = %+v", code, opts)
+		}
+	}
+	if !RouteOptionsForVehicle(99).IsZero() {
+		t.Error("未登録の車格で条件が設定される")
+	}
+	if RouteOptionsForVehicle(3).Key() == RouteOptionsForVehicle(4).Key() {
+		t.Error("大型車とトレーラーのキャッシュのキーが同じ")
+	}
+}
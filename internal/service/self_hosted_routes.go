@@ -0,0 +1,432 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// ルート情報の取得元（model.RouteCache.Source）
+const (
+	RouteSourceGoogle   = "google"   // Google Maps Routes API（API使用量の対象）
+	RouteSourceOSRM     = "osrm"     // セルフホストのOSRM
+	RouteSourceValhalla = "valhalla" // セルフホストのValhalla
+)
+
+// IsSelfHostedRouteSource 取得元がセルフホストのルーティングエンジンか（API使用量の対象外）
+func IsSelfHostedRouteSource(source string) bool {
+	return source == RouteSourceOSRM || source == RouteSourceValhalla
+}
+
+// Coordinate 緯度・経度
+type Coordinate struct {
+	Lat float64
+	Lon float64
+}
+
+// CoordinateResolver 住所を緯度・経度に変換するインターフェース
+type CoordinateResolver interface {
+	Resolve(address string) (*Coordinate, error)
+}
+
+// ParseCoordinate 「35.6812,139.7671」形式（緯度,経度）の文字列を緯度・経度に変換する
+func ParseCoordinate(s string) (*Coordinate, bool) {
+	latStr, lonStr, ok := strings.Cut(s, ",")
+	if !ok {
+		return nil, false
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, false
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(lonStr), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return nil, false
+	}
+	return &Coordinate{Lat: lat, Lon: lon}, true
+}
+
+// NominatimClient Nominatim（OpenStreetMapのジオコーダー、セルフホスト可）で住所を緯度・経度に変換するクライアント
+type NominatimClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewNominatimClient 新しいNominatimClientを作成（baseURLは http://localhost:8080 など）
+func NewNominatimClient(baseURL string) *NominatimClient {
+	return &NominatimClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Resolve 住所を緯度・経度に変換する（「緯度,経度」形式の場合はそのまま使う）
+func (c *NominatimClient) Resolve(address string) (*Coordinate, error) {
+	if coord, ok := ParseCoordinate(address); ok {
+		return coord, nil
+	}
+
+	params := url.Values{
+		"q":               {address},
+		"format":          {"jsonv2"},
+		"limit":           {"1"},
+		"countrycodes":    {"jp"},
+		"accept-language": {"ja"},
+	}
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := getSelfHostedJSON(c.httpClient, c.baseURL+"/search?"+params.Encode(), &results); err != nil {
+		return nil, fmt.Errorf("ジオコーディングエラー（%s）: %w", address, err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("住所が見つかりません: %s", address)
+	}
+	coord, ok := ParseCoordinate(results[0].Lat + "," + results[0].Lon)
+	if !ok {
+		return nil, fmt.Errorf("緯度・経度が不正です: %s,%s", results[0].Lat, results[0].Lon)
+	}
+	return coord, nil
+}
+
+// SelfHostedRoutesClient OSRM・Valhalla形式のセルフホストのルーティングサーバーを使うRouteClient
+// 住所はCoordinateResolverで緯度・経度に変換してから問い合わせる（Google Maps APIの使用量を消費しない）
+// ルート検索の条件は、Valhallaはすべて（costing truck の寸法・重量・危険物・回避）、OSRMは回避（exclude）のみ使用する
+type SelfHostedRoutesClient struct {
+	engine     string // osrm / valhalla
+	baseURL    string
+	profile    string // OSRMのプロファイル・Valhallaのcosting
+	resolver   CoordinateResolver
+	httpClient *http.Client
+}
+
+// NewSelfHostedRoutesClient 新しいSelfHostedRoutesClientを作成
+// engine は osrm（プロファイル driving）または valhalla（costing truck）
+func NewSelfHostedRoutesClient(engine, baseURL string, resolver CoordinateResolver) (*SelfHostedRoutesClient, error) {
+	var profile string
+	switch engine {
+	case RouteSourceOSRM:
+		profile = "driving"
+	case RouteSourceValhalla:
+		profile = "truck"
+	default:
+		return nil, fmt.Errorf("無効なルーティングエンジン: %s（osrm / valhalla を指定してください）", engine)
+	}
+	if baseURL == "" {
+		return nil, errors.New("ルーティングサーバーのURLが設定されていません")
+	}
+	if resolver == nil {
+		return nil, errors.New("住所を緯度・経度に変換するジオコーダーが設定されていません")
+	}
+	return &SelfHostedRoutesClient{
+		engine:   engine,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		profile:  profile,
+		resolver: resolver,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetRoute セルフホストのルーティングサーバーでルート情報を取得
+func (c *SelfHostedRoutesClient) GetRoute(origin, dest string, opts RouteOptions) (*model.RouteCache, error) {
+	if err := validateRouteInput(origin, dest); err != nil {
+		return nil, err
+	}
+	legs, err := c.GetRouteLegs([]string{origin, dest}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return legs[0], nil
+}
+
+// GetRouteLegs セルフホストのルーティングサーバーで経由地を含むルートの区間ごとの情報を取得（1回のリクエスト）
+func (c *SelfHostedRoutesClient) GetRouteLegs(points []string, opts RouteOptions) ([]*model.RouteCache, error) {
+	if err := validateRoutePoints(points); err != nil {
+		return nil, err
+	}
+
+	coords := make([]*Coordinate, len(points))
+	for i, p := range points {
+		coord, err := c.resolver.Resolve(p)
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = coord
+	}
+
+	var legs []selfHostedLeg
+	var err error
+	if c.engine == RouteSourceValhalla {
+		legs, err = c.valhallaRoute(coords, opts)
+	} else {
+		legs, err = c.osrmRoute(coords, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(legs) != len(points)-1 {
+		return nil, fmt.Errorf("区間数が一致しません（地点%d件に対して区間%d件）", len(points), len(legs))
+	}
+
+	now := time.Now()
+	routes := make([]*model.RouteCache, len(legs))
+	for i, leg := range legs {
+		routes[i] = &model.RouteCache{
+			Origin:      points[i],
+			Dest:        points[i+1],
+			DistanceKm:  leg.distanceKm,
+			DurationMin: int(leg.durationSec) / 60,
+			OptionsKey:  c.AppliedOptions(opts).Key(),
+			CreatedAt:   now,
+			Source:      c.engine,
+		}
+	}
+	return routes, nil
+}
+
+// AppliedOptions Valhallaはすべての条件、OSRMは回避のみ使用する
+func (c *SelfHostedRoutesClient) AppliedOptions(opts RouteOptions) RouteOptions {
+	if c.engine == RouteSourceValhalla {
+		return opts
+	}
+	return opts.avoidanceOnly()
+}
+
+// selfHostedLeg ルーティングサーバーの区間（距離km・所要秒）
+type selfHostedLeg struct {
+	distanceKm  float64
+	durationSec float64
+}
+
+// osrmRoute OSRMの /route/v1/{profile}/{経度,緯度;...} を呼び出す
+// 回避はプロファイルの除外クラス（toll / motorway）で指定する。寸法・重量・危険物はプロファイルで決まるため使用しない
+func (c *SelfHostedRoutesClient) osrmRoute(coords []*Coordinate, opts RouteOptions) ([]selfHostedLeg, error) {
+	locations := make([]string, len(coords))
+	for i, coord := range coords {
+		locations[i] = strconv.FormatFloat(coord.Lon, 'f', 6, 64) + "," + strconv.FormatFloat(coord.Lat, 'f', 6, 64)
+	}
+	reqURL := fmt.Sprintf("%s/route/v1/%s/%s?overview=false&steps=false", c.baseURL, c.profile, strings.Join(locations, ";"))
+	var exclude []string
+	if opts.AvoidHighways {
+		exclude = append(exclude, "motorway")
+	}
+	if opts.AvoidTolls {
+		exclude = append(exclude, "toll")
+	}
+	if len(exclude) > 0 {
+		reqURL += "&exclude=" + strings.Join(exclude, ",")
+	}
+
+	var resp struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Routes  []struct {
+			Legs []struct {
+				Distance float64 `json:"distance"` // メートル
+				Duration float64 `json:"duration"` // 秒
+			} `json:"legs"`
+		} `json:"routes"`
+	}
+	if err := getSelfHostedJSON(c.httpClient, reqURL, &resp); err != nil && resp.Code == "" {
+		return nil, fmt.Errorf("OSRM呼び出しエラー: %w", err)
+	}
+	if resp.Code != "Ok" {
+		return nil, fmt.Errorf("OSRM エラー [%s]: %s", resp.Code, resp.Message)
+	}
+	if len(resp.Routes) == 0 {
+		return nil, errors.New("ルートが見つかりません")
+	}
+
+	legs := make([]selfHostedLeg, len(resp.Routes[0].Legs))
+	for i, leg := range resp.Routes[0].Legs {
+		legs[i] = selfHostedLeg{distanceKm: leg.Distance / 1000.0, durationSec: leg.Duration}
+	}
+	return legs, nil
+}
+
+// valhallaRoute Valhallaの /route を呼び出す（ルート検索の条件は costing_options で指定する）
+func (c *SelfHostedRoutesClient) valhallaRoute(coords []*Coordinate, opts RouteOptions) ([]selfHostedLeg, error) {
+	type location struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	}
+	reqBody := struct {
+		Locations         []location                        `json:"locations"`
+		Costing           string                            `json:"costing"`
+		CostingOptions    map[string]valhallaCostingOptions `json:"costing_options,omitempty"`
+		DirectionsOptions map[string]string                 `json:"directions_options"`
+	}{
+		Costing:           c.profile,
+		DirectionsOptions: map[string]string{"units": "kilometers", "directions_type": "none"},
+	}
+	if !opts.IsZero() {
+		reqBody.CostingOptions = map[string]valhallaCostingOptions{c.profile: newValhallaCostingOptions(opts)}
+	}
+	for _, coord := range coords {
+		reqBody.Locations = append(reqBody.Locations, location{Lat: coord.Lat, Lon: coord.Lon})
+	}
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("リクエストJSON作成エラー: %w", err)
+	}
+
+	httpResp, err := c.httpClient.Post(c.baseURL+"/route", "application/json", strings.NewReader(string(jsonBody)))
+	if err != nil {
+		return nil, fmt.Errorf("Valhalla呼び出しエラー: %w", err)
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("レスポンス読み取りエラー: %w", err)
+	}
+
+	var resp struct {
+		Trip struct {
+			Legs []struct {
+				Summary struct {
+					Length float64 `json:"length"` // km
+					Time   float64 `json:"time"`   // 秒
+				} `json:"summary"`
+			} `json:"legs"`
+		} `json:"trip"`
+		ErrorCode int    `json:"error_code"`
+		Error     string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("レスポンスJSONパースエラー: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("Valhalla エラー [%d]: %s", resp.ErrorCode, resp.Error)
+	}
+	if len(resp.Trip.Legs) == 0 {
+		return nil, errors.New("ルートが見つかりません")
+	}
+
+	legs := make([]selfHostedLeg, len(resp.Trip.Legs))
+	for i, leg := range resp.Trip.Legs {
+		legs[i] = selfHostedLeg{distanceKm: leg.Summary.Length, durationSec: leg.Summary.Time}
+	}
+	return legs, nil
+}
+
+// valhallaCostingOptions Valhallaの costing_options（寸法はm、重量はt、use_* は0〜1で0は使用しない）
+type valhallaCostingOptions struct {
+	Height      float64  `json:"height,omitempty"`
+	Width       float64  `json:"width,omitempty"`
+	Length      float64  `json:"length,omitempty"`
+	Weight      float64  `json:"weight,omitempty"`
+	Hazmat      bool     `json:"hazmat,omitempty"`
+	UseTolls    *float64 `json:"use_tolls,omitempty"`
+	UseHighways *float64 `json:"use_highways,omitempty"`
+}
+
+// newValhallaCostingOptions ルート検索の条件をValhallaの costing_options に変換する
+func newValhallaCostingOptions(opts RouteOptions) valhallaCostingOptions {
+	costing := valhallaCostingOptions{
+		Height: opts.HeightM,
+		Width:  opts.WidthM,
+		Length: opts.LengthM,
+		Weight: opts.WeightT,
+		Hazmat: opts.Hazmat,
+	}
+	avoid := 0.0
+	if opts.AvoidTolls {
+		costing.UseTolls = &avoid
+	}
+	if opts.AvoidHighways {
+		costing.UseHighways = &avoid
+	}
+	return costing
+}
+
+// getSelfHostedJSON GETリクエストのJSONレスポンスをデコードする
+// HTTPエラーでも本文がJSONの場合はデコードしたうえでエラーを返す（エラー内容はレスポンスから判定する）
+func getSelfHostedJSON(httpClient *http.Client, reqURL string, v any) error {
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("HTTPリクエスト作成エラー: %w", err)
+	}
+	req.Header.Set("User-Agent", "standard-truck-rate")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("レスポンス読み取りエラー: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("レスポンスJSONパースエラー（HTTP %d）: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// FallbackRouteClient Google Maps APIの使用量が上限に達した場合にセルフホストのルーティングエンジンへ切り替えるRouteClient
+// 上限の判定は ApiUsageService（月間の使用量）と、Google側の上限超過エラー（ErrApiLimitExceeded）の両方で行う
+type FallbackRouteClient struct {
+	primary  RouteClient
+	fallback RouteClient
+	usage    *ApiUsageService
+}
+
+// NewFallbackRouteClient 新しいFallbackRouteClientを作成（usageがnilの場合はGoogle側のエラーでのみ切り替える）
+func NewFallbackRouteClient(primary, fallback RouteClient, usage *ApiUsageService) *FallbackRouteClient {
+	return &FallbackRouteClient{primary: primary, fallback: fallback, usage: usage}
+}
+
+// GetRoute ルート情報を取得（上限超過時はセルフホストのエンジンを使用）
+func (c *FallbackRouteClient) GetRoute(origin, dest string, opts RouteOptions) (*model.RouteCache, error) {
+	if c.primaryAvailable() {
+		route, err := c.primary.GetRoute(origin, dest, opts)
+		if !errors.Is(err, ErrApiLimitExceeded) {
+			return route, err
+		}
+	}
+	return c.fallback.GetRoute(origin, dest, opts)
+}
+
+// GetRouteLegs 経由地を含むルートの区間ごとの情報を取得（上限超過時はセルフホストのエンジンを使用）
+func (c *FallbackRouteClient) GetRouteLegs(points []string, opts RouteOptions) ([]*model.RouteCache, error) {
+	if c.primaryAvailable() {
+		routes, err := c.primary.GetRouteLegs(points, opts)
+		if !errors.Is(err, ErrApiLimitExceeded) {
+			return routes, err
+		}
+	}
+	return c.fallback.GetRouteLegs(points, opts)
+}
+
+// AppliedOptions 使用するエンジン（上限到達後はセルフホストのエンジン）が実際に使用する条件
+// 呼び出し中にGoogle側の割り当て超過で切り替えた場合も、取得したルートの OptionsKey は応答したエンジンの条件になる
+func (c *FallbackRouteClient) AppliedOptions(opts RouteOptions) RouteOptions {
+	if c.primaryAvailable() {
+		return c.primary.AppliedOptions(opts)
+	}
+	return c.fallback.AppliedOptions(opts)
+}
+
+// primaryAvailable 月間の使用量が上限に達していないか（使用量を取得できない場合はGoogleを使う）
+func (c *FallbackRouteClient) primaryAvailable() bool {
+	if c.usage == nil {
+		return true
+	}
+	return !errors.Is(c.usage.CheckLimit(), ErrApiLimitExceeded)
+}
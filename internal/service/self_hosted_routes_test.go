@@ -0,0 +1,367 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// newFakeNominatimServer 住所ごとの緯度・経度を返すNominatim互換のテストサーバー
+func newFakeNominatimServer(t *testing.T, coords map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" || r.URL.Query().Get("countrycodes") != "jp" {
+			t.Errorf("リクエスト = %s", r.URL)
+		}
+		coord, ok := coords[r.URL.Query().Get("q")]
+		if !ok {
+			w.Write([]byte(`[]`))
+			return
+		}
+		lat, lon, _ := strings.Cut(coord, ",")
+		w.Write([]byte(`[{"lat":"` + lat + `","lon":"` + lon + `"}]`))
+	}))
+}
+
+func TestNominatimClient_Resolve(t *testing.T) {
+	server := newFakeNominatimServer(t, map[string]string{"東京都千代田区": "35.6940,139.7536"})
+	defer server.Close()
+	client := NewNominatimClient(server.URL + "/")
+
+	tests := []struct {
+		name    string
+		address string
+		want    Coordinate
+		wantErr bool
+	}{
+		{"住所", "東京都千代田区", Coordinate{Lat: 35.6940, Lon: 139.7536}, false},
+		{"緯度,経度はそのまま使う", "34.6937, 135.5023", Coordinate{Lat: 34.6937, Lon: 135.5023}, false},
+		{"見つからない住所", "存在しない住所", Coordinate{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Resolve(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSelfHostedRoutesClient_Validation(t *testing.T) {
+	resolver := NewNominatimClient("http://localhost")
+	if _, err := NewSelfHostedRoutesClient("graphhopper", "http://localhost", resolver); err == nil {
+		t.Error("未対応のエンジンでエラーにならない")
+	}
+	if _, err := NewSelfHostedRoutesClient(RouteSourceOSRM, "", resolver); err == nil {
+		t.Error("URL未設定でエラーにならない")
+	}
+	if _, err := NewSelfHostedRoutesClient(RouteSourceValhalla, "http://localhost", nil); err == nil {
+		t.Error("ジオコーダー未設定でエラーにならない")
+	}
+}
+
+// TestSelfHostedRoutesClient_OSRM 経度,緯度をセミコロン区切りで送信し、区間ごとの距離（m）・所要時間（秒）を変換すること
+func TestSelfHostedRoutesClient_OSRM(t *testing.T) {
+	geocoder := newFakeNominatimServer(t, map[string]string{
+		"東京都千代田区": "35.6940,139.7536",
+		"神奈川県横浜市": "35.4437,139.6380",
+	})
+	defer geocoder.Close()
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if strings.Contains(r.URL.Path, "0.000000") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"NoRoute","message":"Impossible route between points"}`))
+			return
+		}
+		w.Write([]byte(`{"code":"Ok","routes":[{"legs":[{"distance":30500,"duration":3000},{"distance":29800,"duration":2950}]}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewSelfHostedRoutesClient(RouteSourceOSRM, server.URL, NewNominatimClient(geocoder.URL))
+	if err != nil {
+		t.Fatalf("NewSelfHostedRoutesClient() error = %v", err)
+	}
+
+	legs, err := client.GetRouteLegs([]string{"東京都千代田区", "神奈川県横浜市", "35.6940,139.7536"}, RouteOptions{})
+	if err != nil {
+		t.Fatalf("GetRouteLegs() error = %v", err)
+	}
+	wantPath := "/route/v1/driving/139.753600,35.694000;139.638000,35.443700;139.753600,35.694000"
+	if gotPath != wantPath {
+		t.Errorf("path = %s, want %s", gotPath, wantPath)
+	}
+	if len(legs) != 2 || legs[0].DistanceKm != 30.5 || legs[0].DurationMin != 50 || legs[1].DurationMin != 49 {
+		t.Fatalf("legs = %+v", legs)
+	}
+	if legs[1].Origin != "神奈川県横浜市" || legs[1].Source != RouteSourceOSRM {
+		t.Errorf("区間2 = %+v", legs[1])
+	}
+
+	if _, err := client.GetRoute("東京都千代田区", "0,0", RouteOptions{}); err == nil || !strings.Contains(err.Error(), "NoRoute") {
+		t.Errorf("GetRoute() error = %v, want NoRoute", err)
+	}
+}
+
+// TestSelfHostedRoutesClient_Valhalla 地点をlocationsとしてPOSTし、区間ごとの距離（km）・所要時間（秒）を変換すること
+func TestSelfHostedRoutesClient_Valhalla(t *testing.T) {
+	var got struct {
+		Locations []struct {
+			Lat float64 `json:"lat"`
+			Lon float64 `json:"lon"`
+		} `json:"locations"`
+		Costing string `json:"costing"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/route" {
+			t.Errorf("リクエスト = %s %s", r.Method, r.URL)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		if got.Locations[0].Lat == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error_code":442,"error":"No path could be found for input"}`))
+			return
+		}
+		w.Write([]byte(`{"trip":{"legs":[{"summary":{"length":503.2,"time":21000}}]}}`))
+	}))
+	defer server.Close()
+
+	// 緯度,経度の入力はジオコーダーに問い合わせない
+	client, err := NewSelfHostedRoutesClient(RouteSourceValhalla, server.URL, NewNominatimClient("http://127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("NewSelfHostedRoutesClient() error = %v", err)
+	}
+
+	route, err := client.GetRoute("35.6812,139.7671", "34.7025,135.4959", RouteOptions{})
+	if err != nil {
+		t.Fatalf("GetRoute() error = %v", err)
+	}
+	if got.Costing != "truck" || len(got.Locations) != 2 || got.Locations[1].Lon != 135.4959 {
+		t.Errorf("request = %+v", got)
+	}
+	if route.DistanceKm != 503.2 || route.DurationMin != 350 || route.Source != RouteSourceValhalla {
+		t.Errorf("route = %+v", route)
+	}
+
+	if _, err := client.GetRoute("0,0", "34.7025,135.4959", RouteOptions{}); err == nil || !strings.Contains(err.Error(), "442") {
+		t.Errorf("GetRoute() error = %v, want 442", err)
+	}
+}
+
+// TestSelfHostedRoutesClient_RouteOptions ルート検索の条件をValhallaはcosting_options、OSRMはexcludeで送信すること
+func TestSelfHostedRoutesClient_RouteOptions(t *testing.T) {
+	opts := RouteOptionsForVehicle(4)
+	opts.Hazmat = true
+	opts.AvoidTolls = true
+
+	var costing map[string]map[string]any
+	valhalla := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			CostingOptions map[string]map[string]any `json:"costing_options"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		costing = body.CostingOptions
+		w.Write([]byte(`{"trip":{"legs":[{"summary":{"length":503.2,"time":21000}}]}}`))
+	}))
+	defer valhalla.Close()
+
+	client, err := NewSelfHostedRoutesClient(RouteSourceValhalla, valhalla.URL, NewNominatimClient("http://127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("NewSelfHostedRoutesClient() error = %v", err)
+	}
+	if _, err := client.GetRoute("35.6812,139.7671", "34.7025,135.4959", opts); err != nil {
+		t.Fatalf("GetRoute() error = %v", err)
+	}
+	truck := costing["truck"]
+	if truck["height"] != 3.8 || truck["length"] != 16.5 || truck["weight"] != 36.0 || truck["hazmat"] != true {
+		t.Errorf("costing_options = %+v", costing)
+	}
+	if truck["use_tolls"] != 0.0 {
+		t.Errorf("use_tolls = %v, want 0", truck["use_tolls"])
+	}
+	if _, ok := truck["use_highways"]; ok {
+		t.Errorf("高速道路を回避しないのに use_highways を送信しました: %+v", truck)
+	}
+
+	var exclude string
+	osrm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exclude = r.URL.Query().Get("exclude")
+		w.Write([]byte(`{"code":"Ok","routes":[{"legs":[{"distance":30500,"duration":3000}]}]}`))
+	}))
+	defer osrm.Close()
+
+	client, err = NewSelfHostedRoutesClient(RouteSourceOSRM, osrm.URL, NewNominatimClient("http://127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("NewSelfHostedRoutesClient() error = %v", err)
+	}
+	opts.AvoidHighways = true
+	if _, err := client.GetRoute("35.6812,139.7671", "34.7025,135.4959", opts); err != nil {
+		t.Fatalf("GetRoute() error = %v", err)
+	}
+	if exclude != "motorway,toll" {
+		t.Errorf("exclude = %s, want motorway,toll", exclude)
+	}
+}
+
+// stubRouteClient 呼び出し回数を記録するテスト用RouteClient
+type stubRouteClient struct {
+	source string
+	err    error
+	calls  int
+}
+
+func (c *stubRouteClient) GetRoute(origin, dest string, opts RouteOptions) (*model.RouteCache, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &model.RouteCache{Origin: origin, Dest: dest, DistanceKm: 10, DurationMin: 20, OptionsKey: c.AppliedOptions(opts).Key(), Source: c.source}, nil
+}
+
+// AppliedOptions Valhallaはすべての条件、Google・OSRMは回避のみ使用する
+func (c *stubRouteClient) AppliedOptions(opts RouteOptions) RouteOptions {
+	if c.source == RouteSourceValhalla {
+		return opts
+	}
+	return opts.avoidanceOnly()
+}
+
+func (c *stubRouteClient) GetRouteLegs(points []string, opts RouteOptions) ([]*model.RouteCache, error) {
+	route, err := c.GetRoute(points[0], points[len(points)-1], opts)
+	if err != nil {
+		return nil, err
+	}
+	return []*model.RouteCache{route}, nil
+}
+
+func TestFallbackRouteClient(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestCount int
+		primaryErr   error
+		wantSource   string
+		wantPrimary  int
+		wantErr      bool
+	}{
+		{name: "上限未満はGoogleを使用", requestCount: 100, wantSource: RouteSourceGoogle, wantPrimary: 1},
+		{name: "月間の上限到達後はGoogleを呼び出さない", requestCount: 9000, wantSource: RouteSourceOSRM, wantPrimary: 0},
+		{name: "Google側の割り当て超過で切り替え", requestCount: 100, primaryErr: ErrApiLimitExceeded, wantSource: RouteSourceOSRM, wantPrimary: 1},
+		{name: "その他のエラーは切り替えない", requestCount: 100, primaryErr: errors.New("ルートが見つかりません"), wantPrimary: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &stubRouteClient{source: RouteSourceGoogle, err: tt.primaryErr}
+			fallback := &stubRouteClient{source: RouteSourceOSRM}
+			usage := NewApiUsageService(newMockApiUsageRepository(tt.requestCount, 9000))
+			client := NewFallbackRouteClient(primary, fallback, usage)
+
+			route, err := client.GetRoute("東京都千代田区", "神奈川県横浜市", RouteOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRoute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && route.Source != tt.wantSource {
+				t.Errorf("Source = %s, want %s", route.Source, tt.wantSource)
+			}
+			if primary.calls != tt.wantPrimary {
+				t.Errorf("Googleの呼び出し = %d回, want %d回", primary.calls, tt.wantPrimary)
+			}
+
+			legs, err := client.GetRouteLegs([]string{"東京都千代田区", "神奈川県横浜市"}, RouteOptions{})
+			if err == nil && legs[0].Source != tt.wantSource {
+				t.Errorf("GetRouteLegs() Source = %s, want %s", legs[0].Source, tt.wantSource)
+			}
+		})
+	}
+}
+
+// TestCachedRouteService_AppliedOptions キャッシュのキーを応答したエンジンが使用した条件にし、乗用車のルートをトラックのルートとして返さないこと
+func TestCachedRouteService_AppliedOptions(t *testing.T) {
+	truck := RouteOptionsForVehicle(3)
+	truck.AvoidTolls = true
+
+	// Google（回避のみ）のルートは回避の条件のみのキーで保存する
+	repo := newMockRouteCacheRepository()
+	google := &stubRouteClient{source: RouteSourceGoogle}
+	service := NewCachedRouteService(google, repo, 0)
+	result, err := service.GetRoute("東京都千代田区", "神奈川県横浜市", truck)
+	if err != nil {
+		t.Fatalf("GetRoute() error = %v", err)
+	}
+	if result.Route.OptionsKey != "avoid_tolls" {
+		t.Errorf("OptionsKey = %s, want avoid_tolls", result.Route.OptionsKey)
+	}
+	if result, err := service.GetRoute("東京都千代田区", "神奈川県横浜市", truck); err != nil || !result.FromCache {
+		t.Errorf("同じエンジンでキャッシュが使われません: %+v, %v", result, err)
+	}
+
+	// Valhalla（トラック）ではGoogleの乗用車のルートを使わない
+	valhalla := &stubRouteClient{source: RouteSourceValhalla}
+	result, err = NewCachedRouteService(valhalla, repo, 0).GetRoute("東京都千代田区", "神奈川県横浜市", truck)
+	if err != nil {
+		t.Fatalf("GetRoute() error = %v", err)
+	}
+	if result.FromCache || valhalla.calls != 1 || result.Route.OptionsKey != truck.Key() {
+		t.Errorf("FromCache = %v, calls = %d, OptionsKey = %s, want false, 1, %s", result.FromCache, valhalla.calls, result.Route.OptionsKey, truck.Key())
+	}
+
+	// 呼び出し中にGoogleの割り当て超過で切り替えた場合はValhallaの条件で保存する
+	repo = newMockRouteCacheRepository()
+	primary := &stubRouteClient{source: RouteSourceGoogle, err: ErrApiLimitExceeded}
+	fallback := NewFallbackRouteClient(primary, &stubRouteClient{source: RouteSourceValhalla}, nil)
+	legs, err := NewCachedRouteService(fallback, repo, 0).GetRouteLegs([]string{"東京都千代田区", "神奈川県横浜市"}, truck)
+	if err != nil {
+		t.Fatalf("GetRouteLegs() error = %v", err)
+	}
+	if legs.Legs[0].Route.Source != RouteSourceValhalla || legs.Legs[0].Route.OptionsKey != truck.Key() {
+		t.Errorf("Route = %+v, want valhalla, %s", legs.Legs[0].Route, truck.Key())
+	}
+	if _, err := repo.Get("東京都千代田区", "神奈川県横浜市", "avoid_tolls"); err == nil {
+		t.Error("Valhallaのルートを回避のみのキーで保存しました")
+	}
+}
+
+// TestGoogleRoutesClient_ResourceExhausted 割り当て超過のエラーをErrApiLimitExceededとして返すこと
+func TestGoogleRoutesClient_ResourceExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"code":429,"message":"Quota exceeded","status":"RESOURCE_EXHAUSTED"}}`))
+	}))
+	defer server.Close()
+
+	client := NewGoogleRoutesClient("test-key")
+	client.baseURL = server.URL
+	if _, err := client.GetRoute("東京都千代田区", "神奈川県横浜市", RouteOptions{}); !errors.Is(err, ErrApiLimitExceeded) {
+		t.Errorf("GetRoute() error = %v, want ErrApiLimitExceeded", err)
+	}
+}
+
+func TestRouteResult_CountsApiUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *RouteResult
+		want   bool
+	}{
+		{"Google API", &RouteResult{Route: &model.RouteCache{Source: RouteSourceGoogle}}, true},
+		{"キャッシュ", &RouteResult{Route: &model.RouteCache{}, FromCache: true}, false},
+		{"セルフホスト", &RouteResult{Route: &model.RouteCache{Source: RouteSourceValhalla}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.CountsApiUsage(); got != tt.want {
+				t.Errorf("CountsApiUsage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/y-suzuki/standard-truck-rate/internal/model"
+)
+
+// SurchargeItemGetter 割増項目取得インターフェース（テスト用にモック可能）
+// 該当する項目がない場合は sql.ErrNoRows を返す
+type SurchargeItemGetter interface {
+	GetSurchargeItem(code string) (*model.SurchargeItem, error)
+}
+
+// AppliedSurchargeItem 運賃に適用した割増項目
+type AppliedSurchargeItem struct {
+	Item   *model.SurchargeItem // 割増項目
+	Amount int                  // 割増額（円）
+}
+
+// applySurchargeItems 割増前運賃に対する割増項目ごとの割増額と合計を返す
+func applySurchargeItems(items []*model.SurchargeItem, fare int) ([]AppliedSurchargeItem, int) {
+	if len(items) == 0 {
+		return nil, 0
+	}
+	applied := make([]AppliedSurchargeItem, len(items))
+	total := 0
+	for i, item := range items {
+		amount := item.Amount(fare)
+		applied[i] = AppliedSurchargeItem{Item: item, Amount: amount}
+		total += amount
+	}
+	return applied, total
+}
+
+// surchargeItemsBreakdown 割増項目の計算根拠を文字列で返す
+func surchargeItemsBreakdown(items []AppliedSurchargeItem) string {
+	result := ""
+	for _, a := range items {
+		if a.Item.CalcType == model.SurchargeCalcTypeRate {
+			result += fmt.Sprintf("  %s: +%d円（%d%%増）\n", a.Item.Name, a.Amount, a.Item.RatePercent)
+		} else {
+			result += fmt.Sprintf("  %s: +%d円（定額）\n", a.Item.Name, a.Amount)
+		}
+	}
+	return result
+}
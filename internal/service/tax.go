@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaxRounding 消費税の端数処理
+type TaxRounding string
+
+const (
+	TaxRoundingFloor TaxRounding = "floor" // 切り捨て
+	TaxRoundingRound TaxRounding = "round" // 四捨五入
+)
+
+// 消費税のデフォルト設定
+const (
+	DefaultTaxRatePercent = 10               // 標準税率（%）
+	DefaultTaxRounding    = TaxRoundingFloor // 端数切り捨て
+)
+
+// ParseTaxRounding 文字列から端数処理を判定する（floor / round）
+func ParseTaxRounding(s string) (TaxRounding, error) {
+	switch TaxRounding(s) {
+	case TaxRoundingFloor, TaxRoundingRound:
+		return TaxRounding(s), nil
+	default:
+		return "", fmt.Errorf("無効な端数処理: %s（floor または round を指定してください）", s)
+	}
+}
+
+// Label 表示用ラベル
+func (r TaxRounding) Label() string {
+	if r == TaxRoundingRound {
+		return "四捨五入"
+	}
+	return "切り捨て"
+}
+
+// TaxCalculator 消費税計算（税率・端数処理）
+type TaxCalculator struct {
+	RatePercent int         // 税率（%）
+	Rounding    TaxRounding // 端数処理
+}
+
+// NewTaxCalculator 新しいTaxCalculatorを作成
+func NewTaxCalculator(ratePercent int, rounding TaxRounding) (*TaxCalculator, error) {
+	if ratePercent < 0 || ratePercent > 100 {
+		return nil, fmt.Errorf("無効な税率: %d%%（0-100の範囲で指定してください）", ratePercent)
+	}
+	if _, err := ParseTaxRounding(string(rounding)); err != nil {
+		return nil, err
+	}
+	return &TaxCalculator{RatePercent: ratePercent, Rounding: rounding}, nil
+}
+
+// DefaultTaxCalculator 標準税率10%・切り捨てのTaxCalculatorを返す
+func DefaultTaxCalculator() *TaxCalculator {
+	return &TaxCalculator{RatePercent: DefaultTaxRatePercent, Rounding: DefaultTaxRounding}
+}
+
+// TaxAmount 税抜・税込の金額
+type TaxAmount struct {
+	Exclusive int // 税抜（円）
+	Tax       int // 消費税額（円）
+	Inclusive int // 税込（円）
+}
+
+// FromExclusive 税抜金額から税込金額を求める
+func (t *TaxCalculator) FromExclusive(exclusive int) TaxAmount {
+	tax := t.round(float64(exclusive) * float64(t.RatePercent) / 100)
+	return TaxAmount{Exclusive: exclusive, Tax: tax, Inclusive: exclusive + tax}
+}
+
+// FromInclusive 税込金額から税抜金額を求める（内税の消費税額を端数処理）
+func (t *TaxCalculator) FromInclusive(inclusive int) TaxAmount {
+	tax := t.round(float64(inclusive) * float64(t.RatePercent) / float64(100+t.RatePercent))
+	return TaxAmount{Exclusive: inclusive - tax, Tax: tax, Inclusive: inclusive}
+}
+
+// Label 表示用ラベル（例: 10%・切り捨て）
+func (t *TaxCalculator) Label() string {
+	return fmt.Sprintf("%d%%・%s", t.RatePercent, t.Rounding.Label())
+}
+
+// round 端数処理（浮動小数点誤差を吸収してから丸める）
+func (t *TaxCalculator) round(v float64) int {
+	v = math.Round(v*1e6) / 1e6
+	if t.Rounding == TaxRoundingRound {
+		return int(math.Round(v))
+	}
+	return int(math.Floor(v))
+}
+
+// Breakdown 計算根拠の行を返す
+func (a *TaxAmount) Breakdown() string {
+	return fmt.Sprintf("  税抜: %d円 / 消費税: %d円 / 税込: %d円\n", a.Exclusive, a.Tax, a.Inclusive)
+}
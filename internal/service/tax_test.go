@@ -0,0 +1,96 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaxCalculator_FromExclusive(t *testing.T) {
+	tests := []struct {
+		name          string
+		rounding      TaxRounding
+		exclusive     int
+		wantTax       int
+		wantInclusive int
+	}{
+		{"切り捨て・端数なし", TaxRoundingFloor, 35000, 3500, 38500},
+		{"切り捨て・端数あり", TaxRoundingFloor, 12345, 1234, 13579},
+		{"四捨五入・切り上げ", TaxRoundingRound, 12345, 1235, 13580},
+		{"四捨五入・切り捨て", TaxRoundingRound, 12344, 1234, 13578},
+		{"0円", TaxRoundingFloor, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc, err := NewTaxCalculator(10, tt.rounding)
+			if err != nil {
+				t.Fatalf("NewTaxCalculator() error = %v", err)
+			}
+			got := calc.FromExclusive(tt.exclusive)
+			if got.Exclusive != tt.exclusive || got.Tax != tt.wantTax || got.Inclusive != tt.wantInclusive {
+				t.Errorf("FromExclusive(%d) = %+v, want tax=%d inclusive=%d", tt.exclusive, got, tt.wantTax, tt.wantInclusive)
+			}
+		})
+	}
+}
+
+func TestTaxCalculator_FromInclusive(t *testing.T) {
+	tests := []struct {
+		name          string
+		rounding      TaxRounding
+		inclusive     int
+		wantTax       int
+		wantExclusive int
+	}{
+		{"切り捨て・端数なし", TaxRoundingFloor, 5500, 500, 5000},
+		{"切り捨て・端数あり", TaxRoundingFloor, 12345, 1122, 11223},
+		{"四捨五入", TaxRoundingRound, 12345, 1122, 11223},
+		{"四捨五入・切り上げ", TaxRoundingRound, 12350, 1123, 11227},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc, err := NewTaxCalculator(10, tt.rounding)
+			if err != nil {
+				t.Fatalf("NewTaxCalculator() error = %v", err)
+			}
+			got := calc.FromInclusive(tt.inclusive)
+			if got.Inclusive != tt.inclusive || got.Tax != tt.wantTax || got.Exclusive != tt.wantExclusive {
+				t.Errorf("FromInclusive(%d) = %+v, want tax=%d exclusive=%d", tt.inclusive, got, tt.wantTax, tt.wantExclusive)
+			}
+		})
+	}
+}
+
+func TestNewTaxCalculator_Invalid(t *testing.T) {
+	if _, err := NewTaxCalculator(-1, TaxRoundingFloor); err == nil {
+		t.Error("負の税率でエラーが期待されたが、発生しなかった")
+	}
+	if _, err := NewTaxCalculator(101, TaxRoundingFloor); err == nil {
+		t.Error("100%超の税率でエラーが期待されたが、発生しなかった")
+	}
+	if _, err := NewTaxCalculator(10, TaxRounding("ceil")); err == nil {
+		t.Error("無効な端数処理でエラーが期待されたが、発生しなかった")
+	}
+}
+
+func TestParseTaxRounding(t *testing.T) {
+	for _, s := range []string{"floor", "round"} {
+		if _, err := ParseTaxRounding(s); err != nil {
+			t.Errorf("ParseTaxRounding(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseTaxRounding(""); err == nil {
+		t.Error("空文字でエラーが期待されたが、発生しなかった")
+	}
+}
+
+func TestTaxCalculator_Label(t *testing.T) {
+	if got := DefaultTaxCalculator().Label(); got != "10%・切り捨て" {
+		t.Errorf("Label() = %s, want 10%%・切り捨て", got)
+	}
+	calc, _ := NewTaxCalculator(8, TaxRoundingRound)
+	if got := calc.Label(); !strings.Contains(got, "8%・四捨五入") {
+		t.Errorf("Label() = %s, want 8%%・四捨五入", got)
+	}
+}
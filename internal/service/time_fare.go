@@ -7,9 +7,10 @@ import (
 )
 
 // TimeFareGetter 時間制運賃取得インターフェース（テスト用にモック可能）
+// version が nil の場合は版指定なし（版共通データ）
 type TimeFareGetter interface {
-	GetBaseFare(regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error)
-	GetSurcharge(regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error)
+	GetBaseFare(version *model.TariffVersion, regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error)
+	GetSurcharge(version *model.TariffVersion, regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error)
 }
 
 // TimeFareService 時間制運賃計算サービス
@@ -32,6 +33,7 @@ type TimeFareResult struct {
 	DistanceKm     int // 走行距離 (km)
 	DrivingMinutes int // 走行時間（分）
 	LoadingMinutes int // 荷役時間（分）
+	BreakMinutes   int // 休憩時間（分、改善基準告示の休憩を含める場合のみ）
 	TotalMinutes   int // 総作業時間（分）
 
 	// 適用制度
@@ -44,22 +46,59 @@ type TimeFareResult struct {
 	ExcessHours   int // 超過時間（時間、切り上げ）
 
 	// 運賃計算結果
-	BaseFare           int // 基礎額（円）
-	DistanceSurcharge  int // 距離超過加算額（円）
-	TimeSurcharge      int // 時間超過加算額（円）
-	SubTotal           int // 小計（割増前）
-	NightSurcharge     int // 深夜割増額（円）
-	HolidaySurcharge   int // 休日割増額（円）
-	TotalFare          int // 合計運賃（円）
+	BaseFare          int // 基礎額（円）
+	DistanceSurcharge int // 距離超過加算額（円）
+	TimeSurcharge     int // 時間超過加算額（円）
+	SubTotal          int // 小計（割増前）
+	NightSurcharge    int // 深夜割増額（円）
+	HolidaySurcharge  int // 休日割増額（円）
+	TotalFare         int // 合計運賃（円、税抜）
+
+	// 付帯料金（FareCalculatorServiceが設定、TotalFareに含む）
+	HandlingCharge int // 積込・取卸料（円）
+	WaitingCharge  int // 待機時間料（円）
+
+	// 燃料サーチャージ（FareCalculatorServiceが設定、TotalFareに含む）
+	FuelSurcharge int // 燃料サーチャージ（円）
 
 	// 割増率
 	NightRate   float64 // 深夜割増率（1.0 or 1.3）
 	HolidayRate float64 // 休日割増率（1.0 or 1.2）
 
+	// 深夜時間の内訳（nilは全体に深夜割増）
+	NightSplit *NightSplit
+
+	// 特殊車両割増（BodyTypeがnilの場合は割増なし）
+	BodyType          *model.BodyTypeSurcharge // 車体種別
+	BodyTypeSurcharge int                      // 特殊車両割増額（円）
+
+	// 割増項目（速達割増・付帯作業など、TotalFareに含む）
+	SurchargeItems      []AppliedSurchargeItem // 項目ごとの割増額
+	SurchargeItemsTotal int                    // 割増項目の合計（円）
+
 	// フラグ
 	IsNight         bool // 深夜適用
 	IsHoliday       bool // 休日適用
 	UseSimpleBaseKm bool // シンプル版基礎走行キロ使用
+
+	// 適用運賃版（nilは版指定なし）
+	TariffVersion *model.TariffVersion
+
+	// 複数日運行（総作業時間が1日の拘束時間の上限を超える場合）
+	// 基礎走行キロ・超過距離・超過時間・基礎額・加算額は日ごとの合計
+	Days              []TimeFareDay // 日ごとの内訳（nilは日帰り）
+	DailyLimitMinutes int           // 日数の判定に使った1日の拘束時間の上限（分）
+	Nights            int           // 泊数
+	OvernightCostYen  int           // 1泊あたりの宿泊費（円）
+	OvernightCharge   int           // 宿泊費（円、TotalFareに含む）
+
+	// 消費税（FareCalculatorServiceが設定、nilは未計算）
+	Tax *TaxAmount
+}
+
+// IsMultiDay 複数日運行か
+func (r *TimeFareResult) IsMultiDay() bool {
+	return len(r.Days) > 1
 }
 
 // DetermineHoursSystem 総作業時間から適用時間制を判定
@@ -86,68 +125,69 @@ func (s *TimeFareService) Calculate(
 	drivingMinutes, loadingMinutes int,
 	isNight, isHoliday bool,
 	useSimpleBaseKm bool,
+	opts ...FareOption,
 ) (*TimeFareResult, error) {
+	o := newFareOptions(opts)
+
 	// 入力値検証
 	if err := s.validateInput(regionCode, vehicleCode, distanceKm, drivingMinutes); err != nil {
 		return nil, err
 	}
 
-	// 総作業時間を計算
-	totalMinutes := drivingMinutes + loadingMinutes
-
-	// 適用時間制を判定
-	appliedHours := DetermineHoursSystem(totalMinutes)
-
-	// 基礎額を取得
-	baseFare, err := s.fareGetter.GetBaseFare(regionCode, vehicleCode, appliedHours)
-	if err != nil {
-		return nil, fmt.Errorf("基礎額取得エラー: %w", err)
-	}
+	// 総作業時間を計算（改善基準告示の休憩を含める場合は休憩時間を加算）
+	breakMinutes, minDays := o.complianceBreakMinutes()
+	totalMinutes := drivingMinutes + loadingMinutes + breakMinutes
 
 	// 距離超過加算額を取得
-	distSurcharge, err := s.fareGetter.GetSurcharge(regionCode, vehicleCode, "distance")
+	distSurcharge, err := s.fareGetter.GetSurcharge(o.tariffVersion, regionCode, vehicleCode, "distance")
 	if err != nil {
 		return nil, fmt.Errorf("距離超過加算額取得エラー: %w", err)
 	}
 
 	// 時間超過加算額を取得
-	timeSurcharge, err := s.fareGetter.GetSurcharge(regionCode, vehicleCode, "time")
+	timeSurcharge, err := s.fareGetter.GetSurcharge(o.tariffVersion, regionCode, vehicleCode, "time")
 	if err != nil {
 		return nil, fmt.Errorf("時間超過加算額取得エラー: %w", err)
 	}
 
-	// 基礎走行キロを決定
-	var baseKm int
-	if useSimpleBaseKm {
-		// シンプル版: 車格に関係なく固定値
-		if appliedHours == 4 {
-			baseKm = SimpleBaseKm4Hours
-		} else {
-			baseKm = SimpleBaseKm8Hours
+	// 1日の拘束時間の上限を超える場合は複数日運行として、距離・走行時間・荷役時間を日数で均等に分け、
+	// 日ごとに基礎額と超過加算額を計算する（改善基準告示で宿泊が必要な場合はその日数以上）
+	days := max(WorkingDays(totalMinutes, o.dailyLimitMinutes), minDays)
+	dayKm := splitEvenly(distanceKm, days)
+	dayDriving := splitEvenly(drivingMinutes, days)
+	dayLoading := splitEvenly(loadingMinutes, days)
+	dayBreak := splitEvenly(breakMinutes, days)
+
+	var dayFares []TimeFareDay
+	for i := 0; i < days; i++ {
+		day, err := s.calculateDay(o.tariffVersion, regionCode, vehicleCode, dayKm[i], dayDriving[i], dayLoading[i], dayBreak[i],
+			useSimpleBaseKm, distSurcharge.FareYen, timeSurcharge.FareYen)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		// トラ協PDF版: DBから取得した車格別の値
-		baseKm = baseFare.BaseKm
+		day.Day = i + 1
+		dayFares = append(dayFares, *day)
 	}
 
-	// 超過距離を計算（10km単位）
-	excessKm := 0
-	if distanceKm > baseKm {
-		excessKm = distanceKm - baseKm
+	// 日ごとの計算結果を合計（日帰りの場合は1日分そのもの）
+	var baseKm, excessKm, excessMinutes, excessHours int
+	var baseFare, distanceSurchargeAmount, timeSurchargeAmount int
+	for _, d := range dayFares {
+		baseKm += d.BaseKm
+		excessKm += d.ExcessKm
+		excessMinutes += d.ExcessMinutes
+		excessHours += d.ExcessHours
+		baseFare += d.BaseFare
+		distanceSurchargeAmount += d.DistanceSurcharge
+		timeSurchargeAmount += d.TimeSurcharge
 	}
-	distanceSurchargeAmount := (excessKm / 10) * distSurcharge.FareYen
-
-	// 超過時間を計算（1時間単位、切り上げ）
-	baseMinutes := appliedHours * 60
-	excessMinutes := 0
-	if totalMinutes > baseMinutes {
-		excessMinutes = totalMinutes - baseMinutes
+	appliedHours := dayFares[0].AppliedHours
+	if days == 1 {
+		dayFares = nil
 	}
-	excessHours := (excessMinutes + 59) / 60 // 切り上げ
-	timeSurchargeAmount := excessHours * timeSurcharge.FareYen
 
 	// 小計（割増前）
-	subTotal := baseFare.FareYen + distanceSurchargeAmount + timeSurchargeAmount
+	subTotal := baseFare + distanceSurchargeAmount + timeSurchargeAmount
 
 	// 割増計算
 	nightRate := 1.0
@@ -156,10 +196,14 @@ func (s *TimeFareService) Calculate(
 	holidaySurchargeAmount := 0
 	totalFare := subTotal
 
-	// 深夜割増（3割増）
+	// 特殊車両割増（冷蔵車・冷凍車など）- 割増前の小計に適用
+	bodyTypeSurcharge := o.bodyTypeSurcharge(subTotal)
+	totalFare += bodyTypeSurcharge
+
+	// 深夜割増（3割増）- 深夜時間の内訳がある場合は深夜時間の割合で按分
 	if isNight {
 		nightRate = NightSurchargeRate
-		nightSurchargeAmount = int(float64(subTotal) * (NightSurchargeRate - 1.0))
+		nightSurchargeAmount = int(float64(subTotal) * (NightSurchargeRate - 1.0) * o.nightSplit.Ratio())
 		totalFare += nightSurchargeAmount
 	}
 
@@ -170,12 +214,108 @@ func (s *TimeFareService) Calculate(
 		totalFare += holidaySurchargeAmount
 	}
 
+	// 割増項目（速達割増・付帯作業など）- 割増前の小計に対して計算し、深夜・休日割増の対象外
+	surchargeItems, surchargeItemsTotal := o.surchargeItems(subTotal)
+	totalFare += surchargeItemsTotal
+
+	// 宿泊費（複数日運行の泊数分、実費のため割増の対象外）
+	nights := days - 1
+	overnightCharge := nights * o.overnightCostYen
+	totalFare += overnightCharge
+
 	return &TimeFareResult{
-		RegionCode:        regionCode,
-		VehicleCode:       vehicleCode,
+		RegionCode:          regionCode,
+		VehicleCode:         vehicleCode,
+		DistanceKm:          distanceKm,
+		DrivingMinutes:      drivingMinutes,
+		LoadingMinutes:      loadingMinutes,
+		BreakMinutes:        breakMinutes,
+		TotalMinutes:        totalMinutes,
+		AppliedHours:        appliedHours,
+		BaseKm:              baseKm,
+		ExcessKm:            excessKm,
+		ExcessMinutes:       excessMinutes,
+		ExcessHours:         excessHours,
+		BaseFare:            baseFare,
+		DistanceSurcharge:   distanceSurchargeAmount,
+		TimeSurcharge:       timeSurchargeAmount,
+		SubTotal:            subTotal,
+		NightSurcharge:      nightSurchargeAmount,
+		HolidaySurcharge:    holidaySurchargeAmount,
+		TotalFare:           totalFare,
+		NightRate:           nightRate,
+		HolidayRate:         holidayRate,
+		IsNight:             isNight,
+		IsHoliday:           isHoliday,
+		UseSimpleBaseKm:     useSimpleBaseKm,
+		NightSplit:          o.nightSplit,
+		BodyType:            o.bodyType,
+		BodyTypeSurcharge:   bodyTypeSurcharge,
+		SurchargeItems:      surchargeItems,
+		SurchargeItemsTotal: surchargeItemsTotal,
+		TariffVersion:       o.tariffVersion,
+		Days:                dayFares,
+		DailyLimitMinutes:   o.dailyLimitOrDefault(),
+		Nights:              nights,
+		OvernightCostYen:    o.overnightCostYen,
+		OvernightCharge:     overnightCharge,
+	}, nil
+}
+
+// calculateDay 1日分の時間制運賃（基礎額・距離超過・時間超過）を計算する
+func (s *TimeFareService) calculateDay(
+	version *model.TariffVersion,
+	regionCode, vehicleCode, distanceKm int,
+	drivingMinutes, loadingMinutes, breakMinutes int,
+	useSimpleBaseKm bool,
+	distSurchargeYen, timeSurchargeYen int,
+) (*TimeFareDay, error) {
+	totalMinutes := drivingMinutes + loadingMinutes + breakMinutes
+
+	// 適用時間制を判定
+	appliedHours := DetermineHoursSystem(totalMinutes)
+
+	// 基礎額を取得
+	baseFare, err := s.fareGetter.GetBaseFare(version, regionCode, vehicleCode, appliedHours)
+	if err != nil {
+		return nil, fmt.Errorf("基礎額取得エラー: %w", err)
+	}
+
+	// 基礎走行キロを決定
+	var baseKm int
+	if useSimpleBaseKm {
+		// シンプル版: 車格に関係なく固定値
+		if appliedHours == 4 {
+			baseKm = SimpleBaseKm4Hours
+		} else {
+			baseKm = SimpleBaseKm8Hours
+		}
+	} else {
+		// トラ協PDF版: DBから取得した車格別の値
+		baseKm = baseFare.BaseKm
+	}
+
+	// 超過距離を計算（10km単位）
+	excessKm := 0
+	if distanceKm > baseKm {
+		excessKm = distanceKm - baseKm
+	}
+	distanceSurchargeAmount := (excessKm / 10) * distSurchargeYen
+
+	// 超過時間を計算（1時間単位、切り上げ）
+	baseMinutes := appliedHours * 60
+	excessMinutes := 0
+	if totalMinutes > baseMinutes {
+		excessMinutes = totalMinutes - baseMinutes
+	}
+	excessHours := (excessMinutes + 59) / 60 // 切り上げ
+	timeSurchargeAmount := excessHours * timeSurchargeYen
+
+	return &TimeFareDay{
 		DistanceKm:        distanceKm,
 		DrivingMinutes:    drivingMinutes,
 		LoadingMinutes:    loadingMinutes,
+		BreakMinutes:      breakMinutes,
 		TotalMinutes:      totalMinutes,
 		AppliedHours:      appliedHours,
 		BaseKm:            baseKm,
@@ -185,15 +325,7 @@ func (s *TimeFareService) Calculate(
 		BaseFare:          baseFare.FareYen,
 		DistanceSurcharge: distanceSurchargeAmount,
 		TimeSurcharge:     timeSurchargeAmount,
-		SubTotal:          subTotal,
-		NightSurcharge:    nightSurchargeAmount,
-		HolidaySurcharge:  holidaySurchargeAmount,
-		TotalFare:         totalFare,
-		NightRate:         nightRate,
-		HolidayRate:       holidayRate,
-		IsNight:           isNight,
-		IsHoliday:         isHoliday,
-		UseSimpleBaseKm:   useSimpleBaseKm,
+		SubTotal:          baseFare.FareYen + distanceSurchargeAmount + timeSurchargeAmount,
 	}, nil
 }
 
@@ -235,7 +367,15 @@ func (r *TimeFareResult) Breakdown() string {
 	result := fmt.Sprintf("【計算根拠】\n")
 	result += fmt.Sprintf("  運輸局: %s\n", regionNames[r.RegionCode])
 	result += fmt.Sprintf("  車格: %s\n", vehicleNames[r.VehicleCode])
-	result += fmt.Sprintf("  適用制度: %d時間制\n", r.AppliedHours)
+	if r.TariffVersion != nil {
+		result += fmt.Sprintf("  適用運賃版: %s\n", r.TariffVersion.Label())
+	}
+	if r.IsMultiDay() {
+		result += fmt.Sprintf("  適用制度: %d日運行（1日の拘束時間%d時間%d分で分割、日ごとに時間制を適用）\n",
+			len(r.Days), r.DailyLimitMinutes/60, r.DailyLimitMinutes%60)
+	} else {
+		result += fmt.Sprintf("  適用制度: %d時間制\n", r.AppliedHours)
+	}
 
 	baseKmType := "トラ協PDF版"
 	if r.UseSimpleBaseKm {
@@ -244,7 +384,14 @@ func (r *TimeFareResult) Breakdown() string {
 	result += fmt.Sprintf("  走行距離: %dkm（基礎走行キロ: %dkm [%s]）\n", r.DistanceKm, r.BaseKm, baseKmType)
 	result += fmt.Sprintf("  走行時間: %d時間%d分\n", r.DrivingMinutes/60, r.DrivingMinutes%60)
 	result += fmt.Sprintf("  荷役時間: %d時間%d分\n", r.LoadingMinutes/60, r.LoadingMinutes%60)
+	if r.BreakMinutes > 0 {
+		result += fmt.Sprintf("  休憩時間: %d時間%d分（改善基準告示の連続運転4時間ごとの休憩）\n", r.BreakMinutes/60, r.BreakMinutes%60)
+	}
 	result += fmt.Sprintf("  総作業時間: %d時間%d分\n", r.TotalMinutes/60, r.TotalMinutes%60)
+	for _, d := range r.Days {
+		result += fmt.Sprintf("    %s: 基礎額%d円 + 距離超過%d円 + 時間超過%d円 = %d円\n",
+			d.Label(), d.BaseFare, d.DistanceSurcharge, d.TimeSurcharge, d.SubTotal)
+	}
 	result += fmt.Sprintf("  基礎額: %d円\n", r.BaseFare)
 
 	if r.ExcessKm > 0 {
@@ -256,14 +403,39 @@ func (r *TimeFareResult) Breakdown() string {
 
 	result += fmt.Sprintf("  小計（割増前）: %d円\n", r.SubTotal)
 
+	if r.BodyType != nil && r.BodyType.SurchargePercent > 0 {
+		result += fmt.Sprintf("  特殊車両割増: +%d円（%s %d%%増）\n", r.BodyTypeSurcharge, r.BodyType.Name, r.BodyType.SurchargePercent)
+	}
+
 	if r.IsNight {
-		result += fmt.Sprintf("  深夜割増: +%d円（%.0f%%増）\n", r.NightSurcharge, (r.NightRate-1.0)*100)
+		if r.NightSplit != nil {
+			result += fmt.Sprintf("  深夜割増: +%d円（%.0f%%増 × 深夜%d分/運行%d分）\n",
+				r.NightSurcharge, (r.NightRate-1.0)*100, r.NightSplit.NightMinutes, r.NightSplit.TotalMinutes)
+		} else {
+			result += fmt.Sprintf("  深夜割増: +%d円（%.0f%%増）\n", r.NightSurcharge, (r.NightRate-1.0)*100)
+		}
 	}
 	if r.IsHoliday {
 		result += fmt.Sprintf("  休日割増: +%d円（%.0f%%増）\n", r.HolidaySurcharge, (r.HolidayRate-1.0)*100)
 	}
+	result += surchargeItemsBreakdown(r.SurchargeItems)
+	if r.OvernightCharge > 0 {
+		result += fmt.Sprintf("  宿泊費: +%d円（%d円 × %d泊）\n", r.OvernightCharge, r.OvernightCostYen, r.Nights)
+	}
+	if r.HandlingCharge > 0 {
+		result += fmt.Sprintf("  積込・取卸料: +%d円\n", r.HandlingCharge)
+	}
+	if r.WaitingCharge > 0 {
+		result += fmt.Sprintf("  待機時間料: +%d円\n", r.WaitingCharge)
+	}
+	if r.FuelSurcharge > 0 {
+		result += fmt.Sprintf("  燃料サーチャージ: +%d円\n", r.FuelSurcharge)
+	}
 
 	result += fmt.Sprintf("  合計運賃: %d円\n", r.TotalFare)
+	if r.Tax != nil {
+		result += r.Tax.Breakdown()
+	}
 
 	return result
 }
@@ -14,14 +14,14 @@ type mockTimeFareGetter struct {
 	err            error
 }
 
-func (m *mockTimeFareGetter) GetBaseFare(regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
+func (m *mockTimeFareGetter) GetBaseFare(version *model.TariffVersion, regionCode, vehicleCode, hours int) (*model.JtaTimeBaseFare, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.baseFare, nil
 }
 
-func (m *mockTimeFareGetter) GetSurcharge(regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
+func (m *mockTimeFareGetter) GetSurcharge(version *model.TariffVersion, regionCode, vehicleCode int, surchargeType string) (*model.JtaTimeSurcharge, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
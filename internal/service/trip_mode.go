@@ -0,0 +1,109 @@
+package service
+
+import "fmt"
+
+// TripMode 運行形態
+type TripMode string
+
+const (
+	TripModeOneWay      TripMode = "one_way"      // 片道
+	TripModeRoundTrip   TripMode = "round_trip"   // 往復（実車で戻る）
+	TripModeEmptyReturn TripMode = "empty_return" // 片道＋空車回送
+)
+
+// DefaultEmptyReturnRatePercent 空車回送の計上率のデフォルト（%）
+const DefaultEmptyReturnRatePercent = 50
+
+// ParseTripMode 文字列から運行形態を判定する（空文字は片道）
+func ParseTripMode(s string) (TripMode, error) {
+	switch TripMode(s) {
+	case "":
+		return TripModeOneWay, nil
+	case TripModeOneWay, TripModeRoundTrip, TripModeEmptyReturn:
+		return TripMode(s), nil
+	default:
+		return "", fmt.Errorf("無効な運行形態: %s（one_way / round_trip / empty_return を指定してください）", s)
+	}
+}
+
+// Label 表示用ラベル
+func (m TripMode) Label() string {
+	switch m {
+	case TripModeRoundTrip:
+		return "往復"
+	case TripModeEmptyReturn:
+		return "片道＋空車回送"
+	default:
+		return "片道"
+	}
+}
+
+// Trip 運行形態による距離・走行時間の計上
+// 往復は片道の2倍、空車回送は片道に「片道 × 計上率」の回送分を加算する
+type Trip struct {
+	Mode                   TripMode // 運行形態
+	EmptyReturnRatePercent int      // 空車回送の計上率（%、空車回送のみ）
+
+	OneWayDistanceKm     float64 // 片道距離（km）
+	OneWayDrivingMinutes int     // 片道走行時間（分）
+	ReturnDistanceKm     float64 // 復路（回送）として計上する距離（km）
+	ReturnDrivingMinutes int     // 復路（回送）として計上する走行時間（分）
+}
+
+// NewTrip 片道の距離・走行時間から運行形態に応じた計上距離・走行時間を求める
+func NewTrip(mode TripMode, emptyReturnRatePercent int, oneWayDistanceKm float64, oneWayDrivingMinutes int) (*Trip, error) {
+	if _, err := ParseTripMode(string(mode)); err != nil {
+		return nil, err
+	}
+
+	trip := &Trip{
+		Mode:                 mode,
+		OneWayDistanceKm:     oneWayDistanceKm,
+		OneWayDrivingMinutes: oneWayDrivingMinutes,
+	}
+	switch mode {
+	case TripModeRoundTrip:
+		trip.ReturnDistanceKm = oneWayDistanceKm
+		trip.ReturnDrivingMinutes = oneWayDrivingMinutes
+	case TripModeEmptyReturn:
+		if emptyReturnRatePercent < 0 || emptyReturnRatePercent > 100 {
+			return nil, fmt.Errorf("無効な回送計上率: %d%%（0-100の範囲で指定してください）", emptyReturnRatePercent)
+		}
+		trip.EmptyReturnRatePercent = emptyReturnRatePercent
+		trip.ReturnDistanceKm = oneWayDistanceKm * float64(emptyReturnRatePercent) / 100
+		trip.ReturnDrivingMinutes = oneWayDrivingMinutes * emptyReturnRatePercent / 100
+	}
+	return trip, nil
+}
+
+// DistanceKm 計上距離（km）
+func (t *Trip) DistanceKm() float64 {
+	return t.OneWayDistanceKm + t.ReturnDistanceKm
+}
+
+// DrivingMinutes 計上走行時間（分）
+func (t *Trip) DrivingMinutes() int {
+	return t.OneWayDrivingMinutes + t.ReturnDrivingMinutes
+}
+
+// Label 表示用ラベル（例: 片道＋空車回送（回送50%））
+func (t *Trip) Label() string {
+	if t.Mode == TripModeEmptyReturn {
+		return fmt.Sprintf("%s（回送%d%%）", t.Mode.Label(), t.EmptyReturnRatePercent)
+	}
+	return t.Mode.Label()
+}
+
+// Breakdown 計算根拠を文字列で返す
+func (t *Trip) Breakdown() string {
+	result := fmt.Sprintf("運行形態: %s\n", t.Label())
+	result += fmt.Sprintf("  片道: %.1fkm / %d分\n", t.OneWayDistanceKm, t.OneWayDrivingMinutes)
+	switch t.Mode {
+	case TripModeRoundTrip:
+		result += fmt.Sprintf("  復路: %.1fkm / %d分\n", t.ReturnDistanceKm, t.ReturnDrivingMinutes)
+	case TripModeEmptyReturn:
+		result += fmt.Sprintf("  回送: %.1fkm / %d分（片道 × %d%%）\n", t.ReturnDistanceKm, t.ReturnDrivingMinutes, t.EmptyReturnRatePercent)
+	}
+	result += fmt.Sprintf("  計上: %.1fkm / %d分\n", t.DistanceKm(), t.DrivingMinutes())
+	return result
+}
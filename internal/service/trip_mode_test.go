@@ -0,0 +1,81 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTripMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    TripMode
+		wantErr bool
+	}{
+		{"", TripModeOneWay, false},
+		{"one_way", TripModeOneWay, false},
+		{"round_trip", TripModeRoundTrip, false},
+		{"empty_return", TripModeEmptyReturn, false},
+		{"return", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTripMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTripMode(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          TripMode
+		ratePercent   int
+		wantKm        float64
+		wantMinutes   int
+		wantLabel     string
+		wantBreakdown string
+	}{
+		{"片道", TripModeOneWay, 50, 100.4, 150, "片道", "計上: 100.4km / 150分"},
+		{"往復", TripModeRoundTrip, 50, 200.8, 300, "往復", "復路: 100.4km / 150分"},
+		{"空車回送50%", TripModeEmptyReturn, 50, 150.6, 225, "片道＋空車回送（回送50%）", "回送: 50.2km / 75分（片道 × 50%）"},
+		{"空車回送0%", TripModeEmptyReturn, 0, 100.4, 150, "片道＋空車回送（回送0%）", "計上: 100.4km / 150分"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip, err := NewTrip(tt.mode, tt.ratePercent, 100.4, 150)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if diff := trip.DistanceKm() - tt.wantKm; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("DistanceKm = %f, want %f", trip.DistanceKm(), tt.wantKm)
+			}
+			if trip.DrivingMinutes() != tt.wantMinutes {
+				t.Errorf("DrivingMinutes = %d, want %d", trip.DrivingMinutes(), tt.wantMinutes)
+			}
+			if trip.Label() != tt.wantLabel {
+				t.Errorf("Label = %s, want %s", trip.Label(), tt.wantLabel)
+			}
+			if !strings.Contains(trip.Breakdown(), tt.wantBreakdown) {
+				t.Errorf("Breakdown に %q が含まれていない:\n%s", tt.wantBreakdown, trip.Breakdown())
+			}
+		})
+	}
+}
+
+func TestNewTrip_Error(t *testing.T) {
+	if _, err := NewTrip("return", 50, 100, 60); err == nil {
+		t.Error("無効な運行形態でエラーが発生しなかった")
+	}
+	for _, rate := range []int{-1, 101} {
+		if _, err := NewTrip(TripModeEmptyReturn, rate, 100, 60); err == nil {
+			t.Errorf("回送計上率 %d%% でエラーが発生しなかった", rate)
+		}
+	}
+}